@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/autopilot"
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/exec/tmux"
+)
+
+// autopilotCmd runs the unattended ticket dispatcher.
+var autopilotCmd = &cobra.Command{
+	Use:   "autopilot",
+	Short: "Automatically launch agents for newly ready tickets",
+	Long: `Watch every workspace project for newly ready tickets and launch agents
+for them according to the autopilot rules in the config.
+
+Tickets that are already ready on startup are not dispatched. Rules are
+evaluated in order and the first match picks the harness, model and agent.
+max_concurrent and max_per_project cap the number of live running agents.
+Every decision is logged to stdout.
+
+Dispatching is suspended while the pause file exists ('bdb autopilot pause'
+and 'bdb autopilot resume'), or by sending SIGUSR1 (pause) and SIGUSR2
+(resume) to the process. Use --dry-run to log what would be launched
+without launching anything.`,
+	Args: cobra.NoArgs,
+	RunE: runAutopilot,
+}
+
+// autopilotPauseCmd creates the pause file.
+var autopilotPauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Pause a running autopilot by creating its pause file",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		path, err := autopilotPauseFile(loadAutopilotConfig())
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return fmt.Errorf("failed to create pause file directory: %w", err)
+		}
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			return fmt.Errorf("failed to create pause file: %w", err)
+		}
+		fmt.Printf("Autopilot paused (%s)\n", path)
+		return nil
+	},
+}
+
+// autopilotResumeCmd removes the pause file.
+var autopilotResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume a paused autopilot by removing its pause file",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		path, err := autopilotPauseFile(loadAutopilotConfig())
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove pause file: %w", err)
+		}
+		fmt.Printf("Autopilot resumed (%s)\n", path)
+		return nil
+	},
+}
+
+func init() {
+	autopilotCmd.AddCommand(autopilotPauseCmd)
+	autopilotCmd.AddCommand(autopilotResumeCmd)
+}
+
+func runAutopilot(cmd *cobra.Command, _ []string) error {
+	if !dryRun {
+		ensureTmuxSession()
+	}
+
+	cfgPath := resolveConfigPath()
+	cfgLoader := config.NewYAMLLoader()
+	cfg, err := cfgLoader.Load(cfgPath)
+	if err != nil {
+		return fmt.Errorf("config error: %w", err)
+	}
+	if cfg.Autopilot == nil || len(cfg.Autopilot.Rules) == 0 {
+		return fmt.Errorf("no autopilot rules configured in %s", cfgPath)
+	}
+
+	apCfg := *cfg.Autopilot
+	if apCfg.PauseFile, err = autopilotPauseFile(cfg); err != nil {
+		return err
+	}
+
+	runner := tmux.NewRealRunner()
+	appOpts := domain.AppOptions{
+		ConfigPath:    cfgPath,
+		BeadsDir:      resolveBeadsPath(),
+		DSN:           dsn,
+		DryRun:        dryRun,
+		Debug:         debug,
+		Demo:          demo,
+		AutostartDolt: cfg.General != nil && cfg.General.AutostartDolt,
+		AgentStore:    agentStoreSetting(cfg),
+	}
+	application, err := app.NewApp(cfgLoader, tmux.NewTmuxLauncher(runner, dryRun, false, cfg.Launcher.Target),
+		nil, runner, config.NewRenderer(), appOpts)
+	if err != nil {
+		return fmt.Errorf("failed to initialize app: %w", err)
+	}
+	defer application.Close()
+
+	ctx, stop := signal.NotifyContext(commandContext(cmd), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if _, err := application.CreateProjectContext(ctx); err != nil {
+		return fmt.Errorf("failed to open project: %w", err)
+	}
+	projects, err := autopilotProjects(ctx, application)
+	if err != nil {
+		return err
+	}
+
+	pilot, err := autopilot.New(autopilot.Options{
+		Config:    apCfg,
+		Harnesses: cfg.Harnesses,
+		Projects:  projects,
+		Renderer:  application.Renderer,
+		Launcher:  application.Launcher,
+		Logger:    log.New(os.Stdout, "", log.LstdFlags),
+		DryRun:    dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	go handlePauseSignals(ctx, pilot)
+	return pilot.Run(ctx)
+}
+
+// autopilotProjects opens a ticket and agent store for every workspace project.
+func autopilotProjects(ctx context.Context, application *app.App) ([]autopilot.Project, error) {
+	if err := application.OpenProjectStores(ctx); err != nil {
+		return nil, err
+	}
+
+	var projects []autopilot.Project
+	for _, p := range application.GetProjects() {
+		store, err := application.StoreForProject(ctx, p.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open project %s: %w", p.Dir, err)
+		}
+		agents, err := application.AgentStoreForProject(ctx, p.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open agent store for %s: %w", p.Dir, err)
+		}
+		projects = append(projects, autopilot.Project{Dir: p.Dir, Tickets: store, Agents: autopilot.NewAgentStore(agents)})
+	}
+	return projects, nil
+}
+
+// handlePauseSignals pauses on SIGUSR1 and resumes on SIGUSR2.
+func handlePauseSignals(ctx context.Context, pilot *autopilot.Autopilot) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGUSR1, syscall.SIGUSR2)
+	defer signal.Stop(sigs)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-sigs:
+			if sig == syscall.SIGUSR1 {
+				pilot.Pause()
+			} else {
+				pilot.Resume()
+			}
+		}
+	}
+}
+
+// loadAutopilotConfig loads the config for the pause subcommands,
+// returning nil when it cannot be read so the default pause file is used.
+func loadAutopilotConfig() *domain.Config {
+	cfg, err := config.NewYAMLLoader().Load(resolveConfigPath())
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
+
+// autopilotPauseFile returns the configured pause file or the default one.
+func autopilotPauseFile(cfg *domain.Config) (string, error) {
+	if cfg != nil && cfg.Autopilot != nil && cfg.Autopilot.PauseFile != "" {
+		return cfg.Autopilot.PauseFile, nil
+	}
+	return autopilot.DefaultPauseFile()
+}
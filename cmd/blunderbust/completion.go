@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/discovery"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/ui"
+)
+
+// completionTimeout bounds how long dynamic completion may block the shell.
+// Anything slower (e.g. an unreachable Dolt server) yields no suggestions.
+const completionTimeout = 2 * time.Second
+
+// completionCmd prints shell completion scripts.
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate a shell completion script",
+	Long: `Generate a completion script for your shell.
+
+Completion is dynamic: ticket IDs come from the beads database, harness,
+model and agent names from your config (with discover:active and provider:
+entries expanded from the models cache), and project paths from the
+workspace. Lookups time out quickly so a stopped Dolt server never stalls
+the shell.
+
+  bash:       source <(bdb completion bash)
+  zsh:        bdb completion zsh > "${fpath[1]}/_bdb"
+  fish:       bdb completion fish > ~/.config/fish/completions/bdb.fish
+  powershell: bdb completion powershell | Out-String | Invoke-Expression`,
+	Args:                  cobra.ExactArgs(1),
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(out, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(out)
+		case "fish":
+			return rootCmd.GenFishCompletion(out, true)
+		case "powershell":
+			return rootCmd.GenPowerShellCompletionWithDesc(out)
+		default:
+			return fmt.Errorf("unsupported shell %q (expected bash, zsh, fish or powershell)", args[0])
+		}
+	},
+}
+
+// openCompletionStore opens the ticket store behind completeTicketIDs.
+// Tests replace it with fakes.
+var openCompletionStore = app.OpenTicketStore
+
+// completionConfig loads the config for completion, returning nil on failure.
+func completionConfig() *domain.Config {
+	cfg, err := config.NewYAMLLoader().Load(resolveConfigPath())
+	if err != nil {
+		return nil
+	}
+	return cfg
+}
+
+// completeTicketIDs suggests ticket IDs with their titles as descriptions.
+func completeTicketIDs(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ctx, cancel := context.WithTimeout(commandContext(cmd), completionTimeout)
+	defer cancel()
+
+	store, err := completionStore(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	if closer, ok := store.(interface{ Close() error }); ok {
+		defer closer.Close()
+	}
+
+	tickets, err := store.ListTickets(ctx, data.TicketFilter{})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(tickets))
+	for _, t := range tickets {
+		if strings.HasPrefix(t.ID, toComplete) {
+			completions = append(completions, t.ID+"\t"+t.Title)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionStore opens a read-only view of the tickets without ever
+// auto-starting the Dolt server.
+func completionStore(ctx context.Context) (data.TicketStore, error) {
+	if demo {
+		return fake.NewWithSampleData(), nil
+	}
+	opts := domain.AppOptions{BeadsDir: resolveBeadsPath(), DSN: dsn}
+	if cfg := completionConfig(); cfg != nil {
+		root, _ := filepath.Abs(app.ExtractRepoRoot(opts.BeadsDir))
+		for _, p := range cfg.Workspace.Projects {
+			if p.Dir == root {
+				opts.DoltConnection = p.Dolt
+			}
+		}
+	}
+
+	type result struct {
+		store data.TicketStore
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		s, err := openCompletionStore(ctx, opts)
+		done <- result{s, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return nil, r.err
+		}
+		return r.store, nil
+	case <-ctx.Done():
+		// Port detection shells out without a context; don't wait for it.
+		go func() {
+			if r := <-done; r.store != nil {
+				if closer, ok := r.store.(interface{ Close() error }); ok {
+					_ = closer.Close()
+				}
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// completeHarnessNames suggests harness names from the config.
+func completeHarnessNames(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg := completionConfig()
+	if cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, h := range cfg.Harnesses {
+		if strings.HasPrefix(h.Name, toComplete) {
+			names = append(names, h.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeThemeNames suggests built-in and user theme names.
+func completeThemeNames(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	themes, err := ui.LoadThemes(resolveThemesDir())
+	if err != nil {
+		themes = ui.AvailableThemes
+	}
+	return filterPrefix(ui.ThemeNames(themes), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeModelNames suggests models of the harness given via --harness,
+// or of every harness, with dynamic entries expanded from the models cache.
+func completeModelNames(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg := completionConfig()
+	if cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var entries []string
+	for _, h := range selectedHarnesses(cmd, cfg) {
+		entries = append(entries, h.SupportedModels...)
+	}
+
+	if registry, err := discovery.NewRegistry(""); err == nil && registry.LoadCache() == nil {
+		entries = registry.ExpandModels(entries)
+	}
+	return filterPrefix(entries, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeAgentNames suggests agents of the harness given via --harness,
+// or of every harness.
+func completeAgentNames(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg := completionConfig()
+	if cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var entries []string
+	for _, h := range selectedHarnesses(cmd, cfg) {
+		entries = append(entries, h.SupportedAgents...)
+	}
+	return filterPrefix(entries, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProjectPaths suggests workspace project directories, falling back
+// to directory completion when none match.
+func completeProjectPaths(_ *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cfg := completionConfig()
+	if cfg == nil {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	}
+
+	var dirs []string
+	for _, p := range cfg.Workspace.Projects {
+		if strings.HasPrefix(p.Dir, toComplete) {
+			dirs = append(dirs, p.Dir+"\t"+p.Name)
+		}
+	}
+	if len(dirs) == 0 {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	}
+	return dirs, cobra.ShellCompDirectiveNoFileComp
+}
+
+// selectedHarnesses returns the harness named by --harness, or all harnesses.
+func selectedHarnesses(cmd *cobra.Command, cfg *domain.Config) []domain.Harness {
+	name, _ := cmd.Flags().GetString("harness")
+	if name == "" {
+		return cfg.Harnesses
+	}
+	for _, h := range cfg.Harnesses {
+		if h.Name == name {
+			return []domain.Harness{h}
+		}
+	}
+	return nil
+}
+
+// filterPrefix returns the sorted, de-duplicated values starting with prefix.
+func filterPrefix(values []string, prefix string) []string {
+	seen := make(map[string]bool, len(values))
+	var out []string
+	for _, v := range values {
+		if seen[v] || !strings.HasPrefix(v, prefix) {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+const completionTestConfig = `harnesses:
+  - name: opencode
+    command_template: "opencode --model {{.Model}}"
+    models: [anthropic/claude, openai/o4-mini]
+    agents: [build, plan]
+  - name: amp
+    command_template: "amp"
+    models: [amp/default]
+`
+
+// withCompletionEnv isolates completion from the user's config, models
+// cache and beads database, and opens tickets with open.
+func withCompletionEnv(t *testing.T, open func(context.Context, domain.AppOptions) (data.TicketStore, error)) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	cfgPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(completionTestConfig), 0o600))
+
+	prevConfig, prevBeads, prevDemo, prevOpen := configPath, beadsDir, demo, openCompletionStore
+	t.Cleanup(func() {
+		configPath, beadsDir, demo, openCompletionStore = prevConfig, prevBeads, prevDemo, prevOpen
+	})
+	configPath = cfgPath
+	beadsDir = filepath.Join(dir, ".beads")
+	demo = false
+	openCompletionStore = open
+}
+
+// completionCommand returns a command with a --harness flag and ctx.
+func completionCommand(ctx context.Context, harness string) *cobra.Command {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("harness", harness, "")
+	cmd.SetContext(ctx)
+	return cmd
+}
+
+// blockingStore never answers ListTickets before its context ends.
+type blockingStore struct{}
+
+func (blockingStore) ListTickets(ctx context.Context, _ data.TicketFilter) ([]domain.Ticket, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingStore) LatestUpdate(context.Context) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func TestCompleteTicketIDs(t *testing.T) {
+	withCompletionEnv(t, func(context.Context, domain.AppOptions) (data.TicketStore, error) {
+		return fake.NewWithSampleData(), nil
+	})
+
+	got, directive := completeTicketIDs(completionCommand(context.Background(), ""), nil, "bb-00")
+	assert.Len(t, got, 5)
+	assert.Contains(t, got, "bb-002\tDefine core domain types")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	got, _ = completeTicketIDs(completionCommand(context.Background(), ""), nil, "bb-004")
+	assert.Equal(t, []string{"bb-004\tBuild TUI skeleton"}, got)
+}
+
+func TestCompleteTicketIDs_StoreThatNeverOpensTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	withCompletionEnv(t, func(context.Context, domain.AppOptions) (data.TicketStore, error) {
+		<-release
+		return fake.NewWithSampleData(), nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	got, directive := completeTicketIDs(completionCommand(ctx, ""), nil, "")
+
+	assert.Empty(t, got)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	assert.Less(t, time.Since(start), completionTimeout, "completion must not wait for the store")
+}
+
+func TestCompleteTicketIDs_BlockedQueryTimesOut(t *testing.T) {
+	withCompletionEnv(t, func(context.Context, domain.AppOptions) (data.TicketStore, error) {
+		return blockingStore{}, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	got, directive := completeTicketIDs(completionCommand(ctx, ""), nil, "")
+
+	assert.Empty(t, got)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+func TestCompleteHarnessModelAndAgentNames(t *testing.T) {
+	withCompletionEnv(t, nil)
+
+	got, _ := completeHarnessNames(nil, nil, "o")
+	assert.Equal(t, []string{"opencode"}, got)
+
+	got, _ = completeModelNames(completionCommand(context.Background(), ""), nil, "")
+	assert.Equal(t, []string{"amp/default", "anthropic/claude", "openai/o4-mini"}, got)
+
+	got, _ = completeModelNames(completionCommand(context.Background(), "opencode"), nil, "a")
+	assert.Equal(t, []string{"anthropic/claude"}, got, "models are limited to --harness")
+
+	got, _ = completeAgentNames(completionCommand(context.Background(), "amp"), nil, "")
+	assert.Empty(t, got)
+
+	got, _ = completeAgentNames(completionCommand(context.Background(), ""), nil, "p")
+	assert.Equal(t, []string{"plan"}, got)
+}
+
+func TestCompletion_MissingConfigDegrades(t *testing.T) {
+	withCompletionEnv(t, nil)
+	configPath = filepath.Join(t.TempDir(), "missing.yaml")
+
+	got, directive := completeHarnessNames(nil, nil, "")
+	assert.Empty(t, got)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+func TestSelectionFlagsHaveCompletion(t *testing.T) {
+	for _, tc := range []struct {
+		cmd   *cobra.Command
+		flags []string
+	}{
+		{ctlLaunchCmd, []string{"ticket", "harness", "model", "agent"}},
+		{historyCmd, []string{"ticket", "harness", "model"}},
+	} {
+		for _, name := range tc.flags {
+			_, ok := tc.cmd.GetFlagCompletionFunc(name)
+			assert.True(t, ok, "%s --%s", tc.cmd.Name(), name)
+		}
+	}
+}
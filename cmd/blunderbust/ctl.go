@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/megatherium/blunderbust/internal/control"
+)
+
+// ctlTimeout bounds every control request except the event stream.
+const ctlTimeout = 10 * time.Second
+
+// Flags for the ctl subcommands.
+var (
+	ctlProject  string
+	ctlWorktree string
+	ctlTicket   string
+	ctlHarness  string
+	ctlModel    string
+	ctlAgent    string
+)
+
+// ctlCmd talks to a running bdb over its control socket.
+var ctlCmd = &cobra.Command{
+	Use:   "ctl",
+	Short: "Control a running bdb over its control socket",
+	Long: `Query and drive a bdb TUI started with --control.
+
+Responses are printed as JSON; 'bdb ctl events' prints one JSON event per
+line as agents are launched, change status or are cleared. The socket
+defaults to $XDG_RUNTIME_DIR/blunderbust/bdb.sock and can be changed with
+--control-socket.`,
+}
+
+var ctlProjectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "List workspace projects",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return ctlQuery(cmd, func(ctx context.Context, c *control.Client) (any, error) {
+			return c.Projects(ctx)
+		})
+	},
+}
+
+var ctlTicketsCmd = &cobra.Command{
+	Use:   "tickets",
+	Short: "List ready tickets of a project (default: the active project)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return ctlQuery(cmd, func(ctx context.Context, c *control.Client) (any, error) {
+			return c.Tickets(ctx, ctlProject)
+		})
+	},
+}
+
+var ctlHarnessesCmd = &cobra.Command{
+	Use:   "harnesses",
+	Short: "List harnesses with their models and agents",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return ctlQuery(cmd, func(ctx context.Context, c *control.Client) (any, error) {
+			return c.Harnesses(ctx)
+		})
+	},
+}
+
+var ctlAgentsCmd = &cobra.Command{
+	Use:   "agents",
+	Short: "List agents tracked by the TUI",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return ctlQuery(cmd, func(ctx context.Context, c *control.Client) (any, error) {
+			return c.Agents(ctx)
+		})
+	},
+}
+
+var ctlLaunchCmd = &cobra.Command{
+	Use:   "launch --ticket ID --harness NAME [--model M] [--agent A]",
+	Short: "Launch an agent for a ready ticket",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return ctlQuery(cmd, func(ctx context.Context, c *control.Client) (any, error) {
+			return c.Launch(ctx, control.LaunchRequest{
+				Project:  ctlProject,
+				Worktree: ctlWorktree,
+				Ticket:   ctlTicket,
+				Harness:  ctlHarness,
+				Model:    ctlModel,
+				Agent:    ctlAgent,
+			})
+		})
+	},
+}
+
+var ctlKillCmd = &cobra.Command{
+	Use:               "kill AGENT_ID",
+	Short:             "Kill a running agent",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeCtlAgentIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithTimeout(commandContext(cmd), ctlTimeout)
+		defer cancel()
+		if err := control.NewClient(resolveControlSocket()).Kill(ctx, args[0]); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Killed %s\n", args[0])
+		return nil
+	},
+}
+
+var ctlEventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Stream agent events as JSON lines until interrupted",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		return control.NewClient(resolveControlSocket()).Events(commandContext(cmd), func(ev control.Event) error {
+			return enc.Encode(ev)
+		})
+	},
+}
+
+func init() {
+	ctlTicketsCmd.Flags().StringVar(&ctlProject, "project", "", "Project directory (default: the active project)")
+
+	ctlLaunchCmd.Flags().StringVar(&ctlProject, "project", "", "Project directory (default: the active project)")
+	ctlLaunchCmd.Flags().StringVar(&ctlWorktree, "worktree", "", "Worktree to launch in (default: the project root)")
+	ctlLaunchCmd.Flags().StringVar(&ctlTicket, "ticket", "", "Ticket ID to launch")
+	ctlLaunchCmd.Flags().StringVar(&ctlHarness, "harness", "", "Harness name")
+	ctlLaunchCmd.Flags().StringVar(&ctlModel, "model", "", "Model to pass to the harness")
+	ctlLaunchCmd.Flags().StringVar(&ctlAgent, "agent", "", "Agent to pass to the harness")
+	_ = ctlLaunchCmd.MarkFlagRequired("ticket")
+	_ = ctlLaunchCmd.MarkFlagRequired("harness")
+	_ = ctlLaunchCmd.MarkFlagDirname("worktree")
+	_ = ctlLaunchCmd.RegisterFlagCompletionFunc("ticket", completeTicketIDs)
+	_ = ctlLaunchCmd.RegisterFlagCompletionFunc("harness", completeHarnessNames)
+	_ = ctlLaunchCmd.RegisterFlagCompletionFunc("model", completeModelNames)
+	_ = ctlLaunchCmd.RegisterFlagCompletionFunc("agent", completeAgentNames)
+
+	ctlCmd.AddCommand(ctlProjectsCmd, ctlTicketsCmd, ctlHarnessesCmd, ctlAgentsCmd,
+		ctlLaunchCmd, ctlKillCmd, ctlEventsCmd)
+}
+
+// ctlQuery runs one control request and prints its result as JSON.
+func ctlQuery(cmd *cobra.Command, fn func(context.Context, *control.Client) (any, error)) error {
+	ctx, cancel := context.WithTimeout(commandContext(cmd), ctlTimeout)
+	defer cancel()
+
+	result, err := fn(ctx, control.NewClient(resolveControlSocket()))
+	if err != nil {
+		return err
+	}
+	return printJSON(cmd.OutOrStdout(), result)
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// resolveControlSocket returns --control-socket or the default socket path.
+func resolveControlSocket() string {
+	if controlSocket != "" {
+		return controlSocket
+	}
+	return control.DefaultSocketPath()
+}
+
+// completeCtlAgentIDs suggests agent IDs from the running bdb.
+func completeCtlAgentIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, cancel := context.WithTimeout(commandContext(cmd), completionTimeout)
+	defer cancel()
+
+	agents, err := control.NewClient(resolveControlSocket()).Agents(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var ids []string
+	for _, a := range agents {
+		if strings.HasPrefix(a.ID, toComplete) {
+			ids = append(ids, a.ID+"\t"+a.Ticket+" ("+a.Status+")")
+		}
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/discovery"
+	"github.com/megatherium/blunderbust/internal/doctor"
+	"github.com/megatherium/blunderbust/internal/exec/tmux"
+)
+
+// doctorCmd diagnoses the environment bdb depends on.
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the environment for common setup problems",
+	Long: `Run a series of checks covering tmux, the bd CLI, beads metadata, Dolt
+connectivity, config validity, harness binaries, provider API keys, the
+models cache and Nerd Font support. Each check prints PASS, WARN or FAIL
+together with a suggested fix.
+
+Exits with a non-zero status if any check fails.`,
+	Args: cobra.NoArgs,
+	RunE: runDoctor,
+}
+
+func runDoctor(cmd *cobra.Command, _ []string) error {
+	registry, err := discovery.NewRegistry("")
+	if err != nil {
+		logger.Debug("model registry unavailable", "err", err)
+	}
+
+	d := doctor.New(tmux.NewRealRunner(), config.NewYAMLLoader(), registry, app.DetectNerdFont)
+	results := d.Run(cmd.Context(), doctor.Options{
+		ConfigPath: resolveConfigPath(),
+		BeadsDirs:  []string{resolveBeadsPath()},
+		DSN:        dsn,
+	})
+
+	doctor.Print(os.Stdout, results)
+
+	if _, _, failed := doctor.Summary(results); failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+var (
+	historyProject string
+	historyTicket  string
+	historyHarness string
+	historyModel   string
+	historySince   string
+	historyUntil   string
+	historyLimit   int
+	historyJSON    bool
+)
+
+// historyCmd lists finished agent runs.
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List finished agent runs",
+	Long: `List the agent runs that finished in the workspace projects, newest first.
+
+A run is recorded when bdb sees its agent exit, or when a stale or dead
+agent is pruned from the running agents; those have no exit code and end
+when the agent was last seen. Lines changed count the committed and
+uncommitted changes in the agent's worktree since its branch left the main
+branch.
+
+--since and --until take a date (2006-01-02) or an RFC 3339 time and bound
+the start of the runs; a date given to --until includes that day.`,
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historyProject, "project", "", "Project directory (default: every workspace project)")
+	historyCmd.Flags().StringVar(&historyTicket, "ticket", "", "Only runs for this ticket ID")
+	historyCmd.Flags().StringVar(&historyHarness, "harness", "", "Only runs of this harness")
+	historyCmd.Flags().StringVar(&historyModel, "model", "", "Only runs with this model")
+	historyCmd.Flags().StringVar(&historySince, "since", "", "Only runs started at or after this date or time")
+	historyCmd.Flags().StringVar(&historyUntil, "until", "", "Only runs started before this time, or up to and including this date")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 50, "Maximum number of runs to show (0 for all)")
+	historyCmd.Flags().BoolVar(&historyJSON, "json", false, "Print the runs as JSON")
+	_ = historyCmd.MarkFlagDirname("project")
+	_ = historyCmd.RegisterFlagCompletionFunc("ticket", completeTicketIDs)
+	_ = historyCmd.RegisterFlagCompletionFunc("harness", completeHarnessNames)
+	_ = historyCmd.RegisterFlagCompletionFunc("model", completeModelNames)
+}
+
+func runHistory(cmd *cobra.Command, _ []string) error {
+	filter, err := historyFilter()
+	if err != nil {
+		return err
+	}
+
+	application, err := newStoresApp()
+	if err != nil {
+		return err
+	}
+	defer application.Close()
+
+	ctx := commandContext(cmd)
+	if _, err := application.CreateProjectContext(ctx); err != nil && len(application.GetProjects()) == 0 && filter.ProjectDir == "" {
+		return fmt.Errorf("failed to open project: %w", err)
+	}
+
+	// Runs of the projects that could be read are printed before the
+	// failures of the others.
+	runs, listErr := application.AgentRuns(ctx, filter)
+	out := cmd.OutOrStdout()
+	if historyJSON {
+		err = printJSON(out, historyRecords(runs))
+	} else {
+		err = printHistory(out, runs)
+	}
+	if err != nil {
+		return err
+	}
+	if listErr != nil {
+		cmd.SilenceUsage = true
+	}
+	return listErr
+}
+
+// historyFilter builds the run filter from the flags.
+func historyFilter() (data.AgentRunFilter, error) {
+	filter := data.AgentRunFilter{
+		Ticket:  historyTicket,
+		Harness: historyHarness,
+		Model:   historyModel,
+		Limit:   max(historyLimit, 0),
+	}
+	if historyProject != "" {
+		dir, err := filepath.Abs(historyProject)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --project: %w", err)
+		}
+		filter.ProjectDir = dir
+	}
+
+	var err error
+	if filter.Since, _, err = parseHistoryTime(historySince); err != nil {
+		return filter, fmt.Errorf("invalid --since: %w", err)
+	}
+	until, dateOnly, err := parseHistoryTime(historyUntil)
+	if err != nil {
+		return filter, fmt.Errorf("invalid --until: %w", err)
+	}
+	if dateOnly {
+		until = until.AddDate(0, 0, 1)
+	}
+	filter.Until = until
+	return filter, nil
+}
+
+// parseHistoryTime parses a local date or an RFC 3339 time, reporting
+// which of them s was. An empty s is the zero time.
+func parseHistoryTime(s string) (t time.Time, dateOnly bool, err error) {
+	if s == "" {
+		return time.Time{}, false, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, true, nil
+	}
+	t, err = time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%q is neither a date (2006-01-02) nor an RFC 3339 time", s)
+	}
+	return t, false, nil
+}
+
+// printHistory prints runs as a table.
+func printHistory(w io.Writer, runs []domain.AgentRun) error {
+	if len(runs) == 0 {
+		_, err := fmt.Fprintln(w, "No agent runs found.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STARTED\tDURATION\tTICKET\tHARNESS\tMODEL\tAGENT\tOUTCOME\tLINES\tWORKTREE")
+	for _, r := range runs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t+%d/-%d\t%s\n",
+			r.StartedAt.Local().Format("2006-01-02 15:04"),
+			r.Duration().Round(time.Second),
+			orDash(r.Ticket),
+			r.HarnessName,
+			orDash(r.Model),
+			orDash(r.Agent),
+			r.Outcome(),
+			r.LinesAdded, r.LinesRemoved,
+			r.WorktreePath)
+	}
+	return tw.Flush()
+}
+
+// orDash returns s, or "-" for an empty table cell.
+func orDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
+
+// historyRecord is the JSON form of a run.
+type historyRecord struct {
+	Project      string    `json:"project"`
+	Worktree     string    `json:"worktree"`
+	Ticket       string    `json:"ticket,omitempty"`
+	TicketTitle  string    `json:"ticket_title,omitempty"`
+	Harness      string    `json:"harness"`
+	Model        string    `json:"model,omitempty"`
+	Agent        string    `json:"agent,omitempty"`
+	StartedAt    time.Time `json:"started_at"`
+	FinishedAt   time.Time `json:"finished_at"`
+	Status       string    `json:"status"`
+	ExitCode     *int      `json:"exit_code,omitempty"`
+	LinesAdded   int       `json:"lines_added"`
+	LinesRemoved int       `json:"lines_removed"`
+}
+
+func historyRecords(runs []domain.AgentRun) []historyRecord {
+	records := make([]historyRecord, 0, len(runs))
+	for _, r := range runs {
+		records = append(records, historyRecord{
+			Project:      r.ProjectDir,
+			Worktree:     r.WorktreePath,
+			Ticket:       r.Ticket,
+			TicketTitle:  r.TicketTitle,
+			Harness:      r.HarnessName,
+			Model:        r.Model,
+			Agent:        r.Agent,
+			StartedAt:    r.StartedAt,
+			FinishedAt:   r.FinishedAt,
+			Status:       r.Status.String(),
+			ExitCode:     r.ExitCode,
+			LinesAdded:   r.LinesAdded,
+			LinesRemoved: r.LinesRemoved,
+		})
+	}
+	return records
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/wizard"
+)
+
+// Flags for the init subcommand.
+var (
+	initYes      bool
+	initForce    bool
+	initProjects []string
+)
+
+// initCmd generates a config file from the detected environment.
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create a config file by detecting installed harnesses",
+	Long: `Scan PATH for known AI coding tools, propose harness entries with
+command and prompt templates, pull model lists from the models.dev cache and
+add beads projects to the workspace.
+
+The config is written to ~/.config/blunderbust/config.yaml unless --config
+is given. Use --yes to accept every proposal without prompting.`,
+	Args: cobra.NoArgs,
+	RunE: runInit,
+}
+
+func init() {
+	initCmd.Flags().BoolVarP(&initYes, "yes", "y", false, "Accept all proposals without prompting")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite an existing config file")
+	initCmd.Flags().StringArrayVar(&initProjects, "project", nil, "Project directory to add to the workspace (repeatable)")
+}
+
+func runInit(cmd *cobra.Command, _ []string) error {
+	cfgPath, err := initConfigPath()
+	if err != nil {
+		return err
+	}
+
+	loader := config.NewYAMLLoader()
+	application, err := app.NewApp(loader, nil, nil, nil, nil, domain.AppOptions{ConfigPath: cfgPath, Debug: debug})
+	if err != nil {
+		return fmt.Errorf("failed to initialize app: %w", err)
+	}
+
+	w := wizard.New(os.Stdin, os.Stdout, loader, application.Registry, application.ValidateProject)
+	_, err = w.Run(cmd.Context(), wizard.Options{
+		ConfigPath: cfgPath,
+		Projects:   initProjects,
+		AssumeYes:  initYes,
+		Force:      initForce,
+	})
+	if errors.Is(err, wizard.ErrAborted) {
+		fmt.Println("Aborted; existing config left unchanged.")
+		return nil
+	}
+	return err
+}
+
+// initConfigPath returns the --config path, or the XDG config location that
+// resolveConfigPath prefers when it exists.
+func initConfigPath() (string, error) {
+	if configPath != "" {
+		return configPath, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine user home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "blunderbust", "config.yaml"), nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/megatherium/blunderbust/internal/logging"
+)
+
+// logFollowInterval is how often `bdb logs -f` polls the log file.
+const logFollowInterval = 250 * time.Millisecond
+
+// Flags for the logs command.
+var (
+	logsLines  int
+	logsFollow bool
+	logsPath   bool
+)
+
+// logger is the structured file logger, set up by setupLogging. Until
+// then every record is discarded.
+var logger = logging.Discard()
+
+// logsCmd tails the bdb log file.
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Show the bdb log file",
+	Long: `Print the end of the bdb log file, optionally following new records.
+
+The TUI writes structured logs to $XDG_STATE_HOME/blunderbust/bdb.log
+(~/.local/state/blunderbust/bdb.log by default), rotating it at 5 MiB and
+keeping 3 backups. Use --log-level debug (or --debug) for verbose logs.`,
+	Args: cobra.NoArgs,
+	RunE: runLogs,
+}
+
+func init() {
+	logsCmd.Flags().IntVarP(&logsLines, "lines", "n", 50, "Number of lines to show")
+	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Keep printing new log records")
+	logsCmd.Flags().BoolVar(&logsPath, "path", false, "Print the log file path and exit")
+}
+
+func runLogs(cmd *cobra.Command, _ []string) error {
+	path, err := logging.DefaultFile()
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	if logsPath {
+		fmt.Fprintln(out, path)
+		return nil
+	}
+
+	offset, err := logging.Tail(out, path, logsLines)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if !logsFollow {
+			return fmt.Errorf("no log file at %s yet; run bdb first", path)
+		}
+	}
+	if !logsFollow {
+		return nil
+	}
+	return logging.Follow(commandContext(cmd), out, path, offset, logFollowInterval)
+}
+
+// resolveLogLevel returns the --log-level, or debug when only --debug is given.
+func resolveLogLevel(cmd *cobra.Command) (slog.Level, error) {
+	if debug && !cmd.Flags().Changed("log-level") {
+		return slog.LevelDebug, nil
+	}
+	return logging.ParseLevel(logLevel)
+}
+
+// setupLogging opens the rotating log file and installs the logger.
+func setupLogging(level slog.Level) (io.Closer, error) {
+	path, err := logging.DefaultFile()
+	if err != nil {
+		return nil, err
+	}
+	file, err := logging.OpenRotatingFile(path, logging.DefaultMaxSize, logging.DefaultMaxBackups)
+	if err != nil {
+		return nil, err
+	}
+	logger = logging.New(file, level)
+	return file, nil
+}
@@ -39,9 +39,14 @@ var (
 	configPath string
 	dryRun     bool
 	debug      bool
+	logLevel   string
 	beadsDir   string
 	dsn        string
 	demo       bool
+	themeFlag  string
+
+	serveControl  bool
+	controlSocket string
 )
 
 // rootCmd is the base command for the bdb CLI.
@@ -56,8 +61,9 @@ configurations, and launching development sessions.
 
 If a project-path is provided as a positional argument and the project is not
 already in the workspace, a modal will ask to add it.`,
-	Args: cobra.MaximumNArgs(1),
-	RunE: runRoot,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeProjectPaths,
+	RunE:              runRoot,
 }
 
 // versionCmd prints the version and build information.
@@ -90,14 +96,32 @@ var updateModelsCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(updateModelsCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.AddCommand(autopilotCmd)
+	rootCmd.AddCommand(ctlCmd)
+	rootCmd.AddCommand(logsCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Path to config file (default: ~/.config/blunderbust/config.yaml)")
 	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print commands without executing")
-	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug logging")
+	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug logging (same as --log-level debug)")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Log file level: debug, info, warn or error")
 	rootCmd.PersistentFlags().StringVar(&beadsDir, "beads-dir", "", "Path to beads directory (default: ./.beads)")
-	rootCmd.PersistentFlags().StringVar(&dsn, "dsn", "", "DSN for Dolt server mode (optional, overrides metadata)")
+	rootCmd.PersistentFlags().StringVar(&dsn, "dsn", "", "DSN for Dolt server mode (optional, overrides metadata and connection profiles)")
 	rootCmd.PersistentFlags().BoolVar(&demo, "demo", false, "Use fake data instead of real beads database")
+	rootCmd.Flags().StringVar(&themeFlag, "theme", "", "UI theme: a built-in or a theme from ~/.config/blunderbust/themes")
+	rootCmd.Flags().BoolVar(&serveControl, "control", false, "Serve the JSON control API on a Unix socket")
+	rootCmd.PersistentFlags().StringVar(&controlSocket, "control-socket", "", "Control API socket path (default: $XDG_RUNTIME_DIR/blunderbust/bdb.sock)")
 	// --version flag for compatibility (also available as 'bdb version' subcommand)
 	rootCmd.PersistentFlags().Bool("version", false, "Print version and exit")
+	_ = rootCmd.MarkPersistentFlagFilename("config", "yaml", "yml")
+	_ = rootCmd.MarkPersistentFlagDirname("beads-dir")
+	_ = rootCmd.RegisterFlagCompletionFunc("theme", completeThemeNames)
+	_ = rootCmd.RegisterFlagCompletionFunc("log-level",
+		cobra.FixedCompletions([]string{"debug", "info", "warn", "error"}, cobra.ShellCompDirectiveNoFileComp))
 }
 
 func main() {
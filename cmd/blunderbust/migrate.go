@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// migrateCmd groups the schema migration commands.
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Show or apply schema migrations of bdb's own tables and files",
+	Long: `bdb keeps data of its own next to beads: the running_agents and agent_runs
+tables in each project's Dolt database, or the running agents file in the
+state directory when general.agent_store is "file" or a project has no Dolt
+database. Their schemas change through numbered migrations, recorded in the
+bdb_schema_version table and in the file's version field.
+
+bdb applies pending migrations when it first uses a store. 'bdb migrate up'
+applies them ahead of time, e.g. before several bdb processes start at once.`,
+	Args: cobra.NoArgs,
+}
+
+// migrateStatusCmd prints the schema version of every store.
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the schema version and pending migrations of every store",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runMigrate(cmd, func(ctx context.Context, out io.Writer, t app.MigrationTarget) error {
+			status, err := t.Store.MigrationStatus(ctx)
+			if err != nil {
+				return err
+			}
+			if status.UpToDate() {
+				fmt.Fprintf(out, "%s: up to date (version %d)\n", t.Name, status.Current)
+				return nil
+			}
+			fmt.Fprintf(out, "%s: version %d of %d, %d pending\n", t.Name, status.Current, status.Latest, len(status.Pending))
+			for _, m := range status.Pending {
+				fmt.Fprintf(out, "  %3d  %s\n", m.Version, m.Name)
+			}
+			return nil
+		})
+	},
+}
+
+// migrateUpCmd applies the pending migrations of every store.
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply the pending migrations of every store",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runMigrate(cmd, func(ctx context.Context, out io.Writer, t app.MigrationTarget) error {
+			applied, err := t.Store.MigrateUp(ctx)
+			for _, m := range applied {
+				fmt.Fprintf(out, "%s: applied %d  %s\n", t.Name, m.Version, m.Name)
+			}
+			if err != nil {
+				return err
+			}
+			if len(applied) == 0 {
+				fmt.Fprintf(out, "%s: already up to date\n", t.Name)
+			}
+			return nil
+		})
+	},
+}
+
+func init() {
+	migrateCmd.AddCommand(migrateStatusCmd)
+	migrateCmd.AddCommand(migrateUpCmd)
+}
+
+// runMigrate opens the workspace and calls fn for every migration target.
+// Failures are printed and counted, so one unreachable project does not
+// hide the state of the others.
+func runMigrate(cmd *cobra.Command, fn func(ctx context.Context, out io.Writer, t app.MigrationTarget) error) error {
+	application, err := newStoresApp()
+	if err != nil {
+		return err
+	}
+	defer application.Close()
+
+	ctx := commandContext(cmd)
+	out := cmd.OutOrStdout()
+	failed := 0
+	// Workspace projects that fail to open are reported by MigrationTargets.
+	if _, err := application.CreateProjectContext(ctx); err != nil && len(application.GetProjects()) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: failed to open project: %v\n", err)
+		failed++
+	}
+	targets, err := application.MigrationTargets(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		failed++
+	}
+	for _, t := range targets {
+		if err := fn(ctx, out, t); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", t.Name, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%d store(s) failed", failed)
+	}
+	return nil
+}
+
+// newStoresApp returns an app for commands that work on the stores of the
+// workspace projects, without a launcher or the TUI.
+func newStoresApp() (*app.App, error) {
+	cfgPath := resolveConfigPath()
+	cfgLoader := config.NewYAMLLoader()
+	cfg, err := cfgLoader.Load(cfgPath)
+	if err != nil {
+		return nil, fmt.Errorf("config error: %w", err)
+	}
+
+	application, err := app.NewApp(cfgLoader, nil, nil, nil, nil, domain.AppOptions{
+		ConfigPath:    cfgPath,
+		BeadsDir:      resolveBeadsPath(),
+		DSN:           dsn,
+		Debug:         debug,
+		Demo:          demo,
+		AutostartDolt: cfg.General != nil && cfg.General.AutostartDolt,
+		AgentStore:    agentStoreSetting(cfg),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize app: %w", err)
+	}
+	return application, nil
+}
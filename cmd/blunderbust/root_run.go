@@ -4,29 +4,40 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
 	"github.com/megatherium/blunderbust/internal/app"
 	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/control"
 	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/events"
 	"github.com/megatherium/blunderbust/internal/exec/tmux"
 	"github.com/megatherium/blunderbust/internal/ui"
 )
 
 // runRoot executes the main bdb workflow.
-func runRoot(_ *cobra.Command, args []string) error {
+func runRoot(cmd *cobra.Command, args []string) error {
 	ensureTmuxSession()
-	debugLogf("Debug mode enabled")
+
+	level, err := resolveLogLevel(cmd)
+	if err != nil {
+		return err
+	}
+	if logFile, err := setupLogging(level); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: file logging disabled: %v\n", err)
+	} else {
+		defer logFile.Close()
+	}
 
 	targetProject := resolveTargetProject(args)
 	beadsPath := resolveBeadsPath()
 	cfgPath := resolveConfigPath()
 
-	debugLogf("Config path: %s", cfgPath)
-	debugLogf("Dry run: %v", dryRun)
-	debugLogf("Demo mode: %v", demo)
+	logger.Info("starting bdb", "version", Version, "config", cfgPath, "beads_dir", beadsPath,
+		"target_project", targetProject, "dry_run", dryRun, "demo", demo, "log_level", level.String())
 
 	cfgLoader := config.NewYAMLLoader()
 	cfg, err := cfgLoader.Load(cfgPath)
@@ -35,9 +46,22 @@ func runRoot(_ *cobra.Command, args []string) error {
 		os.Exit(2)
 	}
 
-	debugLogf("Loaded %d harness(es) from config", len(cfg.Harnesses))
+	tuiCfgPath := resolveTUIConfigPath()
+	tuiCfg, err := loadTUIConfig(tuiCfgPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "TUI config error: %v\n", err)
+		os.Exit(2)
+	}
+
+	themesDir := resolveThemesDir()
+	themeName, err := resolveTheme(themesDir, tuiCfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Theme error: %v\n", err)
+		os.Exit(2)
+	}
+
 	target := cfg.Launcher.Target
-	debugLogf("Launcher target: %s", target)
+	logger.Debug("loaded config", "harnesses", len(cfg.Harnesses), "launcher_target", target)
 
 	runner := tmux.NewRealRunner()
 	l := tmux.NewTmuxLauncher(runner, dryRun, false, target)
@@ -46,14 +70,18 @@ func runRoot(_ *cobra.Command, args []string) error {
 
 	appOpts := domain.AppOptions{
 		ConfigPath:    cfgPath,
-		TUIConfigPath: resolveTUIConfigPath(),
+		TUIConfigPath: tuiCfgPath,
+		Theme:         themeName,
+		ThemesDir:     themesDir,
 		BeadsDir:      beadsPath,
 		DSN:           dsn,
 		DryRun:        dryRun,
 		Debug:         debug,
 		Demo:          demo,
 		AutostartDolt: cfg.General != nil && cfg.General.AutostartDolt,
+		AgentStore:    agentStoreSetting(cfg),
 		TargetProject: targetProject,
+		Logger:        logger,
 	}
 
 	application, err := app.NewApp(cfgLoader, l, statusChecker, runner, renderer, appOpts)
@@ -63,9 +91,40 @@ func runRoot(_ *cobra.Command, args []string) error {
 	}
 	defer application.Close()
 
-	m := ui.NewUIModel(application, cfg.Harnesses)
+	m := ui.NewUIModel(application, cfg.Harnesses).
+		WithDefaults(cfg.Defaults).
+		WithWriteback(cfg.Writeback)
+
+	bus, err := events.FromConfig(cfg.Events)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: lifecycle events disabled: %v\n", err)
+	} else {
+		m = m.WithEvents(bus)
+	}
 
-	program := tea.NewProgram(m, tea.WithAltScreen())
+	var program *tea.Program
+	var server *control.Server
+	if serveControl {
+		backend := ui.NewControlBackend(application, func(msg tea.Msg) { program.Send(msg) })
+		server = control.NewServer(backend)
+		socketPath := resolveControlSocket()
+		if err := server.Listen(socketPath); err != nil {
+			return err
+		}
+		defer server.Close()
+		logger.Info("control API listening", "socket", socketPath)
+		m = m.WithControl(server)
+	}
+
+	program = tea.NewProgram(m, tea.WithAltScreen())
+	if server != nil {
+		// Serve only once program is set, since the backend sends to it.
+		go func() {
+			if err := server.Serve(); err != nil {
+				logger.Error("control server stopped", "err", err)
+			}
+		}()
+	}
 	if _, err := program.Run(); err != nil {
 		return fmt.Errorf("running TUI: %w", err)
 	}
@@ -82,13 +141,6 @@ func ensureTmuxSession() {
 	os.Exit(3)
 }
 
-func debugLogf(format string, args ...any) {
-	if !debug {
-		return
-	}
-	fmt.Fprintf(os.Stderr, format+"\n", args...)
-}
-
 func resolveTargetProject(args []string) string {
 	if len(args) == 0 {
 		return ""
@@ -98,16 +150,21 @@ func resolveTargetProject(args []string) string {
 	if absPath, err := filepath.Abs(targetProject); err == nil {
 		targetProject = absPath
 	}
-	debugLogf("Target project: %s", targetProject)
 	return targetProject
 }
 
+// agentStoreSetting returns general.agent_store, or "" for the default.
+func agentStoreSetting(cfg *domain.Config) string {
+	if cfg.General == nil {
+		return ""
+	}
+	return cfg.General.AgentStore
+}
+
 func resolveBeadsPath() string {
 	if beadsDir != "" {
-		debugLogf("Beads directory: %s", beadsDir)
 		return beadsDir
 	}
-	debugLogf("Beads directory: %s", "./.beads")
 	return "./.beads"
 }
 
@@ -138,3 +195,45 @@ func resolveTUIConfigPath() string {
 
 	return "./tui_config.yaml"
 }
+
+// loadTUIConfig loads the TUI config and validates its key bindings so that
+// conflicts are reported before the TUI takes over the terminal.
+func loadTUIConfig(path string) (*config.TUIConfig, error) {
+	tuiCfg, err := config.LoadTUIConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := ui.ApplyKeyBindings(tuiCfg); err != nil {
+		return nil, err
+	}
+	return tuiCfg, nil
+}
+
+// resolveThemesDir returns the directory holding user theme files.
+func resolveThemesDir() string {
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".config", "blunderbust", "themes")
+	}
+	return "./themes"
+}
+
+// resolveTheme loads the user themes and returns the theme to start with:
+// --theme, then tui_config.yaml, then the built-in default.
+func resolveTheme(themesDir string, tuiCfg *config.TUIConfig) (string, error) {
+	themes, err := ui.LoadThemes(themesDir)
+	if err != nil {
+		return "", err
+	}
+
+	name := themeFlag
+	if name == "" && tuiCfg != nil {
+		name = tuiCfg.Theme
+	}
+	if name == "" {
+		return "", nil
+	}
+	if _, ok := ui.FindTheme(themes, name); !ok {
+		return "", fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(ui.ThemeNames(themes), ", "))
+	}
+	return name, nil
+}
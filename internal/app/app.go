@@ -2,21 +2,27 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	osexec "os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/megatherium/blunderbust/internal/config"
 	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/agentfile"
 	"github.com/megatherium/blunderbust/internal/data/dolt"
 	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/data/jsonl"
 	"github.com/megatherium/blunderbust/internal/discovery"
 	"github.com/megatherium/blunderbust/internal/domain"
 	"github.com/megatherium/blunderbust/internal/exec"
 	"github.com/megatherium/blunderbust/internal/exec/tmux"
+	"github.com/megatherium/blunderbust/internal/logging"
 )
 
 // ExtractRepoRoot extracts the repository root path from a beadsDir path.
@@ -86,6 +92,12 @@ type App struct {
 	Registry      *discovery.Registry
 	Opts          domain.AppOptions
 	Fonts         FontConfig
+
+	// agentStore is the shared file (or, in demo mode, in-memory) agent
+	// store, created on first use.
+	agentStore data.AgentStore
+	// pools shares Dolt connection pools between projects on one server.
+	pools *dolt.Pools
 }
 
 // NewApp creates a new App instance with necessary dependencies.
@@ -105,6 +117,7 @@ func NewApp(loader config.Loader, launcher exec.Launcher, statusChecker *tmux.St
 		Registry:      registry,
 		Opts:          opts,
 		Fonts:         FontConfig{HasNerdFont: DetectNerdFont()},
+		pools:         dolt.NewPools(),
 	}, nil
 }
 
@@ -148,10 +161,11 @@ func (a *App) CreateProjectContext(ctx context.Context) (*data.ProjectContext, e
 
 	// Create store for the first project in workspaces config
 	firstProjectDir := a.projects[0].Dir
+	conn := a.projects[0].Dolt
 	a.mu.Unlock()
 
 	beadsDir := filepath.Join(firstProjectDir, ".beads")
-	store, err := a.createStore(ctx, beadsDir)
+	store, err := a.createStore(ctx, beadsDir, conn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create store for project %s at %s: %w", firstProjectDir, beadsDir, err)
 	}
@@ -168,7 +182,7 @@ func (a *App) loadSingleProject(ctx context.Context, beadsDir string) (*data.Pro
 	if beadsDir == "" {
 		beadsDir = ".beads" // reasonable default for fallback
 	}
-	store, err := a.createStore(ctx, beadsDir)
+	store, err := a.createStore(ctx, beadsDir, domain.DoltConnection{})
 	if err != nil {
 		return nil, err
 	}
@@ -186,34 +200,106 @@ func (a *App) loadSingleProject(ctx context.Context, beadsDir string) (*data.Pro
 	return a.Project(), nil
 }
 
-// createStore creates a TicketStore based on AppOptions.
-func (a *App) createStore(ctx context.Context, beadsDir string) (data.TicketStore, error) {
+// createStore creates a TicketStore based on AppOptions, connecting to Dolt
+// with the project's connection profile conn.
+func (a *App) createStore(ctx context.Context, beadsDir string, conn domain.DoltConnection) (data.TicketStore, error) {
 	if a.Opts.Demo {
-		if a.Opts.Debug {
-			fmt.Println("Using fake ticket store (demo mode)")
-		}
+		a.Logger(logging.Dolt).Debug("using fake ticket store (demo mode)")
 		return fake.NewWithSampleData(), nil
 	}
 
 	// We create a local modified AppOptions to override BeadsDir per project context
 	opts := a.Opts
 	opts.BeadsDir = beadsDir
+	opts.DoltConnection = conn
 
-	store, err := dolt.NewStore(ctx, opts, a.Opts.AutostartDolt)
-	if err != nil {
-		return nil, err
+	return openTicketStore(ctx, opts, a.pools)
+}
+
+// OpenTicketStore opens the ticket store of the project in opts.BeadsDir.
+// It reads the issues JSONL export when metadata.json selects the jsonl
+// backend, or when the Dolt server can neither be reached nor started and
+// an export exists; otherwise it connects to Dolt.
+func OpenTicketStore(ctx context.Context, opts domain.AppOptions) (data.TicketStore, error) {
+	return openTicketStore(ctx, opts, nil)
+}
+
+// openTicketStore is OpenTicketStore with the Dolt connection taken from
+// pools, or from a pool of its own if pools is nil.
+func openTicketStore(ctx context.Context, opts domain.AppOptions, pools *dolt.Pools) (data.TicketStore, error) {
+	logger := logging.For(opts.Logger, logging.Dolt).With("beads_dir", opts.BeadsDir)
+	beadsDir := opts.BeadsDir
+	if beadsDir == "" {
+		beadsDir = ".beads"
+	}
+
+	metadata, err := dolt.LoadMetadata(beadsDir)
+	if err == nil && metadata.UsesJSONL() {
+		store, err := jsonl.NewStore(jsonlPath(beadsDir, metadata))
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("reading beads issues export", "path", store.Path())
+		return store, nil
 	}
 
-	if a.Opts.Debug {
-		fmt.Printf("Connected to beads database at %s\n", beadsDir)
+	if pools == nil {
+		pools = dolt.NewPools()
+	}
+	store, err := pools.NewStore(ctx, opts, opts.AutostartDolt)
+	if err != nil {
+		if !dolt.IsConnectionError(err) && !dolt.IsErrServerNotRunning(err) && !errors.Is(err, dolt.ErrAutostartFailed) {
+			return nil, err
+		}
+		path := jsonlPath(beadsDir, metadata)
+		if _, statErr := os.Stat(path); statErr != nil {
+			return nil, err
+		}
+		logger.Warn("dolt unreachable, falling back to the issues export", "err", err, "path", path)
+		return jsonl.NewStore(path)
 	}
 
+	logger.Info("connected to beads database")
+
 	return store, nil
 }
 
+// jsonlPath returns the path of the issues export named in metadata, which
+// may be nil.
+func jsonlPath(beadsDir string, metadata *dolt.Metadata) string {
+	name := jsonl.DefaultFile
+	if metadata != nil && metadata.JSONLExport != "" {
+		name = metadata.JSONLExport
+	}
+	return filepath.Join(beadsDir, name)
+}
+
+// Logger returns the structured logger tagged with subsystem. It is safe
+// to call on a nil App and never returns nil.
+func (a *App) Logger(subsystem string) *slog.Logger {
+	if a == nil {
+		return logging.For(nil, subsystem)
+	}
+	return logging.For(a.Opts.Logger, subsystem)
+}
+
 // CreateStore creates a TicketStore for given beads directory.
 func (a *App) CreateStore(ctx context.Context, beadsDir string) (data.TicketStore, error) {
-	return a.createStore(ctx, beadsDir)
+	a.mu.RLock()
+	conn := a.doltConnection(ExtractRepoRoot(beadsDir))
+	a.mu.RUnlock()
+	return a.createStore(ctx, beadsDir, conn)
+}
+
+// doltConnection returns the connection profile of the configured project
+// at projectDir. The caller must hold a.mu.
+func (a *App) doltConnection(projectDir string) domain.DoltConnection {
+	for _, p := range a.projects {
+		if p.Dir == projectDir {
+			return p.Dolt
+		}
+	}
+	return domain.DoltConnection{}
 }
 
 // StatusChecker returns the status checker for monitoring tmux windows.
@@ -226,14 +312,23 @@ func (a *App) Runner() tmux.CommandRunner {
 	return a.runner
 }
 
-// Close cleans up resources, particularly the project context.
+// Close closes the ticket stores of all projects and the Dolt connection
+// pools they share, including those of stores replaced after a server
+// restart.
 func (a *App) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var errs []error
 	for _, store := range a.Stores {
 		if closer, ok := store.(interface{ Close() error }); ok {
-			closer.Close()
+			errs = append(errs, closer.Close())
 		}
 	}
-	return nil
+	if a.pools != nil {
+		errs = append(errs, a.pools.Close())
+	}
+	return errors.Join(errs...)
 }
 
 // GetProjects returns the list of configured projects.
@@ -255,7 +350,7 @@ func (a *App) SetActiveProject(ctx context.Context, projectDir string) error {
 	}
 
 	beadsDir := filepath.Join(projectDir, ".beads")
-	store, err := a.createStore(ctx, beadsDir)
+	store, err := a.createStore(ctx, beadsDir, a.doltConnection(projectDir))
 	if err != nil {
 		return err
 	}
@@ -264,6 +359,30 @@ func (a *App) SetActiveProject(ctx context.Context, projectDir string) error {
 	return nil
 }
 
+// ConnectedProjects returns the directories of the projects whose ticket
+// store is open, sorted.
+func (a *App) ConnectedProjects() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	dirs := make([]string, 0, len(a.Stores))
+	for dir, store := range a.Stores {
+		if store != nil {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// ConnectedStore returns the open ticket store of projectDir without
+// connecting to it.
+func (a *App) ConnectedStore(projectDir string) (data.TicketStore, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	store, ok := a.Stores[projectDir]
+	return store, ok && store != nil
+}
+
 // StoreForProject returns a store for projectDir, creating it lazily if needed.
 func (a *App) StoreForProject(ctx context.Context, projectDir string) (data.TicketStore, error) {
 	a.mu.RLock()
@@ -271,21 +390,184 @@ func (a *App) StoreForProject(ctx context.Context, projectDir string) (data.Tick
 		a.mu.RUnlock()
 		return store, nil
 	}
+	conn := a.doltConnection(projectDir)
 	a.mu.RUnlock()
 
 	beadsDir := filepath.Join(projectDir, ".beads")
-	store, err := a.createStore(ctx, beadsDir)
+	store, err := a.createStore(ctx, beadsDir, conn)
 	if err != nil {
 		return nil, err
 	}
 
 	a.mu.Lock()
+	defer a.mu.Unlock()
+	// Another caller may have opened the project meanwhile.
+	if existing, exists := a.Stores[projectDir]; exists {
+		if closer, ok := store.(interface{ Close() error }); ok {
+			_ = closer.Close()
+		}
+		return existing, nil
+	}
 	a.Stores[projectDir] = store
-	a.mu.Unlock()
 
 	return store, nil
 }
 
+// OpenProjectStores opens the ticket stores of all configured projects in
+// parallel. Projects on the same Dolt server share one connection pool, so
+// only the first of them waits for the connection. The errors of projects
+// that failed to open are joined.
+func (a *App) OpenProjectStores(ctx context.Context) error {
+	projects := a.GetProjects()
+	errs := make([]error, len(projects))
+	var wg sync.WaitGroup
+	for i, p := range projects {
+		wg.Go(func() {
+			if _, err := a.StoreForProject(ctx, p.Dir); err != nil {
+				errs[i] = fmt.Errorf("failed to open project %s: %w", p.Dir, err)
+			}
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// AgentStoreForProject returns the store that persists the running agents
+// of projectDir. Demo mode keeps them in memory. Otherwise they go to the
+// project's Dolt database, unless general.agent_store selects the file
+// store or the project has no Dolt database; then they go to a file in the
+// user's state directory that all projects share.
+func (a *App) AgentStoreForProject(ctx context.Context, projectDir string) (data.AgentStore, error) {
+	if a.Opts.Demo || a.Opts.AgentStore == domain.AgentStoreFile {
+		return a.sharedAgentStore()
+	}
+
+	store, err := a.StoreForProject(ctx, projectDir)
+	if err != nil {
+		return nil, err
+	}
+	doltStore, ok := store.(*dolt.Store)
+	if !ok {
+		return a.sharedAgentStore()
+	}
+
+	// The table is created once per database; later calls return at once.
+	if err := doltStore.EnsureRunningAgentsTable(ctx); err != nil {
+		return nil, err
+	}
+	return doltStore, nil
+}
+
+// sharedAgentStore returns the agent store used outside Dolt databases.
+func (a *App) sharedAgentStore() (data.AgentStore, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.agentStore != nil {
+		return a.agentStore, nil
+	}
+	if a.Opts.Demo {
+		a.agentStore = &fake.AgentStore{}
+		return a.agentStore, nil
+	}
+	path, err := agentfile.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	a.Logger(logging.Dolt).Debug("persisting running agents in file", "path", path)
+	a.agentStore = agentfile.NewStore(path)
+	return a.agentStore, nil
+}
+
+// MigrationTarget is a store holding data in a schema owned by bdb.
+type MigrationTarget struct {
+	// Name is the project directory or the file path.
+	Name  string
+	Store data.MigratableStore
+}
+
+// MigrationTargets returns the stores whose schema bdb owns: the Dolt
+// databases of the configured projects, unless general.agent_store selects
+// the file store, and the shared agent file if it is in use or exists.
+// Projects that fail to open are reported in the joined error; the targets
+// found are returned anyway.
+func (a *App) MigrationTargets(ctx context.Context) ([]MigrationTarget, error) {
+	if a.Opts.Demo {
+		return nil, nil
+	}
+
+	var targets []MigrationTarget
+	var err error
+	useFile := a.Opts.AgentStore == domain.AgentStoreFile
+	if !useFile {
+		err = a.OpenProjectStores(ctx)
+		for _, p := range a.GetProjects() {
+			store, ok := a.ConnectedStore(p.Dir)
+			if !ok {
+				continue
+			}
+			if m, ok := store.(data.MigratableStore); ok {
+				targets = append(targets, MigrationTarget{Name: p.Dir, Store: m})
+			} else {
+				useFile = true
+			}
+		}
+	}
+
+	path, pathErr := agentfile.DefaultPath()
+	if pathErr != nil {
+		return targets, errors.Join(err, pathErr)
+	}
+	if _, statErr := os.Stat(path); useFile || statErr == nil {
+		targets = append(targets, MigrationTarget{Name: path, Store: agentfile.NewStore(path)})
+	}
+	return targets, err
+}
+
+// AgentRuns returns the finished agent runs of the workspace projects that
+// match filter, newest first. A filter.ProjectDir limits the search to that
+// project. Projects whose agent store fails are reported in the joined
+// error; the runs found are returned anyway.
+func (a *App) AgentRuns(ctx context.Context, filter data.AgentRunFilter) ([]domain.AgentRun, error) {
+	dirs := []string{filter.ProjectDir}
+	if filter.ProjectDir == "" {
+		dirs = nil
+		for _, p := range a.GetProjects() {
+			dirs = append(dirs, p.Dir)
+		}
+		if len(dirs) == 0 && a.ActiveProject != "" {
+			dirs = []string{a.ActiveProject}
+		}
+	}
+
+	var runs []domain.AgentRun
+	var errs []error
+	for _, dir := range dirs {
+		store, err := a.AgentStoreForProject(ctx, dir)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to open agent store for %s: %w", dir, err))
+			continue
+		}
+		// Stores may be shared between projects, so each lists its own.
+		projectFilter := filter
+		projectFilter.ProjectDir = dir
+		found, err := store.ListAgentRuns(ctx, projectFilter)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list agent runs of %s: %w", dir, err))
+			continue
+		}
+		runs = append(runs, found...)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].StartedAt.After(runs[j].StartedAt)
+	})
+	if filter.Limit > 0 && len(runs) > filter.Limit {
+		runs = runs[:filter.Limit]
+	}
+	return runs, errors.Join(errs...)
+}
+
 // GetTargetProject returns the target project path from CLI args, if any.
 func (a *App) GetTargetProject() string {
 	return a.Opts.TargetProject
@@ -1,16 +1,25 @@
 package app
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"log/slog"
+	"os"
 	osexec "os/exec"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/agentfile"
+	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/data/jsonl"
 	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/logging"
 )
 
 // mockFontDetector implements fontDetector for testing.
@@ -139,3 +148,240 @@ func TestApp_SetActiveProject_CreationFailure(t *testing.T) {
 type mockStore struct {
 	data.TicketStore
 }
+
+func TestApp_Logger(t *testing.T) {
+	var buf bytes.Buffer
+	a := &App{Opts: domain.AppOptions{Logger: logging.New(&buf, slog.LevelInfo)}}
+
+	a.Logger(logging.Dolt).Info("connected")
+	assert.Contains(t, buf.String(), "subsystem=dolt")
+
+	var nilApp *App
+	assert.NotNil(t, nilApp.Logger(logging.UI), "nil app yields a discarding logger")
+	assert.NotNil(t, (&App{}).Logger(logging.UI))
+}
+
+func TestOpenTicketStore_JSONL(t *testing.T) {
+	const export = `{"id":"bb-1","title":"Ready","status":"open","priority":1,"issue_type":"task"}` + "\n"
+	// Nothing listens on port 1, so the Dolt connection is refused.
+	const unreachable = `{"backend":"dolt","dolt_database":"beads_bb","dolt_server_host":"127.0.0.1","dolt_server_port":1}`
+
+	tests := []struct {
+		name      string
+		metadata  string
+		files     map[string]string
+		autostart bool
+		wantErr   bool
+	}{
+		{
+			name:     "selected by metadata",
+			metadata: `{"backend":"jsonl","jsonl_export":"export.jsonl"}`,
+			files:    map[string]string{"export.jsonl": export},
+		},
+		{
+			name:     "fallback when dolt is unreachable",
+			metadata: unreachable,
+			files:    map[string]string{"issues.jsonl": export},
+		},
+		{
+			// bd is not on the PATH, so starting the server fails.
+			name:      "fallback when autostart fails",
+			metadata:  unreachable,
+			files:     map[string]string{"issues.jsonl": export},
+			autostart: true,
+		},
+		{
+			name:     "no fallback without an export",
+			metadata: unreachable,
+			wantErr:  true,
+		},
+	}
+
+	t.Setenv("PATH", t.TempDir())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			beadsDir := filepath.Join(t.TempDir(), ".beads")
+			require.NoError(t, os.MkdirAll(beadsDir, 0750))
+			require.NoError(t, os.WriteFile(filepath.Join(beadsDir, "metadata.json"), []byte(tt.metadata), 0600))
+			for name, content := range tt.files {
+				require.NoError(t, os.WriteFile(filepath.Join(beadsDir, name), []byte(content), 0600))
+			}
+
+			store, err := OpenTicketStore(context.Background(), domain.AppOptions{BeadsDir: beadsDir, AutostartDolt: tt.autostart})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, &jsonl.Store{}, store)
+
+			tickets, err := store.ListTickets(context.Background(), data.TicketFilter{})
+			require.NoError(t, err)
+			require.Len(t, tickets, 1)
+			assert.Equal(t, "bb-1", tickets[0].ID)
+		})
+	}
+}
+
+func TestApp_StoreForProject_DoltConnection(t *testing.T) {
+	const metadata = `{"backend":"dolt","dolt_database":"beads_bb","dolt_server_host":"127.0.0.1","dolt_server_port":1}`
+	projectDir := func() string {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, ".beads"), 0750))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".beads", "metadata.json"), []byte(metadata), 0600))
+		return dir
+	}
+	profiled, plain := projectDir(), projectDir()
+	a := &App{
+		Stores: map[string]data.TicketStore{},
+		projects: []domain.Project{
+			{Dir: profiled, Dolt: domain.DoltConnection{DSN: "not a dsn"}},
+			{Dir: plain},
+		},
+	}
+
+	_, err := a.StoreForProject(context.Background(), profiled)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid Dolt DSN", "the project's profile is used")
+
+	_, err = a.StoreForProject(context.Background(), plain)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "invalid Dolt DSN")
+
+	a.Opts.DSN = "also not a dsn"
+	_, err = a.StoreForProject(context.Background(), plain)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid Dolt DSN", "--dsn applies to every project")
+}
+
+func TestApp_AgentStoreForProject(t *testing.T) {
+	stateDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", stateDir)
+	stores := func() map[string]data.TicketStore {
+		return map[string]data.TicketStore{"/proj": fake.NewWithSampleData()}
+	}
+
+	t.Run("demo keeps agents in memory", func(t *testing.T) {
+		a := &App{Stores: stores(), Opts: domain.AppOptions{Demo: true}}
+		store, err := a.AgentStoreForProject(context.Background(), "/proj")
+		require.NoError(t, err)
+		assert.IsType(t, &fake.AgentStore{}, store)
+	})
+
+	t.Run("file store when configured", func(t *testing.T) {
+		a := &App{Stores: stores(), Opts: domain.AppOptions{AgentStore: domain.AgentStoreFile}}
+		store, err := a.AgentStoreForProject(context.Background(), "/proj")
+		require.NoError(t, err)
+		require.IsType(t, &agentfile.Store{}, store)
+		assert.Equal(t, filepath.Join(stateDir, "blunderbust", "running_agents.json"), store.(*agentfile.Store).Path())
+
+		again, err := a.AgentStoreForProject(context.Background(), "/other")
+		require.NoError(t, err)
+		assert.Same(t, store, again, "all projects share the file")
+	})
+
+	t.Run("file store for projects without dolt", func(t *testing.T) {
+		a := &App{Stores: stores(), Opts: domain.AppOptions{AgentStore: domain.AgentStoreDolt}}
+		store, err := a.AgentStoreForProject(context.Background(), "/proj")
+		require.NoError(t, err)
+		assert.IsType(t, &agentfile.Store{}, store)
+	})
+}
+
+func TestApp_OpenProjectStores(t *testing.T) {
+	good := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(good, ".beads"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(good, ".beads", "metadata.json"), []byte(`{"backend":"jsonl"}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(good, ".beads", jsonl.DefaultFile), nil, 0600))
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	a := &App{
+		Stores:   map[string]data.TicketStore{},
+		projects: []domain.Project{{Dir: good}, {Dir: missing}},
+	}
+	err := a.OpenProjectStores(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), missing)
+	assert.Equal(t, []string{good}, a.ConnectedProjects(), "projects that open are kept")
+
+	store, ok := a.ConnectedStore(good)
+	require.True(t, ok)
+	again, err := a.StoreForProject(context.Background(), good)
+	require.NoError(t, err)
+	assert.Same(t, store, again)
+
+	assert.NoError(t, a.Close())
+}
+
+func TestApp_MigrationTargets(t *testing.T) {
+	stateDir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", stateDir)
+	agentFile := filepath.Join(stateDir, "blunderbust", "running_agents.json")
+
+	project := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(project, ".beads"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(project, ".beads", "metadata.json"), []byte(`{"backend":"jsonl"}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(project, ".beads", jsonl.DefaultFile), nil, 0600))
+
+	t.Run("projects without dolt use the file", func(t *testing.T) {
+		a := &App{Stores: map[string]data.TicketStore{}, projects: []domain.Project{{Dir: project}}}
+		targets, err := a.MigrationTargets(context.Background())
+		require.NoError(t, err)
+		require.Len(t, targets, 1)
+		assert.Equal(t, agentFile, targets[0].Name)
+	})
+
+	t.Run("file store leaves projects closed", func(t *testing.T) {
+		a := &App{
+			Stores:   map[string]data.TicketStore{},
+			projects: []domain.Project{{Dir: project}},
+			Opts:     domain.AppOptions{AgentStore: domain.AgentStoreFile},
+		}
+		targets, err := a.MigrationTargets(context.Background())
+		require.NoError(t, err)
+		require.Len(t, targets, 1)
+		assert.Equal(t, agentFile, targets[0].Name)
+		assert.Empty(t, a.ConnectedProjects())
+	})
+
+	t.Run("demo has none", func(t *testing.T) {
+		a := &App{Opts: domain.AppOptions{Demo: true}}
+		targets, err := a.MigrationTargets(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, targets)
+	})
+}
+
+func TestApp_AgentRuns(t *testing.T) {
+	a := &App{
+		Stores:   map[string]data.TicketStore{},
+		projects: []domain.Project{{Dir: "/a"}, {Dir: "/b"}},
+		Opts:     domain.AppOptions{Demo: true},
+	}
+	store, err := a.AgentStoreForProject(context.Background(), "/a")
+	require.NoError(t, err)
+	started := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	for i, run := range []domain.AgentRun{
+		{ProjectDir: "/a", Ticket: "bb-1", HarnessName: "codex", StartedAt: started},
+		{ProjectDir: "/b", Ticket: "bb-1", HarnessName: "codex", StartedAt: started.Add(time.Hour)},
+		{ProjectDir: "/b", Ticket: "bb-2", HarnessName: "claude", StartedAt: started.Add(2 * time.Hour)},
+		{ProjectDir: "/gone", Ticket: "bb-1", HarnessName: "codex", StartedAt: started.Add(3 * time.Hour)},
+	} {
+		require.NoError(t, store.FinishRunningAgent(context.Background(), run), "run %d", i)
+	}
+
+	runs, err := a.AgentRuns(context.Background(), data.AgentRunFilter{Harness: "codex"})
+	require.NoError(t, err)
+	require.Len(t, runs, 2, "runs of projects outside the workspace are left out")
+	assert.Equal(t, "/b", runs[0].ProjectDir, "newest first across projects")
+	assert.Equal(t, "/a", runs[1].ProjectDir)
+
+	runs, err = a.AgentRuns(context.Background(), data.AgentRunFilter{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "bb-2", runs[0].Ticket)
+
+	runs, err = a.AgentRuns(context.Background(), data.AgentRunFilter{ProjectDir: "/gone"})
+	require.NoError(t, err)
+	assert.Len(t, runs, 1, "an explicit project need not be in the workspace")
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package autopilot
+
+import (
+	"context"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// AgentStore tracks the agents running for a project.
+type AgentStore interface {
+	// RunningAgents returns the live agents for projectDir, pruning dead ones.
+	RunningAgents(ctx context.Context, projectDir string) ([]domain.PersistedRunningAgent, error)
+	// RecordAgent persists a newly launched agent.
+	RecordAgent(ctx context.Context, agent domain.PersistedRunningAgent) error
+}
+
+// persistedAgentStore adapts a data.AgentStore.
+type persistedAgentStore struct {
+	store data.AgentStore
+}
+
+// Verify interface compliance at compile time.
+var _ AgentStore = (*persistedAgentStore)(nil)
+
+// NewAgentStore returns an AgentStore backed by store, which validates the
+// agents' processes when they are listed.
+func NewAgentStore(store data.AgentStore) AgentStore {
+	return &persistedAgentStore{store: store}
+}
+
+func (s *persistedAgentStore) RunningAgents(ctx context.Context, projectDir string) ([]domain.PersistedRunningAgent, error) {
+	return s.store.ValidateAndPruneRunningAgents(ctx, []string{projectDir}, nil)
+}
+
+func (s *persistedAgentStore) RecordAgent(ctx context.Context, agent domain.PersistedRunningAgent) error {
+	return s.store.UpsertRunningAgent(ctx, agent)
+}
@@ -0,0 +1,393 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package autopilot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/exec"
+	"github.com/megatherium/blunderbust/internal/logging"
+)
+
+// Project is one workspace project watched by the autopilot.
+type Project struct {
+	Dir     string
+	Tickets data.TicketStore
+	Agents  AgentStore
+}
+
+// Options configures an Autopilot.
+type Options struct {
+	Config    domain.AutopilotConfig
+	Harnesses []domain.Harness
+	Projects  []Project
+	Renderer  *config.Renderer
+	Launcher  exec.Launcher
+	Logger    *log.Logger
+	// DryRun logs what would be launched without launching anything.
+	DryRun bool
+}
+
+// projectState remembers what was last seen for a project.
+type projectState struct {
+	// seeded is set once the tickets that were ready on startup are known.
+	seeded     bool
+	lastUpdate time.Time
+	// relist is set when a ticket was held back by a cap or a dispatched
+	// agent went away, so the project is re-listed on the next poll even
+	// without new updates.
+	relist bool
+	// preexisting holds the tickets that were ready on startup and have
+	// stayed ready since. Only newly ready tickets are dispatched.
+	preexisting map[string]bool
+	// dispatched maps the tickets launched by the autopilot to whether
+	// their agent was recorded. A recorded agent that is no longer running
+	// releases its ticket, so a ticket whose agent failed is dispatched
+	// again while it is still ready.
+	dispatched map[string]bool
+}
+
+// Autopilot dispatches ready tickets to agents according to rules.
+type Autopilot struct {
+	cfg       domain.AutopilotConfig
+	rules     []rule
+	harnesses map[string]domain.Harness
+	projects  []Project
+	renderer  *config.Renderer
+	launcher  exec.Launcher
+	logger    *log.Logger
+	dryRun    bool
+
+	paused    atomic.Bool
+	wasPaused bool
+	state     map[string]*projectState
+	decisions map[string]string
+}
+
+// New validates the rules against the harnesses and returns an Autopilot.
+func New(opts Options) (*Autopilot, error) {
+	if len(opts.Config.Rules) == 0 {
+		return nil, fmt.Errorf("autopilot has no rules configured")
+	}
+	if opts.Config.PollInterval <= 0 {
+		opts.Config.PollInterval = config.DefaultAutopilotPollInterval
+	}
+
+	rules, err := compileRules(opts.Config.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	harnesses := make(map[string]domain.Harness, len(opts.Harnesses))
+	for _, h := range opts.Harnesses {
+		harnesses[h.Name] = h
+	}
+	for i, r := range rules {
+		if _, ok := harnesses[r.Harness]; !ok {
+			return nil, fmt.Errorf("rule %s references unknown harness %q", ruleLabel(r.AutopilotRule, i), r.Harness)
+		}
+	}
+
+	logger := opts.Logger
+	if logger == nil {
+		logger = log.New(os.Stderr, "autopilot: ", log.LstdFlags)
+	}
+	renderer := opts.Renderer
+	if renderer == nil {
+		renderer = config.NewRenderer()
+	}
+
+	return &Autopilot{
+		cfg:       opts.Config,
+		rules:     rules,
+		harnesses: harnesses,
+		projects:  opts.Projects,
+		renderer:  renderer,
+		launcher:  opts.Launcher,
+		logger:    logger,
+		dryRun:    opts.DryRun,
+		state:     make(map[string]*projectState),
+		decisions: make(map[string]string),
+	}, nil
+}
+
+// DefaultPauseFile returns autopilot.pause in bdb's state directory.
+func DefaultPauseFile() (string, error) {
+	dir, err := logging.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "autopilot.pause"), nil
+}
+
+// Pause suspends dispatching until Resume is called.
+func (a *Autopilot) Pause() { a.paused.Store(true) }
+
+// Resume re-enables dispatching paused via Pause. A pause file still applies.
+func (a *Autopilot) Resume() { a.paused.Store(false) }
+
+// Paused reports whether dispatching is suspended by Pause or the pause file.
+func (a *Autopilot) Paused() bool {
+	if a.paused.Load() {
+		return true
+	}
+	if a.cfg.PauseFile == "" {
+		return false
+	}
+	_, err := os.Stat(a.cfg.PauseFile)
+	return err == nil
+}
+
+// Run polls until ctx is cancelled, dispatching on every poll interval.
+func (a *Autopilot) Run(ctx context.Context) error {
+	mode := "live"
+	if a.dryRun {
+		mode = "dry-run"
+	}
+	a.logger.Printf("started (%s): %d project(s), %d rule(s), polling every %s",
+		mode, len(a.projects), len(a.rules), a.cfg.PollInterval)
+
+	ticker := time.NewTicker(a.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		a.tick(ctx)
+		select {
+		case <-ctx.Done():
+			a.logger.Printf("stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick runs one poll cycle across all projects.
+func (a *Autopilot) tick(ctx context.Context) {
+	paused := a.Paused()
+	if paused != a.wasPaused {
+		if paused {
+			a.logger.Printf("paused; no tickets will be dispatched")
+		} else {
+			a.logger.Printf("resumed")
+		}
+		a.wasPaused = paused
+	}
+	if paused {
+		return
+	}
+
+	running := make(map[string]map[string]bool, len(a.projects))
+	counts := make(map[string]int, len(a.projects))
+	total := 0
+	for _, p := range a.projects {
+		agents, err := p.Agents.RunningAgents(ctx, p.Dir)
+		if err != nil {
+			a.logger.Printf("%s: skipping poll, failed to list running agents: %v", p.Dir, err)
+			continue
+		}
+		tickets := make(map[string]bool, len(agents))
+		for _, agent := range agents {
+			tickets[agent.Ticket] = true
+		}
+		running[p.Dir] = tickets
+		counts[p.Dir] = len(agents)
+		total += len(agents)
+		a.releaseFinished(p.Dir, tickets)
+	}
+
+	for _, p := range a.projects {
+		if _, ok := running[p.Dir]; !ok {
+			continue
+		}
+		total = a.pollProject(ctx, p, running[p.Dir], counts[p.Dir], total)
+	}
+}
+
+// projectState returns the state of the project in dir.
+func (a *Autopilot) projectState(dir string) *projectState {
+	st := a.state[dir]
+	if st == nil {
+		st = &projectState{dispatched: make(map[string]bool)}
+		a.state[dir] = st
+	}
+	return st
+}
+
+// releaseFinished forgets the dispatched tickets whose recorded agent is
+// no longer running.
+func (a *Autopilot) releaseFinished(dir string, running map[string]bool) {
+	st := a.projectState(dir)
+	for id, recorded := range st.dispatched {
+		if !recorded || running[id] {
+			continue
+		}
+		delete(st.dispatched, id)
+		delete(a.decisions, dir+"\x00"+id)
+		st.relist = true
+		a.logger.Printf("%s %s: agent is no longer running, ticket is dispatched again if still ready", dir, id)
+	}
+}
+
+// pollProject dispatches the project's newly ready tickets when they
+// changed since the last poll. The first poll only records the tickets
+// that are already ready. It returns the updated global running count.
+func (a *Autopilot) pollProject(ctx context.Context, p Project, running map[string]bool, projectCount, total int) int {
+	st := a.projectState(p.Dir)
+
+	latest, err := p.Tickets.LatestUpdate(ctx)
+	if err != nil {
+		a.logger.Printf("%s: failed to check for updates: %v", p.Dir, err)
+		return total
+	}
+	if st.seeded && !latest.After(st.lastUpdate) && !st.relist {
+		return total
+	}
+
+	tickets, err := p.Tickets.ListTickets(ctx, data.TicketFilter{})
+	if err != nil {
+		a.logger.Printf("%s: failed to list ready tickets: %v", p.Dir, err)
+		return total
+	}
+	st.lastUpdate = latest
+	st.relist = false
+
+	ready := make(map[string]bool, len(tickets))
+	for _, t := range tickets {
+		ready[t.ID] = true
+	}
+	if !st.seeded {
+		st.seeded = true
+		st.preexisting = ready
+		a.logger.Printf("%s: %d ticket(s) already ready, waiting for newly ready ones", p.Dir, len(tickets))
+		return total
+	}
+	// A ticket that left the ready list is new when it comes back.
+	for id := range st.preexisting {
+		if !ready[id] {
+			delete(st.preexisting, id)
+		}
+	}
+	for id := range st.dispatched {
+		if !ready[id] {
+			delete(st.dispatched, id)
+		}
+	}
+
+	for _, t := range tickets {
+		key := p.Dir + "\x00" + t.ID
+		if running[t.ID] || st.preexisting[t.ID] {
+			continue
+		}
+		if _, ok := st.dispatched[t.ID]; ok {
+			continue
+		}
+
+		idx := matchRule(a.rules, t)
+		if idx < 0 {
+			a.decide(key, "%s %s: no rule matches (type=%s priority=%d), skipping", p.Dir, t.ID, t.IssueType, t.Priority)
+			continue
+		}
+		if a.cfg.MaxConcurrent > 0 && total >= a.cfg.MaxConcurrent {
+			st.relist = true
+			a.decide(key, "%s %s: deferred, global cap of %d agents reached", p.Dir, t.ID, a.cfg.MaxConcurrent)
+			continue
+		}
+		if a.cfg.MaxPerProject > 0 && projectCount >= a.cfg.MaxPerProject {
+			st.relist = true
+			a.decide(key, "%s %s: deferred, project cap of %d agents reached", p.Dir, t.ID, a.cfg.MaxPerProject)
+			continue
+		}
+
+		recorded, err := a.dispatch(ctx, p, t, idx)
+		if err != nil {
+			a.decide(key, "%s %s: launch failed: %v", p.Dir, t.ID, err)
+			continue
+		}
+		st.dispatched[t.ID] = recorded
+		delete(a.decisions, key)
+		total++
+		projectCount++
+	}
+	return total
+}
+
+// dispatch renders and launches one ticket with the rule's selection. It
+// reports whether the launched agent was recorded as running.
+func (a *Autopilot) dispatch(ctx context.Context, p Project, t domain.Ticket, idx int) (bool, error) {
+	r := a.rules[idx]
+	selection := domain.Selection{
+		Ticket:  t,
+		Harness: a.harnesses[r.Harness],
+		Model:   r.Model,
+		Agent:   r.Agent,
+	}
+	spec, err := a.renderer.RenderSelection(selection, p.Dir)
+	if err != nil {
+		return false, err
+	}
+	spec.LauncherID = t.ID
+
+	label := ruleLabel(r.AutopilotRule, idx)
+	if a.dryRun {
+		a.logger.Printf("%s %s: would launch via rule %s (harness=%s model=%s agent=%s): %s",
+			p.Dir, t.ID, label, r.Harness, r.Model, r.Agent, spec.RenderedCommand)
+		return false, nil
+	}
+
+	result, err := a.launcher.Launch(ctx, *spec)
+	if err != nil {
+		return false, err
+	}
+	if result.Error != nil {
+		return false, result.Error
+	}
+	a.logger.Printf("%s %s: launched via rule %s (harness=%s model=%s agent=%s pid=%d)",
+		p.Dir, t.ID, label, r.Harness, r.Model, r.Agent, result.PID)
+
+	if result.PID <= 0 {
+		a.logger.Printf("%s %s: no PID reported, agent not recorded", p.Dir, t.ID)
+		return false, nil
+	}
+	err = p.Agents.RecordAgent(ctx, domain.PersistedRunningAgent{
+		ProjectDir:    p.Dir,
+		WorktreePath:  p.Dir,
+		PID:           result.PID,
+		LauncherType:  result.LauncherType,
+		LauncherID:    result.LauncherID,
+		Ticket:        t.ID,
+		TicketTitle:   t.Title,
+		HarnessName:   r.Harness,
+		HarnessBinary: config.ExtractCommandBinary(spec.RenderedCommand),
+		Model:         r.Model,
+		Agent:         r.Agent,
+	})
+	if err != nil {
+		a.logger.Printf("%s %s: failed to record running agent: %v", p.Dir, t.ID, err)
+		return false, nil
+	}
+	return true, nil
+}
+
+// decide logs a decision unless the same decision was already logged for
+// the ticket, so repeated polls of an unchanged ticket stay quiet.
+func (a *Autopilot) decide(key, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if a.decisions[key] == msg {
+		return
+	}
+	a.decisions[key] = msg
+	a.logger.Print(msg)
+}
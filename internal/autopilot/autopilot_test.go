@@ -0,0 +1,300 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package autopilot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+type fakeLauncher struct {
+	specs []domain.LaunchSpec
+	err   error
+}
+
+func (l *fakeLauncher) Launch(_ context.Context, spec domain.LaunchSpec) (*domain.LaunchResult, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	l.specs = append(l.specs, spec)
+	return &domain.LaunchResult{
+		LauncherID:   spec.LauncherID,
+		LauncherType: domain.LauncherTypeTmux,
+		PID:          1000 + len(l.specs),
+	}, nil
+}
+
+type fakeAgents struct {
+	agents []domain.PersistedRunningAgent
+	err    error
+}
+
+func (s *fakeAgents) RunningAgents(_ context.Context, _ string) ([]domain.PersistedRunningAgent, error) {
+	return s.agents, s.err
+}
+
+func (s *fakeAgents) RecordAgent(_ context.Context, agent domain.PersistedRunningAgent) error {
+	s.agents = append(s.agents, agent)
+	return nil
+}
+
+func intPtr(i int) *int { return &i }
+
+var testHarnesses = []domain.Harness{
+	{Name: "opencode", CommandTemplate: "opencode --model {{.Model}} --agent {{.Agent}}"},
+	{Name: "claude", CommandTemplate: "claude"},
+}
+
+func newTestAutopilot(t *testing.T, cfg domain.AutopilotConfig, dryRun bool, projects ...Project) (*Autopilot, *fakeLauncher, *bytes.Buffer) {
+	t.Helper()
+	launcher := &fakeLauncher{}
+	logs := &bytes.Buffer{}
+	a, err := New(Options{
+		Config:    cfg,
+		Harnesses: testHarnesses,
+		Projects:  projects,
+		Launcher:  launcher,
+		Logger:    log.New(logs, "", 0),
+		DryRun:    dryRun,
+	})
+	require.NoError(t, err)
+	return a, launcher, logs
+}
+
+func ticket(id, issueType string, priority int, title string) domain.Ticket {
+	return domain.Ticket{ID: id, Title: title, IssueType: issueType, Priority: priority, UpdatedAt: time.Now()}
+}
+
+// start runs the first poll, which only records the tickets already
+// ready, and then makes tickets newly ready in store.
+func start(a *Autopilot, store *fake.TicketStore, tickets ...domain.Ticket) {
+	a.tick(context.Background())
+	store.Tickets = append(store.Tickets, tickets...)
+}
+
+func TestRuleMatching(t *testing.T) {
+	rules, err := compileRules([]domain.AutopilotRule{
+		{Name: "urgent-bugs", IssueTypes: []string{"Bug"}, PriorityMax: intPtr(1), Harness: "claude"},
+		{Name: "docs", TitleRegex: `(?i)^docs`, Harness: "opencode"},
+		{Name: "low", PriorityMin: intPtr(3), Harness: "opencode"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, matchRule(rules, ticket("a", "bug", 0, "crash")))
+	assert.Equal(t, 1, matchRule(rules, ticket("b", "bug", 2, "Docs: fix typo")))
+	assert.Equal(t, 2, matchRule(rules, ticket("c", "task", 4, "refactor")))
+	assert.Equal(t, -1, matchRule(rules, ticket("d", "task", 2, "refactor")))
+}
+
+func TestNew_UnknownHarness(t *testing.T) {
+	_, err := New(Options{
+		Config:    domain.AutopilotConfig{Rules: []domain.AutopilotRule{{Harness: "missing"}}},
+		Harnesses: testHarnesses,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown harness "missing"`)
+}
+
+func TestTick_DispatchesMatchingTickets(t *testing.T) {
+	store := &fake.TicketStore{}
+	agents := &fakeAgents{}
+	cfg := domain.AutopilotConfig{Rules: []domain.AutopilotRule{
+		{Name: "bugs", IssueTypes: []string{"bug"}, Harness: "opencode", Model: "gpt-5", Agent: "build"},
+	}}
+	a, launcher, logs := newTestAutopilot(t, cfg, false, Project{Dir: "/proj", Tickets: store, Agents: agents})
+
+	start(a, store, ticket("bb-1", "bug", 0, "crash on start"), ticket("bb-2", "feature", 2, "new thing"))
+	a.tick(context.Background())
+
+	require.Len(t, launcher.specs, 1)
+	spec := launcher.specs[0]
+	assert.Equal(t, "bb-1", spec.LauncherID)
+	assert.Equal(t, "opencode --model gpt-5 --agent build", spec.RenderedCommand)
+
+	require.Len(t, agents.agents, 1)
+	assert.Equal(t, "bb-1", agents.agents[0].Ticket)
+	assert.Equal(t, "/proj", agents.agents[0].ProjectDir)
+	assert.Equal(t, "opencode", agents.agents[0].HarnessBinary)
+
+	assert.Contains(t, logs.String(), "bb-1: launched via rule bugs")
+	assert.Contains(t, logs.String(), "bb-2: no rule matches")
+
+	// Nothing changed: the next poll neither relaunches nor re-logs.
+	logs.Reset()
+	a.tick(context.Background())
+	assert.Len(t, launcher.specs, 1)
+	assert.Empty(t, logs.String())
+}
+
+func TestTick_SkipsTicketsReadyOnStartup(t *testing.T) {
+	store := &fake.TicketStore{Tickets: []domain.Ticket{ticket("bb-1", "bug", 0, "crash")}}
+	cfg := domain.AutopilotConfig{Rules: []domain.AutopilotRule{{Harness: "claude"}}}
+	a, launcher, logs := newTestAutopilot(t, cfg, false, Project{Dir: "/proj", Tickets: store, Agents: &fakeAgents{}})
+
+	a.tick(context.Background())
+	assert.Empty(t, launcher.specs)
+	assert.Contains(t, logs.String(), "/proj: 1 ticket(s) already ready")
+
+	store.Tickets = append(store.Tickets, ticket("bb-2", "bug", 0, "hang"))
+	a.tick(context.Background())
+	require.Len(t, launcher.specs, 1)
+	assert.Equal(t, "bb-2", launcher.specs[0].LauncherID)
+
+	// A ticket that leaves the ready list is new when it comes back.
+	readyOnStartup := store.Tickets[0]
+	store.Tickets = store.Tickets[1:]
+	store.Tickets[0].UpdatedAt = time.Now().Add(time.Second)
+	a.tick(context.Background())
+	readyOnStartup.UpdatedAt = time.Now().Add(2 * time.Second)
+	store.Tickets = append(store.Tickets, readyOnStartup)
+	a.tick(context.Background())
+	require.Len(t, launcher.specs, 2)
+	assert.Equal(t, "bb-1", launcher.specs[1].LauncherID)
+}
+
+func TestTick_SkipsRunningTickets(t *testing.T) {
+	store := &fake.TicketStore{}
+	agents := &fakeAgents{agents: []domain.PersistedRunningAgent{{Ticket: "bb-1", PID: 42}}}
+	cfg := domain.AutopilotConfig{Rules: []domain.AutopilotRule{{Harness: "claude"}}}
+	a, launcher, _ := newTestAutopilot(t, cfg, false, Project{Dir: "/proj", Tickets: store, Agents: agents})
+
+	start(a, store, ticket("bb-1", "bug", 0, "crash"))
+	a.tick(context.Background())
+	assert.Empty(t, launcher.specs)
+}
+
+func TestTick_RedispatchesWhenAgentIsGone(t *testing.T) {
+	store := &fake.TicketStore{}
+	agents := &fakeAgents{}
+	cfg := domain.AutopilotConfig{Rules: []domain.AutopilotRule{{Harness: "claude"}}}
+	a, launcher, logs := newTestAutopilot(t, cfg, false, Project{Dir: "/proj", Tickets: store, Agents: agents})
+
+	start(a, store, ticket("bb-1", "bug", 0, "crash"))
+	a.tick(context.Background())
+	require.Len(t, launcher.specs, 1)
+
+	// Still running: not dispatched again.
+	a.tick(context.Background())
+	assert.Len(t, launcher.specs, 1)
+
+	// The agent failed and the ticket is still ready.
+	agents.agents = nil
+	a.tick(context.Background())
+	require.Len(t, launcher.specs, 2)
+	assert.Equal(t, "bb-1", launcher.specs[1].LauncherID)
+	assert.Contains(t, logs.String(), "bb-1: agent is no longer running")
+}
+
+func TestTick_EnforcesCaps(t *testing.T) {
+	storeA, storeB := &fake.TicketStore{}, &fake.TicketStore{}
+	agentsA, agentsB := &fakeAgents{}, &fakeAgents{}
+	cfg := domain.AutopilotConfig{
+		MaxConcurrent: 2,
+		MaxPerProject: 1,
+		Rules:         []domain.AutopilotRule{{Harness: "claude"}},
+	}
+	a, launcher, logs := newTestAutopilot(t, cfg, false,
+		Project{Dir: "/a", Tickets: storeA, Agents: agentsA},
+		Project{Dir: "/b", Tickets: storeB, Agents: agentsB},
+	)
+
+	start(a, storeA, ticket("a-1", "task", 1, "one"), ticket("a-2", "task", 1, "two"))
+	storeB.Tickets = []domain.Ticket{ticket("b-1", "task", 1, "three")}
+	a.tick(context.Background())
+	require.Len(t, launcher.specs, 2)
+	assert.Equal(t, "a-1", launcher.specs[0].LauncherID)
+	assert.Equal(t, "b-1", launcher.specs[1].LauncherID)
+	assert.Contains(t, logs.String(), "a-2: deferred, project cap of 1 agents reached")
+
+	// When a-1 finishes, the deferred ticket is picked up without a new update.
+	agentsA.agents = nil
+	storeA.Tickets = storeA.Tickets[1:]
+	a.tick(context.Background())
+	require.Len(t, launcher.specs, 3)
+	assert.Equal(t, "a-2", launcher.specs[2].LauncherID)
+}
+
+func TestTick_DryRunDoesNotLaunch(t *testing.T) {
+	store := &fake.TicketStore{}
+	agents := &fakeAgents{}
+	cfg := domain.AutopilotConfig{Rules: []domain.AutopilotRule{{Name: "all", Harness: "claude"}}}
+	a, launcher, logs := newTestAutopilot(t, cfg, true, Project{Dir: "/proj", Tickets: store, Agents: agents})
+
+	start(a, store, ticket("bb-1", "bug", 0, "crash"))
+	a.tick(context.Background())
+	assert.Empty(t, launcher.specs)
+	assert.Empty(t, agents.agents)
+	assert.Contains(t, logs.String(), "bb-1: would launch via rule all")
+}
+
+func TestTick_LaunchFailureIsLogged(t *testing.T) {
+	store := &fake.TicketStore{}
+	cfg := domain.AutopilotConfig{Rules: []domain.AutopilotRule{{Harness: "claude"}}}
+	a, launcher, logs := newTestAutopilot(t, cfg, false, Project{Dir: "/proj", Tickets: store, Agents: &fakeAgents{}})
+	launcher.err = errors.New("no tmux")
+
+	start(a, store, ticket("bb-1", "bug", 0, "crash"))
+	a.tick(context.Background())
+	assert.Contains(t, logs.String(), "bb-1: launch failed: no tmux")
+}
+
+func TestTick_AgentStoreErrorSkipsProject(t *testing.T) {
+	store := &fake.TicketStore{Tickets: []domain.Ticket{ticket("bb-1", "bug", 0, "crash")}}
+	cfg := domain.AutopilotConfig{Rules: []domain.AutopilotRule{{Harness: "claude"}}}
+	a, launcher, logs := newTestAutopilot(t, cfg, false,
+		Project{Dir: "/proj", Tickets: store, Agents: &fakeAgents{err: errors.New("db down")}})
+
+	a.tick(context.Background())
+	assert.Empty(t, launcher.specs)
+	assert.Contains(t, logs.String(), "failed to list running agents: db down")
+}
+
+func TestPause(t *testing.T) {
+	pauseFile := filepath.Join(t.TempDir(), "autopilot.pause")
+	store := &fake.TicketStore{}
+	cfg := domain.AutopilotConfig{
+		PauseFile: pauseFile,
+		Rules:     []domain.AutopilotRule{{Harness: "claude"}},
+	}
+	a, launcher, logs := newTestAutopilot(t, cfg, false, Project{Dir: "/proj", Tickets: store, Agents: &fakeAgents{}})
+
+	start(a, store, ticket("bb-1", "bug", 0, "crash"))
+	a.Pause()
+	a.tick(context.Background())
+	assert.Empty(t, launcher.specs)
+	assert.Contains(t, logs.String(), "paused")
+
+	a.Resume()
+	require.NoError(t, os.WriteFile(pauseFile, nil, 0o600))
+	assert.True(t, a.Paused(), "pause file keeps autopilot paused")
+	a.tick(context.Background())
+	assert.Empty(t, launcher.specs)
+
+	require.NoError(t, os.Remove(pauseFile))
+	a.tick(context.Background())
+	assert.Len(t, launcher.specs, 1)
+	assert.Contains(t, logs.String(), "resumed")
+}
+
+func TestDefaultPauseFile(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/state")
+	path, err := DefaultPauseFile()
+	require.NoError(t, err)
+	assert.Equal(t, "/state/blunderbust/autopilot.pause", path)
+}
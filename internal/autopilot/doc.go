@@ -0,0 +1,14 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package autopilot implements the unattended `bdb autopilot` dispatcher.
+//
+// Autopilot polls each project's LatestUpdate, matches newly ready tickets
+// against the configured rules and launches agents for them, honouring
+// global and per-project concurrency caps derived from live running agents.
+// Every decision is logged, and dispatching can be suspended with a pause
+// file, a signal or dry-run mode for tuning rules.
+package autopilot
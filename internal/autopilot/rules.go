@@ -0,0 +1,81 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package autopilot
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// rule is an AutopilotRule with its title regex compiled.
+type rule struct {
+	domain.AutopilotRule
+	title *regexp.Regexp
+}
+
+func compileRules(rules []domain.AutopilotRule) ([]rule, error) {
+	compiled := make([]rule, 0, len(rules))
+	for i, r := range rules {
+		c := rule{AutopilotRule: r}
+		if r.TitleRegex != "" {
+			re, err := regexp.Compile(r.TitleRegex)
+			if err != nil {
+				return nil, fmt.Errorf("rule %s: invalid title_regex: %w", ruleLabel(r, i), err)
+			}
+			c.title = re
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+// matches reports whether the ticket satisfies every criterion of the rule.
+// Priority bounds are inclusive; lower numbers are more urgent.
+func (r rule) matches(t domain.Ticket) bool {
+	if len(r.IssueTypes) > 0 && !containsFold(r.IssueTypes, t.IssueType) {
+		return false
+	}
+	if r.PriorityMin != nil && t.Priority < *r.PriorityMin {
+		return false
+	}
+	if r.PriorityMax != nil && t.Priority > *r.PriorityMax {
+		return false
+	}
+	if r.title != nil && !r.title.MatchString(t.Title) {
+		return false
+	}
+	return true
+}
+
+// matchRule returns the index of the first rule matching the ticket, or -1.
+func matchRule(rules []rule, t domain.Ticket) int {
+	for i := range rules {
+		if rules[i].matches(t) {
+			return i
+		}
+	}
+	return -1
+}
+
+func ruleLabel(r domain.AutopilotRule, index int) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return fmt.Sprintf("#%d", index)
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// DefaultPromptTemplate is the prompt template proposed for generated harnesses.
+const DefaultPromptTemplate = "Work on ticket {{.TicketID}}: {{.TicketTitle}}\n\n{{.TicketDescription}}"
+
+// HarnessPreset describes a sensible starting configuration for a known tool.
+type HarnessPreset struct {
+	// CommandTemplate is the command template, with %s replaced by the detected binary.
+	CommandTemplate string
+	// Provider is the models.dev provider whose models the tool accepts.
+	// Empty when the tool accepts any provider/model ID or takes no model.
+	Provider string
+	// Models are the model entries proposed when the tool accepts any
+	// models.dev ID (e.g. discover:active).
+	Models []string
+	// Agents are the agent modes supported by the tool.
+	Agents []string
+}
+
+// harnessPresets holds templates for tools that take model or agent flags.
+// Tools without an entry are launched as their bare binary.
+var harnessPresets = map[string]HarnessPreset{
+	"opencode": {
+		CommandTemplate: "%s --model {{.Model}} --agent {{.Agent}}",
+		Models:          []string{"discover:active"},
+		Agents:          []string{"build", "plan"},
+	},
+	"aider": {
+		CommandTemplate: "%s --model {{.Model}}",
+		Models:          []string{"discover:active"},
+	},
+	"claude": {
+		CommandTemplate: "%s --model {{.Model.Name}}",
+		Provider:        "anthropic",
+	},
+	"codex": {
+		CommandTemplate: "%s --model {{.Model.Name}}",
+		Provider:        "openai",
+	},
+	"gemini": {
+		CommandTemplate: "%s --model {{.Model.Name}}",
+		Provider:        "google",
+	},
+}
+
+// KnownHarnesses returns the names of all harnesses with known binary aliases, sorted.
+func KnownHarnesses() []string {
+	names := make([]string, 0, len(harnessBinaryAliases))
+	for name := range harnessBinaryAliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PresetForHarness returns the preset for a known harness. Harnesses without
+// a dedicated preset get one that launches the bare binary.
+func PresetForHarness(name string) HarnessPreset {
+	if preset, ok := harnessPresets[name]; ok {
+		return preset
+	}
+	return HarnessPreset{CommandTemplate: "%s"}
+}
+
+// NewHarnessFromPreset builds a harness definition for the given tool and
+// detected binary. Models are taken from the preset unless overridden.
+func NewHarnessFromPreset(name, binary string, models []string) domain.Harness {
+	preset := PresetForHarness(name)
+	if models == nil {
+		models = append([]string{}, preset.Models...)
+	}
+	agents := append([]string{}, preset.Agents...)
+
+	return domain.Harness{
+		Name:            name,
+		CommandTemplate: formatPresetCommand(preset.CommandTemplate, binary),
+		PromptTemplate:  DefaultPromptTemplate,
+		SupportedModels: models,
+		SupportedAgents: agents,
+		Env:             map[string]string{},
+	}
+}
+
+// formatPresetCommand substitutes the binary into a preset command template.
+func formatPresetCommand(template, binary string) string {
+	return strings.Replace(template, "%s", binary, 1)
+}
@@ -63,3 +63,38 @@ func contains(items []string, value string) bool {
 	}
 	return false
 }
+
+func TestNewHarnessFromPreset(t *testing.T) {
+	opencode := NewHarnessFromPreset("opencode", "opencode", nil)
+	if opencode.CommandTemplate != "opencode --model {{.Model}} --agent {{.Agent}}" {
+		t.Fatalf("unexpected opencode command: %q", opencode.CommandTemplate)
+	}
+	if !contains(opencode.SupportedModels, "discover:active") {
+		t.Fatalf("expected discover:active in opencode models: %v", opencode.SupportedModels)
+	}
+	if opencode.PromptTemplate != DefaultPromptTemplate {
+		t.Fatalf("expected default prompt template, got %q", opencode.PromptTemplate)
+	}
+
+	mistral := NewHarnessFromPreset("mistral", "vibe", nil)
+	if mistral.CommandTemplate != "vibe" {
+		t.Fatalf("expected bare binary for tools without a preset, got %q", mistral.CommandTemplate)
+	}
+
+	claude := NewHarnessFromPreset("claude", "claude", []string{"provider:anthropic"})
+	if len(claude.SupportedModels) != 1 || claude.SupportedModels[0] != "provider:anthropic" {
+		t.Fatalf("expected explicit models to override preset, got %v", claude.SupportedModels)
+	}
+}
+
+func TestKnownHarnessesSorted(t *testing.T) {
+	names := KnownHarnesses()
+	if len(names) != len(harnessBinaryAliases) {
+		t.Fatalf("expected %d names, got %d", len(harnessBinaryAliases), len(names))
+	}
+	for i := 1; i < len(names); i++ {
+		if names[i-1] > names[i] {
+			t.Fatalf("names not sorted: %v", names)
+		}
+	}
+}
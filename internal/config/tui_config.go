@@ -12,6 +12,33 @@ package config
 type TUIConfig struct {
 	FilePickerRecents    []string `yaml:"filepicker_recents,omitempty"`
 	FilePickerMaxRecents int      `yaml:"filepicker_max_recents,omitempty"`
+
+	// Theme names the theme to start with: a built-in or a user theme from
+	// the themes directory. The --theme flag takes precedence.
+	Theme string `yaml:"theme,omitempty"`
+
+	// Keys overrides UI key bindings, mapping an action name (e.g. "quit")
+	// to the keys that trigger it. Actions not listed keep their defaults.
+	Keys map[string][]string `yaml:"keys,omitempty"`
+	// FilePickerKeys overrides file picker key bindings in the same way.
+	FilePickerKeys map[string][]string `yaml:"filepicker_keys,omitempty"`
+
+	// FilterPresets are named ticket filters, switched with the number keys
+	// in the order they are listed.
+	FilterPresets []FilterPreset `yaml:"filter_presets,omitempty"`
+}
+
+// FilterPreset is a saved ticket filter bar. Empty fields are not filtered
+// on; the priority bounds are inclusive.
+type FilterPreset struct {
+	Name        string `yaml:"name"`
+	Status      string `yaml:"status,omitempty"`
+	IssueType   string `yaml:"type,omitempty"`
+	MinPriority *int   `yaml:"min_priority,omitempty"`
+	MaxPriority *int   `yaml:"max_priority,omitempty"`
+	Assignee    string `yaml:"assignee,omitempty"`
+	Label       string `yaml:"label,omitempty"`
+	Search      string `yaml:"search,omitempty"`
 }
 
 // DefaultMaxRecents is the default value for FilePickerMaxRecents.
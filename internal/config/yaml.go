@@ -13,6 +13,9 @@ type yamlConfig struct {
 	Defaults   *yamlDefaults            `yaml:"defaults,omitempty"`
 	General    *yamlGeneralConfig       `yaml:"general,omitempty"`
 	Workspaces map[string]yamlWorkspace `yaml:"workspaces,omitempty"`
+	Autopilot  *yamlAutopilot           `yaml:"autopilot,omitempty"`
+	Events     *yamlEvents              `yaml:"events,omitempty"`
+	Writeback  *yamlWriteback           `yaml:"writeback,omitempty"`
 }
 
 type yamlWorkspace struct {
@@ -20,8 +23,27 @@ type yamlWorkspace struct {
 }
 
 type yamlProject struct {
-	Dir  string `yaml:"dir"`
-	Name string `yaml:"name,omitempty"`
+	Dir  string              `yaml:"dir"`
+	Name string              `yaml:"name,omitempty"`
+	Dolt *yamlDoltConnection `yaml:"dolt,omitempty"`
+}
+
+// yamlDoltConnection is the raw YAML structure for a project's Dolt
+// connection profile.
+type yamlDoltConnection struct {
+	DSN             string `yaml:"dsn,omitempty"`
+	Host            string `yaml:"host,omitempty"`
+	Port            int    `yaml:"port,omitempty"`
+	Socket          string `yaml:"socket,omitempty"`
+	User            string `yaml:"user,omitempty"`
+	Database        string `yaml:"database,omitempty"`
+	PasswordFile    string `yaml:"password_file,omitempty"`
+	PasswordCommand string `yaml:"password_command,omitempty"`
+	TLS             string `yaml:"tls,omitempty"`
+	TLSCA           string `yaml:"tls_ca,omitempty"`
+	TLSCert         string `yaml:"tls_cert,omitempty"`
+	TLSKey          string `yaml:"tls_key,omitempty"`
+	TLSServerName   string `yaml:"tls_server_name,omitempty"`
 }
 
 // yamlLauncherConfig is the raw YAML structure for launcher configuration.
@@ -48,7 +70,53 @@ type yamlDefaults struct {
 
 // yamlGeneralConfig is the raw YAML structure for general settings.
 type yamlGeneralConfig struct {
-	AutostartDolt *bool `yaml:"autostart_dolt,omitempty"`
+	AutostartDolt *bool  `yaml:"autostart_dolt,omitempty"`
+	AgentStore    string `yaml:"agent_store,omitempty"`
+}
+
+// yamlAutopilot is the raw YAML structure for autopilot settings.
+type yamlAutopilot struct {
+	PollInterval  string              `yaml:"poll_interval,omitempty"`
+	MaxConcurrent int                 `yaml:"max_concurrent,omitempty"`
+	MaxPerProject int                 `yaml:"max_per_project,omitempty"`
+	PauseFile     string              `yaml:"pause_file,omitempty"`
+	Rules         []yamlAutopilotRule `yaml:"rules,omitempty"`
+}
+
+// yamlAutopilotRule is the raw YAML structure for a single dispatch rule.
+type yamlAutopilotRule struct {
+	Name        string   `yaml:"name,omitempty"`
+	IssueTypes  []string `yaml:"issue_types,omitempty"`
+	PriorityMin *int     `yaml:"priority_min,omitempty"`
+	PriorityMax *int     `yaml:"priority_max,omitempty"`
+	TitleRegex  string   `yaml:"title_regex,omitempty"`
+	Harness     string   `yaml:"harness"`
+	Model       string   `yaml:"model,omitempty"`
+	Agent       string   `yaml:"agent,omitempty"`
+}
+
+// yamlEvents is the raw YAML structure for the event log and hooks.
+type yamlEvents struct {
+	LogFile     string          `yaml:"log_file,omitempty"`
+	DisableLog  bool            `yaml:"disable_log,omitempty"`
+	HookTimeout string          `yaml:"hook_timeout,omitempty"`
+	Hooks       []yamlEventHook `yaml:"hooks,omitempty"`
+}
+
+// yamlEventHook is the raw YAML structure for a single shell hook.
+type yamlEventHook struct {
+	Name    string   `yaml:"name,omitempty"`
+	Events  []string `yaml:"events,omitempty"`
+	Command string   `yaml:"command"`
+}
+
+// yamlWriteback is the raw YAML structure for writing back to beads.
+type yamlWriteback struct {
+	ClaimOnLaunch   bool   `yaml:"claim_on_launch,omitempty"`
+	Assignee        string `yaml:"assignee,omitempty"`
+	CommentOnLaunch bool   `yaml:"comment_on_launch,omitempty"`
+	CommentOnFinish bool   `yaml:"comment_on_finish,omitempty"`
+	Author          string `yaml:"author,omitempty"`
 }
 
 // YAMLLoader implements the Loader interface for YAML configuration files.
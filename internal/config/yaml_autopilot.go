@@ -0,0 +1,128 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// DefaultAutopilotPollInterval is used when autopilot.poll_interval is omitted.
+const DefaultAutopilotPollInterval = 30 * time.Second
+
+// minAutopilotPollInterval keeps autopilot from hammering the Dolt server.
+const minAutopilotPollInterval = time.Second
+
+// convertAutopilot validates the autopilot section against the configured harnesses.
+func (l *YAMLLoader) convertAutopilot(raw *yamlAutopilot, harnesses []domain.Harness) (*domain.AutopilotConfig, error) {
+	cfg := &domain.AutopilotConfig{
+		PollInterval:  DefaultAutopilotPollInterval,
+		MaxConcurrent: raw.MaxConcurrent,
+		MaxPerProject: raw.MaxPerProject,
+		PauseFile:     raw.PauseFile,
+	}
+
+	if raw.PollInterval != "" {
+		interval, err := time.ParseDuration(raw.PollInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid autopilot.poll_interval %q: %w", raw.PollInterval, err)
+		}
+		if interval < minAutopilotPollInterval {
+			return nil, fmt.Errorf("autopilot.poll_interval must be at least %s, got %s", minAutopilotPollInterval, interval)
+		}
+		cfg.PollInterval = interval
+	}
+
+	if raw.MaxConcurrent < 0 || raw.MaxPerProject < 0 {
+		return nil, fmt.Errorf("autopilot.max_concurrent and autopilot.max_per_project must not be negative")
+	}
+
+	byName := make(map[string]domain.Harness, len(harnesses))
+	for _, h := range harnesses {
+		byName[h.Name] = h
+	}
+
+	for i, rawRule := range raw.Rules {
+		rule, err := convertAutopilotRule(rawRule, i, byName)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Rules = append(cfg.Rules, rule)
+	}
+
+	return cfg, nil
+}
+
+func convertAutopilotRule(raw yamlAutopilotRule, index int, harnesses map[string]domain.Harness) (domain.AutopilotRule, error) {
+	label := raw.Name
+	if label == "" {
+		label = fmt.Sprintf("#%d", index)
+	}
+
+	harness, ok := harnesses[raw.Harness]
+	if raw.Harness == "" {
+		return domain.AutopilotRule{}, fmt.Errorf("autopilot rule %s is missing required field: harness", label)
+	}
+	if !ok {
+		return domain.AutopilotRule{}, fmt.Errorf("autopilot rule %s references unknown harness %q", label, raw.Harness)
+	}
+
+	if raw.Agent != "" && len(harness.SupportedAgents) > 0 && !slices.Contains(harness.SupportedAgents, raw.Agent) {
+		return domain.AutopilotRule{}, fmt.Errorf("autopilot rule %s: agent %q is not supported by harness %q", label, raw.Agent, raw.Harness)
+	}
+
+	if raw.PriorityMin != nil && raw.PriorityMax != nil && *raw.PriorityMin > *raw.PriorityMax {
+		return domain.AutopilotRule{}, fmt.Errorf("autopilot rule %s: priority_min %d is greater than priority_max %d",
+			label, *raw.PriorityMin, *raw.PriorityMax)
+	}
+
+	if raw.TitleRegex != "" {
+		if _, err := regexp.Compile(raw.TitleRegex); err != nil {
+			return domain.AutopilotRule{}, fmt.Errorf("autopilot rule %s: invalid title_regex: %w", label, err)
+		}
+	}
+
+	return domain.AutopilotRule{
+		Name:        raw.Name,
+		IssueTypes:  raw.IssueTypes,
+		PriorityMin: raw.PriorityMin,
+		PriorityMax: raw.PriorityMax,
+		TitleRegex:  raw.TitleRegex,
+		Harness:     raw.Harness,
+		Model:       raw.Model,
+		Agent:       raw.Agent,
+	}, nil
+}
+
+// autopilotToYAML converts the autopilot section back to its YAML form.
+func autopilotToYAML(cfg *domain.AutopilotConfig) *yamlAutopilot {
+	raw := &yamlAutopilot{
+		MaxConcurrent: cfg.MaxConcurrent,
+		MaxPerProject: cfg.MaxPerProject,
+		PauseFile:     cfg.PauseFile,
+	}
+	if cfg.PollInterval > 0 && cfg.PollInterval != DefaultAutopilotPollInterval {
+		raw.PollInterval = cfg.PollInterval.String()
+	}
+	for _, rule := range cfg.Rules {
+		raw.Rules = append(raw.Rules, yamlAutopilotRule{
+			Name:        rule.Name,
+			IssueTypes:  rule.IssueTypes,
+			PriorityMin: rule.PriorityMin,
+			PriorityMax: rule.PriorityMax,
+			TitleRegex:  rule.TitleRegex,
+			Harness:     rule.Harness,
+			Model:       rule.Model,
+			Agent:       rule.Agent,
+		})
+	}
+	return raw
+}
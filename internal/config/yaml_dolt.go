@@ -0,0 +1,92 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// doltTLSModes are the TLS modes the MySQL driver understands without a
+// registered config.
+var doltTLSModes = []string{"true", "false", "skip-verify", "preferred"}
+
+// convertDoltConnection converts a project's dolt section, which may be nil.
+// Relative file paths are resolved against configDir.
+func convertDoltConnection(raw *yamlDoltConnection, configDir string) (domain.DoltConnection, error) {
+	if raw == nil {
+		return domain.DoltConnection{}, nil
+	}
+	resolve := func(path string) string {
+		path = strings.TrimSpace(path)
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(configDir, path)
+	}
+
+	conn := domain.DoltConnection{
+		DSN:             strings.TrimSpace(raw.DSN),
+		Host:            strings.TrimSpace(raw.Host),
+		Port:            raw.Port,
+		Socket:          resolve(raw.Socket),
+		User:            strings.TrimSpace(raw.User),
+		Database:        strings.TrimSpace(raw.Database),
+		PasswordFile:    resolve(raw.PasswordFile),
+		PasswordCommand: strings.TrimSpace(raw.PasswordCommand),
+		TLS:             strings.TrimSpace(raw.TLS),
+		TLSCA:           resolve(raw.TLSCA),
+		TLSCert:         resolve(raw.TLSCert),
+		TLSKey:          resolve(raw.TLSKey),
+		TLSServerName:   strings.TrimSpace(raw.TLSServerName),
+	}
+
+	if conn.Port < 0 || conn.Port > 65535 {
+		return conn, fmt.Errorf("dolt.port: %d is not a valid port", conn.Port)
+	}
+	if conn.PasswordFile != "" && conn.PasswordCommand != "" {
+		return conn, fmt.Errorf("dolt: set either password_file or password_command, not both")
+	}
+	if conn.TLS != "" && !slices.Contains(doltTLSModes, conn.TLS) {
+		return conn, fmt.Errorf("dolt.tls: unknown mode %q, expected one of %s",
+			conn.TLS, strings.Join(doltTLSModes, ", "))
+	}
+	if conn.TLS == "false" && (conn.TLSCA != "" || conn.TLSCert != "" || conn.TLSKey != "") {
+		return conn, fmt.Errorf("dolt.tls is false, but tls_ca, tls_cert or tls_key is set")
+	}
+	if (conn.TLSCert == "") != (conn.TLSKey == "") {
+		return conn, fmt.Errorf("dolt: tls_cert and tls_key must be set together")
+	}
+	return conn, nil
+}
+
+// doltConnectionToYAML converts a connection profile back to its YAML form,
+// or nil if it overrides nothing.
+func doltConnectionToYAML(conn domain.DoltConnection) *yamlDoltConnection {
+	if conn.IsZero() {
+		return nil
+	}
+	return &yamlDoltConnection{
+		DSN:             conn.DSN,
+		Host:            conn.Host,
+		Port:            conn.Port,
+		Socket:          conn.Socket,
+		User:            conn.User,
+		Database:        conn.Database,
+		PasswordFile:    conn.PasswordFile,
+		PasswordCommand: conn.PasswordCommand,
+		TLS:             conn.TLS,
+		TLSCA:           conn.TLSCA,
+		TLSCert:         conn.TLSCert,
+		TLSKey:          conn.TLSKey,
+		TLSServerName:   conn.TLSServerName,
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/events"
+)
+
+// convertEvents validates the events section.
+func (l *YAMLLoader) convertEvents(raw *yamlEvents) (*domain.EventsConfig, error) {
+	cfg := &domain.EventsConfig{
+		LogFile:     raw.LogFile,
+		DisableLog:  raw.DisableLog,
+		HookTimeout: events.DefaultHookTimeout,
+	}
+
+	if raw.HookTimeout != "" {
+		timeout, err := time.ParseDuration(raw.HookTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid events.hook_timeout %q: %w", raw.HookTimeout, err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("events.hook_timeout must be positive, got %s", timeout)
+		}
+		cfg.HookTimeout = timeout
+	}
+
+	for i, rawHook := range raw.Hooks {
+		label := rawHook.Name
+		if label == "" {
+			label = fmt.Sprintf("#%d", i)
+		}
+		if rawHook.Command == "" {
+			return nil, fmt.Errorf("event hook %s is missing required field: command", label)
+		}
+		for _, name := range rawHook.Events {
+			if !slices.Contains(events.Types, events.Type(name)) {
+				return nil, fmt.Errorf("event hook %s references unknown event %q", label, name)
+			}
+		}
+		cfg.Hooks = append(cfg.Hooks, domain.EventHook{
+			Name:    rawHook.Name,
+			Events:  rawHook.Events,
+			Command: rawHook.Command,
+		})
+	}
+
+	return cfg, nil
+}
+
+// eventsToYAML converts the events section back to its YAML form.
+func eventsToYAML(cfg *domain.EventsConfig) *yamlEvents {
+	raw := &yamlEvents{
+		LogFile:    cfg.LogFile,
+		DisableLog: cfg.DisableLog,
+	}
+	if cfg.HookTimeout > 0 && cfg.HookTimeout != events.DefaultHookTimeout {
+		raw.HookTimeout = cfg.HookTimeout.String()
+	}
+	for _, hook := range cfg.Hooks {
+		raw.Hooks = append(raw.Hooks, yamlEventHook{
+			Name:    hook.Name,
+			Events:  hook.Events,
+			Command: hook.Command,
+		})
+	}
+	return raw
+}
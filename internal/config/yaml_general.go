@@ -0,0 +1,36 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// convertGeneral converts the general section, which may be nil.
+// autostart_dolt defaults to true and agent_store to dolt.
+func convertGeneral(raw *yamlGeneralConfig) (*domain.GeneralConfig, error) {
+	cfg := &domain.GeneralConfig{AutostartDolt: true, AgentStore: domain.AgentStoreDolt}
+	if raw == nil {
+		return cfg, nil
+	}
+	if raw.AutostartDolt != nil {
+		cfg.AutostartDolt = *raw.AutostartDolt
+	}
+
+	switch store := strings.TrimSpace(raw.AgentStore); store {
+	case "":
+	case domain.AgentStoreDolt, domain.AgentStoreFile:
+		cfg.AgentStore = store
+	default:
+		return nil, fmt.Errorf("general.agent_store: unknown store %q, expected %q or %q",
+			raw.AgentStore, domain.AgentStoreDolt, domain.AgentStoreFile)
+	}
+	return cfg, nil
+}
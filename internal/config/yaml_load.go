@@ -113,9 +113,14 @@ func (l *YAMLLoader) parseWorkspace(defaultWorkspace yamlWorkspace, configDir st
 		if name == "" {
 			name = filepath.Base(p.Dir)
 		}
+		conn, err := convertDoltConnection(p.Dolt, configDir)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", name, err)
+		}
 		projects = append(projects, domain.Project{
 			Dir:  cleanDir,
 			Name: name,
+			Dolt: conn,
 		})
 	}
 	return projects, nil
@@ -171,11 +176,31 @@ func (l *YAMLLoader) convertAndValidate(raw *yamlConfig, configDir string) (*dom
 		}
 	}
 
-	autostart := true
-	if raw.General != nil && raw.General.AutostartDolt != nil {
-		autostart = *raw.General.AutostartDolt
+	general, err := convertGeneral(raw.General)
+	if err != nil {
+		return nil, err
+	}
+	config.General = general
+
+	if raw.Autopilot != nil {
+		autopilot, err := l.convertAutopilot(raw.Autopilot, config.Harnesses)
+		if err != nil {
+			return nil, err
+		}
+		config.Autopilot = autopilot
+	}
+
+	if raw.Events != nil {
+		eventsCfg, err := l.convertEvents(raw.Events)
+		if err != nil {
+			return nil, err
+		}
+		config.Events = eventsCfg
+	}
+
+	if raw.Writeback != nil {
+		config.Writeback = l.convertWriteback(raw.Writeback)
 	}
-	config.General = &domain.GeneralConfig{AutostartDolt: autostart}
 
 	return config, nil
 }
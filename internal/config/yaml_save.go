@@ -64,15 +64,29 @@ func (l *YAMLLoader) domainToYAML(cfg *domain.Config) yamlConfig {
 		autostart := cfg.General.AutostartDolt
 		yamlCfg.General = &yamlGeneralConfig{
 			AutostartDolt: &autostart,
+			AgentStore:    cfg.General.AgentStore,
 		}
 	}
 
+	if cfg.Autopilot != nil {
+		yamlCfg.Autopilot = autopilotToYAML(cfg.Autopilot)
+	}
+
+	if cfg.Events != nil {
+		yamlCfg.Events = eventsToYAML(cfg.Events)
+	}
+
+	if cfg.Writeback != nil {
+		yamlCfg.Writeback = writebackToYAML(cfg.Writeback)
+	}
+
 	if len(cfg.Workspace.Projects) > 0 {
 		projects := make([]yamlProject, len(cfg.Workspace.Projects))
 		for i, project := range cfg.Workspace.Projects {
 			projects[i] = yamlProject{
 				Dir:  project.Dir,
 				Name: project.Name,
+				Dolt: doltConnectionToYAML(project.Dolt),
 			}
 		}
 		yamlCfg.Workspaces = map[string]yamlWorkspace{
@@ -880,6 +880,111 @@ harnesses:
 	}
 }
 
+func TestYAMLLoader_Load_GeneralConfig_AgentStore(t *testing.T) {
+	tests := []struct {
+		name      string
+		general   string
+		wantStore string
+		wantErr   string
+	}{
+		{name: "default", general: "", wantStore: domain.AgentStoreDolt},
+		{name: "file", general: "general:\n  agent_store: file\n", wantStore: domain.AgentStoreFile},
+		{name: "dolt", general: "general:\n  agent_store: dolt\n", wantStore: domain.AgentStoreDolt},
+		{name: "unknown", general: "general:\n  agent_store: sqlite\n", wantErr: `unknown store "sqlite"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			content := tt.general + "harnesses:\n  - name: test\n    command_template: \"test\"\n"
+			if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			config, err := NewYAMLLoader().Load(configPath)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if config.General.AgentStore != tt.wantStore {
+				t.Errorf("AgentStore = %q, want %q", config.General.AgentStore, tt.wantStore)
+			}
+		})
+	}
+}
+
+func TestYAMLLoader_Load_ProjectDoltConnection(t *testing.T) {
+	tests := []struct {
+		name    string
+		dolt    string
+		want    domain.DoltConnection
+		wantErr string
+	}{
+		{name: "none", dolt: ""},
+		{
+			name: "socket and password file",
+			dolt: "socket: /run/dolt.sock\nuser: bdb\npassword_file: secrets/dolt\n",
+			want: domain.DoltConnection{Socket: "/run/dolt.sock", User: "bdb", PasswordFile: "CONFIG/secrets/dolt"},
+		},
+		{
+			name: "tls files",
+			dolt: "host: db.internal\nport: 3306\ntls: skip-verify\ntls_ca: ca.pem\ntls_cert: /etc/dolt/client.pem\ntls_key: /etc/dolt/client.key\n",
+			want: domain.DoltConnection{
+				Host: "db.internal", Port: 3306, TLS: "skip-verify",
+				TLSCA: "CONFIG/ca.pem", TLSCert: "/etc/dolt/client.pem", TLSKey: "/etc/dolt/client.key",
+			},
+		},
+		{name: "dsn", dolt: "dsn: root@tcp(db:3306)/\n", want: domain.DoltConnection{DSN: "root@tcp(db:3306)/"}},
+		{name: "both password sources", dolt: "password_file: p\npassword_command: pass dolt\n", wantErr: "not both"},
+		{name: "unknown tls mode", dolt: "tls: maybe\n", wantErr: `unknown mode "maybe"`},
+		{name: "tls disabled with files", dolt: "tls: \"false\"\ntls_ca: ca.pem\n", wantErr: "tls is false"},
+		{name: "cert without key", dolt: "tls_cert: client.pem\n", wantErr: "set together"},
+		{name: "invalid port", dolt: "port: 70000\n", wantErr: "not a valid port"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			content := "workspaces:\n  default:\n    projects:\n      - dir: " + tmpDir + "\n"
+			if tt.dolt != "" {
+				content += "        dolt:\n"
+				for _, line := range strings.Split(strings.TrimSuffix(tt.dolt, "\n"), "\n") {
+					content += "          " + line + "\n"
+				}
+			}
+			content += "harnesses:\n  - name: test\n    command_template: \"test\"\n"
+			configPath := filepath.Join(tmpDir, "config.yaml")
+			if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			config, err := NewYAMLLoader().Load(configPath)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			want := tt.want
+			for _, path := range []*string{&want.PasswordFile, &want.TLSCA} {
+				*path = strings.Replace(*path, "CONFIG", tmpDir, 1)
+			}
+			if got := config.Workspace.Projects[0].Dolt; got != want {
+				t.Errorf("Dolt = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
 func TestYAMLLoader_Load_MissingProjectDirectory(t *testing.T) {
 	yamlContent := `
 workspaces:
@@ -1019,7 +1124,7 @@ func TestYAMLLoader_SaveAndLoad(t *testing.T) {
 		Workspace: domain.Workspace{
 			Name: "default",
 			Projects: []domain.Project{
-				{Dir: projectDir, Name: "test-project"},
+				{Dir: projectDir, Name: "test-project", Dolt: domain.DoltConnection{Socket: "/run/dolt.sock", TLS: "true"}},
 			},
 		},
 	}
@@ -1060,7 +1165,10 @@ func TestYAMLLoader_SaveAndLoad(t *testing.T) {
 		t.Error("General config not preserved correctly")
 	}
 	if len(loadedCfg.Workspace.Projects) != 1 {
-		t.Errorf("Expected 1 project, got %d", len(loadedCfg.Workspace.Projects))
+		t.Fatalf("Expected 1 project, got %d", len(loadedCfg.Workspace.Projects))
+	}
+	if got := loadedCfg.Workspace.Projects[0].Dolt; got != cfg.Workspace.Projects[0].Dolt {
+		t.Errorf("Dolt connection not preserved correctly, got %+v", got)
 	}
 }
 
@@ -1093,3 +1201,262 @@ func TestYAMLLoader_SaveEmptyConfig(t *testing.T) {
 		t.Errorf("Expected 1 harness, got %d", len(loadedCfg.Harnesses))
 	}
 }
+
+func TestYAMLLoader_Load_Autopilot(t *testing.T) {
+	yamlContent := `
+harnesses:
+  - name: opencode
+    command_template: "opencode --agent {{.Agent}}"
+    agents: [build, plan]
+autopilot:
+  poll_interval: 1m
+  max_concurrent: 3
+  max_per_project: 1
+  rules:
+    - name: bugs
+      issue_types: [bug]
+      priority_max: 1
+      title_regex: "^fix"
+      harness: opencode
+      agent: build
+`
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "test.yaml")
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	loader := NewYAMLLoader()
+	cfg, err := loader.Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	ap := cfg.Autopilot
+	if ap == nil {
+		t.Fatal("Expected autopilot config")
+	}
+	if ap.PollInterval != time.Minute || ap.MaxConcurrent != 3 || ap.MaxPerProject != 1 {
+		t.Errorf("Unexpected autopilot settings: %+v", ap)
+	}
+	if len(ap.Rules) != 1 || ap.Rules[0].PriorityMin != nil || *ap.Rules[0].PriorityMax != 1 {
+		t.Fatalf("Unexpected rules: %+v", ap.Rules)
+	}
+
+	// Round-trip through Save.
+	savedPath := filepath.Join(tmpDir, "saved.yaml")
+	if err := loader.Save(savedPath, cfg); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	reloaded, err := loader.Load(savedPath)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if reloaded.Autopilot == nil || reloaded.Autopilot.PollInterval != time.Minute ||
+		len(reloaded.Autopilot.Rules) != 1 || reloaded.Autopilot.Rules[0].TitleRegex != "^fix" {
+		t.Errorf("Autopilot config not preserved: %+v", reloaded.Autopilot)
+	}
+}
+
+func TestYAMLLoader_Load_AutopilotDefaults(t *testing.T) {
+	yamlContent := `
+harnesses:
+  - name: opencode
+    command_template: "opencode"
+autopilot:
+  rules:
+    - harness: opencode
+`
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "test.yaml")
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := NewYAMLLoader().Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Autopilot.PollInterval != DefaultAutopilotPollInterval {
+		t.Errorf("Expected default poll interval, got %s", cfg.Autopilot.PollInterval)
+	}
+}
+
+func TestYAMLLoader_Load_AutopilotInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		wantErr string
+	}{
+		{"unknown harness", "rules: [{harness: missing}]", `unknown harness "missing"`},
+		{"missing harness", "rules: [{name: r}]", "missing required field: harness"},
+		{"bad regex", "rules: [{harness: opencode, title_regex: '('}]", "invalid title_regex"},
+		{"inverted priority", "rules: [{harness: opencode, priority_min: 3, priority_max: 1}]", "greater than priority_max"},
+		{"unsupported agent", "rules: [{harness: opencode, agent: review}]", `agent "review" is not supported`},
+		{"bad interval", "poll_interval: soon", "invalid autopilot.poll_interval"},
+		{"short interval", "poll_interval: 10ms", "at least"},
+		{"negative cap", "max_concurrent: -1", "must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlContent := fmt.Sprintf(`
+harnesses:
+  - name: opencode
+    command_template: "opencode"
+    agents: [build]
+autopilot:
+  %s
+`, tt.section)
+			configPath := filepath.Join(t.TempDir(), "test.yaml")
+			if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			_, err := NewYAMLLoader().Load(configPath)
+			if err == nil {
+				t.Fatal("Expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestYAMLLoader_Load_Events(t *testing.T) {
+	yamlContent := `
+harnesses:
+  - name: opencode
+    command_template: "opencode"
+events:
+  log_file: /tmp/bdb-events.jsonl
+  hook_timeout: 5s
+  hooks:
+    - name: notify
+      events: [agent.exited, agent.failed]
+      command: notify-send "$BDB_TICKET $BDB_EVENT"
+    - command: cat >> /tmp/all-events
+`
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "test.yaml")
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	loader := NewYAMLLoader()
+	cfg, err := loader.Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	ev := cfg.Events
+	if ev == nil {
+		t.Fatal("Expected events config")
+	}
+	if ev.LogFile != "/tmp/bdb-events.jsonl" || ev.HookTimeout != 5*time.Second || len(ev.Hooks) != 2 {
+		t.Fatalf("Unexpected events settings: %+v", ev)
+	}
+	if ev.Hooks[0].Name != "notify" || len(ev.Hooks[0].Events) != 2 || len(ev.Hooks[1].Events) != 0 {
+		t.Errorf("Unexpected hooks: %+v", ev.Hooks)
+	}
+
+	savedPath := filepath.Join(tmpDir, "saved.yaml")
+	if err := loader.Save(savedPath, cfg); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	reloaded, err := loader.Load(savedPath)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if reloaded.Events == nil || reloaded.Events.HookTimeout != 5*time.Second ||
+		len(reloaded.Events.Hooks) != 2 || reloaded.Events.Hooks[1].Command != "cat >> /tmp/all-events" {
+		t.Errorf("Events config not preserved: %+v", reloaded.Events)
+	}
+}
+
+func TestYAMLLoader_Load_Writeback(t *testing.T) {
+	yamlContent := `
+harnesses:
+  - name: opencode
+    command_template: "opencode"
+writeback:
+  claim_on_launch: true
+  assignee: alice
+  comment_on_finish: true
+`
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "test.yaml")
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	loader := NewYAMLLoader()
+	cfg, err := loader.Load(configPath)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	wb := cfg.Writeback
+	if wb == nil {
+		t.Fatal("Expected writeback config")
+	}
+	want := domain.WritebackConfig{
+		ClaimOnLaunch:   true,
+		Assignee:        "alice",
+		CommentOnFinish: true,
+		Author:          DefaultWritebackAuthor,
+	}
+	if *wb != want {
+		t.Errorf("Unexpected writeback settings: got %+v, want %+v", *wb, want)
+	}
+
+	savedPath := filepath.Join(tmpDir, "saved.yaml")
+	if err := loader.Save(savedPath, cfg); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	reloaded, err := loader.Load(savedPath)
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if reloaded.Writeback == nil || *reloaded.Writeback != want {
+		t.Errorf("Writeback config not preserved: %+v", reloaded.Writeback)
+	}
+}
+
+func TestYAMLLoader_Load_EventsInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		wantErr string
+	}{
+		{"unknown event", "hooks: [{command: 'true', events: [agent.started]}]", `unknown event "agent.started"`},
+		{"missing command", "hooks: [{name: h}]", "missing required field: command"},
+		{"bad timeout", "hook_timeout: soon", "invalid events.hook_timeout"},
+		{"zero timeout", "hook_timeout: 0s", "must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlContent := fmt.Sprintf(`
+harnesses:
+  - name: opencode
+    command_template: "opencode"
+events:
+  %s
+`, tt.section)
+			configPath := filepath.Join(t.TempDir(), "test.yaml")
+			if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			_, err := NewYAMLLoader().Load(configPath)
+			if err == nil {
+				t.Fatal("Expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -9,20 +9,28 @@ package config
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 // yamlTUIConfig is the raw YAML structure for TUI-specific configuration.
 type yamlTUIConfig struct {
-	FilePickerRecents    []string `yaml:"filepicker_recents,omitempty"`
-	FilePickerMaxRecents int      `yaml:"filepicker_max_recents,omitempty"`
+	FilePickerRecents    []string            `yaml:"filepicker_recents,omitempty"`
+	FilePickerMaxRecents int                 `yaml:"filepicker_max_recents,omitempty"`
+	Theme                string              `yaml:"theme,omitempty"`
+	Keys                 map[string][]string `yaml:"keys,omitempty"`
+	FilePickerKeys       map[string][]string `yaml:"filepicker_keys,omitempty"`
+	FilterPresets        []FilterPreset      `yaml:"filter_presets,omitempty"`
 }
 
 // LoadTUIConfig reads and parses a TUI YAML configuration file.
 // If the file does not exist, returns defaults (no error).
 // Returns actionable errors for parse errors or other I/O failures.
 // If FilePickerMaxRecents is not specified or invalid, defaults to DefaultMaxRecents.
+// Key binding overrides are checked for empty entries here; action names and
+// conflicts are validated by the UI, which owns the default bindings.
 func LoadTUIConfig(path string) (*TUIConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -39,8 +47,22 @@ func LoadTUIConfig(path string) (*TUIConfig, error) {
 		return nil, fmt.Errorf("failed to parse TUI YAML in %s: %w", path, err)
 	}
 
+	if err := validateKeyOverrides("keys", raw.Keys); err != nil {
+		return nil, fmt.Errorf("invalid TUI config %s: %w", path, err)
+	}
+	if err := validateKeyOverrides("filepicker_keys", raw.FilePickerKeys); err != nil {
+		return nil, fmt.Errorf("invalid TUI config %s: %w", path, err)
+	}
+	if err := validateFilterPresets(raw.FilterPresets); err != nil {
+		return nil, fmt.Errorf("invalid TUI config %s: %w", path, err)
+	}
+
 	cfg := &TUIConfig{
 		FilePickerRecents: raw.FilePickerRecents,
+		Theme:             raw.Theme,
+		Keys:              raw.Keys,
+		FilePickerKeys:    raw.FilePickerKeys,
+		FilterPresets:     raw.FilterPresets,
 	}
 
 	// Default FilePickerMaxRecents to DefaultMaxRecents if not specified or invalid
@@ -57,6 +79,10 @@ func LoadTUIConfig(path string) (*TUIConfig, error) {
 func SaveTUIConfig(path string, cfg *TUIConfig) error {
 	yamlCfg := yamlTUIConfig{
 		FilePickerRecents: cfg.FilePickerRecents,
+		Theme:             cfg.Theme,
+		Keys:              cfg.Keys,
+		FilePickerKeys:    cfg.FilePickerKeys,
+		FilterPresets:     cfg.FilterPresets,
 	}
 
 	if cfg.FilePickerMaxRecents > 0 {
@@ -74,3 +100,45 @@ func SaveTUIConfig(path string, cfg *TUIConfig) error {
 
 	return nil
 }
+
+// validateKeyOverrides rejects actions bound to no keys or to blank keys.
+func validateKeyOverrides(section string, overrides map[string][]string) error {
+	actions := make([]string, 0, len(overrides))
+	for action := range overrides {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	for _, action := range actions {
+		keys := overrides[action]
+		if len(keys) == 0 {
+			return fmt.Errorf("%s.%s: at least one key is required", section, action)
+		}
+		for _, k := range keys {
+			if strings.TrimSpace(k) == "" {
+				return fmt.Errorf("%s.%s: keys must not be empty", section, action)
+			}
+		}
+	}
+	return nil
+}
+
+// validateFilterPresets rejects unnamed or duplicate presets and inverted
+// priority ranges.
+func validateFilterPresets(presets []FilterPreset) error {
+	seen := make(map[string]bool, len(presets))
+	for i, p := range presets {
+		name := strings.TrimSpace(p.Name)
+		if name == "" {
+			return fmt.Errorf("filter_presets[%d]: name is required", i)
+		}
+		if seen[name] {
+			return fmt.Errorf("filter_presets[%d]: duplicate name %q", i, name)
+		}
+		seen[name] = true
+		if p.MinPriority != nil && p.MaxPriority != nil && *p.MinPriority > *p.MaxPriority {
+			return fmt.Errorf("filter_presets.%s: min_priority %d is greater than max_priority %d", name, *p.MinPriority, *p.MaxPriority)
+		}
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -288,3 +289,141 @@ func TestDefaultMaxRecents(t *testing.T) {
 		t.Errorf("Expected DefaultMaxRecents to be 5, got %d", DefaultMaxRecents)
 	}
 }
+
+func TestLoadTUIConfig_KeyOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "tui_config.yaml")
+
+	yamlContent := `
+keys:
+  quit: [x, ctrl+c]
+  clear_agent: [d]
+filepicker_keys:
+  up: [e, up]
+`
+
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0o600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadTUIConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadTUIConfig failed: %v", err)
+	}
+
+	if got := cfg.Keys["quit"]; len(got) != 2 || got[0] != "x" || got[1] != "ctrl+c" {
+		t.Errorf("Expected quit keys [x ctrl+c], got %v", got)
+	}
+	if got := cfg.FilePickerKeys["up"]; len(got) != 2 || got[0] != "e" {
+		t.Errorf("Expected filepicker up keys [e up], got %v", got)
+	}
+
+	// Saving recents must not drop key overrides.
+	cfg.FilePickerRecents = []string{"/path/to/one"}
+	if err := SaveTUIConfig(configPath, cfg); err != nil {
+		t.Fatalf("SaveTUIConfig failed: %v", err)
+	}
+	reloaded, err := LoadTUIConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadTUIConfig after save failed: %v", err)
+	}
+	if got := reloaded.Keys["clear_agent"]; len(got) != 1 || got[0] != "d" {
+		t.Errorf("Expected clear_agent keys [d] after save, got %v", got)
+	}
+}
+
+func TestLoadTUIConfig_InvalidKeyOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"empty list", "keys:\n  quit: []\n", "keys.quit: at least one key is required"},
+		{"blank key", "filepicker_keys:\n  up: [\"\"]\n", "filepicker_keys.up: keys must not be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "tui_config.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.yaml), 0o600); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			_, err := LoadTUIConfig(configPath)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadTUIConfig_FilterPresets(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "tui_config.yaml")
+
+	yamlContent := `
+filter_presets:
+  - name: my bugs
+    type: bug
+    assignee: alice
+    min_priority: 0
+    max_priority: 1
+  - name: backend
+    label: backend
+`
+
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0o600); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := LoadTUIConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadTUIConfig failed: %v", err)
+	}
+	if len(cfg.FilterPresets) != 2 {
+		t.Fatalf("Expected 2 filter presets, got %d", len(cfg.FilterPresets))
+	}
+	bugs := cfg.FilterPresets[0]
+	if bugs.Name != "my bugs" || bugs.IssueType != "bug" || bugs.MinPriority == nil || *bugs.MinPriority != 0 || *bugs.MaxPriority != 1 {
+		t.Errorf("Unexpected first preset: %+v", bugs)
+	}
+	if cfg.FilterPresets[1].MinPriority != nil {
+		t.Errorf("Expected open priority range for the second preset")
+	}
+
+	if err := SaveTUIConfig(configPath, cfg); err != nil {
+		t.Fatalf("SaveTUIConfig failed: %v", err)
+	}
+	reloaded, err := LoadTUIConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadTUIConfig after save failed: %v", err)
+	}
+	if len(reloaded.FilterPresets) != 2 || reloaded.FilterPresets[1].Label != "backend" {
+		t.Errorf("Expected presets to survive a save, got %+v", reloaded.FilterPresets)
+	}
+}
+
+func TestLoadTUIConfig_InvalidFilterPresets(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"missing name", "filter_presets:\n  - type: bug\n", "filter_presets[0]: name is required"},
+		{"duplicate name", "filter_presets:\n  - name: a\n  - name: a\n", "duplicate name \"a\""},
+		{"inverted range", "filter_presets:\n  - name: a\n    min_priority: 3\n    max_priority: 1\n", "min_priority 3 is greater than max_priority 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "tui_config.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.yaml), 0o600); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			_, err := LoadTUIConfig(configPath)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
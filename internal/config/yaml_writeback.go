@@ -0,0 +1,45 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"strings"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// DefaultWritebackAuthor is used when writeback.author is omitted.
+const DefaultWritebackAuthor = "blunderbust"
+
+// convertWriteback converts the writeback section.
+func (l *YAMLLoader) convertWriteback(raw *yamlWriteback) *domain.WritebackConfig {
+	cfg := &domain.WritebackConfig{
+		ClaimOnLaunch:   raw.ClaimOnLaunch,
+		Assignee:        strings.TrimSpace(raw.Assignee),
+		CommentOnLaunch: raw.CommentOnLaunch,
+		CommentOnFinish: raw.CommentOnFinish,
+		Author:          strings.TrimSpace(raw.Author),
+	}
+	if cfg.Author == "" {
+		cfg.Author = DefaultWritebackAuthor
+	}
+	return cfg
+}
+
+// writebackToYAML converts the writeback section back to its YAML form.
+func writebackToYAML(cfg *domain.WritebackConfig) *yamlWriteback {
+	raw := &yamlWriteback{
+		ClaimOnLaunch:   cfg.ClaimOnLaunch,
+		Assignee:        cfg.Assignee,
+		CommentOnLaunch: cfg.CommentOnLaunch,
+		CommentOnFinish: cfg.CommentOnFinish,
+	}
+	if cfg.Author != DefaultWritebackAuthor {
+		raw.Author = cfg.Author
+	}
+	return raw
+}
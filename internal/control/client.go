@@ -0,0 +1,179 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package control
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// Client talks to a bdb control server.
+type Client struct {
+	http    *http.Client
+	baseURL string
+}
+
+// NewClient returns a Client connected to the Unix socket at socketPath.
+func NewClient(socketPath string) *Client {
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socketPath)
+		},
+	}
+	// The host is ignored by the dialer; it only has to form a valid URL.
+	return &Client{http: &http.Client{Transport: transport}, baseURL: "http://bdb"}
+}
+
+// Projects lists the workspace projects.
+func (c *Client) Projects(ctx context.Context) ([]Project, error) {
+	var projects []Project
+	err := c.do(ctx, http.MethodGet, "/v1/projects", nil, &projects)
+	return projects, err
+}
+
+// Tickets lists the ready tickets of a project ("" for the active project).
+func (c *Client) Tickets(ctx context.Context, project string) ([]Ticket, error) {
+	path := "/v1/tickets"
+	if project != "" {
+		path += "?project=" + url.QueryEscape(project)
+	}
+	var tickets []Ticket
+	err := c.do(ctx, http.MethodGet, path, nil, &tickets)
+	return tickets, err
+}
+
+// Harnesses lists the configured harnesses.
+func (c *Client) Harnesses(ctx context.Context) ([]Harness, error) {
+	var harnesses []Harness
+	err := c.do(ctx, http.MethodGet, "/v1/harnesses", nil, &harnesses)
+	return harnesses, err
+}
+
+// Agents lists the agents known to the TUI.
+func (c *Client) Agents(ctx context.Context) ([]Agent, error) {
+	var agents []Agent
+	err := c.do(ctx, http.MethodGet, "/v1/agents", nil, &agents)
+	return agents, err
+}
+
+// Launch starts an agent.
+func (c *Client) Launch(ctx context.Context, req LaunchRequest) (*LaunchResponse, error) {
+	var res LaunchResponse
+	if err := c.do(ctx, http.MethodPost, "/v1/launch", req, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Kill terminates an agent.
+func (c *Client) Kill(ctx context.Context, agentID string) error {
+	return c.do(ctx, http.MethodPost, "/v1/agents/"+url.PathEscape(agentID)+"/kill", nil, nil)
+}
+
+// Events streams agent events to fn until ctx is cancelled, the server
+// closes the stream or fn returns an error.
+func (c *Client) Events(ctx context.Context, fn func(Event) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/v1/events", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach bdb control socket: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return decodeError(resp)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var ev Event
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			return fmt.Errorf("malformed event: %w", err)
+		}
+		if err := fn(ev); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, context.Canceled) && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach bdb control socket: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return decodeError(resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("malformed response: %w", err)
+	}
+	return nil
+}
+
+// decodeError turns an error response back into an error wrapping the
+// matching sentinel, so callers can use errors.Is on both sides.
+func decodeError(resp *http.Response) error {
+	var body errorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Error == "" {
+		body.Error = resp.Status
+	}
+	apiErr := &apiError{msg: body.Error}
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		apiErr.kind = ErrNotFound
+	case http.StatusBadRequest:
+		apiErr.kind = ErrInvalidRequest
+	}
+	return apiErr
+}
+
+// apiError carries the server's message and unwraps to the sentinel
+// matching the HTTP status.
+type apiError struct {
+	msg  string
+	kind error
+}
+
+func (e *apiError) Error() string { return e.msg }
+func (e *apiError) Unwrap() error { return e.kind }
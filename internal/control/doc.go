@@ -0,0 +1,25 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package control implements the local JSON control API of a running bdb.
+//
+// The API is plain HTTP served on a Unix socket so that editor plugins and
+// status bars can list projects, tickets, harnesses and agents, trigger
+// launches, kill agents and stream agent status changes. The Server is
+// independent of the TUI: requests are delegated to a Backend, which the
+// ui package implements by turning them into Bubble Tea messages. Client
+// is the counterpart used by `bdb ctl`.
+//
+// Endpoints:
+//
+//	GET  /v1/projects
+//	GET  /v1/tickets?project=DIR
+//	GET  /v1/harnesses
+//	GET  /v1/agents
+//	POST /v1/launch             (LaunchRequest body)
+//	POST /v1/agents/{id}/kill
+//	GET  /v1/events             (newline-delimited JSON Event stream)
+package control
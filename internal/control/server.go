@@ -0,0 +1,256 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// eventBuffer is how many events a slow subscriber may lag behind before
+// further events are dropped for it.
+const eventBuffer = 64
+
+// Server serves the control API for a Backend.
+type Server struct {
+	backend Backend
+	mux     *http.ServeMux
+
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+
+	httpServer *http.Server
+	listener   net.Listener
+	socketPath string
+}
+
+// NewServer creates a Server answering requests with backend.
+func NewServer(backend Backend) *Server {
+	s := &Server{
+		backend:     backend,
+		mux:         http.NewServeMux(),
+		subscribers: make(map[chan Event]struct{}),
+	}
+	s.mux.HandleFunc("GET /v1/projects", s.handleProjects)
+	s.mux.HandleFunc("GET /v1/tickets", s.handleTickets)
+	s.mux.HandleFunc("GET /v1/harnesses", s.handleHarnesses)
+	s.mux.HandleFunc("GET /v1/agents", s.handleAgents)
+	s.mux.HandleFunc("POST /v1/launch", s.handleLaunch)
+	s.mux.HandleFunc("POST /v1/agents/{id}/kill", s.handleKill)
+	s.mux.HandleFunc("GET /v1/events", s.handleEvents)
+	return s
+}
+
+// Handler returns the HTTP handler, for tests and custom listeners.
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// DefaultSocketPath returns $XDG_RUNTIME_DIR/blunderbust/bdb.sock, falling
+// back to a per-user directory under the system temp dir.
+func DefaultSocketPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "blunderbust", "bdb.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("blunderbust-%d", os.Getuid()), "bdb.sock")
+}
+
+// Listen binds the Unix socket. A stale socket left by a crashed bdb is
+// removed; a socket another bdb is still serving on is an error.
+func (s *Server) Listen(socketPath string) error {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0o700); err != nil {
+		return fmt.Errorf("failed to create control socket directory: %w", err)
+	}
+	if _, err := os.Stat(socketPath); err == nil {
+		if conn, dialErr := net.DialTimeout("unix", socketPath, time.Second); dialErr == nil {
+			conn.Close()
+			return fmt.Errorf("control socket %s is already in use by another bdb", socketPath)
+		}
+		if err := os.Remove(socketPath); err != nil {
+			return fmt.Errorf("failed to remove stale control socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on control socket: %w", err)
+	}
+	if err := os.Chmod(socketPath, 0o600); err != nil {
+		listener.Close()
+		return fmt.Errorf("failed to restrict control socket permissions: %w", err)
+	}
+
+	s.listener = listener
+	s.socketPath = socketPath
+	s.httpServer = &http.Server{Handler: s.mux, ReadHeaderTimeout: 5 * time.Second}
+	return nil
+}
+
+// Serve handles connections on the socket bound by Listen until Close.
+func (s *Server) Serve() error {
+	if s.listener == nil {
+		return fmt.Errorf("control server is not listening")
+	}
+	if err := s.httpServer.Serve(s.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Close stops the server, ends event streams and removes the socket.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	for ch := range s.subscribers {
+		close(ch)
+		delete(s.subscribers, ch)
+	}
+	s.mu.Unlock()
+
+	if s.httpServer == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	err := s.httpServer.Shutdown(ctx)
+	_ = os.Remove(s.socketPath)
+	return err
+}
+
+// Publish sends an event to every /v1/events subscriber. It never blocks;
+// subscribers that fall behind miss events.
+func (s *Server) Publish(ev Event) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subscribers {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+func (s *Server) subscribe() chan Event {
+	ch := make(chan Event, eventBuffer)
+	s.mu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.mu.Unlock()
+	return ch
+}
+
+func (s *Server) unsubscribe(ch chan Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subscribers[ch]; ok {
+		delete(s.subscribers, ch)
+		close(ch)
+	}
+}
+
+func (s *Server) handleProjects(w http.ResponseWriter, r *http.Request) {
+	projects, err := s.backend.Projects(r.Context())
+	respond(w, projects, err)
+}
+
+func (s *Server) handleTickets(w http.ResponseWriter, r *http.Request) {
+	tickets, err := s.backend.Tickets(r.Context(), r.URL.Query().Get("project"))
+	respond(w, tickets, err)
+}
+
+func (s *Server) handleHarnesses(w http.ResponseWriter, r *http.Request) {
+	harnesses, err := s.backend.Harnesses(r.Context())
+	respond(w, harnesses, err)
+}
+
+func (s *Server) handleAgents(w http.ResponseWriter, r *http.Request) {
+	agents, err := s.backend.Agents(r.Context())
+	respond(w, agents, err)
+}
+
+func (s *Server) handleLaunch(w http.ResponseWriter, r *http.Request) {
+	var req LaunchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respond(w, nil, fmt.Errorf("%w: malformed JSON body: %v", ErrInvalidRequest, err))
+		return
+	}
+	if req.Ticket == "" || req.Harness == "" {
+		respond(w, nil, fmt.Errorf("%w: ticket and harness are required", ErrInvalidRequest))
+		return
+	}
+	res, err := s.backend.Launch(r.Context(), req)
+	respond(w, res, err)
+}
+
+func (s *Server) handleKill(w http.ResponseWriter, r *http.Request) {
+	err := s.backend.Kill(r.Context(), r.PathValue("id"))
+	respond(w, map[string]bool{"ok": true}, err)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respond(w, nil, errors.New("streaming is not supported"))
+		return
+	}
+
+	ch := s.subscribe()
+	defer s.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := enc.Encode(ev); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// errorResponse is the body of every non-2xx response.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func respond(w http.ResponseWriter, body any, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, ErrInvalidRequest):
+			status = http.StatusBadRequest
+		case errors.Is(err, context.DeadlineExceeded):
+			status = http.StatusGatewayTimeout
+		}
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(errorResponse{Error: err.Error()})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(body)
+}
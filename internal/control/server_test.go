@@ -0,0 +1,218 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package control
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBackend struct {
+	launched []LaunchRequest
+	killed   []string
+}
+
+func (b *fakeBackend) Projects(context.Context) ([]Project, error) {
+	return []Project{{Name: "app", Dir: "/src/app", Active: true}}, nil
+}
+
+func (b *fakeBackend) Tickets(_ context.Context, project string) ([]Ticket, error) {
+	if project != "" && project != "/src/app" {
+		return nil, fmt.Errorf("project %s: %w", project, ErrNotFound)
+	}
+	return []Ticket{{ID: "bb-1", Title: "Fix crash", Priority: 1}}, nil
+}
+
+func (b *fakeBackend) Harnesses(context.Context) ([]Harness, error) {
+	return []Harness{{Name: "opencode", Models: []string{"gpt-5"}, Agents: []string{"build"}}}, nil
+}
+
+func (b *fakeBackend) Agents(context.Context) ([]Agent, error) {
+	return []Agent{{ID: "bb-1", Status: "running", Ticket: "bb-1", Harness: "opencode"}}, nil
+}
+
+func (b *fakeBackend) Launch(_ context.Context, req LaunchRequest) (*LaunchResponse, error) {
+	b.launched = append(b.launched, req)
+	return &LaunchResponse{AgentID: req.Ticket, LauncherID: req.Ticket, PID: 42, Command: "opencode"}, nil
+}
+
+func (b *fakeBackend) Kill(_ context.Context, agentID string) error {
+	if agentID != "bb-1" {
+		return fmt.Errorf("agent %s: %w", agentID, ErrNotFound)
+	}
+	b.killed = append(b.killed, agentID)
+	return nil
+}
+
+func newTestServer(t *testing.T) (*Server, *fakeBackend, *Client) {
+	t.Helper()
+	backend := &fakeBackend{}
+	server := NewServer(backend)
+	ts := httptest.NewServer(server.Handler())
+	t.Cleanup(ts.Close)
+	t.Cleanup(func() { _ = server.Close() })
+	return server, backend, &Client{http: ts.Client(), baseURL: ts.URL}
+}
+
+func TestClient_Queries(t *testing.T) {
+	_, _, client := newTestServer(t)
+	ctx := context.Background()
+
+	projects, err := client.Projects(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []Project{{Name: "app", Dir: "/src/app", Active: true}}, projects)
+
+	tickets, err := client.Tickets(ctx, "/src/app")
+	require.NoError(t, err)
+	require.Len(t, tickets, 1)
+	assert.Equal(t, "bb-1", tickets[0].ID)
+
+	harnesses, err := client.Harnesses(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"build"}, harnesses[0].Agents)
+
+	agents, err := client.Agents(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "running", agents[0].Status)
+}
+
+func TestClient_NotFoundRoundTrips(t *testing.T) {
+	_, _, client := newTestServer(t)
+
+	_, err := client.Tickets(context.Background(), "/elsewhere")
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, "project /elsewhere: not found", err.Error())
+
+	err = client.Kill(context.Background(), "nope")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestClient_LaunchAndKill(t *testing.T) {
+	_, backend, client := newTestServer(t)
+	ctx := context.Background()
+
+	res, err := client.Launch(ctx, LaunchRequest{Ticket: "bb-1", Harness: "opencode", Model: "gpt-5"})
+	require.NoError(t, err)
+	assert.Equal(t, 42, res.PID)
+	require.Len(t, backend.launched, 1)
+	assert.Equal(t, "gpt-5", backend.launched[0].Model)
+
+	require.NoError(t, client.Kill(ctx, "bb-1"))
+	assert.Equal(t, []string{"bb-1"}, backend.killed)
+}
+
+func TestServer_LaunchValidation(t *testing.T) {
+	server, backend, client := newTestServer(t)
+
+	_, err := client.Launch(context.Background(), LaunchRequest{Ticket: "bb-1"})
+	require.ErrorIs(t, err, ErrInvalidRequest)
+	assert.Empty(t, backend.launched)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/launch", strings.NewReader("{"))
+	server.Handler().ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "malformed JSON body")
+}
+
+func TestServer_MethodNotAllowed(t *testing.T) {
+	server, _, _ := newTestServer(t)
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/launch", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestClient_Events(t *testing.T) {
+	server, _, client := newTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	received := make(chan Event, 2)
+	done := make(chan error, 1)
+	go func() {
+		done <- client.Events(ctx, func(ev Event) error {
+			received <- ev
+			if ev.Type == EventAgentRemoved {
+				return errors.New("stop")
+			}
+			return nil
+		})
+	}()
+
+	// Wait until the subscriber is connected before publishing.
+	require.Eventually(t, func() bool {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		return len(server.subscribers) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	server.Publish(Event{Type: EventAgentStatus, Agent: Agent{ID: "bb-1", Status: "completed"}})
+	server.Publish(Event{Type: EventAgentRemoved, Agent: Agent{ID: "bb-1"}})
+
+	first := <-received
+	assert.Equal(t, EventAgentStatus, first.Type)
+	assert.Equal(t, "completed", first.Agent.Status)
+	assert.False(t, first.Time.IsZero())
+	assert.Equal(t, EventAgentRemoved, (<-received).Type)
+	assert.EqualError(t, <-done, "stop")
+}
+
+func TestServer_ListenUnixSocket(t *testing.T) {
+	// Keep the path short: Unix socket paths are limited to ~100 bytes.
+	dir, err := os.MkdirTemp("", "bdb")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socketPath := filepath.Join(dir, "ctl", "bdb.sock")
+
+	server := NewServer(&fakeBackend{})
+	require.NoError(t, server.Listen(socketPath))
+	go func() { _ = server.Serve() }()
+
+	info, err := os.Stat(socketPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	projects, err := NewClient(socketPath).Projects(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, projects, 1)
+
+	err = NewServer(&fakeBackend{}).Listen(socketPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already in use")
+
+	require.NoError(t, server.Close())
+	_, err = os.Stat(socketPath)
+	assert.True(t, os.IsNotExist(err), "socket is removed on close")
+}
+
+func TestServer_ListenReplacesStaleSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "bdb")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socketPath := filepath.Join(dir, "bdb.sock")
+	require.NoError(t, os.WriteFile(socketPath, nil, 0o600))
+
+	server := NewServer(&fakeBackend{})
+	require.NoError(t, server.Listen(socketPath))
+	require.NoError(t, server.Close())
+}
+
+func TestDefaultSocketPath(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	assert.Equal(t, "/run/user/1000/blunderbust/bdb.sock", DefaultSocketPath())
+}
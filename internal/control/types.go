@@ -0,0 +1,141 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package control
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// Errors a Backend returns to select the HTTP status of a response.
+var (
+	// ErrNotFound maps to 404 Not Found.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidRequest maps to 400 Bad Request.
+	ErrInvalidRequest = errors.New("invalid request")
+)
+
+// Backend answers control requests, typically on behalf of the running TUI.
+type Backend interface {
+	Projects(ctx context.Context) ([]Project, error)
+	// Tickets lists the ready tickets of a project; "" means the active project.
+	Tickets(ctx context.Context, project string) ([]Ticket, error)
+	Harnesses(ctx context.Context) ([]Harness, error)
+	Agents(ctx context.Context) ([]Agent, error)
+	Launch(ctx context.Context, req LaunchRequest) (*LaunchResponse, error)
+	Kill(ctx context.Context, agentID string) error
+}
+
+// Project is a workspace project.
+type Project struct {
+	Name   string `json:"name"`
+	Dir    string `json:"dir"`
+	Active bool   `json:"active"`
+}
+
+// Ticket is a ready ticket.
+type Ticket struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Status      string    `json:"status"`
+	Priority    int       `json:"priority"`
+	IssueType   string    `json:"issue_type"`
+	Assignee    string    `json:"assignee,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Harness is a configured harness with its selectable models and agents.
+type Harness struct {
+	Name   string   `json:"name"`
+	Models []string `json:"models"`
+	Agents []string `json:"agents"`
+}
+
+// Agent is an agent known to the TUI.
+type Agent struct {
+	ID          string    `json:"id"`
+	LauncherID  string    `json:"launcher_id"`
+	Status      string    `json:"status"`
+	Ticket      string    `json:"ticket"`
+	TicketTitle string    `json:"ticket_title,omitempty"`
+	Harness     string    `json:"harness"`
+	Model       string    `json:"model,omitempty"`
+	Agent       string    `json:"agent,omitempty"`
+	Worktree    string    `json:"worktree,omitempty"`
+	StartedAt   time.Time `json:"started_at"`
+}
+
+// LaunchRequest selects a ticket, harness, model and agent to launch.
+// Project defaults to the active project and Worktree to the project root.
+type LaunchRequest struct {
+	Project  string `json:"project,omitempty"`
+	Worktree string `json:"worktree,omitempty"`
+	Ticket   string `json:"ticket"`
+	Harness  string `json:"harness"`
+	Model    string `json:"model,omitempty"`
+	Agent    string `json:"agent,omitempty"`
+}
+
+// LaunchResponse describes a successful launch.
+type LaunchResponse struct {
+	AgentID    string `json:"agent_id"`
+	LauncherID string `json:"launcher_id"`
+	PID        int    `json:"pid"`
+	Command    string `json:"command"`
+}
+
+// EventType identifies what happened to an agent.
+type EventType string
+
+const (
+	EventAgentLaunched EventType = "agent.launched"
+	EventAgentStatus   EventType = "agent.status"
+	EventAgentRemoved  EventType = "agent.removed"
+)
+
+// Event is one entry of the /v1/events stream.
+type Event struct {
+	Type  EventType `json:"type"`
+	Agent Agent     `json:"agent"`
+	Time  time.Time `json:"time"`
+}
+
+// AgentFromInfo converts the TUI's agent info to its API form.
+func AgentFromInfo(info *domain.AgentInfo) Agent {
+	return Agent{
+		ID:          info.ID,
+		LauncherID:  info.LauncherID,
+		Status:      info.Status.String(),
+		Ticket:      info.TicketID,
+		TicketTitle: info.TicketTitle,
+		Harness:     info.HarnessName,
+		Model:       info.ModelName,
+		Agent:       info.AgentName,
+		Worktree:    info.WorktreePath,
+		StartedAt:   info.StartedAt,
+	}
+}
+
+// TicketFromDomain converts a domain ticket to its API form.
+func TicketFromDomain(t domain.Ticket) Ticket {
+	return Ticket{
+		ID:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Priority:    t.Priority,
+		IssueType:   t.IssueType,
+		Assignee:    t.Assignee,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
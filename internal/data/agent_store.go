@@ -0,0 +1,147 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package data
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// DefaultRunningAgentMaxAge is how long an agent may go unseen before
+// DeleteStaleRunningAgents removes it.
+const DefaultRunningAgentMaxAge = time.Hour
+
+// AgentStore persists the agents bdb launched, so they can be picked up
+// again after a restart.
+type AgentStore interface {
+	// UpsertRunningAgent records an agent, keyed by project, worktree and PID.
+	UpsertRunningAgent(ctx context.Context, a domain.PersistedRunningAgent) error
+	// ValidateAndPruneRunningAgents returns the agents of projectDirs whose
+	// process still runs their harness, newest first, and removes the
+	// others. A nil inspector inspects the processes of this host.
+	ValidateAndPruneRunningAgents(ctx context.Context, projectDirs []string, inspector ProcessInspector) ([]domain.PersistedRunningAgent, error)
+	// DeleteStaleRunningAgents removes agents not seen for maxAge, or for
+	// DefaultRunningAgentMaxAge if maxAge is not positive.
+	DeleteStaleRunningAgents(ctx context.Context, maxAge time.Duration) error
+
+	// FinishRunningAgent adds run to the history and removes the running
+	// agent with the same project and launcher ID. Agents removed by
+	// ValidateAndPruneRunningAgents and DeleteStaleRunningAgents go to the
+	// history too, through FinishedRun.
+	FinishRunningAgent(ctx context.Context, run domain.AgentRun) error
+	// ListAgentRuns returns the finished runs matching filter, newest first.
+	ListAgentRuns(ctx context.Context, filter AgentRunFilter) ([]domain.AgentRun, error)
+}
+
+// AgentRunFilter controls which runs ListAgentRuns returns. Zero values
+// leave a field unfiltered.
+type AgentRunFilter struct {
+	ProjectDir string
+	Ticket     string
+	Harness    string
+	Model      string
+	// Since and Until bound the start time of the runs, inclusive and
+	// exclusive.
+	Since time.Time
+	Until time.Time
+	Limit int
+}
+
+// Matches reports whether run passes the filter, for stores filtering in
+// memory. Limit is not applied.
+func (f AgentRunFilter) Matches(run domain.AgentRun) bool {
+	return (f.ProjectDir == "" || run.ProjectDir == f.ProjectDir) &&
+		(f.Ticket == "" || run.Ticket == f.Ticket) &&
+		(f.Harness == "" || run.HarnessName == f.Harness) &&
+		(f.Model == "" || run.Model == f.Model) &&
+		(f.Since.IsZero() || !run.StartedAt.Before(f.Since)) &&
+		(f.Until.IsZero() || run.StartedAt.Before(f.Until))
+}
+
+// FinishedRun returns the history record of a running agent that stopped
+// without bdb seeing its exit: it finished when it was last seen, with an
+// unknown exit status. The lines changed are counted in its worktree.
+func FinishedRun(ctx context.Context, a domain.PersistedRunningAgent) domain.AgentRun {
+	run := domain.AgentRun{
+		ProjectDir:   a.ProjectDir,
+		WorktreePath: a.WorktreePath,
+		LauncherID:   a.LauncherID,
+		Ticket:       a.Ticket,
+		TicketTitle:  a.TicketTitle,
+		HarnessName:  a.HarnessName,
+		Model:        a.Model,
+		Agent:        a.Agent,
+		StartedAt:    a.StartedAt,
+		FinishedAt:   a.LastSeen,
+		Status:       domain.AgentUnknown,
+	}
+	// A removed worktree leaves the counts at zero.
+	run.LinesAdded, run.LinesRemoved, _ = LinesChanged(ctx, a.WorktreePath)
+	return run
+}
+
+// ProcessInspector provides process existence and command lookup.
+type ProcessInspector interface {
+	PIDExists(pid int) bool
+	CommandForPID(ctx context.Context, pid int) (string, error)
+}
+
+// HostProcessInspector inspects the processes of this host.
+type HostProcessInspector struct{}
+
+// PIDExists reports whether a process with the given PID exists.
+func (HostProcessInspector) PIDExists(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, syscall.Signal(0))
+	return err == nil || err == syscall.EPERM
+}
+
+// CommandForPID returns the command line of the process, as ps reports it.
+func (HostProcessInspector) CommandForPID(ctx context.Context, pid int) (string, error) {
+	if pid <= 0 || pid > 999999 {
+		return "", fmt.Errorf("invalid PID: %d", pid)
+	}
+	//nolint:gosec // PID is validated above, command is safe
+	cmd := exec.CommandContext(ctx, "ps", "-p", strconv.Itoa(pid), "-o", "command=")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// AgentProcessAlive reports whether the process of a still exists and runs
+// its harness, so a reused PID is not mistaken for the agent.
+func AgentProcessAlive(ctx context.Context, a domain.PersistedRunningAgent, inspector ProcessInspector) bool {
+	if inspector == nil {
+		inspector = HostProcessInspector{}
+	}
+	if !inspector.PIDExists(a.PID) {
+		return false
+	}
+
+	cmd, err := inspector.CommandForPID(ctx, a.PID)
+	if err != nil {
+		return false
+	}
+
+	candidates := config.HarnessBinaryCandidates(a.HarnessName)
+	if a.HarnessBinary != "" {
+		candidates = append(candidates, a.HarnessBinary)
+	}
+	return config.CommandMatchesAnyBinary(cmd, candidates)
+}
@@ -0,0 +1,25 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package agentfile implements the AgentStore interface with a JSON file in
+// the user's state directory, so running agents can be restored without
+// adding a table to the shared beads database.
+//
+// The file holds the agents of every project and the history of their
+// finished runs, capped at the newest runs. Each operation takes an
+// exclusive flock on a sibling ".lock" file and replaces the file
+// atomically, so several bdb processes can share it. The file records the
+// version of its format; older files are migrated on the next write or by
+// MigrateUp.
+//
+// Usage
+//
+//	path, err := agentfile.DefaultPath()
+//	if err != nil {
+//		return err
+//	}
+//	agents, err := agentfile.NewStore(path).ValidateAndPruneRunningAgents(ctx, dirs, nil)
+package agentfile
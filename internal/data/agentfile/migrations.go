@@ -0,0 +1,103 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package agentfile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/megatherium/blunderbust/internal/data/migrate"
+)
+
+// migrations are the format changes of the file. They edit its content as a
+// generic JSON document, since the file may predate the record type.
+var migrations = []migrate.Migration[map[string]any]{
+	{
+		Version: 1,
+		Name:    "wrap agents in a versioned document",
+		Up: func(_ context.Context, doc map[string]any) error {
+			// read already wrapped a bare list; an empty file gets the key.
+			if _, ok := doc["agents"]; !ok {
+				doc["agents"] = []any{}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 2,
+		Name:    "add the run history",
+		Up: func(_ context.Context, doc map[string]any) error {
+			if _, ok := doc["runs"]; !ok {
+				doc["runs"] = []any{}
+			}
+			return nil
+		},
+	},
+}
+
+// documentBackend migrates a document in memory. The caller writes it back
+// in one piece, so all migrations are saved or none.
+type documentBackend struct {
+	doc map[string]any
+}
+
+func (b *documentBackend) Version(context.Context) (int, error) {
+	switch v := b.doc["version"].(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return v, nil
+	case float64:
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("invalid version %v", v)
+	}
+}
+
+func (b *documentBackend) Apply(ctx context.Context, m migrate.Migration[map[string]any]) error {
+	if err := m.Up(ctx, b.doc); err != nil {
+		return err
+	}
+	b.doc["version"] = m.Version
+	return nil
+}
+
+// MigrationStatus returns the migration state of the file. Writes replace
+// the file atomically, so it is read without taking the lock.
+func (s *Store) MigrationStatus(ctx context.Context) (migrate.Status, error) {
+	doc, err := s.read()
+	if err != nil {
+		return migrate.Status{}, err
+	}
+	status, err := migrate.GetStatus(ctx, &documentBackend{doc: doc}, migrations)
+	if err != nil {
+		return status, fmt.Errorf("%s: %w", s.path, err)
+	}
+	return status, nil
+}
+
+// MigrateUp applies the pending migrations to the file and returns those
+// applied. Every write migrates the file too; this only saves the result
+// without changing the agents or runs.
+func (s *Store) MigrateUp(ctx context.Context) ([]migrate.Info, error) {
+	var applied []migrate.Info
+	err := s.locked(func() error {
+		d, pending, err := s.load(ctx)
+		if err != nil || len(pending) == 0 {
+			return err
+		}
+		if err := s.write(d); err != nil {
+			return err
+		}
+		applied = pending
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate %s: %w", s.path, err)
+	}
+	return applied, nil
+}
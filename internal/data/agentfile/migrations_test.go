@@ -0,0 +1,115 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package agentfile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/megatherium/blunderbust/internal/data/migrate"
+)
+
+// writeFile writes content to the store's file.
+func writeFile(t *testing.T, s *Store, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(s.Path()), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(s.Path(), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+const legacyFile = `[{"id": 3, "project_dir": "/a", "worktree_path": "/a", "pid": 42, "launcher_type": 0,
+	"launcher_id": "x", "harness_name": "codex", "started_at": "2026-01-01T11:00:00Z", "last_seen": "2026-01-01T11:00:00Z"}]`
+
+func TestStore_MigratesLegacyFile(t *testing.T) {
+	s, _ := newTestStore(t)
+	writeFile(t, s, legacyFile)
+	ctx := context.Background()
+
+	status, err := s.MigrationStatus(ctx)
+	if err != nil {
+		t.Fatalf("MigrationStatus failed: %v", err)
+	}
+	if status.Current != 0 || status.UpToDate() {
+		t.Fatalf("expected a pending migration for a legacy file, got %+v", status)
+	}
+
+	applied, err := s.MigrateUp(ctx)
+	if err != nil {
+		t.Fatalf("MigrateUp failed: %v", err)
+	}
+	if len(applied) != len(migrations) {
+		t.Errorf("expected %d applied migrations, got %+v", len(migrations), applied)
+	}
+
+	content, err := os.ReadFile(s.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var d document
+	if err := json.Unmarshal(content, &d); err != nil {
+		t.Fatalf("expected a versioned document, got %s", content)
+	}
+	if d.Version != len(migrations) || len(d.Agents) != 1 || d.Agents[0].ID != 3 {
+		t.Errorf("expected the agent to survive the migration, got %+v", d)
+	}
+
+	if applied, err := s.MigrateUp(ctx); err != nil || len(applied) != 0 {
+		t.Errorf("expected nothing left to apply, got %+v (%v)", applied, err)
+	}
+}
+
+func TestStore_WritesMigrateLegacyFile(t *testing.T) {
+	s, _ := newTestStore(t)
+	writeFile(t, s, legacyFile)
+
+	agents, err := s.ValidateAndPruneRunningAgents(context.Background(), []string{"/a"}, fakeInspector{42: "codex"})
+	if err != nil {
+		t.Fatalf("ValidateAndPruneRunningAgents failed: %v", err)
+	}
+	if len(agents) != 1 {
+		t.Fatalf("expected the legacy agent, got %+v", agents)
+	}
+
+	status, err := s.MigrationStatus(context.Background())
+	if err != nil || !status.UpToDate() {
+		t.Errorf("expected the write to save the migrated file, got %+v (%v)", status, err)
+	}
+}
+
+func TestStore_NewerFileVersion(t *testing.T) {
+	s, _ := newTestStore(t)
+	writeFile(t, s, `{"version": 99, "agents": []}`)
+
+	_, err := s.ValidateAndPruneRunningAgents(context.Background(), []string{"/a"}, fakeInspector{})
+	if !errors.Is(err, migrate.ErrNewerSchema) {
+		t.Errorf("expected ErrNewerSchema, got %v", err)
+	}
+	if _, err := s.MigrationStatus(context.Background()); !errors.Is(err, migrate.ErrNewerSchema) {
+		t.Errorf("expected ErrNewerSchema from MigrationStatus, got %v", err)
+	}
+}
+
+func TestStore_MigrationStatus_MissingFile(t *testing.T) {
+	s, _ := newTestStore(t)
+	status, err := s.MigrationStatus(context.Background())
+	if err != nil {
+		t.Fatalf("MigrationStatus failed: %v", err)
+	}
+	if status.Current != 0 || len(status.Pending) != len(migrations) {
+		t.Errorf("expected every migration pending, got %+v", status)
+	}
+	if _, err := os.Stat(s.Path()); !os.IsNotExist(err) {
+		t.Errorf("expected the status check to leave the file alone, got %v", err)
+	}
+}
@@ -0,0 +1,489 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package agentfile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/migrate"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/logging"
+)
+
+// Store implements data.AgentStore with a JSON file.
+type Store struct {
+	path string
+	// now returns the current time, for last_seen.
+	now func() time.Time
+}
+
+// Verify interface compliance at compile time.
+var (
+	_ data.AgentStore      = (*Store)(nil)
+	_ data.MigratableStore = (*Store)(nil)
+)
+
+// record is the file format of one agent.
+type record struct {
+	ID            int                 `json:"id"`
+	ProjectDir    string              `json:"project_dir"`
+	WorktreePath  string              `json:"worktree_path"`
+	PID           int                 `json:"pid"`
+	LauncherType  domain.LauncherType `json:"launcher_type"`
+	LauncherID    string              `json:"launcher_id"`
+	Ticket        string              `json:"ticket,omitempty"`
+	TicketTitle   string              `json:"ticket_title,omitempty"`
+	HarnessName   string              `json:"harness_name"`
+	HarnessBinary string              `json:"harness_binary,omitempty"`
+	Model         string              `json:"model,omitempty"`
+	Agent         string              `json:"agent,omitempty"`
+	StartedAt     time.Time           `json:"started_at"`
+	LastSeen      time.Time           `json:"last_seen"`
+}
+
+// runRecord is the file format of one finished run.
+type runRecord struct {
+	ID           int       `json:"id"`
+	ProjectDir   string    `json:"project_dir"`
+	WorktreePath string    `json:"worktree_path"`
+	LauncherID   string    `json:"launcher_id,omitempty"`
+	Ticket       string    `json:"ticket,omitempty"`
+	TicketTitle  string    `json:"ticket_title,omitempty"`
+	HarnessName  string    `json:"harness_name"`
+	Model        string    `json:"model,omitempty"`
+	Agent        string    `json:"agent,omitempty"`
+	StartedAt    time.Time `json:"started_at"`
+	FinishedAt   time.Time `json:"finished_at"`
+	Status       string    `json:"status"`
+	ExitCode     *int      `json:"exit_code,omitempty"`
+	LinesAdded   int       `json:"lines_added"`
+	LinesRemoved int       `json:"lines_removed"`
+}
+
+// maxRuns caps the run history kept in the file; the oldest runs are
+// dropped first.
+const maxRuns = 1000
+
+// document is the file format: the agents, the finished runs and the
+// number of the last migration applied to the file.
+type document struct {
+	Version int         `json:"version"`
+	Agents  []record    `json:"agents"`
+	Runs    []runRecord `json:"runs"`
+}
+
+// NewStore returns a store keeping its agents in the file at path. The
+// file and its directory are created on the first write.
+func NewStore(path string) *Store {
+	return &Store{path: path, now: time.Now}
+}
+
+// DefaultPath returns running_agents.json in bdb's state directory.
+func DefaultPath() (string, error) {
+	dir, err := logging.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "running_agents.json"), nil
+}
+
+// Path returns the file the store writes to.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// UpsertRunningAgent inserts or updates the agent with the same project,
+// worktree and PID.
+func (s *Store) UpsertRunningAgent(ctx context.Context, a domain.PersistedRunningAgent) error {
+	if a.ProjectDir == "" || a.WorktreePath == "" || a.PID <= 0 || a.HarnessName == "" {
+		return fmt.Errorf("invalid running agent data")
+	}
+	if a.LauncherID == "" {
+		a.LauncherID = "unknown"
+	}
+
+	err := s.update(ctx, func(d *document) error {
+		now := s.now().UTC()
+		r := toRecord(a)
+		r.LastSeen = now
+		i := slices.IndexFunc(d.Agents, func(b record) bool {
+			return b.ProjectDir == r.ProjectDir && b.WorktreePath == r.WorktreePath && b.PID == r.PID
+		})
+		if i >= 0 {
+			r.ID, r.StartedAt = d.Agents[i].ID, d.Agents[i].StartedAt
+			d.Agents[i] = r
+			return nil
+		}
+
+		r.ID, r.StartedAt = 1, now
+		for _, b := range d.Agents {
+			r.ID = max(r.ID, b.ID+1)
+		}
+		d.Agents = append(d.Agents, r)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert running agent: %w", err)
+	}
+	return nil
+}
+
+// ValidateAndPruneRunningAgents returns the agents of projectDirs whose
+// process is still alive, newest first, and moves the others to the run
+// history.
+func (s *Store) ValidateAndPruneRunningAgents(ctx context.Context, projectDirs []string, inspector data.ProcessInspector) ([]domain.PersistedRunningAgent, error) {
+	ended, err := s.finishedRuns(ctx, func(r record) bool {
+		return slices.Contains(projectDirs, r.ProjectDir) && !data.AgentProcessAlive(ctx, r.agent(), inspector)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate running agents: %w", err)
+	}
+
+	var valid []domain.PersistedRunningAgent
+	err = s.update(ctx, func(d *document) error {
+		now := s.now().UTC()
+		kept := d.Agents[:0]
+		for _, r := range d.Agents {
+			if slices.Contains(projectDirs, r.ProjectDir) {
+				if run, ok := ended[r.key()]; ok {
+					d.addRun(run)
+					continue
+				}
+				// Agents added since the snapshot were just seen.
+				r.LastSeen = now
+				valid = append(valid, r.agent())
+			}
+			kept = append(kept, r)
+		}
+		d.Agents = kept
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate running agents: %w", err)
+	}
+
+	slices.SortStableFunc(valid, func(a, b domain.PersistedRunningAgent) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+	return valid, nil
+}
+
+// DeleteStaleRunningAgents moves agents not seen for maxAge to the run
+// history.
+func (s *Store) DeleteStaleRunningAgents(ctx context.Context, maxAge time.Duration) error {
+	if maxAge <= 0 {
+		maxAge = data.DefaultRunningAgentMaxAge
+	}
+	cutoff := s.now().Add(-maxAge)
+	ended, err := s.finishedRuns(ctx, func(r record) bool { return r.LastSeen.Before(cutoff) })
+	if err != nil {
+		return fmt.Errorf("failed deleting stale running agents: %w", err)
+	}
+
+	err = s.update(ctx, func(d *document) error {
+		d.Agents = slices.DeleteFunc(d.Agents, func(r record) bool {
+			run, ok := ended[r.key()]
+			if !ok || !r.LastSeen.Before(cutoff) {
+				// Seen again since the snapshot.
+				return false
+			}
+			d.addRun(run)
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed deleting stale running agents: %w", err)
+	}
+	return nil
+}
+
+// FinishRunningAgent adds run to the history and removes the running agent
+// with the same project and launcher ID.
+func (s *Store) FinishRunningAgent(ctx context.Context, run domain.AgentRun) error {
+	if run.ProjectDir == "" || run.HarnessName == "" {
+		return fmt.Errorf("invalid agent run data")
+	}
+	err := s.update(ctx, func(d *document) error {
+		d.Agents = slices.DeleteFunc(d.Agents, func(r record) bool {
+			return r.ProjectDir == run.ProjectDir && r.LauncherID == run.LauncherID
+		})
+		d.addRun(run)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record agent run: %w", err)
+	}
+	return nil
+}
+
+// ListAgentRuns returns the finished runs matching filter, newest first.
+// Writes replace the file atomically, so it is read without taking the
+// lock.
+func (s *Store) ListAgentRuns(ctx context.Context, filter data.AgentRunFilter) ([]domain.AgentRun, error) {
+	d, _, err := s.load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent runs: %w", err)
+	}
+
+	var runs []domain.AgentRun
+	for _, r := range d.Runs {
+		if run := r.run(); filter.Matches(run) {
+			runs = append(runs, run)
+		}
+	}
+	slices.SortStableFunc(runs, func(a, b domain.AgentRun) int {
+		if c := b.StartedAt.Compare(a.StartedAt); c != 0 {
+			return c
+		}
+		return b.ID - a.ID
+	})
+	if filter.Limit > 0 && len(runs) > filter.Limit {
+		runs = runs[:filter.Limit]
+	}
+	return runs, nil
+}
+
+// addRun appends run to the history with the next ID, dropping the oldest
+// runs beyond maxRuns.
+func (d *document) addRun(run domain.AgentRun) {
+	r := toRunRecord(run)
+	r.ID = 1
+	for _, b := range d.Runs {
+		r.ID = max(r.ID, b.ID+1)
+	}
+	d.Runs = append(d.Runs, r)
+	if n := len(d.Runs) - maxRuns; n > 0 {
+		d.Runs = slices.Delete(d.Runs, 0, n)
+	}
+}
+
+// agentKey identifies an agent across reads of the file.
+type agentKey struct{ id, pid int }
+
+func (r record) key() agentKey {
+	return agentKey{r.ID, r.PID}
+}
+
+// finishedRuns returns the history records of the agents that ended selects,
+// keyed by agent. FinishedRun counts the lines changed with git, so the
+// records are built from a snapshot of the file without holding its lock;
+// callers move the agents that are still in the file under the lock.
+func (s *Store) finishedRuns(ctx context.Context, ended func(r record) bool) (map[agentKey]domain.AgentRun, error) {
+	d, _, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	runs := make(map[agentKey]domain.AgentRun)
+	for _, r := range d.Agents {
+		if ended(r) {
+			runs[r.key()] = data.FinishedRun(ctx, r.agent())
+		}
+	}
+	return runs, nil
+}
+
+// update applies fn to the document in the file while holding its lock and
+// writes the result back.
+func (s *Store) update(ctx context.Context, fn func(d *document) error) error {
+	return s.locked(func() error {
+		d, _, err := s.load(ctx)
+		if err != nil {
+			return err
+		}
+		if err := fn(&d); err != nil {
+			return err
+		}
+		return s.write(d)
+	})
+}
+
+// locked runs fn while holding the file's lock.
+func (s *Store) locked(fn func() error) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return err
+	}
+	lock, err := os.OpenFile(s.path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return err
+	}
+	defer lock.Close()
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock %s: %w", s.path, err)
+	}
+	defer func() { _ = syscall.Flock(int(lock.Fd()), syscall.LOCK_UN) }()
+	return fn()
+}
+
+// load reads the document, migrating the file's content in memory first.
+// The migrations are saved with the next write.
+func (s *Store) load(ctx context.Context) (document, []migrate.Info, error) {
+	doc, err := s.read()
+	if err != nil {
+		return document{}, nil, err
+	}
+	applied, err := migrate.Up(ctx, &documentBackend{doc: doc}, migrations)
+	if err != nil {
+		return document{}, nil, fmt.Errorf("%s: %w", s.path, err)
+	}
+
+	content, err := json.Marshal(doc)
+	if err != nil {
+		return document{}, nil, err
+	}
+	var d document
+	if err := json.Unmarshal(content, &d); err != nil {
+		return document{}, nil, fmt.Errorf("%s is corrupted: %w", s.path, err)
+	}
+	return d, applied, nil
+}
+
+// read returns the file's content as a generic document for migrations. A
+// missing file is an empty document.
+func (s *Store) read() (map[string]any, error) {
+	content, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]any{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Files written before migrations hold a bare list of agents.
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '[' {
+		var agents []any
+		if err := json.Unmarshal(trimmed, &agents); err != nil {
+			return nil, fmt.Errorf("%s is corrupted: %w", s.path, err)
+		}
+		return map[string]any{"agents": agents}, nil
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("%s is corrupted: %w", s.path, err)
+	}
+	return doc, nil
+}
+
+// write replaces the file through a temporary file, so readers never see a
+// partial write.
+func (s *Store) write(d document) error {
+	d.Version = len(migrations)
+	if d.Agents == nil {
+		d.Agents = []record{}
+	}
+	if d.Runs == nil {
+		d.Runs = []runRecord{}
+	}
+	content, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(content, '\n')); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func toRecord(a domain.PersistedRunningAgent) record {
+	return record{
+		ID:            a.ID,
+		ProjectDir:    a.ProjectDir,
+		WorktreePath:  a.WorktreePath,
+		PID:           a.PID,
+		LauncherType:  a.LauncherType,
+		LauncherID:    a.LauncherID,
+		Ticket:        a.Ticket,
+		TicketTitle:   a.TicketTitle,
+		HarnessName:   a.HarnessName,
+		HarnessBinary: a.HarnessBinary,
+		Model:         a.Model,
+		Agent:         a.Agent,
+		StartedAt:     a.StartedAt,
+		LastSeen:      a.LastSeen,
+	}
+}
+
+func (r record) agent() domain.PersistedRunningAgent {
+	return domain.PersistedRunningAgent{
+		ID:            r.ID,
+		ProjectDir:    r.ProjectDir,
+		WorktreePath:  r.WorktreePath,
+		PID:           r.PID,
+		LauncherType:  r.LauncherType,
+		LauncherID:    r.LauncherID,
+		Ticket:        r.Ticket,
+		TicketTitle:   r.TicketTitle,
+		HarnessName:   r.HarnessName,
+		HarnessBinary: r.HarnessBinary,
+		Model:         r.Model,
+		Agent:         r.Agent,
+		StartedAt:     r.StartedAt,
+		LastSeen:      r.LastSeen,
+	}
+}
+
+func toRunRecord(r domain.AgentRun) runRecord {
+	return runRecord{
+		ID:           r.ID,
+		ProjectDir:   r.ProjectDir,
+		WorktreePath: r.WorktreePath,
+		LauncherID:   r.LauncherID,
+		Ticket:       r.Ticket,
+		TicketTitle:  r.TicketTitle,
+		HarnessName:  r.HarnessName,
+		Model:        r.Model,
+		Agent:        r.Agent,
+		StartedAt:    r.StartedAt.UTC(),
+		FinishedAt:   r.FinishedAt.UTC(),
+		Status:       r.Status.String(),
+		ExitCode:     r.ExitCode,
+		LinesAdded:   r.LinesAdded,
+		LinesRemoved: r.LinesRemoved,
+	}
+}
+
+func (r runRecord) run() domain.AgentRun {
+	status, _ := domain.ParseAgentStatus(r.Status)
+	return domain.AgentRun{
+		ID:           r.ID,
+		ProjectDir:   r.ProjectDir,
+		WorktreePath: r.WorktreePath,
+		LauncherID:   r.LauncherID,
+		Ticket:       r.Ticket,
+		TicketTitle:  r.TicketTitle,
+		HarnessName:  r.HarnessName,
+		Model:        r.Model,
+		Agent:        r.Agent,
+		StartedAt:    r.StartedAt,
+		FinishedAt:   r.FinishedAt,
+		Status:       status,
+		ExitCode:     r.ExitCode,
+		LinesAdded:   r.LinesAdded,
+		LinesRemoved: r.LinesRemoved,
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package agentfile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+type fakeInspector map[int]string
+
+func (f fakeInspector) PIDExists(pid int) bool {
+	_, ok := f[pid]
+	return ok
+}
+
+func (f fakeInspector) CommandForPID(_ context.Context, pid int) (string, error) {
+	return f[pid], nil
+}
+
+func newTestStore(t *testing.T) (*Store, *time.Time) {
+	t.Helper()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := NewStore(filepath.Join(t.TempDir(), "state", "running_agents.json"))
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func agent(project string, pid int, ticket string) domain.PersistedRunningAgent {
+	return domain.PersistedRunningAgent{
+		ProjectDir: project, WorktreePath: project, PID: pid, Ticket: ticket,
+		HarnessName: "codex", HarnessBinary: "codex",
+	}
+}
+
+func TestStore_UpsertAndValidate(t *testing.T) {
+	s, now := newTestStore(t)
+	ctx := context.Background()
+
+	if err := s.UpsertRunningAgent(ctx, agent("/a", 101, "bb-1")); err != nil {
+		t.Fatalf("UpsertRunningAgent failed: %v", err)
+	}
+	*now = now.Add(time.Minute)
+	for _, a := range []domain.PersistedRunningAgent{agent("/a", 202, "bb-2"), agent("/b", 303, "bb-3"), agent("/a", 101, "bb-9")} {
+		if err := s.UpsertRunningAgent(ctx, a); err != nil {
+			t.Fatalf("UpsertRunningAgent failed: %v", err)
+		}
+	}
+
+	// 202 is gone and 303 belongs to another project.
+	inspector := fakeInspector{101: "/usr/bin/codex exec", 303: "codex"}
+	valid, err := s.ValidateAndPruneRunningAgents(ctx, []string{"/a"}, inspector)
+	if err != nil {
+		t.Fatalf("ValidateAndPruneRunningAgents failed: %v", err)
+	}
+	if len(valid) != 1 || valid[0].ID != 1 || valid[0].Ticket != "bb-9" || valid[0].LauncherID != "unknown" {
+		t.Fatalf("unexpected agents: %+v", valid)
+	}
+
+	valid, err = s.ValidateAndPruneRunningAgents(ctx, []string{"/a", "/b"}, inspector)
+	if err != nil {
+		t.Fatalf("ValidateAndPruneRunningAgents failed: %v", err)
+	}
+	if len(valid) != 2 || valid[0].PID != 303 || valid[1].PID != 101 {
+		t.Fatalf("expected the pruned agent to stay gone and newest first, got %+v", valid)
+	}
+}
+
+// upsertingInspector reports every process as gone, and upserts an agent
+// while checking the first one, like a launch racing the validation.
+type upsertingInspector struct {
+	s     *Store
+	agent domain.PersistedRunningAgent
+	once  sync.Once
+}
+
+func (u *upsertingInspector) PIDExists(int) bool {
+	u.once.Do(func() { _ = u.s.UpsertRunningAgent(context.Background(), u.agent) })
+	return false
+}
+
+func (u *upsertingInspector) CommandForPID(context.Context, int) (string, error) { return "", nil }
+
+func TestStore_ValidateChecksAgentsOutsideTheLock(t *testing.T) {
+	s, _ := newTestStore(t)
+	ctx := context.Background()
+	if err := s.UpsertRunningAgent(ctx, agent("/a", 101, "bb-1")); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan []domain.PersistedRunningAgent)
+	go func() {
+		valid, err := s.ValidateAndPruneRunningAgents(ctx, []string{"/a"}, &upsertingInspector{s: s, agent: agent("/a", 202, "bb-2")})
+		if err != nil {
+			t.Errorf("ValidateAndPruneRunningAgents failed: %v", err)
+		}
+		done <- valid
+	}()
+
+	select {
+	case valid := <-done:
+		if len(valid) != 1 || valid[0].PID != 202 {
+			t.Errorf("expected the agent upserted meanwhile to be kept, got %+v", valid)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the process check ran while the file was locked")
+	}
+
+	runs, err := s.ListAgentRuns(ctx, data.AgentRunFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 || runs[0].Ticket != "bb-1" {
+		t.Errorf("expected the gone agent in the history, got %+v", runs)
+	}
+}
+
+func TestStore_UpsertRejectsInvalidAgent(t *testing.T) {
+	s, _ := newTestStore(t)
+	if err := s.UpsertRunningAgent(context.Background(), domain.PersistedRunningAgent{ProjectDir: "/a"}); err == nil {
+		t.Fatal("expected an error for an agent without PID and harness")
+	}
+	if _, err := os.Stat(s.Path()); !os.IsNotExist(err) {
+		t.Errorf("expected no file to be written, got %v", err)
+	}
+}
+
+func TestStore_DeleteStaleRunningAgents(t *testing.T) {
+	s, now := newTestStore(t)
+	ctx := context.Background()
+	if err := s.UpsertRunningAgent(ctx, agent("/a", 101, "bb-1")); err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(2 * time.Hour)
+	if err := s.UpsertRunningAgent(ctx, agent("/a", 202, "bb-2")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.DeleteStaleRunningAgents(ctx, 0); err != nil {
+		t.Fatalf("DeleteStaleRunningAgents failed: %v", err)
+	}
+	valid, err := s.ValidateAndPruneRunningAgents(ctx, []string{"/a"}, fakeInspector{101: "codex", 202: "codex"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(valid) != 1 || valid[0].PID != 202 {
+		t.Fatalf("expected only the recent agent, got %+v", valid)
+	}
+
+	runs, err := s.ListAgentRuns(ctx, data.AgentRunFilter{})
+	if err != nil {
+		t.Fatalf("ListAgentRuns failed: %v", err)
+	}
+	if len(runs) != 1 || runs[0].Ticket != "bb-1" || runs[0].ExitCode != nil || runs[0].Status != domain.AgentUnknown ||
+		!runs[0].FinishedAt.Equal(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the stale agent in the history with an unknown outcome, finished when last seen, got %+v", runs)
+	}
+}
+
+func TestStore_FinishRunningAgent(t *testing.T) {
+	s, now := newTestStore(t)
+	ctx := context.Background()
+	a := agent("/a", 101, "bb-1")
+	a.LauncherID = "@1"
+	if err := s.UpsertRunningAgent(ctx, a); err != nil {
+		t.Fatal(err)
+	}
+
+	exitCode := 0
+	for i, run := range []domain.AgentRun{
+		{ProjectDir: "/a", LauncherID: "@1", Ticket: "bb-1", HarnessName: "codex", Model: "gpt",
+			StartedAt: *now, FinishedAt: now.Add(time.Minute), Status: domain.AgentCompleted, ExitCode: &exitCode, LinesAdded: 4},
+		{ProjectDir: "/a", LauncherID: "@2", Ticket: "bb-2", HarnessName: "claude",
+			StartedAt: now.Add(time.Hour), FinishedAt: now.Add(2 * time.Hour), Status: domain.AgentFailed},
+		{ProjectDir: "/b", LauncherID: "@3", Ticket: "bb-1", HarnessName: "codex",
+			StartedAt: now.Add(2 * time.Hour), FinishedAt: now.Add(3 * time.Hour), Status: domain.AgentCompleted},
+	} {
+		if err := s.FinishRunningAgent(ctx, run); err != nil {
+			t.Fatalf("FinishRunningAgent %d failed: %v", i, err)
+		}
+	}
+
+	valid, err := s.ValidateAndPruneRunningAgents(ctx, []string{"/a"}, fakeInspector{101: "codex"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(valid) != 0 {
+		t.Fatalf("expected the finished agent to be removed, got %+v", valid)
+	}
+
+	runs, err := s.ListAgentRuns(ctx, data.AgentRunFilter{Ticket: "bb-1"})
+	if err != nil {
+		t.Fatalf("ListAgentRuns failed: %v", err)
+	}
+	if len(runs) != 2 || runs[0].ProjectDir != "/b" || runs[1].LinesAdded != 4 || runs[1].ExitCode == nil {
+		t.Fatalf("expected both bb-1 runs newest first, got %+v", runs)
+	}
+
+	runs, err = s.ListAgentRuns(ctx, data.AgentRunFilter{ProjectDir: "/a", Since: now.Add(30 * time.Minute)})
+	if err != nil {
+		t.Fatalf("ListAgentRuns failed: %v", err)
+	}
+	if len(runs) != 1 || runs[0].Ticket != "bb-2" || runs[0].Status != domain.AgentFailed {
+		t.Fatalf("expected the failed bb-2 run, got %+v", runs)
+	}
+}
+
+func TestStore_ConcurrentUpserts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "running_agents.json")
+	var wg sync.WaitGroup
+	for pid := 1; pid <= 20; pid++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Separate stores stand in for separate bdb processes.
+			if err := NewStore(path).UpsertRunningAgent(context.Background(), agent("/a", pid, "")); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	inspector := fakeInspector{}
+	for pid := 1; pid <= 20; pid++ {
+		inspector[pid] = "codex"
+	}
+	valid, err := NewStore(path).ValidateAndPruneRunningAgents(context.Background(), []string{"/a"}, inspector)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(valid) != 20 {
+		t.Fatalf("expected 20 agents, got %d", len(valid))
+	}
+}
+
+func TestStore_CorruptedFile(t *testing.T) {
+	s, _ := newTestStore(t)
+	if err := os.MkdirAll(filepath.Dir(s.Path()), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(s.Path(), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := s.ValidateAndPruneRunningAgents(context.Background(), []string{"/a"}, fakeInspector{})
+	if err == nil || !strings.Contains(err.Error(), "is corrupted") {
+		t.Fatalf("expected a corrupted file error, got %v", err)
+	}
+}
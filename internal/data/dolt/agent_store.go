@@ -2,78 +2,33 @@ package dolt
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
-	"os/exec"
-	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
-	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/domain"
 )
 
-const defaultRunningAgentMaxAge = time.Hour
+// Verify interface compliance at compile time.
+var _ data.AgentStore = (*Store)(nil)
 
-// ProcessInspector provides process existence and command lookup.
-type ProcessInspector interface {
-	PIDExists(pid int) bool
-	CommandForPID(ctx context.Context, pid int) (string, error)
-}
-
-type hostProcessInspector struct{}
-
-func (hostProcessInspector) PIDExists(pid int) bool {
-	if pid <= 0 {
-		return false
-	}
-	err := syscall.Kill(pid, syscall.Signal(0))
-	return err == nil || err == syscall.EPERM
-}
-
-func (hostProcessInspector) CommandForPID(ctx context.Context, pid int) (string, error) {
-	if pid <= 0 || pid > 999999 {
-		return "", fmt.Errorf("invalid PID: %d", pid)
-	}
-	//nolint:gosec // PID is validated above, command is safe
-	cmd := exec.CommandContext(ctx, "ps", "-p", strconv.Itoa(pid), "-o", "command=")
-	out, err := cmd.Output()
-	if err != nil {
-		return "", err
-	}
-	return strings.TrimSpace(string(out)), nil
-}
-
-// EnsureRunningAgentsTable ensures the running_agents table exists.
+// EnsureRunningAgentsTable ensures the running_agents and agent_runs tables
+// exist by applying the pending schema migrations. It is only called when the Dolt
+// database is used as the agent store, so other setups leave the shared
+// beads database alone. Stores sharing a pool check each database only
+// once.
 func (s *Store) EnsureRunningAgentsTable(ctx context.Context) error {
-	const query = `
-CREATE TABLE IF NOT EXISTS running_agents (
-    id INT PRIMARY KEY AUTO_INCREMENT,
-    project_dir VARCHAR(255) NOT NULL,
-    worktree_path VARCHAR(255) NOT NULL,
-    pid INT NOT NULL,
-    launcher_type INT NOT NULL,
-    launcher_id VARCHAR(100),
-    ticket VARCHAR(100),
-    ticket_title TEXT,
-    harness_name VARCHAR(50) NOT NULL,
-    harness_binary VARCHAR(100),
-    model VARCHAR(50),
-    agent VARCHAR(50),
-    started_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    last_seen DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    UNIQUE KEY uniq_running_agent (project_dir, worktree_path, pid),
-    INDEX idx_running_agents_project_dir (project_dir),
-    INDEX idx_running_agents_last_seen (last_seen)
-) `
-	_, err := s.db.ExecContext(ctx, query)
-	if err != nil {
+	if s.pool != nil && s.pools.hasAgentTable(s.pool, s.database) {
+		return nil
+	}
+	if _, err := s.MigrateUp(ctx); err != nil {
 		return fmt.Errorf("failed to ensure running_agents table: %w", err)
 	}
-	if err := ensureRunningAgentsTicketTitleColumn(ctx, s); err != nil {
-		return err
+	if s.pool != nil {
+		s.pools.setAgentTable(s.pool, s.database)
 	}
-
 	return nil
 }
 
@@ -103,7 +58,7 @@ ON DUPLICATE KEY UPDATE
 	model = VALUES(model),
 	agent = VALUES(agent),
 	last_seen = CURRENT_TIMESTAMP`
-	_, err := s.db.ExecContext(ctx, query,
+	_, err := s.db.ExecContext(ctx, s.qualify(query),
 		a.ProjectDir,
 		a.WorktreePath,
 		a.PID,
@@ -123,6 +78,11 @@ ON DUPLICATE KEY UPDATE
 	return nil
 }
 
+// runningAgentColumns are the running_agents columns scanned by
+// listRunningAgents.
+const runningAgentColumns = `id, project_dir, worktree_path, pid, launcher_type, launcher_id, ticket, ticket_title,
+	harness_name, harness_binary, model, agent, started_at, last_seen`
+
 // ListRunningAgentsByProjects returns running agents for the given project directories.
 func (s *Store) ListRunningAgentsByProjects(ctx context.Context, projectDirs []string) ([]domain.PersistedRunningAgent, error) {
 	if s.closed {
@@ -141,16 +101,20 @@ func (s *Store) ListRunningAgentsByProjects(ctx context.Context, projectDirs []s
 		args = append(args, dir)
 	}
 
-	//nolint:gosec // placeholders are safe, never user input
+	return s.listRunningAgents(ctx, fmt.Sprintf("project_dir IN (%s)", placeholders), args...)
+}
+
+// listRunningAgents returns the running agents matching where, newest first.
+func (s *Store) listRunningAgents(ctx context.Context, where string, args ...any) ([]domain.PersistedRunningAgent, error) {
+	//nolint:gosec // where is built from constants, never user input
 	query := fmt.Sprintf(`
 SELECT
-	id, project_dir, worktree_path, pid, launcher_type, launcher_id, ticket, ticket_title,
-	harness_name, harness_binary, model, agent, started_at, last_seen
+	%s
 FROM running_agents
-WHERE project_dir IN (%s)
-ORDER BY started_at DESC`, placeholders)
+WHERE %s
+ORDER BY started_at DESC`, runningAgentColumns, where)
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	rows, err := s.db.QueryContext(ctx, s.qualify(query), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query running agents: %w", err)
 	}
@@ -187,28 +151,9 @@ ORDER BY started_at DESC`, placeholders)
 	return agents, nil
 }
 
-func ensureRunningAgentsTicketTitleColumn(ctx context.Context, s *Store) error {
-	_, err := s.db.ExecContext(ctx, `ALTER TABLE running_agents ADD COLUMN ticket_title TEXT`)
-	if err == nil {
-		return nil
-	}
-	// Column already exists on upgraded/newer schemas. Dolt/MySQL variants:
-	// - "Duplicate column name 'ticket_title'"
-	// - `Column "ticket_title" already exists`
-	errMsg := strings.ToLower(err.Error())
-	if strings.Contains(errMsg, "duplicate column name") ||
-		(strings.Contains(errMsg, "ticket_title") && strings.Contains(errMsg, "already exists")) {
-		return nil
-	}
-	return fmt.Errorf("failed to ensure running_agents.ticket_title column: %w", err)
-}
-
-// ValidateAndPruneRunningAgents validates running agents and removes invalid rows.
-func (s *Store) ValidateAndPruneRunningAgents(ctx context.Context, projectDirs []string, inspector ProcessInspector) ([]domain.PersistedRunningAgent, error) {
-	if inspector == nil {
-		inspector = hostProcessInspector{}
-	}
-
+// ValidateAndPruneRunningAgents validates running agents and moves invalid
+// rows to the run history.
+func (s *Store) ValidateAndPruneRunningAgents(ctx context.Context, projectDirs []string, inspector data.ProcessInspector) ([]domain.PersistedRunningAgent, error) {
 	agents, err := s.ListRunningAgentsByProjects(ctx, projectDirs)
 	if err != nil {
 		return nil, err
@@ -216,11 +161,10 @@ func (s *Store) ValidateAndPruneRunningAgents(ctx context.Context, projectDirs [
 
 	valid := make([]domain.PersistedRunningAgent, 0, len(agents))
 	for i := range agents {
-		isValid, err := s.validateRunningAgent(ctx, agents[i], inspector)
-		if err != nil {
-			return nil, err
-		}
-		if !isValid {
+		if !data.AgentProcessAlive(ctx, agents[i], inspector) {
+			if err := s.finishRunningAgentByID(ctx, agents[i]); err != nil {
+				return nil, err
+			}
 			continue
 		}
 
@@ -233,51 +177,182 @@ func (s *Store) ValidateAndPruneRunningAgents(ctx context.Context, projectDirs [
 	return valid, nil
 }
 
-func (s *Store) validateRunningAgent(ctx context.Context, a domain.PersistedRunningAgent, inspector ProcessInspector) (bool, error) {
-	if !inspector.PIDExists(a.PID) {
-		return false, s.deleteRunningAgentByID(ctx, a.ID)
+// DeleteStaleRunningAgents moves rows older than maxAge by last_seen to the
+// run history.
+func (s *Store) DeleteStaleRunningAgents(ctx context.Context, maxAge time.Duration) error {
+	if maxAge <= 0 {
+		maxAge = data.DefaultRunningAgentMaxAge
 	}
-
-	cmd, err := inspector.CommandForPID(ctx, a.PID)
+	cutoff := time.Now().UTC().Add(-maxAge)
+	agents, err := s.listRunningAgents(ctx, "last_seen < ?", cutoff)
 	if err != nil {
-		return false, s.deleteRunningAgentByID(ctx, a.ID)
+		return fmt.Errorf("failed deleting stale running agents: %w", err)
 	}
-
-	candidates := config.HarnessBinaryCandidates(a.HarnessName)
-	if a.HarnessBinary != "" {
-		candidates = append(candidates, a.HarnessBinary)
+	for _, a := range agents {
+		if err := s.finishRunningAgentByID(ctx, a); err != nil {
+			return fmt.Errorf("failed deleting stale running agents: %w", err)
+		}
 	}
+	return nil
+}
 
-	if !config.CommandMatchesAnyBinary(cmd, candidates) {
-		return false, s.deleteRunningAgentByID(ctx, a.ID)
+// FinishRunningAgent adds run to the agent_runs table and deletes the
+// running agent with the same project and launcher ID in one transaction.
+func (s *Store) FinishRunningAgent(ctx context.Context, run domain.AgentRun) error {
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+	if run.ProjectDir == "" || run.HarnessName == "" {
+		return fmt.Errorf("invalid agent run data")
 	}
+	err := s.moveToHistory(ctx, run, `DELETE FROM running_agents WHERE project_dir = ? AND launcher_id = ?`,
+		run.ProjectDir, run.LauncherID)
+	if err != nil {
+		return fmt.Errorf("failed to record agent run: %w", err)
+	}
+	return nil
+}
 
-	return true, nil
+// finishRunningAgentByID moves a running agent that stopped unseen to the
+// run history.
+func (s *Store) finishRunningAgentByID(ctx context.Context, a domain.PersistedRunningAgent) error {
+	err := s.moveToHistory(ctx, data.FinishedRun(ctx, a), `DELETE FROM running_agents WHERE id = ?`, a.ID)
+	if err != nil {
+		return fmt.Errorf("failed deleting running agent id=%d: %w", a.ID, err)
+	}
+	return nil
 }
 
-// DeleteStaleRunningAgents deletes rows older than maxAge by last_seen.
-func (s *Store) DeleteStaleRunningAgents(ctx context.Context, maxAge time.Duration) error {
-	if maxAge <= 0 {
-		maxAge = defaultRunningAgentMaxAge
+// moveToHistory inserts run and runs the delete statement in one
+// transaction.
+func (s *Store) moveToHistory(ctx context.Context, run domain.AgentRun, deleteQuery string, args ...any) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
 	}
-	cutoff := time.Now().UTC().Add(-maxAge)
-	_, err := s.db.ExecContext(ctx, `DELETE FROM running_agents WHERE last_seen < ?`, cutoff)
+	defer func() { _ = tx.Rollback() }()
+
+	const insert = `
+INSERT INTO agent_runs (
+	project_dir, worktree_path, launcher_id, ticket, ticket_title, harness_name, model, agent,
+	started_at, finished_at, status, exit_code, lines_added, lines_removed
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, s.qualify(insert),
+		run.ProjectDir,
+		run.WorktreePath,
+		run.LauncherID,
+		run.Ticket,
+		run.TicketTitle,
+		run.HarnessName,
+		run.Model,
+		run.Agent,
+		run.StartedAt.UTC(),
+		run.FinishedAt.UTC(),
+		run.Status.String(),
+		run.ExitCode,
+		run.LinesAdded,
+		run.LinesRemoved,
+	)
 	if err != nil {
-		return fmt.Errorf("failed deleting stale running agents: %w", err)
+		return err
 	}
-	return nil
+	if _, err := tx.ExecContext(ctx, s.qualify(deleteQuery), args...); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (s *Store) deleteRunningAgentByID(ctx context.Context, id int) error {
-	_, err := s.db.ExecContext(ctx, `DELETE FROM running_agents WHERE id = ?`, id)
+// ListAgentRuns returns the finished runs matching filter, newest first.
+func (s *Store) ListAgentRuns(ctx context.Context, filter data.AgentRunFilter) ([]domain.AgentRun, error) {
+	if s.closed {
+		return nil, fmt.Errorf("store is closed")
+	}
+
+	var conds []string
+	var args []any
+	for _, f := range []struct {
+		column, value string
+	}{
+		{"project_dir", filter.ProjectDir},
+		{"ticket", filter.Ticket},
+		{"harness_name", filter.Harness},
+		{"model", filter.Model},
+	} {
+		if f.value != "" {
+			conds = append(conds, f.column+" = ?")
+			args = append(args, f.value)
+		}
+	}
+	if !filter.Since.IsZero() {
+		conds = append(conds, "started_at >= ?")
+		args = append(args, filter.Since.UTC())
+	}
+	if !filter.Until.IsZero() {
+		conds = append(conds, "started_at < ?")
+		args = append(args, filter.Until.UTC())
+	}
+
+	query := `
+SELECT
+	id, project_dir, worktree_path, launcher_id, ticket, ticket_title, harness_name, model, agent,
+	started_at, finished_at, status, exit_code, lines_added, lines_removed
+FROM agent_runs`
+	if len(conds) > 0 {
+		query += "\nWHERE " + strings.Join(conds, " AND ")
+	}
+	query += "\nORDER BY started_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += "\nLIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.qualify(query), args...)
 	if err != nil {
-		return fmt.Errorf("failed deleting running agent id=%d: %w", id, err)
+		return nil, s.queryError(err, "failed to query agent runs")
 	}
-	return nil
+	defer rows.Close()
+
+	var runs []domain.AgentRun
+	for rows.Next() {
+		var (
+			r        domain.AgentRun
+			status   string
+			exitCode sql.NullInt64
+		)
+		if err := rows.Scan(
+			&r.ID,
+			&r.ProjectDir,
+			&r.WorktreePath,
+			&r.LauncherID,
+			&r.Ticket,
+			&r.TicketTitle,
+			&r.HarnessName,
+			&r.Model,
+			&r.Agent,
+			&r.StartedAt,
+			&r.FinishedAt,
+			&status,
+			&exitCode,
+			&r.LinesAdded,
+			&r.LinesRemoved,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan agent run row: %w", err)
+		}
+		r.Status, _ = domain.ParseAgentStatus(status)
+		if exitCode.Valid {
+			code := int(exitCode.Int64)
+			r.ExitCode = &code
+		}
+		runs = append(runs, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating agent run rows: %w", err)
+	}
+	return runs, nil
 }
 
 func (s *Store) touchRunningAgentByID(ctx context.Context, id int) error {
-	_, err := s.db.ExecContext(ctx, `UPDATE running_agents SET last_seen = CURRENT_TIMESTAMP WHERE id = ?`, id)
+	_, err := s.db.ExecContext(ctx, s.qualify(`UPDATE running_agents SET last_seen = CURRENT_TIMESTAMP WHERE id = ?`), id)
 	if err != nil {
 		return fmt.Errorf("failed touching running agent id=%d: %w", id, err)
 	}
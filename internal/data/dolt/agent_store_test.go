@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/domain"
 )
 
@@ -36,31 +37,7 @@ func TestStore_EnsureRunningAgentsTable(t *testing.T) {
 	defer db.Close()
 
 	store := &Store{db: db}
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS running_agents").
-		WillReturnResult(sqlmock.NewResult(0, 0))
-	mock.ExpectExec("ALTER TABLE running_agents ADD COLUMN ticket_title TEXT").
-		WillReturnError(errors.New("Error 1060: Duplicate column name 'ticket_title'"))
-
-	if err := store.EnsureRunningAgentsTable(context.Background()); err != nil {
-		t.Fatalf("expected nil error, got %v", err)
-	}
-	if err := mock.ExpectationsWereMet(); err != nil {
-		t.Fatalf("unfulfilled expectations: %v", err)
-	}
-}
-
-func TestStore_EnsureRunningAgentsTable_ColumnAlreadyExistsVariant(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("failed to create mock: %v", err)
-	}
-	defer db.Close()
-
-	store := &Store{db: db}
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS running_agents").
-		WillReturnResult(sqlmock.NewResult(0, 0))
-	mock.ExpectExec("ALTER TABLE running_agents ADD COLUMN ticket_title TEXT").
-		WillReturnError(errors.New(`Error 1105 (HY000): Column "ticket_title" already exists`))
+	expectSchemaVersion(mock, len(migrations))
 
 	if err := store.EnsureRunningAgentsTable(context.Background()); err != nil {
 		t.Fatalf("expected nil error, got %v", err)
@@ -166,12 +143,8 @@ func TestStore_ValidateAndPruneRunningAgents(t *testing.T) {
 	mock.ExpectExec("UPDATE running_agents SET last_seen = CURRENT_TIMESTAMP WHERE id = \\?").
 		WithArgs(1).
 		WillReturnResult(sqlmock.NewResult(0, 1))
-	mock.ExpectExec("DELETE FROM running_agents WHERE id = \\?").
-		WithArgs(2).
-		WillReturnResult(sqlmock.NewResult(0, 1))
-	mock.ExpectExec("DELETE FROM running_agents WHERE id = \\?").
-		WithArgs(3).
-		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectMoveToHistory(mock, 2)
+	expectMoveToHistory(mock, 3)
 
 	inspector := fakeInspector{
 		exists: map[int]bool{
@@ -207,9 +180,14 @@ func TestStore_DeleteStaleRunningAgents(t *testing.T) {
 	defer db.Close()
 
 	store := &Store{db: db}
-	mock.ExpectExec("DELETE FROM running_agents WHERE last_seen < \\?").
+	old := time.Now().UTC().Add(-2 * time.Hour)
+	mock.ExpectQuery("FROM running_agents\\s+WHERE last_seen < \\?").
 		WithArgs(sqlmock.AnyArg()).
-		WillReturnResult(sqlmock.NewResult(0, 1))
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "project_dir", "worktree_path", "pid", "launcher_type", "launcher_id", "ticket", "ticket_title",
+			"harness_name", "harness_binary", "model", "agent", "started_at", "last_seen",
+		}).AddRow(7, "/repo", "/repo", 101, int(domain.LauncherTypeTmux), "bb-7", "bb-7", "Title 7", "codex", "codex", "m", "a", old, old))
+	expectMoveToHistory(mock, 7)
 
 	if err := store.DeleteStaleRunningAgents(context.Background(), time.Hour); err != nil {
 		t.Fatalf("expected nil error, got %v", err)
@@ -218,3 +196,129 @@ func TestStore_DeleteStaleRunningAgents(t *testing.T) {
 		t.Fatalf("unfulfilled expectations: %v", err)
 	}
 }
+
+// expectMoveToHistory expects the transaction moving running agent id to
+// agent_runs.
+func expectMoveToHistory(mock sqlmock.Sqlmock, id int) {
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO agent_runs").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("DELETE FROM running_agents WHERE id = \\?").
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func TestStore_FinishRunningAgent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db}
+	started := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	exitCode := 1
+	run := domain.AgentRun{
+		ProjectDir:   "/repo",
+		WorktreePath: "/repo-wt",
+		LauncherID:   "@3",
+		Ticket:       "bb-1",
+		TicketTitle:  "Title 1",
+		HarnessName:  "codex",
+		Model:        "m",
+		Agent:        "a",
+		StartedAt:    started,
+		FinishedAt:   started.Add(time.Hour),
+		Status:       domain.AgentFailed,
+		ExitCode:     &exitCode,
+		LinesAdded:   12,
+		LinesRemoved: 3,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO agent_runs").
+		WithArgs("/repo", "/repo-wt", "@3", "bb-1", "Title 1", "codex", "m", "a",
+			started, started.Add(time.Hour), "failed", 1, 12, 3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM running_agents WHERE project_dir = ? AND launcher_id = ?")).
+		WithArgs("/repo", "@3").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := store.FinishRunningAgent(context.Background(), run); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_FinishRunningAgent_RollsBack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db}
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO agent_runs").
+		WillReturnError(errors.New("table not found"))
+	mock.ExpectRollback()
+
+	err = store.FinishRunningAgent(context.Background(), domain.AgentRun{ProjectDir: "/repo", HarnessName: "codex"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_ListAgentRuns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db}
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{
+		"id", "project_dir", "worktree_path", "launcher_id", "ticket", "ticket_title", "harness_name", "model", "agent",
+		"started_at", "finished_at", "status", "exit_code", "lines_added", "lines_removed",
+	}).
+		AddRow(2, "/repo", "/repo", "@2", "bb-1", "Title 1", "codex", "m", "a", since, since, "failed", 2, 5, 1).
+		AddRow(1, "/repo", "/repo", "@1", "bb-1", "Title 1", "codex", "m", "a", since, since, "completed", nil, 0, 0)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM agent_runs
+WHERE project_dir = ? AND ticket = ? AND harness_name = ? AND started_at >= ?
+ORDER BY started_at DESC, id DESC
+LIMIT ?`)).
+		WithArgs("/repo", "bb-1", "codex", since, 10).
+		WillReturnRows(rows)
+
+	runs, err := store.ListAgentRuns(context.Background(), data.AgentRunFilter{
+		ProjectDir: "/repo",
+		Ticket:     "bb-1",
+		Harness:    "codex",
+		Since:      since,
+		Limit:      10,
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 runs, got %+v", runs)
+	}
+	if runs[0].Status != domain.AgentFailed || runs[0].ExitCode == nil || *runs[0].ExitCode != 2 || runs[0].LinesAdded != 5 {
+		t.Errorf("unexpected first run: %+v", runs[0])
+	}
+	if runs[1].Status != domain.AgentCompleted || runs[1].ExitCode != nil {
+		t.Errorf("unexpected second run: %+v", runs[1])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
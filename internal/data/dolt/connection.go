@@ -0,0 +1,172 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// passwordCommandTimeout bounds how long a password_command may run.
+const passwordCommandTimeout = 10 * time.Second
+
+// connectionFor returns the connection profile of opts, with the --dsn
+// override applied.
+func connectionFor(opts domain.AppOptions) domain.DoltConnection {
+	conn := opts.DoltConnection
+	if opts.DSN != "" {
+		conn.DSN = opts.DSN
+	}
+	return conn
+}
+
+// buildServerConfig builds the driver config for the server described by
+// metadata and conn. Precedence, highest first: the DSN, the profile
+// fields, metadata.json, the defaults. The password comes from the DSN,
+// then password_command, password_file and BEADS_DOLT_PASSWORD.
+func buildServerConfig(ctx context.Context, metadata *Metadata, conn domain.DoltConnection) (*mysql.Config, error) {
+	cfg := mysql.NewConfig()
+	if conn.DSN != "" {
+		parsed, err := mysql.ParseDSN(conn.DSN)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Dolt DSN: %w", err)
+		}
+		cfg = parsed
+	} else if conn.Socket != "" {
+		cfg.Net = "unix"
+		cfg.Addr = conn.Socket
+	} else {
+		cfg.Net = "tcp"
+		host := cmp.Or(conn.Host, metadata.ServerHost, "127.0.0.1")
+		port := cmp.Or(conn.Port, metadata.ServerPort, 3307) // 3307 is the default Dolt sql-server port
+		cfg.Addr = net.JoinHostPort(host, strconv.Itoa(port))
+	}
+
+	cfg.User = cmp.Or(cfg.User, conn.User, metadata.ServerUser, "root")
+	cfg.DBName = cmp.Or(cfg.DBName, conn.Database, metadata.DoltDatabase)
+
+	if cfg.Passwd == "" {
+		passwd, err := resolvePassword(ctx, conn)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Passwd = passwd
+	}
+
+	if err := applyTLS(cfg, conn); err != nil {
+		return nil, err
+	}
+
+	// The store scans DATETIME columns into time.Time.
+	cfg.ParseTime = true
+	cfg.Loc = time.UTC
+	return cfg, nil
+}
+
+// resolvePassword returns the password from password_command, password_file
+// or BEADS_DOLT_PASSWORD, in that order.
+func resolvePassword(ctx context.Context, conn domain.DoltConnection) (string, error) {
+	switch {
+	case conn.PasswordCommand != "":
+		cmdCtx, cancel := context.WithTimeout(ctx, passwordCommandTimeout)
+		defer cancel()
+
+		var stderr bytes.Buffer
+		//nolint:gosec // The command comes from the user's own config file
+		cmd := exec.CommandContext(cmdCtx, "sh", "-c", conn.PasswordCommand)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", fmt.Errorf("dolt password_command failed: %w: %s", err, msg)
+			}
+			return "", fmt.Errorf("dolt password_command failed: %w", err)
+		}
+		return strings.TrimRight(string(out), "\r\n"), nil
+	case conn.PasswordFile != "":
+		content, err := os.ReadFile(conn.PasswordFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read dolt password_file: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	default:
+		return os.Getenv("BEADS_DOLT_PASSWORD"), nil
+	}
+}
+
+// applyTLS sets the TLS mode of conn on cfg. Certificate files build a
+// custom tls.Config; a bare mode is left for the driver to resolve. Without
+// either, the TLS settings of the DSN are kept.
+func applyTLS(cfg *mysql.Config, conn domain.DoltConnection) error {
+	hasFiles := conn.TLSCA != "" || conn.TLSCert != "" || conn.TLSKey != ""
+	if conn.TLS == "" && !hasFiles && conn.TLSServerName == "" {
+		return nil
+	}
+
+	mode := cmp.Or(conn.TLS, "true")
+	cfg.TLS = nil
+	cfg.AllowFallbackToPlaintext = false
+	if !hasFiles && conn.TLSServerName == "" {
+		cfg.TLSConfig = mode
+		return nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: conn.TLSServerName,
+	}
+	switch mode {
+	case "true":
+	case "skip-verify":
+		tlsCfg.InsecureSkipVerify = true
+	case "preferred":
+		cfg.AllowFallbackToPlaintext = true
+	case "false":
+		return errors.New("dolt tls is false, but TLS files or server name are set")
+	default:
+		return fmt.Errorf("unknown dolt tls mode %q", conn.TLS)
+	}
+
+	if conn.TLSCA != "" {
+		pem, err := os.ReadFile(conn.TLSCA)
+		if err != nil {
+			return fmt.Errorf("failed to read dolt tls_ca: %w", err)
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		if !tlsCfg.RootCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("dolt tls_ca %s contains no PEM certificates", conn.TLSCA)
+		}
+	}
+	if conn.TLSCert != "" || conn.TLSKey != "" {
+		if conn.TLSCert == "" || conn.TLSKey == "" {
+			return errors.New("dolt tls_cert and tls_key must be set together")
+		}
+		cert, err := tls.LoadX509KeyPair(conn.TLSCert, conn.TLSKey)
+		if err != nil {
+			return fmt.Errorf("failed to load dolt client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	cfg.TLSConfig = ""
+	cfg.TLS = tlsCfg
+	return nil
+}
@@ -0,0 +1,245 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func TestBuildServerConfig(t *testing.T) {
+	full := Metadata{
+		DoltDatabase: "beads_test",
+		ServerHost:   "10.11.0.1",
+		ServerPort:   13307,
+		ServerUser:   "mysql-root",
+	}
+
+	tests := []struct {
+		name     string
+		metadata Metadata
+		conn     domain.DoltConnection
+		password string // BEADS_DOLT_PASSWORD
+		expected string
+	}{
+		{
+			name:     "metadata",
+			metadata: full,
+			expected: "mysql-root@tcp(10.11.0.1:13307)/beads_test?parseTime=true",
+		},
+		{
+			name: "password from environment",
+			metadata: Metadata{
+				DoltDatabase: "beads_prod",
+				ServerHost:   "db.example.com",
+				ServerPort:   3306,
+				ServerUser:   "admin",
+			},
+			password: "secret123",
+			expected: "admin:secret123@tcp(db.example.com:3306)/beads_prod?parseTime=true",
+		},
+		{
+			name:     "defaults for missing fields",
+			metadata: Metadata{DoltDatabase: "beads_default"},
+			expected: "root@tcp(127.0.0.1:3307)/beads_default?parseTime=true",
+		},
+		{
+			name:     "profile fields over metadata",
+			metadata: full,
+			conn:     domain.DoltConnection{Host: "db.internal", Port: 3306, User: "bdb", Database: "beads_other"},
+			expected: "bdb@tcp(db.internal:3306)/beads_other?parseTime=true",
+		},
+		{
+			name:     "partial profile keeps metadata",
+			metadata: full,
+			conn:     domain.DoltConnection{Port: 3306},
+			expected: "mysql-root@tcp(10.11.0.1:3306)/beads_test?parseTime=true",
+		},
+		{
+			name:     "socket over host and port",
+			metadata: full,
+			conn:     domain.DoltConnection{Socket: "/run/dolt.sock", Host: "db.internal", Port: 3306},
+			expected: "mysql-root@unix(/run/dolt.sock)/beads_test?parseTime=true",
+		},
+		{
+			name:     "IPv6 host",
+			metadata: Metadata{DoltDatabase: "beads", ServerHost: "::1", ServerPort: 3307},
+			expected: "root@tcp([::1]:3307)/beads?parseTime=true",
+		},
+		{
+			name:     "DSN over profile fields and metadata",
+			metadata: full,
+			conn:     domain.DoltConnection{DSN: "dsn-user:dsn-pw@tcp(remote:3306)/dsn_db", Host: "ignored", Socket: "/ignored.sock"},
+			password: "ignored",
+			expected: "dsn-user:dsn-pw@tcp(remote:3306)/dsn_db?parseTime=true",
+		},
+		{
+			name:     "DSN without user and database",
+			metadata: full,
+			conn:     domain.DoltConnection{DSN: "tcp(remote:3306)/", User: "bdb"},
+			password: "env-pw",
+			expected: "bdb:env-pw@tcp(remote:3306)/beads_test?parseTime=true",
+		},
+		{
+			name:     "DSN keeps its TLS mode",
+			metadata: full,
+			conn:     domain.DoltConnection{DSN: "u@tcp(remote:3306)/db?tls=skip-verify"},
+			expected: "u@tcp(remote:3306)/db?parseTime=true&tls=skip-verify",
+		},
+		{
+			name:     "profile TLS mode over DSN",
+			metadata: full,
+			conn:     domain.DoltConnection{DSN: "u@tcp(remote:3306)/db?tls=skip-verify", TLS: "true"},
+			expected: "u@tcp(remote:3306)/db?parseTime=true&tls=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BEADS_DOLT_PASSWORD", tt.password)
+
+			cfg, err := buildServerConfig(context.Background(), &tt.metadata, tt.conn)
+			if err != nil {
+				t.Fatalf("buildServerConfig failed: %v", err)
+			}
+			if got := cfg.FormatDSN(); got != tt.expected {
+				t.Errorf("DSN mismatch\nexpected: %s\ngot:      %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBuildServerConfig_DSNOverride(t *testing.T) {
+	opts := domain.AppOptions{
+		DSN:            "flag@tcp(flag-host:3306)/",
+		DoltConnection: domain.DoltConnection{DSN: "profile@tcp(profile-host:3306)/", Database: "beads_profile"},
+	}
+	cfg, err := buildServerConfig(context.Background(), &Metadata{DoltDatabase: "beads"}, connectionFor(opts))
+	if err != nil {
+		t.Fatalf("buildServerConfig failed: %v", err)
+	}
+	if cfg.User != "flag" || cfg.Addr != "flag-host:3306" || cfg.DBName != "beads_profile" {
+		t.Errorf("expected --dsn to win over the profile DSN, got %s", cfg.FormatDSN())
+	}
+}
+
+func TestResolvePassword(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "password")
+	if err := os.WriteFile(file, []byte("from file \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BEADS_DOLT_PASSWORD", "from env")
+
+	tests := []struct {
+		name     string
+		conn     domain.DoltConnection
+		expected string
+		wantErr  string
+	}{
+		{name: "environment", expected: "from env"},
+		{name: "file over environment", conn: domain.DoltConnection{PasswordFile: file}, expected: "from file "},
+		{
+			name:     "command over file",
+			conn:     domain.DoltConnection{PasswordFile: file, PasswordCommand: "printf 'from command\\n'"},
+			expected: "from command",
+		},
+		{name: "missing file", conn: domain.DoltConnection{PasswordFile: filepath.Join(dir, "missing")}, wantErr: "password_file"},
+		{name: "failing command", conn: domain.DoltConnection{PasswordCommand: "echo locked >&2; exit 1"}, wantErr: "locked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolvePassword(context.Background(), tt.conn)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error mentioning %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolvePassword failed: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBuildServerConfig_TLSFiles(t *testing.T) {
+	ca := writeTestCA(t)
+	metadata := &Metadata{DoltDatabase: "beads"}
+
+	cfg, err := buildServerConfig(context.Background(), metadata, domain.DoltConnection{TLSCA: ca, TLSServerName: "dolt.internal"})
+	if err != nil {
+		t.Fatalf("buildServerConfig failed: %v", err)
+	}
+	if cfg.TLS == nil || cfg.TLS.RootCAs == nil || cfg.TLS.ServerName != "dolt.internal" || cfg.TLS.InsecureSkipVerify {
+		t.Fatalf("expected a verifying TLS config with the CA, got %+v", cfg.TLS)
+	}
+
+	for name, conn := range map[string]domain.DoltConnection{
+		"tls disabled":     {TLS: "false", TLSCA: ca},
+		"unknown mode":     {TLS: "maybe", TLSCA: ca},
+		"cert without key": {TLSCert: ca},
+		"not a PEM file":   {TLSCA: metadataPath(t)},
+	} {
+		if _, err := buildServerConfig(context.Background(), metadata, conn); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// writeTestCA writes a self-signed certificate and returns its path.
+func writeTestCA(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// metadataPath writes a file that is not PEM and returns its path.
+func metadataPath(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "metadata.json")
+	if err := os.WriteFile(path, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
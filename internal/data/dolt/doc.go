@@ -10,8 +10,14 @@
 // This package connects to a running dolt sql-server via the MySQL protocol using
 // github.com/go-sql-driver/mysql. Supports multiple concurrent connections.
 //
-// Server connection details are read from metadata.json. The server can be
-// auto-started if configured.
+// Server connection details are read from metadata.json, overridden by the
+// project's domain.DoltConnection profile and the --dsn flag. Connections
+// may use TCP or a Unix socket, with TLS. The server can be auto-started if
+// configured.
+//
+// Stores opened through one Pools share a connection pool per server and
+// credentials. Such pools have no default database, so each store prefixes
+// the table names of its queries with its own database.
 //
 // DSN format: user:password@tcp(host:port)/database?parseTime=true&loc=UTC
 //
@@ -37,9 +43,14 @@
 //   - "failed to connect": Database is corrupted or locked
 //   - "schema verification failed": Missing or incompatible schema
 //
-// Queries the ready_issues view which filters for unblocked,
-// non-deferred, non-ephemeral issues.
+// Tickets are listed from the ready_issues view, which filters for
+// unblocked, non-deferred, non-ephemeral issues, or from the issues table
+// for the other ticket scopes.
+//
+// Writes are limited to the data.WritableTicketStore methods, which the TUI
+// only calls when writeback is configured.
 //
-// Note: This package provides read-only access. Blunderbust never writes
-// to the beads database.
+// The tables bdb adds for itself, running_agents and agent_runs, are
+// created and changed by numbered migrations, recorded in
+// bdb_schema_version.
 package dolt
@@ -25,6 +25,10 @@ const (
 	ServerMode Mode = "server"
 )
 
+// BackendJSONL is the Backend of projects that keep their issues only in
+// the JSONL export, without a Dolt database.
+const BackendJSONL = "jsonl"
+
 // Metadata represents the parsed .beads/metadata.json file.
 type Metadata struct {
 	// Database backend type (should be "dolt")
@@ -41,6 +45,8 @@ type Metadata struct {
 	ServerUser string `json:"dolt_server_user"`
 	// ServerReadyTimeoutSeconds is the timeout in seconds to wait for Dolt server to be ready
 	ServerReadyTimeoutSeconds int `json:"dolt_server_ready_timeout"`
+	// JSONLExport is the issues export file name within the beads directory
+	JSONLExport string `json:"jsonl_export"`
 }
 
 // ConnectionMode always returns ServerMode since embedded mode is no longer supported.
@@ -48,9 +54,15 @@ func (m *Metadata) ConnectionMode() Mode {
 	return ServerMode
 }
 
+// UsesJSONL returns true if the project has no Dolt database and tickets
+// have to be read from the JSONL export.
+func (m *Metadata) UsesJSONL() bool {
+	return m.Backend == BackendJSONL
+}
+
 // IsValid returns true if the metadata contains the minimum required fields.
 func (m *Metadata) IsValid() bool {
-	return m.DoltDatabase != ""
+	return m.DoltDatabase != "" || m.UsesJSONL()
 }
 
 // ServerReadyTimeout returns the configured server ready timeout or default 10 seconds.
@@ -7,10 +7,13 @@
 package dolt
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/megatherium/blunderbust/internal/domain"
 )
 
 func TestLoadMetadata_DefaultMode(t *testing.T) {
@@ -189,6 +192,30 @@ func TestLoadMetadata_MissingDoltDatabase(t *testing.T) {
 	}
 }
 
+func TestLoadMetadata_JSONLBackend(t *testing.T) {
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0750); err != nil {
+		t.Fatalf("Failed to create beads dir: %v", err)
+	}
+
+	metadataJSON := `{"backend": "jsonl", "jsonl_export": "export.jsonl"}`
+	if err := os.WriteFile(filepath.Join(beadsDir, "metadata.json"), []byte(metadataJSON), 0644); err != nil {
+		t.Fatalf("Failed to write metadata.json: %v", err)
+	}
+
+	metadata, err := LoadMetadata(beadsDir)
+	if err != nil {
+		t.Fatalf("Expected no error without dolt_database, got: %v", err)
+	}
+	if !metadata.UsesJSONL() || metadata.JSONLExport != "export.jsonl" {
+		t.Errorf("Expected the jsonl backend with export.jsonl, got %+v", metadata)
+	}
+
+	if err := Probe(context.Background(), beadsDir, domain.DoltConnection{}); err == nil || !strings.Contains(err.Error(), "no Dolt database") {
+		t.Errorf("Expected Probe to refuse the jsonl backend, got: %v", err)
+	}
+}
+
 func TestMetadata_ConnectionMode(t *testing.T) {
 	tests := []struct {
 		name     string
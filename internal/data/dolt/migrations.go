@@ -0,0 +1,187 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/migrate"
+)
+
+// Verify interface compliance at compile time.
+var _ data.MigratableStore = (*Store)(nil)
+
+// schemaTx is the transaction a migration runs in. Its methods qualify the
+// table names like the store's.
+type schemaTx struct {
+	tx       *sql.Tx
+	database string
+}
+
+func (t *schemaTx) exec(ctx context.Context, query string, args ...any) error {
+	_, err := t.tx.ExecContext(ctx, qualifyTables(query, t.database), args...)
+	return err
+}
+
+// columnExists reports whether table has column.
+func (t *schemaTx) columnExists(ctx context.Context, table, column string) (bool, error) {
+	var n int
+	err := t.tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM information_schema.columns `+
+			`WHERE table_schema = COALESCE(NULLIF(?, ''), DATABASE()) AND table_name = ? AND column_name = ?`,
+		t.database, table, column).Scan(&n)
+	return n > 0, err
+}
+
+// migrations are the schema changes of the tables bdb adds to a beads
+// database. DDL may commit implicitly, so each migration must be safe to
+// run again on a database it already changed.
+var migrations = []migrate.Migration[*schemaTx]{
+	{
+		Version: 1,
+		Name:    "create running_agents",
+		Up: func(ctx context.Context, tx *schemaTx) error {
+			return tx.exec(ctx, `
+CREATE TABLE IF NOT EXISTS running_agents (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    project_dir VARCHAR(255) NOT NULL,
+    worktree_path VARCHAR(255) NOT NULL,
+    pid INT NOT NULL,
+    launcher_type INT NOT NULL,
+    launcher_id VARCHAR(100),
+    ticket VARCHAR(100),
+    harness_name VARCHAR(50) NOT NULL,
+    harness_binary VARCHAR(100),
+    model VARCHAR(50),
+    agent VARCHAR(50),
+    started_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uniq_running_agent (project_dir, worktree_path, pid),
+    INDEX idx_running_agents_project_dir (project_dir),
+    INDEX idx_running_agents_last_seen (last_seen)
+)`)
+		},
+	},
+	{
+		Version: 2,
+		Name:    "add running_agents.ticket_title",
+		Up: func(ctx context.Context, tx *schemaTx) error {
+			// Databases set up before migrations may have the column already.
+			exists, err := tx.columnExists(ctx, "running_agents", "ticket_title")
+			if err != nil || exists {
+				return err
+			}
+			return tx.exec(ctx, `ALTER TABLE running_agents ADD COLUMN ticket_title TEXT`)
+		},
+	},
+	{
+		Version: 3,
+		Name:    "create agent_runs",
+		Up: func(ctx context.Context, tx *schemaTx) error {
+			return tx.exec(ctx, `
+CREATE TABLE IF NOT EXISTS agent_runs (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    project_dir VARCHAR(255) NOT NULL,
+    worktree_path VARCHAR(255) NOT NULL,
+    launcher_id VARCHAR(100),
+    ticket VARCHAR(100),
+    ticket_title TEXT,
+    harness_name VARCHAR(50) NOT NULL,
+    model VARCHAR(50),
+    agent VARCHAR(50),
+    started_at DATETIME NOT NULL,
+    finished_at DATETIME NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    exit_code INT,
+    lines_added INT NOT NULL DEFAULT 0,
+    lines_removed INT NOT NULL DEFAULT 0,
+    INDEX idx_agent_runs_ticket (ticket),
+    INDEX idx_agent_runs_started_at (started_at)
+)`)
+		},
+	},
+}
+
+// schemaBackend records the applied migrations of a store's database in
+// the bdb_schema_version table.
+type schemaBackend struct {
+	s *Store
+}
+
+// Version returns the highest applied migration. A database without the
+// version table has none.
+func (b schemaBackend) Version(ctx context.Context) (int, error) {
+	var tables int
+	err := b.s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM information_schema.tables `+
+			`WHERE table_schema = COALESCE(NULLIF(?, ''), DATABASE()) AND table_name = 'bdb_schema_version'`,
+		b.s.database).Scan(&tables)
+	if err != nil || tables == 0 {
+		return 0, err
+	}
+
+	var version int
+	err = b.s.db.QueryRowContext(ctx, b.s.qualify(`SELECT COALESCE(MAX(version), 0) FROM bdb_schema_version`)).Scan(&version)
+	return version, err
+}
+
+// Apply runs m and records it in one transaction.
+func (b schemaBackend) Apply(ctx context.Context, m migrate.Migration[*schemaTx]) error {
+	const createVersionTable = `
+CREATE TABLE IF NOT EXISTS bdb_schema_version (
+    version INT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+	if _, err := b.s.db.ExecContext(ctx, b.s.qualify(createVersionTable)); err != nil {
+		return fmt.Errorf("failed to create bdb_schema_version table: %w", err)
+	}
+
+	tx, err := b.s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stx := &schemaTx{tx: tx, database: b.s.database}
+	if err := m.Up(ctx, stx); err != nil {
+		return err
+	}
+	if err := stx.exec(ctx, `INSERT INTO bdb_schema_version (version, name) VALUES (?, ?)`, m.Version, m.Name); err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+	return tx.Commit()
+}
+
+// MigrationStatus returns the migration state of the tables bdb adds to
+// the database.
+func (s *Store) MigrationStatus(ctx context.Context) (migrate.Status, error) {
+	if s.closed {
+		return migrate.Status{}, fmt.Errorf("store is closed")
+	}
+	status, err := migrate.GetStatus(ctx, schemaBackend{s}, migrations)
+	if err != nil {
+		return status, s.queryError(err, "failed to read migration status")
+	}
+	return status, nil
+}
+
+// MigrateUp applies the pending migrations of the tables bdb adds to the
+// database and returns those applied.
+func (s *Store) MigrateUp(ctx context.Context) ([]migrate.Info, error) {
+	if s.closed {
+		return nil, fmt.Errorf("store is closed")
+	}
+	applied, err := migrate.Up(ctx, schemaBackend{s}, migrations)
+	if err != nil {
+		return applied, s.queryError(err, "failed to migrate database")
+	}
+	return applied, nil
+}
@@ -0,0 +1,196 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/megatherium/blunderbust/internal/data/migrate"
+)
+
+// expectSchemaVersion expects the version lookup of schemaBackend, with
+// version 0 meaning no bdb_schema_version table.
+func expectSchemaVersion(mock sqlmock.Sqlmock, version int) {
+	tables := 0
+	if version > 0 {
+		tables = 1
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("FROM information_schema.tables")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tables))
+	if version > 0 {
+		mock.ExpectQuery(`SELECT COALESCE\(MAX\(version\), 0\) FROM \S*bdb_schema_version`).
+			WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(version))
+	}
+}
+
+// expectMigration expects the transaction of migration version around the
+// statements expected by body.
+func expectMigration(mock sqlmock.Sqlmock, version int, body func()) {
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS bdb_schema_version").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	body()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO bdb_schema_version (version, name) VALUES (?, ?)")).
+		WithArgs(version, migrations[version-1].Name).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func expectColumnExists(mock sqlmock.Sqlmock, exists bool) {
+	n := 0
+	if exists {
+		n = 1
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("FROM information_schema.columns")).
+		WithArgs("", "running_agents", "ticket_title").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(n))
+}
+
+func expectAgentRunsMigration(mock sqlmock.Sqlmock) {
+	expectMigration(mock, 3, func() {
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS agent_runs").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	})
+}
+
+func TestStore_MigrationStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db}
+	expectSchemaVersion(mock, 1)
+
+	status, err := store.MigrationStatus(context.Background())
+	if err != nil {
+		t.Fatalf("MigrationStatus failed: %v", err)
+	}
+	if status.Current != 1 || status.Latest != len(migrations) {
+		t.Errorf("expected version 1 of %d, got %+v", len(migrations), status)
+	}
+	expected := []migrate.Info{
+		{Version: 2, Name: "add running_agents.ticket_title"},
+		{Version: 3, Name: "create agent_runs"},
+	}
+	if !reflect.DeepEqual(status.Pending, expected) {
+		t.Errorf("expected pending %+v, got %+v", expected, status.Pending)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_MigrationStatus_NewerSchema(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db}
+	expectSchemaVersion(mock, len(migrations)+1)
+
+	if _, err := store.MigrationStatus(context.Background()); !errors.Is(err, migrate.ErrNewerSchema) {
+		t.Errorf("expected ErrNewerSchema, got %v", err)
+	}
+}
+
+func TestStore_MigrateUp_FreshDatabase(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db}
+	expectSchemaVersion(mock, 0)
+	expectMigration(mock, 1, func() {
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS running_agents").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	})
+	expectMigration(mock, 2, func() {
+		expectColumnExists(mock, false)
+		mock.ExpectExec(regexp.QuoteMeta("ALTER TABLE running_agents ADD COLUMN ticket_title TEXT")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	})
+	expectAgentRunsMigration(mock)
+
+	applied, err := store.MigrateUp(context.Background())
+	if err != nil {
+		t.Fatalf("MigrateUp failed: %v", err)
+	}
+	if len(applied) != len(migrations) {
+		t.Errorf("expected %d applied migrations, got %+v", len(migrations), applied)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_MigrateUp_ColumnFromBeforeMigrations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	// Older bdb versions created the table with the column but no version.
+	store := &Store{db: db}
+	expectSchemaVersion(mock, 0)
+	expectMigration(mock, 1, func() {
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS running_agents").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	})
+	expectMigration(mock, 2, func() {
+		expectColumnExists(mock, true)
+	})
+	expectAgentRunsMigration(mock)
+
+	if _, err := store.MigrateUp(context.Background()); err != nil {
+		t.Fatalf("MigrateUp failed: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_MigrateUp_RollsBackFailedMigration(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db}
+	expectSchemaVersion(mock, 1)
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS bdb_schema_version").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectBegin()
+	expectColumnExists(mock, false)
+	mock.ExpectExec("ALTER TABLE running_agents").
+		WillReturnError(errors.New("Error 1142: ALTER command denied"))
+	mock.ExpectRollback()
+
+	applied, err := store.MigrateUp(context.Background())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(applied) != 0 {
+		t.Errorf("expected nothing applied, got %+v", applied)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// Pools shares connection pools between the stores of databases served by
+// the same Dolt sql-server. A pool is keyed by the server address and the
+// credentials, opened without a default database, and closed when its last
+// store is closed; each store qualifies its table names with its database.
+// Pools is safe for concurrent use, so workspace projects can be opened in
+// parallel while the first of them connects.
+type Pools struct {
+	mu     sync.Mutex
+	pools  map[string]*sharedPool
+	closed bool
+}
+
+// sharedPool is one connection pool and the databases checked through it.
+type sharedPool struct {
+	key  string
+	db   *sql.DB
+	refs int
+
+	// ready is closed once db is open or err is set.
+	ready chan struct{}
+	err   error
+
+	// verified and agentTables record the databases whose schema was
+	// verified and whose running_agents table exists. Guarded by Pools.mu.
+	verified    map[string]bool
+	agentTables map[string]bool
+}
+
+// NewPools returns an empty set of connection pools.
+func NewPools() *Pools {
+	return &Pools{pools: make(map[string]*sharedPool)}
+}
+
+// NewStore creates a TicketStore like the package-level NewStore, sharing
+// the connection pool with the other stores of p on the same server.
+func (p *Pools) NewStore(ctx context.Context, opts domain.AppOptions, autostart bool) (*Store, error) {
+	beadsDir := opts.BeadsDir
+	if beadsDir == "" {
+		beadsDir = ".beads"
+	}
+
+	metadata, err := LoadMetadata(beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.UsesJSONL() {
+		return nil, errJSONLBackend
+	}
+
+	return handleServerMode(ctx, p, beadsDir, metadata, opts, autostart)
+}
+
+// Close closes every pool, including those of stores still open. The
+// stores fail their queries afterwards.
+func (p *Pools) Close() error {
+	p.mu.Lock()
+	pools := p.pools
+	p.pools = make(map[string]*sharedPool)
+	p.closed = true
+	p.mu.Unlock()
+
+	var errs []error
+	for _, sp := range pools {
+		<-sp.ready
+		if sp.db != nil {
+			errs = append(errs, sp.db.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// acquire returns the pool for cfg, opening it if no store uses it yet,
+// and the database the store should use. Concurrent callers for the same
+// server wait for the first one to connect. Each successful acquire must
+// be paired with a release.
+func (p *Pools) acquire(ctx context.Context, metadata *Metadata, conn domain.DoltConnection) (*sharedPool, string, error) {
+	cfg, err := buildServerConfig(ctx, metadata, conn)
+	if err != nil {
+		return nil, "", err
+	}
+	database := cfg.DBName
+	cfg.DBName = ""
+	key := poolKey(cfg, conn)
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, "", errors.New("dolt connection pools are closed")
+	}
+	sp, ok := p.pools[key]
+	if !ok {
+		sp = &sharedPool{key: key, ready: make(chan struct{})}
+		p.pools[key] = sp
+	}
+	sp.refs++
+	p.mu.Unlock()
+
+	if !ok {
+		sp.db, sp.err = openServerDB(ctx, cfg)
+		if sp.err != nil {
+			p.mu.Lock()
+			if p.pools[key] == sp {
+				delete(p.pools, key)
+			}
+			p.mu.Unlock()
+		}
+		close(sp.ready)
+	}
+
+	select {
+	case <-sp.ready:
+	case <-ctx.Done():
+		p.release(sp)
+		return nil, "", ctx.Err()
+	}
+	if sp.err != nil {
+		return nil, "", sp.err
+	}
+
+	if err := p.verifySchema(ctx, sp, database); err != nil {
+		p.release(sp)
+		return nil, "", err
+	}
+	return sp, database, nil
+}
+
+// verifySchema verifies the schema of database once per pool.
+func (p *Pools) verifySchema(ctx context.Context, sp *sharedPool, database string) error {
+	p.mu.Lock()
+	done := sp.verified[database]
+	p.mu.Unlock()
+	if done {
+		return nil
+	}
+
+	if err := verifySchema(ctx, sp.db, database); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	if sp.verified == nil {
+		sp.verified = make(map[string]bool)
+	}
+	sp.verified[database] = true
+	p.mu.Unlock()
+	return nil
+}
+
+// release drops a reference to sp and closes it with the last one.
+func (p *Pools) release(sp *sharedPool) error {
+	p.mu.Lock()
+	sp.refs--
+	last := sp.refs == 0 && p.pools[sp.key] == sp
+	if last {
+		delete(p.pools, sp.key)
+	}
+	p.mu.Unlock()
+
+	if last && sp.db != nil {
+		return sp.db.Close()
+	}
+	return nil
+}
+
+// hasAgentTable reports whether the running_agents table of database is
+// known to exist.
+func (p *Pools) hasAgentTable(sp *sharedPool, database string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return sp.agentTables[database]
+}
+
+// setAgentTable records that the running_agents table of database exists.
+func (p *Pools) setAgentTable(sp *sharedPool, database string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if sp.agentTables == nil {
+		sp.agentTables = make(map[string]bool)
+	}
+	sp.agentTables[database] = true
+}
+
+// poolKey identifies the server and credentials of cfg, which must have
+// no database. Custom TLS configs are not part of the DSN, so the profile
+// fields they were built from are added.
+func poolKey(cfg *mysql.Config, conn domain.DoltConnection) string {
+	key := cfg.FormatDSN()
+	if cfg.TLS != nil {
+		key += "|" + strings.Join([]string{conn.TLS, conn.TLSCA, conn.TLSCert, conn.TLSKey, conn.TLSServerName}, "|")
+	}
+	return key
+}
+
+// openServerDB opens a connection pool to the Dolt sql-server of cfg and
+// pings it.
+func openServerDB(ctx context.Context, cfg *mysql.Config) (*sql.DB, error) {
+	// A connector rather than a DSN, so a custom tls.Config is kept.
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MySQL connection pool: %w", err)
+	}
+	db := sql.OpenDB(connector)
+
+	// Configure connection pool for server mode
+	db.SetMaxOpenConns(10)
+	db.SetMaxIdleConns(5)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	// Test the connection with timeout
+	pingCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(pingCtx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf(
+			"cannot connect to Dolt server at %s: %w; "+
+				"check that the server is running and accessible",
+			cfg.Addr, err,
+		)
+	}
+	return db, nil
+}
+
+// beadsTables matches the beads and bdb tables after the keywords that
+// name a table, so qualify can prefix them with a database.
+var beadsTables = regexp.MustCompile(
+	`\b(FROM|JOIN|INTO|UPDATE|TABLE|TABLE IF NOT EXISTS)(\s+)` +
+		`(issues|ready_issues|dependencies|labels|comments|running_agents|agent_runs|bdb_schema_version)\b`)
+
+// qualifyTables prefixes the beads tables in query with database. An empty
+// database leaves the query unchanged, for connections with a default one.
+func qualifyTables(query, database string) string {
+	if database == "" {
+		return query
+	}
+	quoted := "`" + strings.ReplaceAll(database, "`", "``") + "`."
+	return beadsTables.ReplaceAllString(query, "${1}${2}"+quoted+"${3}")
+}
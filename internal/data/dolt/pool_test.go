@@ -0,0 +1,249 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func TestQualifyTables(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		database string
+		expected string
+	}{
+		{
+			name:     "no database",
+			query:    "SELECT COUNT(*) FROM ready_issues LIMIT 1",
+			expected: "SELECT COUNT(*) FROM ready_issues LIMIT 1",
+		},
+		{
+			name:     "select",
+			query:    "SELECT COUNT(*) FROM ready_issues LIMIT 1",
+			database: "beads_a",
+			expected: "SELECT COUNT(*) FROM `beads_a`.ready_issues LIMIT 1",
+		},
+		{
+			name:     "join",
+			query:    "FROM dependencies d JOIN issues b ON b.id = d.depends_on_id",
+			database: "beads_a",
+			expected: "FROM `beads_a`.dependencies d JOIN `beads_a`.issues b ON b.id = d.depends_on_id",
+		},
+		{
+			name:     "writes",
+			query:    "INSERT INTO comments (issue_id) VALUES (?); UPDATE issues SET updated_at = ?",
+			database: "beads_a",
+			expected: "INSERT INTO `beads_a`.comments (issue_id) VALUES (?); UPDATE `beads_a`.issues SET updated_at = ?",
+		},
+		{
+			name:     "create and alter",
+			query:    "CREATE TABLE IF NOT EXISTS running_agents (id INT); ALTER TABLE running_agents ADD COLUMN x TEXT",
+			database: "beads_a",
+			expected: "CREATE TABLE IF NOT EXISTS `beads_a`.running_agents (id INT); ALTER TABLE `beads_a`.running_agents ADD COLUMN x TEXT",
+		},
+		{
+			name:     "other names are kept",
+			query:    "SELECT issue_id FROM issues_archive WHERE labels = ?",
+			database: "beads_a",
+			expected: "SELECT issue_id FROM issues_archive WHERE labels = ?",
+		},
+		{
+			name:     "backticks are escaped",
+			query:    "SELECT id FROM issues",
+			database: "odd`name",
+			expected: "SELECT id FROM `odd``name`.issues",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := qualifyTables(tt.query, tt.database); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPoolKey(t *testing.T) {
+	key := func(metadata Metadata, conn domain.DoltConnection) string {
+		t.Helper()
+		cfg, err := buildServerConfig(context.Background(), &metadata, conn)
+		if err != nil {
+			t.Fatalf("buildServerConfig failed: %v", err)
+		}
+		cfg.DBName = ""
+		return poolKey(cfg, conn)
+	}
+
+	a := key(Metadata{DoltDatabase: "beads_a", ServerPort: 3307}, domain.DoltConnection{})
+	b := key(Metadata{DoltDatabase: "beads_b", ServerPort: 3307}, domain.DoltConnection{})
+	if a != b {
+		t.Errorf("expected databases on one server to share a key, got %q and %q", a, b)
+	}
+
+	for name, other := range map[string]string{
+		"port":     key(Metadata{DoltDatabase: "beads_a", ServerPort: 3308}, domain.DoltConnection{}),
+		"user":     key(Metadata{DoltDatabase: "beads_a", ServerPort: 3307}, domain.DoltConnection{User: "bdb"}),
+		"tls mode": key(Metadata{DoltDatabase: "beads_a", ServerPort: 3307}, domain.DoltConnection{TLS: "skip-verify"}),
+		"tls ca":   key(Metadata{DoltDatabase: "beads_a", ServerPort: 3307}, domain.DoltConnection{TLSCA: writeTestCA(t)}),
+	} {
+		if other == a {
+			t.Errorf("%s: expected a different key", name)
+		}
+	}
+}
+
+// sqlmockDB is a mock database for pools, closed by the test's cleanup
+// unless a pool closes it first.
+type sqlmockDB struct {
+	*sql.DB
+	mock sqlmock.Sqlmock
+}
+
+func newSQLMock(t *testing.T) *sqlmockDB {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return &sqlmockDB{DB: db, mock: mock}
+}
+
+// newTestPool registers a pool backed by db under key, as acquire would.
+func newTestPool(p *Pools, key string, db *sqlmockDB) *sharedPool {
+	sp := &sharedPool{key: key, db: db.DB, ready: make(chan struct{})}
+	close(sp.ready)
+	p.pools[key] = sp
+	return sp
+}
+
+func TestPools_ReleaseClosesWithLastStore(t *testing.T) {
+	db := newSQLMock(t)
+	pools := NewPools()
+	sp := newTestPool(pools, "server", db)
+	sp.refs = 2
+
+	first := &Store{db: sp.db, mode: ServerMode, database: "beads_a", pools: pools, pool: sp}
+	second := &Store{db: sp.db, mode: ServerMode, database: "beads_b", pools: pools, pool: sp}
+
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, ok := pools.pools["server"]; !ok {
+		t.Fatal("expected the pool to stay open for the second store")
+	}
+
+	db.mock.ExpectClose()
+	if err := second.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, ok := pools.pools["server"]; ok {
+		t.Error("expected the pool to be removed with its last store")
+	}
+	if err := db.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPools_Close(t *testing.T) {
+	db := newSQLMock(t)
+	pools := NewPools()
+	sp := newTestPool(pools, "server", db)
+	sp.refs = 1
+	store := &Store{db: sp.db, mode: ServerMode, database: "beads_a", pools: pools, pool: sp}
+
+	db.mock.ExpectClose()
+	if err := pools.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	// The store's pool is gone already; closing it must not close it twice.
+	if err := store.Close(); err != nil {
+		t.Fatalf("store Close failed: %v", err)
+	}
+	if err := db.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+
+	if _, _, err := pools.acquire(context.Background(), &Metadata{DoltDatabase: "beads_a"}, domain.DoltConnection{}); err == nil {
+		t.Error("expected acquire to fail on closed pools")
+	}
+}
+
+func TestPools_VerifiesSchemaOncePerDatabase(t *testing.T) {
+	db := newSQLMock(t)
+	pools := NewPools()
+	sp := newTestPool(pools, "server", db)
+
+	db.mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM `beads_a`.ready_issues")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	db.mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM `beads_b`.ready_issues")).
+		WillReturnError(errors.New("Error 1049: Unknown database 'beads_b'"))
+
+	ctx := context.Background()
+	for range 2 {
+		if err := pools.verifySchema(ctx, sp, "beads_a"); err != nil {
+			t.Fatalf("verifySchema failed: %v", err)
+		}
+	}
+	if err := pools.verifySchema(ctx, sp, "beads_b"); err == nil {
+		t.Fatal("expected the schema of beads_b to fail verification")
+	}
+	if err := db.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_EnsureRunningAgentsTable_OncePerDatabase(t *testing.T) {
+	db := newSQLMock(t)
+	pools := NewPools()
+	sp := newTestPool(pools, "server", db)
+
+	expectSchemaVersion(db.mock, len(migrations))
+
+	ctx := context.Background()
+	for range 2 {
+		// A new store for the same database, e.g. after a server restart.
+		store := &Store{db: sp.db, mode: ServerMode, database: "beads_a", pools: pools, pool: sp}
+		if err := store.EnsureRunningAgentsTable(ctx); err != nil {
+			t.Fatalf("EnsureRunningAgentsTable failed: %v", err)
+		}
+	}
+	if err := db.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_QualifiesQueries(t *testing.T) {
+	db := newSQLMock(t)
+	store := &Store{db: db.DB, mode: ServerMode, database: "beads_a"}
+
+	db.mock.ExpectQuery(regexp.QuoteMeta("SELECT MAX(updated_at) FROM `beads_a`.issues")).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(nil))
+	db.mock.ExpectQuery(regexp.QuoteMeta("SELECT @@beads_a_working")).
+		WillReturnRows(sqlmock.NewRows([]string{"hash"}).AddRow("abc"))
+
+	ctx := context.Background()
+	if _, err := store.LatestUpdate(ctx); err != nil {
+		t.Fatalf("LatestUpdate failed: %v", err)
+	}
+	// The shared pool has no current database to look up.
+	if token, err := store.ChangeToken(ctx); err != nil || token != "abc" {
+		t.Fatalf("expected token abc, got %q (%v)", token, err)
+	}
+	if err := db.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
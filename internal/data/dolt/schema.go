@@ -12,14 +12,14 @@ import (
 	"fmt"
 )
 
-// verifySchema checks that the database has the expected schema by querying
-// the ready_issues view. Returns an actionable error if the schema is missing
+// verifySchema checks that database has the expected schema by querying its
+// ready_issues view. Returns an actionable error if the schema is missing
 // or incompatible.
-func verifySchema(ctx context.Context, db *sql.DB) error {
+func verifySchema(ctx context.Context, db *sql.DB, database string) error {
 	// Try to query the ready_issues view with a LIMIT 0 to just check schema
 	// without fetching data
 	var count int
-	err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM ready_issues LIMIT 1").Scan(&count)
+	err := db.QueryRowContext(ctx, qualifyTables("SELECT COUNT(*) FROM ready_issues LIMIT 1", database)).Scan(&count)
 	if err != nil {
 		return fmt.Errorf(
 			"schema verification failed: unable to query ready_issues view: %w; "+
@@ -8,7 +8,6 @@ package dolt
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"os"
 	"os/exec"
@@ -16,94 +15,52 @@ import (
 	"strings"
 	"time"
 
-	"github.com/go-sql-driver/mysql"
+	"github.com/megatherium/blunderbust/internal/domain"
 )
 
-// newServerStore creates a Store connected to a Dolt sql-server.
-// Uses standard MySQL driver for remote connections.
+// newServerStore creates a Store connected to a Dolt sql-server, sharing
+// the connection pool of pools with other databases on the same server.
 // Note: beadsDir parameter is unused in server mode since we connect to a
 // remote server rather than a local database directory.
-func newServerStore(ctx context.Context, beadsDir string, metadata *Metadata, autostart bool) (*Store, error) {
-	// Build MySQL DSN
-	dsn := buildServerDSN(metadata)
-
-	db, err := sql.Open("mysql", dsn)
+func newServerStore(ctx context.Context, pools *Pools, beadsDir string, metadata *Metadata, conn domain.DoltConnection, autostart bool) (*Store, error) {
+	sp, database, err := pools.acquire(ctx, metadata, conn)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create MySQL connection pool: %w", err)
-	}
-
-	// Configure connection pool for server mode
-	db.SetMaxOpenConns(10)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
-
-	// Test the connection with timeout
-	pingCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-
-	if err := db.PingContext(pingCtx); err != nil {
-		_ = db.Close()
-		return nil, fmt.Errorf(
-			"cannot connect to Dolt server at %s:%d: %w; "+
-				"check that the server is running and accessible",
-			metadata.ServerHost, metadata.ServerPort, err,
-		)
-	}
-
-	// Verify the database schema is accessible
-	if err := verifySchema(ctx, db); err != nil {
-		_ = db.Close()
 		return nil, err
 	}
-	store := &Store{
-		db:        db,
+	return &Store{
+		db:        sp.db,
 		mode:      ServerMode,
 		beadsDir:  beadsDir,
 		metadata:  metadata,
+		conn:      conn,
 		autostart: autostart,
-	}
-
-	if err := store.EnsureRunningAgentsTable(ctx); err != nil {
-		_ = db.Close()
-		return nil, err
-	}
-
-	return store, nil
+		database:  database,
+		pools:     pools,
+		pool:      sp,
+	}, nil
 }
 
-// buildServerDSN constructs the MySQL DSN from metadata using mysql.Config
-// for proper escaping of special characters in passwords.
-func buildServerDSN(metadata *Metadata) string {
-	cfg := mysql.NewConfig()
-
-	// Set defaults
-	cfg.Net = "tcp"
-	cfg.User = metadata.ServerUser
-	if cfg.User == "" {
-		cfg.User = "root"
+// Probe checks that the Dolt server configured in beadsDir, overridden by
+// conn, is reachable and exposes the ready_issues view. Unlike NewStore it
+// never starts the server or creates tables, which makes it safe to use for
+// diagnostics.
+func Probe(ctx context.Context, beadsDir string, conn domain.DoltConnection) error {
+	metadata, err := LoadMetadata(beadsDir)
+	if err != nil {
+		return err
 	}
-
-	// Build address with defaults
-	host := metadata.ServerHost
-	if host == "" {
-		host = "127.0.0.1"
+	if metadata.UsesJSONL() {
+		return errJSONLBackend
 	}
-	port := metadata.ServerPort
-	if port == 0 {
-		port = 3307 // Default Dolt sql-server port
+	if metadata.ServerPort == 0 {
+		// Detection failures fall back to the default port in buildServerConfig.
+		_, _ = metadata.ResolveServerPort(beadsDir)
 	}
-	cfg.Addr = fmt.Sprintf("%s:%d", host, port)
-
-	// Check for password in environment
-	cfg.Passwd = os.Getenv("BEADS_DOLT_PASSWORD")
-
-	cfg.DBName = metadata.DoltDatabase
-
-	// Connection parameters
-	cfg.ParseTime = true
-	cfg.Loc = time.UTC
 
-	return cfg.FormatDSN()
+	pools := NewPools()
+	defer pools.Close()
+	_, _, err = pools.acquire(ctx, metadata, conn)
+	return err
 }
 
 // StartServer attempts to start the Dolt server by running 'bd dolt start'.
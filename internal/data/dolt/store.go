@@ -11,12 +11,15 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"os"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/search"
 	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/logging"
 )
 
 // Store implements data.TicketStore using Dolt.
@@ -26,11 +29,27 @@ type Store struct {
 	closed    bool
 	beadsDir  string
 	metadata  *Metadata
+	conn      domain.DoltConnection
 	autostart bool
+
+	// database qualifies the table names of every query, as the pool is
+	// shared with other databases. Empty for connections with a default
+	// database.
+	database string
+	pools    *Pools
+	pool     *sharedPool
+
+	// workingVar is the system variable holding the working set hash of the
+	// connected database, looked up on first use.
+	mu         sync.Mutex
+	workingVar string
 }
 
 // Verify interface compliance at compile time.
-var _ data.TicketStore = (*Store)(nil)
+var (
+	_ data.TicketStore      = (*Store)(nil)
+	_ data.ChangeTokenStore = (*Store)(nil)
+)
 
 // ErrServerNotRunning is returned when the Dolt server is not running and autostart is disabled.
 type ErrServerNotRunning struct {
@@ -41,27 +60,31 @@ func (e *ErrServerNotRunning) Error() string {
 	return e.Message
 }
 
+// ErrAutostartFailed is returned when the Dolt server was not running and
+// starting it failed, e.g. because dolt is not installed.
+var ErrAutostartFailed = errors.New("failed to auto-start dolt server")
+
 // IsErrServerNotRunning returns true if the error is an ErrServerNotRunning.
 func IsErrServerNotRunning(err error) bool {
 	var e *ErrServerNotRunning
 	return errors.As(err, &e)
 }
 
-func handleServerMode(ctx context.Context, beadsDir string, metadata *Metadata, opts domain.AppOptions, autostart bool) (*Store, error) {
-	if opts.Debug {
-		fmt.Fprintf(os.Stderr, "Dolt server mode enabled\n")
-	}
+func handleServerMode(ctx context.Context, pools *Pools, beadsDir string, metadata *Metadata, opts domain.AppOptions, autostart bool) (*Store, error) {
+	logger := logging.For(opts.Logger, logging.Dolt).With("beads_dir", beadsDir)
+	logger.Debug("dolt server mode enabled")
 	// Resolve server port if not explicitly configured
 	if metadata.ServerPort == 0 {
 		resolvedPort, err := metadata.ResolveServerPort(beadsDir)
-		if err != nil && opts.Debug {
-			fmt.Fprintf(os.Stderr, "Warning: failed to auto-detect Dolt port: %v\n", err)
+		if err != nil {
+			logger.Warn("failed to auto-detect dolt port", "err", err)
 		}
-		if resolvedPort > 0 && opts.Debug {
-			fmt.Fprintf(os.Stderr, "Auto-detected Dolt server port: %d\n", resolvedPort)
+		if resolvedPort > 0 {
+			logger.Debug("auto-detected dolt server port", "port", resolvedPort)
 		}
 	}
-	store, err := newServerStore(ctx, beadsDir, metadata, autostart)
+	conn := connectionFor(opts)
+	store, err := newServerStore(ctx, pools, beadsDir, metadata, conn, autostart)
 	if err != nil {
 		// Check if it's a connection error
 		if !IsConnectionError(err) {
@@ -75,14 +98,12 @@ func handleServerMode(ctx context.Context, beadsDir string, metadata *Metadata,
 			}
 		}
 
-		if opts.Debug {
-			fmt.Fprintf(os.Stderr, "Dolt server not running, attempting to start...\n")
-		}
+		logger.Info("dolt server not running, attempting to start")
 		if startErr := StartServer(ctx, beadsDir, metadata); startErr != nil {
-			return nil, fmt.Errorf("failed to auto-start dolt server: %w", startErr)
+			return nil, fmt.Errorf("%w: %w", ErrAutostartFailed, startErr)
 		}
 		// Retry connection after starting server
-		return newServerStore(ctx, beadsDir, metadata, autostart)
+		return newServerStore(ctx, pools, beadsDir, metadata, conn, autostart)
 	}
 	return store, nil
 }
@@ -90,20 +111,15 @@ func handleServerMode(ctx context.Context, beadsDir string, metadata *Metadata,
 // NewStore creates a TicketStore connected to a Dolt database.
 // It reads metadata.json from the beads directory and connects to the configured dolt sql-server.
 // If autostart is true and the server is not running, it will attempt to start it.
+// The store gets a connection pool of its own; use Pools.NewStore to share
+// pools between projects.
 func NewStore(ctx context.Context, opts domain.AppOptions, autostart bool) (*Store, error) {
-	beadsDir := opts.BeadsDir
-	if beadsDir == "" {
-		beadsDir = ".beads"
-	}
-
-	metadata, err := LoadMetadata(beadsDir)
-	if err != nil {
-		return nil, err
-	}
-
-	return handleServerMode(ctx, beadsDir, metadata, opts, autostart)
+	return NewPools().NewStore(ctx, opts, autostart)
 }
 
+// errJSONLBackend is returned for projects without a Dolt database.
+var errJSONLBackend = errors.New("metadata.json selects the jsonl backend, which has no Dolt database")
+
 // IsConnectionError returns true if the error indicates the server is not running.
 func IsConnectionError(err error) bool {
 	if err == nil {
@@ -116,12 +132,16 @@ func IsConnectionError(err error) bool {
 		strings.Contains(errStr, "dial tcp")
 }
 
-// Close closes the database connection.
+// Close closes the database connection, or releases the shared pool,
+// which is closed with its last store.
 func (s *Store) Close() error {
 	if s.closed {
 		return nil
 	}
 	s.closed = true
+	if s.pool != nil {
+		return s.pools.release(s.pool)
+	}
 	return s.db.Close()
 }
 
@@ -153,61 +173,49 @@ func (s *Store) TryStartServer(ctx context.Context) (*Store, error) {
 	}
 
 	// Create new store with fresh connection
-	return newServerStore(ctx, s.beadsDir, s.metadata, s.autostart)
+	pools := s.pools
+	if pools == nil {
+		pools = NewPools()
+	}
+	return newServerStore(ctx, pools, s.beadsDir, s.metadata, s.conn, s.autostart)
 }
 
-// ListTickets queries the ready_issues view and returns tickets matching the filter.
+// ListTickets returns tickets matching the filter. The ready scope queries
+// the ready_issues view; the others query the issues table and load the
+// open blockers of each ticket.
 func (s *Store) ListTickets(ctx context.Context, filter data.TicketFilter) ([]domain.Ticket, error) {
 	if s.closed {
 		return nil, fmt.Errorf("store is closed")
 	}
 
-	query, args := buildListTicketsQuery(filter)
+	query, args, err := buildListTicketsQuery(filter)
+	if err != nil {
+		return nil, err
+	}
 
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	rows, err := s.db.QueryContext(ctx, s.qualify(query), args...)
 	if err != nil {
-		if s.mode == ServerMode && IsConnectionError(err) {
-			// Return a more descriptive error that wraps the connection failure
-			if s.autostart {
-				return nil, &ErrServerNotRunning{
-					Message: "Dolt server connection failed. Would you like to restart it?",
-				}
-			}
-			return nil, &ErrServerNotRunning{
-				Message: "Dolt server connection failed. Please check that it's running.",
-			}
-		}
-		return nil, fmt.Errorf("failed to query tickets: %w", err)
+		return nil, s.queryError(err, "failed to query tickets")
 	}
 	defer rows.Close()
 
-	return scanTickets(rows)
+	return scanTickets(rows, filter.Scope != data.ScopeReady)
 }
 
-// LatestUpdate returns the maximum updated_at timestamp from the ready_issues view.
-// Returns a zero time.Time if no tickets exist.
+// LatestUpdate returns the maximum updated_at timestamp from the issues table,
+// so changes outside the ready scope, like a blocker being closed, are seen
+// too. Returns a zero time.Time if no tickets exist.
 func (s *Store) LatestUpdate(ctx context.Context) (time.Time, error) {
 	if s.closed {
 		return time.Time{}, fmt.Errorf("store is closed")
 	}
 
 	var latest sql.NullTime
-	query := "SELECT MAX(updated_at) FROM ready_issues"
-	err := s.db.QueryRowContext(ctx, query).Scan(&latest)
+	query := "SELECT MAX(updated_at) FROM issues"
+	err := s.db.QueryRowContext(ctx, s.qualify(query)).Scan(&latest)
 
 	if err != nil {
-		if s.mode == ServerMode && IsConnectionError(err) {
-			// Return a more descriptive error that wraps connection failure
-			if s.autostart {
-				return time.Time{}, &ErrServerNotRunning{
-					Message: "Dolt server connection failed. Would you like to restart it?",
-				}
-			}
-			return time.Time{}, &ErrServerNotRunning{
-				Message: "Dolt server connection failed. Please check that it's running.",
-			}
-		}
-		return time.Time{}, fmt.Errorf("failed to query latest update: %w", err)
+		return time.Time{}, s.queryError(err, "failed to query latest update")
 	}
 
 	if !latest.Valid {
@@ -217,26 +225,146 @@ func (s *Store) LatestUpdate(ctx context.Context) (time.Time, error) {
 	return latest.Time, nil
 }
 
+// databaseNamePattern matches database names usable in a system variable
+// name without quoting.
+var databaseNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// ChangeToken returns the hash of the database's working set, which changes
+// with every write to any table, including deletions and dependency
+// changes that leave updated_at untouched.
+func (s *Store) ChangeToken(ctx context.Context) (string, error) {
+	if s.closed {
+		return "", fmt.Errorf("store is closed")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.workingVar == "" {
+		database := s.database
+		if database == "" {
+			var current sql.NullString
+			if err := s.db.QueryRowContext(ctx, "SELECT DATABASE()").Scan(&current); err != nil {
+				return "", s.queryError(err, "failed to query current database")
+			}
+			database = current.String
+		}
+		if !databaseNamePattern.MatchString(database) {
+			return "", fmt.Errorf("cannot track changes of database %q", database)
+		}
+		s.workingVar = "@@" + database + "_working"
+	}
+
+	var hash string
+	if err := s.db.QueryRowContext(ctx, "SELECT "+s.workingVar).Scan(&hash); err != nil {
+		return "", s.queryError(err, "failed to query working set hash")
+	}
+	return hash, nil
+}
+
+// qualify prefixes the table names in query with the store's database.
+func (s *Store) qualify(query string) string {
+	return qualifyTables(query, s.database)
+}
+
+// queryError wraps a failed query. Lost server connections become an
+// ErrServerNotRunning so the UI can offer to restart the server.
+func (s *Store) queryError(err error, msg string) error {
+	if s.mode == ServerMode && IsConnectionError(err) {
+		if s.autostart {
+			return &ErrServerNotRunning{
+				Message: "Dolt server connection failed. Would you like to restart it?",
+			}
+		}
+		return &ErrServerNotRunning{
+			Message: "Dolt server connection failed. Please check that it's running.",
+		}
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// ticketColumns are the columns scanTickets reads, in order.
+const ticketColumns = `id, title, description, status, priority, issue_type, assignee, created_at, updated_at`
+
+// openBlockersFrom selects the open issues blocking the issue i.
+const openBlockersFrom = `FROM dependencies d JOIN issues b ON b.id = d.depends_on_id ` +
+	`WHERE d.issue_id = i.id AND d.type = 'blocks' AND b.status != 'closed'`
+
+// blockersColumn lists the IDs of the open blockers, comma separated.
+const blockersColumn = `(SELECT GROUP_CONCAT(d.depends_on_id ORDER BY d.depends_on_id SEPARATOR ',') ` +
+	openBlockersFrom + `) AS blockers`
+
+// scopeConditions select the issues of the scopes that query the issues table.
+var scopeConditions = map[data.TicketScope]string{
+	data.ScopeInProgress: `status = 'in_progress'`,
+	data.ScopeBlocked:    `status != 'closed' AND (status = 'blocked' OR EXISTS (SELECT 1 ` + openBlockersFrom + `))`,
+	data.ScopeAll:        `status != 'closed'`,
+}
+
+// writeInCondition appends a condition keeping rows whose column holds one
+// of the comma-separated values of field, and returns args with them added.
+// A single value compares with =, no values add nothing.
+func writeInCondition(sb *strings.Builder, args []any, column, field string) []any {
+	values := data.FilterValues(field)
+	switch len(values) {
+	case 0:
+		return args
+	case 1:
+		sb.WriteString(" AND " + column + " = ?")
+	default:
+		sb.WriteString(" AND " + column + " IN (?" + strings.Repeat(", ?", len(values)-1) + ")")
+	}
+	for _, v := range values {
+		args = append(args, v)
+	}
+	return args
+}
+
 // buildListTicketsQuery constructs the SQL query with optional filters.
-func buildListTicketsQuery(filter data.TicketFilter) (query string, args []any) {
-	// Base query - we select specific fields from ready_issues view
-	// ready_issues already filters for unblocked, non-deferred, non-ephemeral issues
+// It fails if filter.Search is not a valid search query.
+func buildListTicketsQuery(filter data.TicketFilter) (query string, args []any, err error) {
 	var sb strings.Builder
-	sb.WriteString(`SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1`)
+	if filter.Scope == data.ScopeReady {
+		// ready_issues already filters for unblocked, non-deferred, non-ephemeral issues
+		sb.WriteString(`SELECT ` + ticketColumns + ` FROM ready_issues WHERE 1=1`)
+	} else {
+		cond, ok := scopeConditions[filter.Scope]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown ticket scope %d", filter.Scope)
+		}
+		sb.WriteString(`SELECT ` + ticketColumns + `, ` + blockersColumn + ` FROM issues i WHERE ` + cond)
+	}
+
+	args = writeInCondition(&sb, args, "status", filter.Status)
+	args = writeInCondition(&sb, args, "issue_type", filter.IssueType)
+
+	if filter.Assignee != "" {
+		sb.WriteString(" AND assignee = ?")
+		args = append(args, filter.Assignee)
+	}
 
-	if filter.Status != "" {
-		sb.WriteString(" AND status = ?")
-		args = append(args, filter.Status)
+	if filter.MinPriority != nil {
+		sb.WriteString(" AND priority >= ?")
+		args = append(args, *filter.MinPriority)
 	}
 
-	if filter.IssueType != "" {
-		sb.WriteString(" AND issue_type = ?")
-		args = append(args, filter.IssueType)
+	if filter.MaxPriority != nil {
+		sb.WriteString(" AND priority <= ?")
+		args = append(args, *filter.MaxPriority)
 	}
 
-	if filter.Search != "" {
-		sb.WriteString(" AND title LIKE ?")
-		args = append(args, "%"+filter.Search+"%")
+	if filter.Label != "" {
+		sb.WriteString(" AND id IN (SELECT issue_id FROM labels WHERE label = ?)")
+		args = append(args, filter.Label)
+	}
+
+	q, err := search.Parse(filter.Search)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid search: %w", err)
+	}
+	if !q.IsZero() {
+		cond, searchArgs := q.SQL()
+		sb.WriteString(" AND " + cond)
+		args = append(args, searchArgs...)
 	}
 
 	// Order by priority (lower number = higher priority), then by updated_at (most recent first)
@@ -247,18 +375,19 @@ func buildListTicketsQuery(filter data.TicketFilter) (query string, args []any)
 		args = append(args, filter.Limit)
 	}
 
-	return sb.String(), args
+	return sb.String(), args, nil
 }
 
 // scanTickets reads rows from the result set and converts them to domain.Ticket.
-func scanTickets(rows *sql.Rows) ([]domain.Ticket, error) {
+// withBlockers reads the blockers column that follows ticketColumns.
+func scanTickets(rows *sql.Rows, withBlockers bool) ([]domain.Ticket, error) {
 	var tickets []domain.Ticket
 
 	for rows.Next() {
 		var t domain.Ticket
-		var assignee sql.NullString
+		var assignee, blockers sql.NullString
 
-		err := rows.Scan(
+		dest := []any{
 			&t.ID,
 			&t.Title,
 			&t.Description,
@@ -268,14 +397,20 @@ func scanTickets(rows *sql.Rows) ([]domain.Ticket, error) {
 			&assignee,
 			&t.CreatedAt,
 			&t.UpdatedAt,
-		)
-		if err != nil {
+		}
+		if withBlockers {
+			dest = append(dest, &blockers)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, fmt.Errorf("failed to scan ticket row: %w", err)
 		}
 
 		if assignee.Valid {
 			t.Assignee = assignee.String
 		}
+		if blockers.String != "" {
+			t.Blockers = strings.Split(blockers.String, ",")
+		}
 
 		tickets = append(tickets, t)
 	}
@@ -9,12 +9,15 @@ package dolt
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/search/searchtest"
 )
 
 func TestStore_ListTickets_NoFilter(t *testing.T) {
@@ -134,8 +137,8 @@ func TestStore_ListTickets_WithSearchFilter(t *testing.T) {
 
 	store := &Store{db: db, mode: ServerMode}
 
-	mock.ExpectQuery(`SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1 AND title LIKE \? ORDER BY priority ASC, updated_at DESC`).
-		WithArgs("%test%").
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1 AND "+searchTextSQL+" ORDER BY priority ASC, updated_at DESC")).
+		WithArgs("%test%", "%test%", "%test%", "%test%", "%test%").
 		WillReturnRows(sqlmock.NewRows([]string{
 			"id", "title", "description", "status", "priority", "issue_type", "assignee", "created_at", "updated_at",
 		}).
@@ -200,8 +203,8 @@ func TestStore_ListTickets_CombinedFilters(t *testing.T) {
 
 	store := &Store{db: db, mode: ServerMode}
 
-	mock.ExpectQuery(`SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1 AND status = \? AND issue_type = \? AND title LIKE \? ORDER BY priority ASC, updated_at DESC LIMIT \?`).
-		WithArgs("open", "bug", "%crash%", 10).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1 AND status = ? AND issue_type = ? AND "+searchTextSQL+" ORDER BY priority ASC, updated_at DESC LIMIT ?")).
+		WithArgs("open", "bug", "%crash%", "%crash%", "%crash%", "%crash%", "%crash%", 10).
 		WillReturnRows(sqlmock.NewRows([]string{
 			"id", "title", "description", "status", "priority", "issue_type", "assignee", "created_at", "updated_at",
 		}).
@@ -264,6 +267,42 @@ func TestStore_ListTickets_WithAssignee(t *testing.T) {
 	}
 }
 
+func TestStore_ListTickets_BlockedScope(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: ServerMode}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + ticketColumns + ", " + blockersColumn + " FROM issues i WHERE " + scopeConditions[data.ScopeBlocked])).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "title", "description", "status", "priority", "issue_type", "assignee", "created_at", "updated_at", "blockers",
+		}).
+			AddRow("bb-010", "Waits on two", "", "open", 1, "task", nil, time.Now(), time.Now(), "bb-001,bb-002").
+			AddRow("bb-011", "Marked blocked", "", "blocked", 2, "task", nil, time.Now(), time.Now(), nil))
+
+	tickets, err := store.ListTickets(context.Background(), data.TicketFilter{Scope: data.ScopeBlocked})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tickets) != 2 {
+		t.Fatalf("expected 2 tickets, got %d", len(tickets))
+	}
+	if !reflect.DeepEqual(tickets[0].Blockers, []string{"bb-001", "bb-002"}) {
+		t.Errorf("unexpected blockers: %v", tickets[0].Blockers)
+	}
+	if tickets[1].Blockers != nil || !tickets[1].IsBlocked() {
+		t.Errorf("expected a blocked ticket without blockers, got %+v", tickets[1])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
 func TestStore_ListTickets_EmptyResult(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -325,7 +364,7 @@ func TestStore_LatestUpdate_Success(t *testing.T) {
 	store := &Store{db: db, mode: ServerMode}
 
 	now := time.Now()
-	mock.ExpectQuery(`SELECT MAX\(updated_at\) FROM ready_issues`).
+	mock.ExpectQuery(`SELECT MAX\(updated_at\) FROM issues`).
 		WillReturnRows(sqlmock.NewRows([]string{"MAX(updated_at)"}).
 			AddRow(now))
 
@@ -344,6 +383,53 @@ func TestStore_LatestUpdate_Success(t *testing.T) {
 	}
 }
 
+func TestStore_ChangeToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: ServerMode}
+
+	mock.ExpectQuery(`SELECT DATABASE\(\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"DATABASE()"}).AddRow("beads_bb"))
+	mock.ExpectQuery(`SELECT @@beads_bb_working`).
+		WillReturnRows(sqlmock.NewRows([]string{"@@beads_bb_working"}).AddRow("hash1"))
+	mock.ExpectQuery(`SELECT @@beads_bb_working`).
+		WillReturnRows(sqlmock.NewRows([]string{"@@beads_bb_working"}).AddRow("hash2"))
+
+	for _, want := range []string{"hash1", "hash2"} {
+		got, err := store.ChangeToken(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_ChangeToken_UnsupportedDatabaseName(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: ServerMode}
+	mock.ExpectQuery(`SELECT DATABASE\(\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"DATABASE()"}).AddRow("beads-bb; DROP"))
+
+	if _, err := store.ChangeToken(context.Background()); err == nil {
+		t.Fatal("expected an error for a database name needing quotes")
+	}
+}
+
 func TestStore_LatestUpdate_EmptyTable(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -353,7 +439,7 @@ func TestStore_LatestUpdate_EmptyTable(t *testing.T) {
 
 	store := &Store{db: db, mode: ServerMode}
 
-	mock.ExpectQuery(`SELECT MAX\(updated_at\) FROM ready_issues`).
+	mock.ExpectQuery(`SELECT MAX\(updated_at\) FROM issues`).
 		WillReturnRows(sqlmock.NewRows([]string{"MAX(updated_at)"}).
 			AddRow(nil))
 
@@ -401,7 +487,7 @@ func TestStore_LatestUpdate_ConnectionError(t *testing.T) {
 
 	store := &Store{db: db, mode: ServerMode, autostart: true}
 
-	mock.ExpectQuery(`SELECT MAX\(updated_at\) FROM ready_issues`).
+	mock.ExpectQuery(`SELECT MAX\(updated_at\) FROM issues`).
 		WillReturnError(&ErrServerNotRunning{
 			Message: "Dolt server connection failed",
 		})
@@ -446,11 +532,41 @@ func TestBuildListTicketsQuery(t *testing.T) {
 			expected: "SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1 AND issue_type = ? ORDER BY priority ASC, updated_at DESC",
 			args:     []any{"bug"},
 		},
+		{
+			name:     "status and type lists",
+			filter:   data.TicketFilter{Status: "open, in_progress", IssueType: "bug,"},
+			expected: "SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1 AND status IN (?, ?) AND issue_type = ? ORDER BY priority ASC, updated_at DESC",
+			args:     []any{"open", "in_progress", "bug"},
+		},
 		{
 			name:     "search filter",
 			filter:   data.TicketFilter{Search: "test"},
-			expected: "SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1 AND title LIKE ? ORDER BY priority ASC, updated_at DESC",
-			args:     []any{"%test%"},
+			expected: "SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1 AND " + searchTextSQL + " ORDER BY priority ASC, updated_at DESC",
+			args:     []any{"%test%", "%test%", "%test%", "%test%", "%test%"},
+		},
+		{
+			name:     "assignee and priority range",
+			filter:   data.TicketFilter{Assignee: "alice", MinPriority: intPtr(1), MaxPriority: intPtr(2)},
+			expected: "SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1 AND assignee = ? AND priority >= ? AND priority <= ? ORDER BY priority ASC, updated_at DESC",
+			args:     []any{"alice", 1, 2},
+		},
+		{
+			name:     "label filter",
+			filter:   data.TicketFilter{Label: "backend"},
+			expected: "SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1 AND id IN (SELECT issue_id FROM labels WHERE label = ?) ORDER BY priority ASC, updated_at DESC",
+			args:     []any{"backend"},
+		},
+		{
+			name:     "in progress scope",
+			filter:   data.TicketFilter{Scope: data.ScopeInProgress, IssueType: "bug"},
+			expected: "SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at, (SELECT GROUP_CONCAT(d.depends_on_id ORDER BY d.depends_on_id SEPARATOR ',') FROM dependencies d JOIN issues b ON b.id = d.depends_on_id WHERE d.issue_id = i.id AND d.type = 'blocks' AND b.status != 'closed') AS blockers FROM issues i WHERE status = 'in_progress' AND issue_type = ? ORDER BY priority ASC, updated_at DESC",
+			args:     []any{"bug"},
+		},
+		{
+			name:     "all open scope",
+			filter:   data.TicketFilter{Scope: data.ScopeAll},
+			expected: "SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at, (SELECT GROUP_CONCAT(d.depends_on_id ORDER BY d.depends_on_id SEPARATOR ',') FROM dependencies d JOIN issues b ON b.id = d.depends_on_id WHERE d.issue_id = i.id AND d.type = 'blocks' AND b.status != 'closed') AS blockers FROM issues i WHERE status != 'closed' ORDER BY priority ASC, updated_at DESC",
+			args:     nil,
 		},
 		{
 			name:     "limit filter",
@@ -461,14 +577,17 @@ func TestBuildListTicketsQuery(t *testing.T) {
 		{
 			name:     "combined filters",
 			filter:   data.TicketFilter{Status: "open", IssueType: "feature", Search: "auth", Limit: 5},
-			expected: "SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1 AND status = ? AND issue_type = ? AND title LIKE ? ORDER BY priority ASC, updated_at DESC LIMIT ?",
-			args:     []any{"open", "feature", "%auth%", 5},
+			expected: "SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1 AND status = ? AND issue_type = ? AND " + searchTextSQL + " ORDER BY priority ASC, updated_at DESC LIMIT ?",
+			args:     []any{"open", "feature", "%auth%", "%auth%", "%auth%", "%auth%", "%auth%", 5},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			query, args := buildListTicketsQuery(tt.filter)
+			query, args, err := buildListTicketsQuery(tt.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
 
 			if query != tt.expected {
 				t.Errorf("query mismatch\nexpected: %s\ngot:      %s", tt.expected, query)
@@ -487,6 +606,44 @@ func TestBuildListTicketsQuery(t *testing.T) {
 	}
 }
 
+func intPtr(i int) *int { return &i }
+
+// searchTextSQL is the condition a free-text search term compiles to.
+const searchTextSQL = "(LOWER(id) LIKE ? OR LOWER(title) LIKE ? OR LOWER(COALESCE(description, '')) LIKE ? OR LOWER(COALESCE(notes, '')) LIKE ? OR id IN (SELECT issue_id FROM labels WHERE LOWER(label) LIKE ?))"
+
+func TestBuildListTicketsQuery_SharedSearchCases(t *testing.T) {
+	for _, tc := range searchtest.Cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			query, args, err := buildListTicketsQuery(data.TicketFilter{Search: tc.Query})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			expected := "SELECT id, title, description, status, priority, issue_type, assignee, created_at, updated_at FROM ready_issues WHERE 1=1 AND " + tc.SQL + " ORDER BY priority ASC, updated_at DESC"
+			if query != expected {
+				t.Errorf("query mismatch\nexpected: %s\ngot:      %s", expected, query)
+			}
+			if !reflect.DeepEqual(args, tc.Args) {
+				t.Errorf("args mismatch: expected %v, got %v", tc.Args, args)
+			}
+		})
+	}
+}
+
+func TestBuildListTicketsQuery_UnknownScope(t *testing.T) {
+	_, _, err := buildListTicketsQuery(data.TicketFilter{Scope: data.TicketScope(42)})
+	if err == nil {
+		t.Fatal("expected an error for an unknown scope")
+	}
+}
+
+func TestBuildListTicketsQuery_InvalidSearch(t *testing.T) {
+	_, _, err := buildListTicketsQuery(data.TicketFilter{Search: "prio:high"})
+	if err == nil {
+		t.Fatal("expected an error for an invalid priority")
+	}
+}
+
 func TestScanTickets(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -508,7 +665,7 @@ func TestScanTickets(t *testing.T) {
 		t.Fatalf("failed to query: %v", err)
 	}
 
-	tickets, err := scanTickets(rows)
+	tickets, err := scanTickets(rows, false)
 	if err != nil {
 		t.Fatalf("failed to scan tickets: %v", err)
 	}
@@ -543,7 +700,7 @@ func TestVerifySchema(t *testing.T) {
 	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM ready_issues LIMIT 1`).
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
 
-	if err := verifySchema(context.Background(), db); err != nil {
+	if err := verifySchema(context.Background(), db, ""); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 
@@ -563,7 +720,7 @@ func TestVerifySchema_Failure(t *testing.T) {
 	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM ready_issues LIMIT 1`).
 		WillReturnError(sqlmock.ErrCancelled)
 
-	err = verifySchema(context.Background(), db)
+	err = verifySchema(context.Background(), db, "")
 	if err == nil {
 		t.Fatal("expected error for schema verification failure")
 	}
@@ -577,56 +734,6 @@ func TestVerifySchema_Failure(t *testing.T) {
 	}
 }
 
-func TestBuildServerDSN(t *testing.T) {
-	tests := []struct {
-		name     string
-		metadata Metadata
-		expected string
-	}{
-		{
-			name: "full config",
-			metadata: Metadata{
-				DoltDatabase: "beads_test",
-				ServerHost:   "10.11.0.1",
-				ServerPort:   13307,
-				ServerUser:   "mysql-root",
-			},
-			expected: "mysql-root@tcp(10.11.0.1:13307)/beads_test?parseTime=true",
-		},
-		{
-			name: "with password",
-			metadata: Metadata{
-				DoltDatabase: "beads_prod",
-				ServerHost:   "db.example.com",
-				ServerPort:   3306,
-				ServerUser:   "admin",
-			},
-			expected: "admin:secret123@tcp(db.example.com:3306)/beads_prod?parseTime=true",
-		},
-		{
-			name: "defaults for missing fields",
-			metadata: Metadata{
-				DoltDatabase: "beads_default",
-			},
-			expected: "root@tcp(127.0.0.1:3307)/beads_default?parseTime=true",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Set password env var for the with_password test
-			if tt.name == "with password" {
-				t.Setenv("BEADS_DOLT_PASSWORD", "secret123")
-			}
-
-			got := buildServerDSN(&tt.metadata)
-			if got != tt.expected {
-				t.Errorf("DSN mismatch\nexpected: %s\ngot:      %s", tt.expected, got)
-			}
-		})
-	}
-}
-
 func TestStore_Close_Idempotent(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
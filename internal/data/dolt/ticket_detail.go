@@ -0,0 +1,138 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// Verify interface compliance at compile time.
+var _ data.TicketDetailStore = (*Store)(nil)
+
+const ticketDetailQuery = `SELECT id, title, description, design, acceptance_criteria, notes, status, priority, issue_type, assignee, created_at, updated_at FROM issues WHERE id = ?`
+
+const ticketLabelsQuery = `SELECT label FROM labels WHERE issue_id = ? ORDER BY label`
+
+const ticketDependenciesQuery = `SELECT d.depends_on_id, d.type, COALESCE(i.title, ''), COALESCE(i.status, '') FROM dependencies d LEFT JOIN issues i ON i.id = d.depends_on_id WHERE d.issue_id = ? ORDER BY d.depends_on_id`
+
+const ticketCommentsQuery = `SELECT author, text, created_at FROM comments WHERE issue_id = ? ORDER BY created_at, id`
+
+// TicketDetail loads the full issue with its labels, dependencies and
+// comments. Unlike ListTickets it reads the issues table, so closed and
+// blocked tickets can be shown too.
+func (s *Store) TicketDetail(ctx context.Context, id string) (*domain.TicketDetail, error) {
+	if s.closed {
+		return nil, fmt.Errorf("store is closed")
+	}
+
+	var d domain.TicketDetail
+	var design, acceptance, notes, assignee sql.NullString
+	err := s.db.QueryRowContext(ctx, s.qualify(ticketDetailQuery), id).Scan(
+		&d.ID,
+		&d.Title,
+		&d.Description,
+		&design,
+		&acceptance,
+		&notes,
+		&d.Status,
+		&d.Priority,
+		&d.IssueType,
+		&assignee,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", data.ErrTicketNotFound, id)
+	}
+	if err != nil {
+		return nil, s.queryError(err, "failed to query ticket")
+	}
+	d.Design = design.String
+	d.AcceptanceCriteria = acceptance.String
+	d.Notes = notes.String
+	d.Assignee = assignee.String
+
+	if d.Labels, err = s.ticketLabels(ctx, id); err != nil {
+		return nil, err
+	}
+	if d.Dependencies, err = s.ticketDependencies(ctx, id); err != nil {
+		return nil, err
+	}
+	if d.Comments, err = s.ticketComments(ctx, id); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (s *Store) ticketLabels(ctx context.Context, id string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, s.qualify(ticketLabelsQuery), id)
+	if err != nil {
+		return nil, s.queryError(err, "failed to query ticket labels")
+	}
+	defer rows.Close()
+
+	var labels []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, fmt.Errorf("failed to scan label row: %w", err)
+		}
+		labels = append(labels, label)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating label rows: %w", err)
+	}
+	return labels, nil
+}
+
+func (s *Store) ticketDependencies(ctx context.Context, id string) ([]domain.TicketDependency, error) {
+	rows, err := s.db.QueryContext(ctx, s.qualify(ticketDependenciesQuery), id)
+	if err != nil {
+		return nil, s.queryError(err, "failed to query ticket dependencies")
+	}
+	defer rows.Close()
+
+	var deps []domain.TicketDependency
+	for rows.Next() {
+		var dep domain.TicketDependency
+		if err := rows.Scan(&dep.ID, &dep.Type, &dep.Title, &dep.Status); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency row: %w", err)
+		}
+		deps = append(deps, dep)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dependency rows: %w", err)
+	}
+	return deps, nil
+}
+
+func (s *Store) ticketComments(ctx context.Context, id string) ([]domain.TicketComment, error) {
+	rows, err := s.db.QueryContext(ctx, s.qualify(ticketCommentsQuery), id)
+	if err != nil {
+		return nil, s.queryError(err, "failed to query ticket comments")
+	}
+	defer rows.Close()
+
+	var comments []domain.TicketComment
+	for rows.Next() {
+		var c domain.TicketComment
+		if err := rows.Scan(&c.Author, &c.Text, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan comment row: %w", err)
+		}
+		comments = append(comments, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating comment rows: %w", err)
+	}
+	return comments, nil
+}
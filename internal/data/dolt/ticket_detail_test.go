@@ -0,0 +1,91 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/megatherium/blunderbust/internal/data"
+)
+
+func TestStore_TicketDetail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: ServerMode}
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(ticketDetailQuery)).
+		WithArgs("bb-001").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "title", "description", "design", "acceptance_criteria", "notes",
+			"status", "priority", "issue_type", "assignee", "created_at", "updated_at",
+		}).AddRow("bb-001", "Test Ticket", "Some *markdown*", "Use a cache", nil, "", "open", 1, "task", "alice", now, now))
+	mock.ExpectQuery(regexp.QuoteMeta(ticketLabelsQuery)).
+		WithArgs("bb-001").
+		WillReturnRows(sqlmock.NewRows([]string{"label"}).AddRow("backend").AddRow("ui"))
+	mock.ExpectQuery(regexp.QuoteMeta(ticketDependenciesQuery)).
+		WithArgs("bb-001").
+		WillReturnRows(sqlmock.NewRows([]string{"depends_on_id", "type", "title", "status"}).
+			AddRow("bb-000", "blocks", "Set up schema", "closed"))
+	mock.ExpectQuery(regexp.QuoteMeta(ticketCommentsQuery)).
+		WithArgs("bb-001").
+		WillReturnRows(sqlmock.NewRows([]string{"author", "text", "created_at"}).
+			AddRow("bob", "Looks good", now))
+
+	d, err := store.TicketDetail(context.Background(), "bb-001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if d.Title != "Test Ticket" || d.Design != "Use a cache" || d.Assignee != "alice" {
+		t.Errorf("unexpected issue fields: %+v", d)
+	}
+	if d.AcceptanceCriteria != "" {
+		t.Errorf("expected NULL acceptance criteria to be empty, got %q", d.AcceptanceCriteria)
+	}
+	if len(d.Labels) != 2 || d.Labels[0] != "backend" {
+		t.Errorf("unexpected labels: %v", d.Labels)
+	}
+	if len(d.Dependencies) != 1 || d.Dependencies[0].ID != "bb-000" || d.Dependencies[0].Type != "blocks" {
+		t.Errorf("unexpected dependencies: %+v", d.Dependencies)
+	}
+	if len(d.Comments) != 1 || d.Comments[0].Author != "bob" {
+		t.Errorf("unexpected comments: %+v", d.Comments)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_TicketDetail_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: ServerMode}
+
+	mock.ExpectQuery(regexp.QuoteMeta(ticketDetailQuery)).
+		WithArgs("bb-404").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err = store.TicketDetail(context.Background(), "bb-404")
+	if !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("expected ErrTicketNotFound, got %v", err)
+	}
+}
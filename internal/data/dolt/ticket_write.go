@@ -0,0 +1,82 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/data"
+)
+
+// Verify interface compliance at compile time.
+var _ data.WritableTicketStore = (*Store)(nil)
+
+const setStatusQuery = `UPDATE issues SET status = ?, updated_at = ? WHERE id = ?`
+
+const setAssigneeQuery = `UPDATE issues SET assignee = ?, updated_at = ? WHERE id = ?`
+
+const addCommentQuery = `INSERT INTO comments (issue_id, author, text, created_at) VALUES (?, ?, ?, ?)`
+
+const touchIssueQuery = `UPDATE issues SET updated_at = ? WHERE id = ?`
+
+const issueExistsQuery = `SELECT COUNT(*) FROM issues WHERE id = ?`
+
+// SetStatus sets the status of the ticket with the given ID.
+func (s *Store) SetStatus(ctx context.Context, id, status string) error {
+	return s.updateIssue(ctx, id, "failed to set ticket status", setStatusQuery, status, time.Now(), id)
+}
+
+// SetAssignee sets the assignee of the ticket with the given ID.
+func (s *Store) SetAssignee(ctx context.Context, id, assignee string) error {
+	return s.updateIssue(ctx, id, "failed to set ticket assignee", setAssigneeQuery, assignee, time.Now(), id)
+}
+
+// AddComment adds a comment to the ticket with the given ID. The ticket's
+// updated_at is bumped too, so other sessions notice the change.
+func (s *Store) AddComment(ctx context.Context, id, author, text string) error {
+	now := time.Now()
+	if err := s.updateIssue(ctx, id, "failed to add ticket comment", touchIssueQuery, now, id); err != nil {
+		return err
+	}
+	if _, err := s.db.ExecContext(ctx, s.qualify(addCommentQuery), id, author, text, now); err != nil {
+		return s.queryError(err, "failed to add ticket comment")
+	}
+	return nil
+}
+
+// updateIssue runs an UPDATE of a single issue, reporting unknown IDs as
+// data.ErrTicketNotFound. MySQL and Dolt count changed rows, not matched
+// ones, and updated_at only keeps whole seconds, so an UPDATE that changes
+// nothing is checked against the issues table before it counts as unknown.
+func (s *Store) updateIssue(ctx context.Context, id, errMsg, query string, args ...any) error {
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+
+	res, err := s.db.ExecContext(ctx, s.qualify(query), args...)
+	if err != nil {
+		return s.queryError(err, errMsg)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", errMsg, err)
+	}
+	if n > 0 {
+		return nil
+	}
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, s.qualify(issueExistsQuery), id).Scan(&count); err != nil {
+		return s.queryError(err, errMsg)
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s", data.ErrTicketNotFound, id)
+	}
+	return nil
+}
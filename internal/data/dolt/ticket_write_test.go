@@ -0,0 +1,150 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/megatherium/blunderbust/internal/data"
+)
+
+func TestStore_SetStatusAndAssignee(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: ServerMode}
+
+	mock.ExpectExec(regexp.QuoteMeta(setStatusQuery)).
+		WithArgs("in_progress", sqlmock.AnyArg(), "bb-001").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(setAssigneeQuery)).
+		WithArgs("alice", sqlmock.AnyArg(), "bb-001").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := store.SetStatus(context.Background(), "bb-001", "in_progress"); err != nil {
+		t.Fatalf("SetStatus: unexpected error: %v", err)
+	}
+	if err := store.SetAssignee(context.Background(), "bb-001", "alice"); err != nil {
+		t.Fatalf("SetAssignee: unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_AddComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: ServerMode}
+
+	mock.ExpectExec(regexp.QuoteMeta(touchIssueQuery)).
+		WithArgs(sqlmock.AnyArg(), "bb-001").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(addCommentQuery)).
+		WithArgs("bb-001", "blunderbust", "Agent launched", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+
+	if err := store.AddComment(context.Background(), "bb-001", "blunderbust", "Agent launched"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_Writes_UnknownTicket(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: ServerMode}
+
+	mock.ExpectExec(regexp.QuoteMeta(setStatusQuery)).
+		WithArgs("in_progress", sqlmock.AnyArg(), "bb-404").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(issueExistsQuery)).
+		WithArgs("bb-404").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta(touchIssueQuery)).
+		WithArgs(sqlmock.AnyArg(), "bb-404").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(issueExistsQuery)).
+		WithArgs("bb-404").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	if err := store.SetStatus(context.Background(), "bb-404", "in_progress"); !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("SetStatus: expected ErrTicketNotFound, got %v", err)
+	}
+	if err := store.AddComment(context.Background(), "bb-404", "bdb", "hi"); !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("AddComment: expected ErrTicketNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_Writes_NothingChanged(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: ServerMode}
+
+	// The ticket already has this assignee, and the comment's touch lands
+	// in the same second as the previous write: neither changes a row.
+	mock.ExpectExec(regexp.QuoteMeta(setAssigneeQuery)).
+		WithArgs("alice", sqlmock.AnyArg(), "bb-001").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(issueExistsQuery)).
+		WithArgs("bb-001").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta(touchIssueQuery)).
+		WithArgs(sqlmock.AnyArg(), "bb-001").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(issueExistsQuery)).
+		WithArgs("bb-001").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta(addCommentQuery)).
+		WithArgs("bb-001", "bdb", "Agent launched", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+
+	if err := store.SetAssignee(context.Background(), "bb-001", "alice"); err != nil {
+		t.Errorf("SetAssignee: unexpected error: %v", err)
+	}
+	if err := store.AddComment(context.Background(), "bb-001", "bdb", "Agent launched"); err != nil {
+		t.Errorf("AddComment: unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_Writes_StoreClosed(t *testing.T) {
+	store := &Store{closed: true}
+	if err := store.SetAssignee(context.Background(), "bb-001", "alice"); err == nil {
+		t.Error("expected an error from a closed store")
+	}
+}
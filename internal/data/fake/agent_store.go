@@ -0,0 +1,132 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package fake
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// AgentStore is an in-memory fake implementing data.AgentStore. It does
+// not inspect processes, so agents stay until they go stale.
+type AgentStore struct {
+	Agents []domain.PersistedRunningAgent
+	Runs   []domain.AgentRun
+
+	mu sync.Mutex
+}
+
+// Verify interface compliance at compile time.
+var _ data.AgentStore = (*AgentStore)(nil)
+
+// UpsertRunningAgent records a, replacing an agent with the same project,
+// worktree and PID.
+func (s *AgentStore) UpsertRunningAgent(_ context.Context, a domain.PersistedRunningAgent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	a.LastSeen = now
+	i := slices.IndexFunc(s.Agents, func(b domain.PersistedRunningAgent) bool {
+		return b.ProjectDir == a.ProjectDir && b.WorktreePath == a.WorktreePath && b.PID == a.PID
+	})
+	if i >= 0 {
+		a.ID, a.StartedAt = s.Agents[i].ID, s.Agents[i].StartedAt
+		s.Agents[i] = a
+		return nil
+	}
+	a.ID, a.StartedAt = 1, now
+	for _, b := range s.Agents {
+		a.ID = max(a.ID, b.ID+1)
+	}
+	s.Agents = append(s.Agents, a)
+	return nil
+}
+
+// ValidateAndPruneRunningAgents returns the agents of projectDirs, newest
+// first. The inspector is ignored.
+func (s *AgentStore) ValidateAndPruneRunningAgents(_ context.Context, projectDirs []string, _ data.ProcessInspector) ([]domain.PersistedRunningAgent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var agents []domain.PersistedRunningAgent
+	for _, a := range s.Agents {
+		if slices.Contains(projectDirs, a.ProjectDir) {
+			agents = append(agents, a)
+		}
+	}
+	slices.SortStableFunc(agents, func(a, b domain.PersistedRunningAgent) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+	return agents, nil
+}
+
+// DeleteStaleRunningAgents moves agents not seen for maxAge to Runs.
+func (s *AgentStore) DeleteStaleRunningAgents(ctx context.Context, maxAge time.Duration) error {
+	if maxAge <= 0 {
+		maxAge = data.DefaultRunningAgentMaxAge
+	}
+	cutoff := time.Now().Add(-maxAge)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Agents = slices.DeleteFunc(s.Agents, func(a domain.PersistedRunningAgent) bool {
+		if !a.LastSeen.Before(cutoff) {
+			return false
+		}
+		s.addRun(data.FinishedRun(ctx, a))
+		return true
+	})
+	return nil
+}
+
+// FinishRunningAgent adds run to Runs and removes the agent with the same
+// project and launcher ID.
+func (s *AgentStore) FinishRunningAgent(_ context.Context, run domain.AgentRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Agents = slices.DeleteFunc(s.Agents, func(a domain.PersistedRunningAgent) bool {
+		return a.ProjectDir == run.ProjectDir && a.LauncherID == run.LauncherID
+	})
+	s.addRun(run)
+	return nil
+}
+
+// ListAgentRuns returns the runs matching filter, newest first.
+func (s *AgentStore) ListAgentRuns(_ context.Context, filter data.AgentRunFilter) ([]domain.AgentRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var runs []domain.AgentRun
+	for _, r := range s.Runs {
+		if filter.Matches(r) {
+			runs = append(runs, r)
+		}
+	}
+	slices.SortStableFunc(runs, func(a, b domain.AgentRun) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+	if filter.Limit > 0 && len(runs) > filter.Limit {
+		runs = runs[:filter.Limit]
+	}
+	return runs, nil
+}
+
+// addRun appends run with the next ID. The caller must hold s.mu.
+func (s *AgentStore) addRun(run domain.AgentRun) {
+	run.ID = 1
+	for _, r := range s.Runs {
+		run.ID = max(run.ID, r.ID+1)
+	}
+	s.Runs = append(s.Runs, run)
+}
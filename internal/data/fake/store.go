@@ -8,33 +8,73 @@ package fake
 
 import (
 	"context"
-	"strings"
+	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/search"
 	"github.com/megatherium/blunderbust/internal/domain"
 )
 
 // TicketStore is an in-memory fake implementing data.TicketStore.
 type TicketStore struct {
 	Tickets []domain.Ticket
+	// Details holds the full issues returned by TicketDetail, keyed by ID.
+	// Tickets without an entry get a detail with just the ticket fields.
+	// Blocked tickets list their blockers in Ticket.Blockers.
+	// Its labels and notes are also what ListTickets matches TicketFilter.Label
+	// and TicketFilter.Search against.
+	Details map[string]domain.TicketDetail
+
+	// mu guards the fields above once the store is shared, since the
+	// write methods change them.
+	mu sync.Mutex
 }
 
 // Verify interface compliance at compile time.
-var _ data.TicketStore = (*TicketStore)(nil)
+var (
+	_ data.TicketStore         = (*TicketStore)(nil)
+	_ data.TicketDetailStore   = (*TicketStore)(nil)
+	_ data.WritableTicketStore = (*TicketStore)(nil)
+)
 
 // ListTickets returns tickets matching the given filter.
 func (s *TicketStore) ListTickets(_ context.Context, filter data.TicketFilter) ([]domain.Ticket, error) {
+	query, err := search.Parse(filter.Search)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	var results []domain.Ticket
 	for i := range s.Tickets {
 		t := &s.Tickets[i]
-		if filter.Status != "" && t.Status != filter.Status {
+		if !inScope(t, filter.Scope) {
+			continue
+		}
+		if !data.MatchesFilterValues(filter.Status, t.Status) {
+			continue
+		}
+		if !data.MatchesFilterValues(filter.IssueType, t.IssueType) {
+			continue
+		}
+		if filter.Assignee != "" && t.Assignee != filter.Assignee {
 			continue
 		}
-		if filter.IssueType != "" && t.IssueType != filter.IssueType {
+		if filter.MinPriority != nil && t.Priority < *filter.MinPriority {
 			continue
 		}
-		if filter.Search != "" && !strings.Contains(strings.ToLower(t.Title), strings.ToLower(filter.Search)) {
+		if filter.MaxPriority != nil && t.Priority > *filter.MaxPriority {
+			continue
+		}
+		if filter.Label != "" && !slices.Contains(s.Details[t.ID].Labels, filter.Label) {
+			continue
+		}
+		if !query.Match(s.searchDocument(t)) {
 			continue
 		}
 		results = append(results, *t)
@@ -45,9 +85,44 @@ func (s *TicketStore) ListTickets(_ context.Context, filter data.TicketFilter) (
 	return results, nil
 }
 
+// inScope reports whether t belongs to scope. The fake does not model the
+// other rules of beads' ready_issues view, so the ready scope only leaves out
+// tickets that are in progress or blocked.
+func inScope(t *domain.Ticket, scope data.TicketScope) bool {
+	switch scope {
+	case data.ScopeInProgress:
+		return t.Status == "in_progress"
+	case data.ScopeBlocked:
+		return t.Status != "closed" && t.IsBlocked()
+	case data.ScopeAll:
+		return t.Status != "closed"
+	default:
+		return t.Status != "in_progress" && !t.IsBlocked()
+	}
+}
+
+// searchDocument returns what a search query is matched against for t.
+func (s *TicketStore) searchDocument(t *domain.Ticket) search.Document {
+	detail := s.Details[t.ID]
+	return search.Document{
+		ID:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		Notes:       detail.Notes,
+		Status:      t.Status,
+		IssueType:   t.IssueType,
+		Assignee:    t.Assignee,
+		Priority:    t.Priority,
+		Labels:      detail.Labels,
+	}
+}
+
 // LatestUpdate returns the maximum updated_at timestamp from the ticket collection.
 // Returns a zero time.Time if no tickets exist.
 func (s *TicketStore) LatestUpdate(_ context.Context) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	var latest time.Time
 	for _, t := range s.Tickets {
 		if t.UpdatedAt.After(latest) {
@@ -57,6 +132,65 @@ func (s *TicketStore) LatestUpdate(_ context.Context) (time.Time, error) {
 	return latest, nil
 }
 
+// TicketDetail returns the full issue for the ticket with the given ID.
+func (s *TicketStore) TicketDetail(_ context.Context, id string) (*domain.TicketDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if d, ok := s.Details[id]; ok {
+		return &d, nil
+	}
+	for _, t := range s.Tickets {
+		if t.ID == id {
+			return &domain.TicketDetail{Ticket: t}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", data.ErrTicketNotFound, id)
+}
+
+// SetStatus sets the status of the ticket with the given ID.
+func (s *TicketStore) SetStatus(_ context.Context, id, status string) error {
+	return s.update(id, func(t *domain.Ticket, _ *domain.TicketDetail) { t.Status = status })
+}
+
+// SetAssignee sets the assignee of the ticket with the given ID.
+func (s *TicketStore) SetAssignee(_ context.Context, id, assignee string) error {
+	return s.update(id, func(t *domain.Ticket, _ *domain.TicketDetail) { t.Assignee = assignee })
+}
+
+// AddComment adds a comment to the detail of the ticket with the given ID.
+func (s *TicketStore) AddComment(_ context.Context, id, author, text string) error {
+	return s.update(id, func(_ *domain.Ticket, d *domain.TicketDetail) {
+		d.Comments = append(d.Comments, domain.TicketComment{Author: author, Text: text, CreatedAt: time.Now()})
+	})
+}
+
+// update applies fn to the ticket with the given ID and its detail, keeping
+// the ticket fields of both in sync.
+func (s *TicketStore) update(id string, fn func(t *domain.Ticket, d *domain.TicketDetail)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.Tickets, func(t domain.Ticket) bool { return t.ID == id })
+	if i < 0 {
+		return fmt.Errorf("%w: %s", data.ErrTicketNotFound, id)
+	}
+	t := &s.Tickets[i]
+	d, ok := s.Details[id]
+	if !ok {
+		d.Ticket = *t
+	}
+	fn(t, &d)
+	t.UpdatedAt = time.Now()
+	d.Ticket = *t
+
+	if s.Details == nil {
+		s.Details = make(map[string]domain.TicketDetail)
+	}
+	s.Details[id] = d
+	return nil
+}
+
 // NewWithSampleData returns a FakeTicketStore pre-loaded with sample tickets.
 func NewWithSampleData() *TicketStore {
 	now := time.Now()
@@ -8,10 +8,13 @@ package fake
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"testing"
 	"time"
 
 	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/search/searchtest"
 	"github.com/megatherium/blunderbust/internal/domain"
 )
 
@@ -61,6 +64,26 @@ func TestFakeStore_ListTickets_WithStatusFilter(t *testing.T) {
 	}
 }
 
+func TestFakeStore_ListTickets_WithStatusList(t *testing.T) {
+	now := time.Now()
+	store := &TicketStore{
+		Tickets: []domain.Ticket{
+			{ID: "bb-001", Title: "First", Status: "open", Priority: 1, IssueType: "task", CreatedAt: now, UpdatedAt: now},
+			{ID: "bb-002", Title: "Second", Status: "closed", Priority: 2, IssueType: "bug", CreatedAt: now, UpdatedAt: now},
+			{ID: "bb-003", Title: "Third", Status: "in_progress", Priority: 2, IssueType: "bug", CreatedAt: now, UpdatedAt: now},
+		},
+	}
+
+	tickets, err := store.ListTickets(context.Background(), data.TicketFilter{Scope: data.ScopeAll, Status: "open, in_progress"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tickets) != 2 || tickets[0].ID != "bb-001" || tickets[1].ID != "bb-003" {
+		t.Errorf("expected bb-001 and bb-003, got %v", tickets)
+	}
+}
+
 func TestFakeStore_ListTickets_WithSearchFilter(t *testing.T) {
 	now := time.Now()
 	store := &TicketStore{
@@ -108,6 +131,109 @@ func TestFakeStore_ListTickets_WithLimit(t *testing.T) {
 	}
 }
 
+func TestFakeStore_ListTickets_WithFilterBarFields(t *testing.T) {
+	now := time.Now()
+	store := &TicketStore{
+		Tickets: []domain.Ticket{
+			{ID: "bb-001", Title: "First", Status: "open", Priority: 0, IssueType: "bug", Assignee: "alice", CreatedAt: now, UpdatedAt: now},
+			{ID: "bb-002", Title: "Second", Status: "open", Priority: 2, IssueType: "bug", Assignee: "alice", CreatedAt: now, UpdatedAt: now},
+			{ID: "bb-003", Title: "Third", Status: "open", Priority: 1, IssueType: "bug", Assignee: "bob", CreatedAt: now, UpdatedAt: now},
+		},
+		Details: map[string]domain.TicketDetail{
+			"bb-002": {Labels: []string{"backend"}},
+		},
+	}
+
+	one, two := 1, 2
+	tickets, err := store.ListTickets(context.Background(), data.TicketFilter{Assignee: "alice", MinPriority: &one, MaxPriority: &two})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tickets) != 1 || tickets[0].ID != "bb-002" {
+		t.Errorf("expected only bb-002, got %+v", tickets)
+	}
+
+	tickets, err = store.ListTickets(context.Background(), data.TicketFilter{Label: "backend"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tickets) != 1 || tickets[0].ID != "bb-002" {
+		t.Errorf("expected only the labelled bb-002, got %+v", tickets)
+	}
+}
+
+func TestFakeStore_ListTickets_SharedSearchCases(t *testing.T) {
+	store := &TicketStore{Details: map[string]domain.TicketDetail{}}
+	for _, d := range searchtest.Documents {
+		store.Tickets = append(store.Tickets, domain.Ticket{
+			ID: d.ID, Title: d.Title, Description: d.Description, Status: d.Status,
+			Priority: d.Priority, IssueType: d.IssueType, Assignee: d.Assignee,
+		})
+		store.Details[d.ID] = domain.TicketDetail{Notes: d.Notes, Labels: d.Labels}
+	}
+
+	for _, tc := range searchtest.Cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			tickets, err := store.ListTickets(context.Background(), data.TicketFilter{Scope: data.ScopeAll, Search: tc.Query})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, ticket := range tickets {
+				got = append(got, ticket.ID)
+			}
+			if !reflect.DeepEqual(got, tc.Want) {
+				t.Errorf("got %v, want %v", got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestFakeStore_ListTickets_InvalidSearch(t *testing.T) {
+	store := NewWithSampleData()
+	if _, err := store.ListTickets(context.Background(), data.TicketFilter{Search: "type:"}); err == nil {
+		t.Fatal("expected an error for a search term without a value")
+	}
+}
+
+func TestFakeStore_ListTickets_Scopes(t *testing.T) {
+	store := &TicketStore{
+		Tickets: []domain.Ticket{
+			{ID: "bb-001", Status: "open"},
+			{ID: "bb-002", Status: "in_progress"},
+			{ID: "bb-003", Status: "open", Blockers: []string{"bb-002"}},
+			{ID: "bb-004", Status: "blocked"},
+			{ID: "bb-005", Status: "closed", Blockers: []string{"bb-002"}},
+		},
+	}
+
+	tests := []struct {
+		scope data.TicketScope
+		want  []string
+	}{
+		{data.ScopeReady, []string{"bb-001"}},
+		{data.ScopeInProgress, []string{"bb-002"}},
+		{data.ScopeBlocked, []string{"bb-003", "bb-004"}},
+		{data.ScopeAll, []string{"bb-001", "bb-002", "bb-003", "bb-004"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scope.String(), func(t *testing.T) {
+			tickets, err := store.ListTickets(context.Background(), data.TicketFilter{Scope: tt.scope})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, ticket := range tickets {
+				got = append(got, ticket.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFakeStore_LatestUpdate_HasTickets(t *testing.T) {
 	now := time.Now()
 	yesterday := now.Add(-24 * time.Hour)
@@ -147,3 +273,59 @@ func TestFakeStore_LatestUpdate_Empty(t *testing.T) {
 		t.Errorf("expected zero time, got %v", latest)
 	}
 }
+
+func TestFakeStore_TicketDetail(t *testing.T) {
+	store := &TicketStore{
+		Tickets: []domain.Ticket{{ID: "bb-001", Title: "First"}, {ID: "bb-002", Title: "Second"}},
+		Details: map[string]domain.TicketDetail{
+			"bb-002": {Ticket: domain.Ticket{ID: "bb-002", Title: "Second"}, Labels: []string{"ui"}},
+		},
+	}
+
+	d, err := store.TicketDetail(context.Background(), "bb-001")
+	if err != nil || d.Title != "First" {
+		t.Errorf("TicketDetail(bb-001) = %+v, %v; want ticket fields", d, err)
+	}
+	d, err = store.TicketDetail(context.Background(), "bb-002")
+	if err != nil || len(d.Labels) != 1 {
+		t.Errorf("TicketDetail(bb-002) = %+v, %v; want configured detail", d, err)
+	}
+	if _, err := store.TicketDetail(context.Background(), "bb-404"); !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("expected ErrTicketNotFound, got %v", err)
+	}
+}
+
+func TestFakeStore_Writes(t *testing.T) {
+	store := &TicketStore{
+		Tickets: []domain.Ticket{{ID: "bb-001", Title: "First", Status: "open"}},
+	}
+	ctx := context.Background()
+
+	if err := store.SetStatus(ctx, "bb-001", "in_progress"); err != nil {
+		t.Fatalf("SetStatus: unexpected error: %v", err)
+	}
+	if err := store.SetAssignee(ctx, "bb-001", "alice"); err != nil {
+		t.Fatalf("SetAssignee: unexpected error: %v", err)
+	}
+	if err := store.AddComment(ctx, "bb-001", "bdb", "Agent launched"); err != nil {
+		t.Fatalf("AddComment: unexpected error: %v", err)
+	}
+
+	if got := store.Tickets[0]; got.Status != "in_progress" || got.Assignee != "alice" {
+		t.Errorf("ticket not updated: %+v", got)
+	}
+	d, err := store.TicketDetail(ctx, "bb-001")
+	if err != nil {
+		t.Fatalf("TicketDetail: unexpected error: %v", err)
+	}
+	if d.Title != "First" || d.Status != "in_progress" {
+		t.Errorf("detail lost the ticket fields: %+v", d.Ticket)
+	}
+	if len(d.Comments) != 1 || d.Comments[0].Author != "bdb" || d.Comments[0].Text != "Agent launched" {
+		t.Errorf("unexpected comments: %+v", d.Comments)
+	}
+
+	if err := store.SetStatus(ctx, "bb-404", "closed"); !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("expected ErrTicketNotFound, got %v", err)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 )
 
@@ -71,6 +72,48 @@ func (g *gitClient) CheckDirty(ctx context.Context, path string) bool {
 	return len(bytes.TrimSpace(output)) > 0
 }
 
+// LinesChanged counts the lines added and removed in the worktree at path
+// since its branch left the main branch, including uncommitted changes to
+// tracked files. On the main branch itself only the uncommitted changes
+// count.
+func LinesChanged(ctx context.Context, path string) (added, removed int, err error) {
+	base := "HEAD"
+	if mainBranch, branchErr := NewGitClient().DetectMainBranch(ctx, path); branchErr == nil {
+		cmd := exec.CommandContext(ctx, "git", "-C", path, "merge-base", "HEAD", mainBranch)
+		if out, mergeErr := cmd.Output(); mergeErr == nil {
+			base = strings.TrimSpace(string(out))
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "-C", path, "diff", "--numstat", base)
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to diff worktree %s: %w", path, err)
+	}
+	added, removed = parseNumstat(output)
+	return added, removed, nil
+}
+
+// parseNumstat sums the output of `git diff --numstat`. Binary files, shown
+// as "-", count no lines.
+func parseNumstat(output []byte) (added, removed int) {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		a, errA := strconv.Atoi(fields[0])
+		r, errR := strconv.Atoi(fields[1])
+		if errA != nil || errR != nil {
+			continue
+		}
+		added += a
+		removed += r
+	}
+	return added, removed
+}
+
 // parseWorktreePorcelain parses the output of `git worktree list --porcelain`.
 // Each worktree is separated by an empty line, with fields in key-value format.
 func parseWorktreePorcelain(output []byte) []WorktreeEntry {
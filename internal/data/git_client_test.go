@@ -0,0 +1,78 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package data_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/megatherium/blunderbust/internal/data"
+)
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+}
+
+func writeRepoFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLinesChanged(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	repo := filepath.Join(t.TempDir(), "repo")
+	if err := os.Mkdir(repo, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-q", "-b", "main")
+	writeRepoFile(t, filepath.Join(repo, "a.txt"), "one\ntwo\nthree\n")
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-q", "-m", "initial")
+
+	worktree := filepath.Join(filepath.Dir(repo), "feature")
+	runGit(t, repo, "worktree", "add", "-q", "-b", "feature", worktree)
+	// One committed line and one uncommitted change count alike.
+	writeRepoFile(t, filepath.Join(worktree, "a.txt"), "one\ntwo\nthree\nfour\n")
+	runGit(t, worktree, "commit", "-q", "-am", "add four")
+	writeRepoFile(t, filepath.Join(worktree, "a.txt"), "one\nTWO\nthree\nfour\n")
+
+	added, removed, err := data.LinesChanged(context.Background(), worktree)
+	if err != nil {
+		t.Fatalf("LinesChanged failed: %v", err)
+	}
+	if added != 2 || removed != 1 {
+		t.Errorf("expected +2 -1, got +%d -%d", added, removed)
+	}
+
+	added, removed, err = data.LinesChanged(context.Background(), repo)
+	if err != nil {
+		t.Fatalf("LinesChanged failed: %v", err)
+	}
+	if added != 0 || removed != 0 {
+		t.Errorf("expected a clean main checkout to have no changes, got +%d -%d", added, removed)
+	}
+}
+
+func TestLinesChanged_NotAGitRepo(t *testing.T) {
+	if _, _, err := data.LinesChanged(context.Background(), t.TempDir()); err == nil {
+		t.Fatal("expected an error outside a git repository")
+	}
+}
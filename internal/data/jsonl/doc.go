@@ -0,0 +1,32 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package jsonl implements the TicketStore interface on top of the issues
+// JSONL export that beads keeps next to its database, so tickets can be
+// browsed without a running Dolt server.
+//
+// Each line of the export is one issue with its labels, dependencies and
+// comments. The store reads the file again whenever its modification time or
+// size changes, and reports the modification time as LatestUpdate.
+//
+// Usage
+//
+//	store, err := jsonl.NewStore(filepath.Join(".beads", "issues.jsonl"))
+//	if err != nil {
+//		return err
+//	}
+//	tickets, err := store.ListTickets(ctx, data.TicketFilter{})
+//
+// Readiness is computed from the dependency records like beads' ready_issues
+// view: open issues without an open "blocks" dependency that are neither
+// deferred, ephemeral nor templates. Blocking is inherited through
+// "parent-child" dependencies, so the children of a blocked epic are not
+// ready either. As in the Dolt store, the blocked scope only lists issues
+// with blockers of their own.
+//
+// The store is read-only; it does not implement data.WritableTicketStore,
+// since bd would overwrite the export on its next sync.
+package jsonl
@@ -0,0 +1,342 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package jsonl
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/search"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// DefaultFile is the name of the export in the beads directory when
+// metadata.json does not name one.
+const DefaultFile = "issues.jsonl"
+
+// maxLineSize bounds a single issue line; descriptions can be long.
+const maxLineSize = 16 * 1024 * 1024
+
+// Store implements data.TicketStore by reading a beads JSONL export.
+type Store struct {
+	path string
+	// now returns the current time, for the deferred check.
+	now func() time.Time
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	issues  []issue
+	byID    map[string]*issue
+}
+
+// Verify interface compliance at compile time.
+var (
+	_ data.TicketStore       = (*Store)(nil)
+	_ data.TicketDetailStore = (*Store)(nil)
+	_ data.ChangeTokenStore  = (*Store)(nil)
+)
+
+// issue is one line of the export. Beads omits empty labels, dependencies
+// and comments.
+type issue struct {
+	ID                 string       `json:"id"`
+	Title              string       `json:"title"`
+	Description        string       `json:"description"`
+	Design             string       `json:"design"`
+	AcceptanceCriteria string       `json:"acceptance_criteria"`
+	Notes              string       `json:"notes"`
+	Status             string       `json:"status"`
+	Priority           int          `json:"priority"`
+	IssueType          string       `json:"issue_type"`
+	Assignee           string       `json:"assignee"`
+	CreatedAt          time.Time    `json:"created_at"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+	DeferUntil         *time.Time   `json:"defer_until"`
+	Ephemeral          bool         `json:"ephemeral"`
+	IsTemplate         bool         `json:"is_template"`
+	Labels             []string     `json:"labels"`
+	Dependencies       []dependency `json:"dependencies"`
+	Comments           []comment    `json:"comments"`
+}
+
+type dependency struct {
+	DependsOnID string `json:"depends_on_id"`
+	Type        string `json:"type"`
+}
+
+type comment struct {
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewStore returns a store reading the export at path. It reads the file
+// once, so a missing or malformed export is reported right away.
+func NewStore(path string) (*Store, error) {
+	s := &Store{path: path, now: time.Now}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Path returns the path of the export.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// load reads the export unless its modification time and size are unchanged
+// since the last read. The caller must not hold s.mu.
+func (s *Store) load() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat issues export: %w", err)
+	}
+	if s.byID != nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return nil
+	}
+
+	issues, err := readIssues(s.path)
+	if err != nil {
+		return err
+	}
+	s.issues = issues
+	s.byID = make(map[string]*issue, len(issues))
+	for i := range s.issues {
+		s.byID[s.issues[i].ID] = &s.issues[i]
+	}
+	s.modTime, s.size = info.ModTime(), info.Size()
+	return nil
+}
+
+func readIssues(path string) ([]issue, error) {
+	f, err := os.Open(path) //nolint:gosec // path comes from the beads directory
+	if err != nil {
+		return nil, fmt.Errorf("failed to open issues export: %w", err)
+	}
+	defer f.Close()
+
+	var issues []issue
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var is issue
+		if err := json.Unmarshal(scanner.Bytes(), &is); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid issue: %w", path, line, err)
+		}
+		if is.ID == "" || is.Status == "tombstone" {
+			continue
+		}
+		issues = append(issues, is)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read issues export: %w", err)
+	}
+	return issues, nil
+}
+
+// ListTickets returns tickets matching the filter, ordered like the Dolt
+// store: by priority, then most recently updated first.
+func (s *Store) ListTickets(_ context.Context, filter data.TicketFilter) ([]domain.Ticket, error) {
+	query, err := search.Parse(filter.Search)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search: %w", err)
+	}
+	if filter.Scope < data.ScopeReady || filter.Scope > data.ScopeAll {
+		return nil, fmt.Errorf("unknown ticket scope %d", filter.Scope)
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var tickets []domain.Ticket
+	for i := range s.issues {
+		is := &s.issues[i]
+		if !s.inScope(is, filter.Scope) || !matches(is, filter, query) {
+			continue
+		}
+		t := is.ticket()
+		t.Blockers = s.openBlockers(is)
+		tickets = append(tickets, t)
+	}
+
+	slices.SortStableFunc(tickets, func(a, b domain.Ticket) int {
+		return cmp.Or(cmp.Compare(a.Priority, b.Priority), b.UpdatedAt.Compare(a.UpdatedAt))
+	})
+	if filter.Limit > 0 && len(tickets) > filter.Limit {
+		tickets = tickets[:filter.Limit]
+	}
+	return tickets, nil
+}
+
+// inScope reports whether is belongs to scope, mirroring the Dolt store's
+// queries.
+func (s *Store) inScope(is *issue, scope data.TicketScope) bool {
+	switch scope {
+	case data.ScopeInProgress:
+		return is.Status == "in_progress"
+	case data.ScopeBlocked:
+		return is.Status != "closed" && (is.Status == "blocked" || len(s.openBlockers(is)) > 0)
+	case data.ScopeAll:
+		return is.Status != "closed"
+	default:
+		return is.Status == "open" && !is.Ephemeral && !is.IsTemplate &&
+			(is.DeferUntil == nil || !is.DeferUntil.After(s.now())) &&
+			len(s.openBlockers(is)) == 0 && !s.blockedParent(is, 0)
+	}
+}
+
+// maxParentDepth bounds the walk up "parent-child" dependencies, like the
+// recursion limit of beads' ready_issues view, and ends cycles.
+const maxParentDepth = 50
+
+// blockedParent reports whether an ancestor of is, reached through
+// "parent-child" dependencies, has an open blocker. beads keeps the children
+// of blocked issues out of ready_issues, so the ready scope does too.
+func (s *Store) blockedParent(is *issue, depth int) bool {
+	if depth >= maxParentDepth {
+		return false
+	}
+	for _, d := range is.Dependencies {
+		if d.Type != "parent-child" {
+			continue
+		}
+		parent, ok := s.byID[d.DependsOnID]
+		if ok && (len(s.openBlockers(parent)) > 0 || s.blockedParent(parent, depth+1)) {
+			return true
+		}
+	}
+	return false
+}
+
+// openBlockers returns the sorted IDs of the issues blocking is that are not
+// closed. Blockers missing from the export count as closed.
+func (s *Store) openBlockers(is *issue) []string {
+	var blockers []string
+	for _, d := range is.Dependencies {
+		if d.Type != "blocks" {
+			continue
+		}
+		if b, ok := s.byID[d.DependsOnID]; ok && b.Status != "closed" {
+			blockers = append(blockers, d.DependsOnID)
+		}
+	}
+	slices.Sort(blockers)
+	return blockers
+}
+
+func matches(is *issue, filter data.TicketFilter, query search.Query) bool {
+	switch {
+	case !data.MatchesFilterValues(filter.Status, is.Status),
+		!data.MatchesFilterValues(filter.IssueType, is.IssueType),
+		filter.Assignee != "" && is.Assignee != filter.Assignee,
+		filter.MinPriority != nil && is.Priority < *filter.MinPriority,
+		filter.MaxPriority != nil && is.Priority > *filter.MaxPriority,
+		filter.Label != "" && !slices.Contains(is.Labels, filter.Label):
+		return false
+	}
+	return query.Match(search.Document{
+		ID:          is.ID,
+		Title:       is.Title,
+		Description: is.Description,
+		Notes:       is.Notes,
+		Status:      is.Status,
+		IssueType:   is.IssueType,
+		Assignee:    is.Assignee,
+		Priority:    is.Priority,
+		Labels:      is.Labels,
+	})
+}
+
+func (is *issue) ticket() domain.Ticket {
+	return domain.Ticket{
+		ID:          is.ID,
+		Title:       is.Title,
+		Description: is.Description,
+		Status:      is.Status,
+		Priority:    is.Priority,
+		IssueType:   is.IssueType,
+		Assignee:    is.Assignee,
+		CreatedAt:   is.CreatedAt,
+		UpdatedAt:   is.UpdatedAt,
+	}
+}
+
+// LatestUpdate returns the modification time of the export, so any change
+// bd writes to it is picked up.
+func (s *Store) LatestUpdate(_ context.Context) (time.Time, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to stat issues export: %w", err)
+	}
+	return info.ModTime(), nil
+}
+
+// ChangeToken returns the modification time and size of the export, so a
+// rewrite within the same timestamp granularity is still noticed.
+func (s *Store) ChangeToken(_ context.Context) (string, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stat issues export: %w", err)
+	}
+	return fmt.Sprintf("%d:%d", info.ModTime().UnixNano(), info.Size()), nil
+}
+
+// TicketDetail returns the full issue with its labels, dependencies and
+// comments. Closed and blocked tickets are included.
+func (s *Store) TicketDetail(_ context.Context, id string) (*domain.TicketDetail, error) {
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	is, ok := s.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", data.ErrTicketNotFound, id)
+	}
+
+	d := &domain.TicketDetail{
+		Ticket:             is.ticket(),
+		Design:             is.Design,
+		AcceptanceCriteria: is.AcceptanceCriteria,
+		Notes:              is.Notes,
+		Labels:             slices.Sorted(slices.Values(is.Labels)),
+	}
+	for _, dep := range is.Dependencies {
+		td := domain.TicketDependency{ID: dep.DependsOnID, Type: dep.Type}
+		if target, ok := s.byID[dep.DependsOnID]; ok {
+			td.Title, td.Status = target.Title, target.Status
+		}
+		d.Dependencies = append(d.Dependencies, td)
+	}
+	slices.SortStableFunc(d.Dependencies, func(a, b domain.TicketDependency) int { return cmp.Compare(a.ID, b.ID) })
+	for _, c := range is.Comments {
+		d.Comments = append(d.Comments, domain.TicketComment{Author: c.Author, Text: c.Text, CreatedAt: c.CreatedAt})
+	}
+	slices.SortStableFunc(d.Comments, func(a, b domain.TicketComment) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return d, nil
+}
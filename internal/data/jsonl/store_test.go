@@ -0,0 +1,296 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package jsonl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/search/searchtest"
+)
+
+// sampleExport is a beads export with a blocked, a deferred and an
+// ephemeral issue, in the format bd writes it.
+const sampleExport = `{"id":"bb-1","title":"Open","status":"open","priority":1,"issue_type":"task","assignee":null,"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-02T00:00:00Z","labels":["ui"]}
+{"id":"bb-2","title":"Blocked by bb-3","status":"open","priority":0,"issue_type":"bug","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-02T00:00:00Z","dependencies":[{"issue_id":"bb-2","depends_on_id":"bb-3","type":"blocks"},{"issue_id":"bb-2","depends_on_id":"bb-4","type":"blocks"},{"issue_id":"bb-2","depends_on_id":"bb-1","type":"related"}],"comments":[{"id":2,"issue_id":"bb-2","author":"bob","text":"second","created_at":"2026-01-04T00:00:00Z"},{"id":1,"issue_id":"bb-2","author":"alice","text":"first","created_at":"2026-01-03T00:00:00Z"}]}
+{"id":"bb-3","title":"In progress","status":"in_progress","priority":2,"issue_type":"task","assignee":"alice","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-03T00:00:00Z"}
+{"id":"bb-4","title":"Closed","status":"closed","priority":1,"issue_type":"task","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}
+{"id":"bb-5","title":"Deferred","status":"open","priority":1,"issue_type":"task","defer_until":"2999-01-01T00:00:00Z","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-05T00:00:00Z"}
+{"id":"bb-6","title":"Wisp","status":"open","priority":1,"issue_type":"task","ephemeral":true,"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}
+{"id":"bb-7","title":"Explicitly blocked","status":"blocked","priority":3,"issue_type":"task","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}
+
+{"id":"bb-8","title":"Deleted","status":"tombstone","priority":1,"issue_type":"task","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}
+`
+
+func writeExport(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write export: %v", err)
+	}
+	return path
+}
+
+func listIDs(t *testing.T, s *Store, filter data.TicketFilter) []string {
+	t.Helper()
+	tickets, err := s.ListTickets(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("ListTickets failed: %v", err)
+	}
+	var ids []string
+	for _, ticket := range tickets {
+		ids = append(ids, ticket.ID)
+	}
+	return ids
+}
+
+func TestStore_ListTickets_Scopes(t *testing.T) {
+	s, err := NewStore(writeExport(t, sampleExport))
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	tests := []struct {
+		scope data.TicketScope
+		want  []string
+	}{
+		{data.ScopeReady, []string{"bb-1"}},
+		{data.ScopeInProgress, []string{"bb-3"}},
+		{data.ScopeBlocked, []string{"bb-2", "bb-7"}},
+		{data.ScopeAll, []string{"bb-2", "bb-5", "bb-1", "bb-6", "bb-3", "bb-7"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scope.String(), func(t *testing.T) {
+			if got := listIDs(t, s, data.TicketFilter{Scope: tt.scope}); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	tickets, err := s.ListTickets(context.Background(), data.TicketFilter{Scope: data.ScopeBlocked})
+	if err != nil {
+		t.Fatalf("ListTickets failed: %v", err)
+	}
+	if !reflect.DeepEqual(tickets[0].Blockers, []string{"bb-3"}) {
+		t.Errorf("expected bb-2 to be blocked by bb-3 only, got %v", tickets[0].Blockers)
+	}
+}
+
+// parentExport has an epic blocked by an open issue, its child and
+// grandchild, and the child of an unblocked epic.
+const parentExport = `{"id":"bb-1","title":"Blocker","status":"open","priority":1,"issue_type":"task","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}
+{"id":"bb-2","title":"Blocked epic","status":"open","priority":1,"issue_type":"epic","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-02T00:00:00Z","dependencies":[{"issue_id":"bb-2","depends_on_id":"bb-1","type":"blocks"}]}
+{"id":"bb-3","title":"Child","status":"open","priority":1,"issue_type":"task","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-03T00:00:00Z","dependencies":[{"issue_id":"bb-3","depends_on_id":"bb-2","type":"parent-child"}]}
+{"id":"bb-4","title":"Grandchild","status":"open","priority":1,"issue_type":"task","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-04T00:00:00Z","dependencies":[{"issue_id":"bb-4","depends_on_id":"bb-3","type":"parent-child"}]}
+{"id":"bb-5","title":"Open epic","status":"open","priority":1,"issue_type":"epic","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-05T00:00:00Z"}
+{"id":"bb-6","title":"Child of open epic","status":"open","priority":1,"issue_type":"task","created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-06T00:00:00Z","dependencies":[{"issue_id":"bb-6","depends_on_id":"bb-5","type":"parent-child"}]}
+`
+
+func TestStore_ListTickets_BlockedParent(t *testing.T) {
+	s, err := NewStore(writeExport(t, parentExport))
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	if got, want := listIDs(t, s, data.TicketFilter{}), []string{"bb-6", "bb-5", "bb-1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ready: got %v, want %v", got, want)
+	}
+	if got, want := listIDs(t, s, data.TicketFilter{Scope: data.ScopeBlocked}), []string{"bb-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("blocked: got %v, want %v", got, want)
+	}
+
+	// Closing the blocker makes the whole epic ready.
+	content := strings.Replace(parentExport, `"title":"Blocker","status":"open"`, `"title":"Blocker","status":"closed"`, 1)
+	s, err = NewStore(writeExport(t, content))
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if got, want := listIDs(t, s, data.TicketFilter{}), []string{"bb-6", "bb-5", "bb-4", "bb-3", "bb-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ready after closing the blocker: got %v, want %v", got, want)
+	}
+}
+
+func TestStore_ListTickets_Filters(t *testing.T) {
+	s, err := NewStore(writeExport(t, sampleExport))
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	one := 1
+	all := data.TicketFilter{Scope: data.ScopeAll}
+	tests := []struct {
+		name   string
+		filter func(f data.TicketFilter) data.TicketFilter
+		want   []string
+	}{
+		{"assignee", func(f data.TicketFilter) data.TicketFilter { f.Assignee = "alice"; return f }, []string{"bb-3"}},
+		{"label", func(f data.TicketFilter) data.TicketFilter { f.Label = "ui"; return f }, []string{"bb-1"}},
+		{"type", func(f data.TicketFilter) data.TicketFilter { f.IssueType = "bug"; return f }, []string{"bb-2"}},
+		{"status list", func(f data.TicketFilter) data.TicketFilter { f.Status = "in_progress, blocked"; return f }, []string{"bb-3", "bb-7"}},
+		{"type list", func(f data.TicketFilter) data.TicketFilter { f.IssueType = "bug,feature"; return f }, []string{"bb-2"}},
+		{"priority", func(f data.TicketFilter) data.TicketFilter { f.MinPriority, f.MaxPriority = &one, &one; return f }, []string{"bb-5", "bb-1", "bb-6"}},
+		{"limit", func(f data.TicketFilter) data.TicketFilter { f.Limit = 2; return f }, []string{"bb-2", "bb-5"}},
+		{"search", func(f data.TicketFilter) data.TicketFilter { f.Search = "label:ui"; return f }, []string{"bb-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := listIDs(t, s, tt.filter(all)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := s.ListTickets(context.Background(), data.TicketFilter{Search: "type:"}); err == nil {
+		t.Error("expected an error for a search term without a value")
+	}
+}
+
+func TestStore_ListTickets_SharedSearchCases(t *testing.T) {
+	var lines []string
+	for _, d := range searchtest.Documents {
+		line, err := json.Marshal(map[string]any{
+			"id": d.ID, "title": d.Title, "description": d.Description, "notes": d.Notes,
+			"status": d.Status, "issue_type": d.IssueType, "assignee": d.Assignee,
+			"priority": d.Priority, "labels": d.Labels,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, string(line))
+	}
+	s, err := NewStore(writeExport(t, strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	for _, tc := range searchtest.Cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got := listIDs(t, s, data.TicketFilter{Scope: data.ScopeAll, Search: tc.Query})
+			// The documents share updated_at, so they are sorted by priority.
+			want := map[string]bool{}
+			for _, id := range tc.Want {
+				want[id] = true
+			}
+			if len(got) != len(want) {
+				t.Fatalf("got %v, want %v", got, tc.Want)
+			}
+			for _, id := range got {
+				if !want[id] {
+					t.Errorf("got %v, want %v", got, tc.Want)
+				}
+			}
+		})
+	}
+}
+
+func TestStore_ReloadsOnChange(t *testing.T) {
+	path := writeExport(t, sampleExport)
+	s, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	before, err := s.LatestUpdate(context.Background())
+	if err != nil {
+		t.Fatalf("LatestUpdate failed: %v", err)
+	}
+
+	// bd closing bb-3 unblocks bb-2.
+	updated := strings.Replace(sampleExport, `"status":"in_progress"`, `"status":"closed"`, 1)
+	if err := os.WriteFile(path, []byte(updated), 0600); err != nil {
+		t.Fatal(err)
+	}
+	later := before.Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	after, err := s.LatestUpdate(context.Background())
+	if err != nil {
+		t.Fatalf("LatestUpdate failed: %v", err)
+	}
+	if !after.After(before) {
+		t.Errorf("expected LatestUpdate to move past %v, got %v", before, after)
+	}
+	if got := listIDs(t, s, data.TicketFilter{}); !reflect.DeepEqual(got, []string{"bb-2", "bb-1"}) {
+		t.Errorf("got %v after the change, want [bb-2 bb-1]", got)
+	}
+}
+
+func TestStore_ChangeToken(t *testing.T) {
+	path := writeExport(t, sampleExport)
+	s, err := NewStore(path)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := s.ChangeToken(context.Background())
+	if err != nil {
+		t.Fatalf("ChangeToken failed: %v", err)
+	}
+
+	// A deletion written within the same mtime still changes the token.
+	updated := strings.Replace(sampleExport, `"status":"tombstone"`, `"status":"gone"`, 1)
+	if err := os.WriteFile(path, []byte(updated), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+
+	after, err := s.ChangeToken(context.Background())
+	if err != nil {
+		t.Fatalf("ChangeToken failed: %v", err)
+	}
+	if after == before {
+		t.Errorf("expected the token to change, still %q", after)
+	}
+}
+
+func TestStore_TicketDetail(t *testing.T) {
+	s, err := NewStore(writeExport(t, sampleExport))
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	d, err := s.TicketDetail(context.Background(), "bb-2")
+	if err != nil {
+		t.Fatalf("TicketDetail failed: %v", err)
+	}
+	if len(d.Dependencies) != 3 || d.Dependencies[0].ID != "bb-1" || d.Dependencies[1].Status != "in_progress" ||
+		d.Dependencies[2].Title != "Closed" {
+		t.Errorf("unexpected dependencies: %+v", d.Dependencies)
+	}
+	if len(d.Comments) != 2 || d.Comments[0].Text != "first" || d.Comments[1].Author != "bob" {
+		t.Errorf("unexpected comments: %+v", d.Comments)
+	}
+
+	if _, err := s.TicketDetail(context.Background(), "bb-8"); !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("expected tombstones to be missing, got %v", err)
+	}
+}
+
+func TestNewStore_Errors(t *testing.T) {
+	if _, err := NewStore(filepath.Join(t.TempDir(), DefaultFile)); err == nil {
+		t.Error("expected an error for a missing export")
+	}
+
+	_, err := NewStore(writeExport(t, sampleExport+"{not json\n"))
+	if err == nil || !strings.Contains(err.Error(), ":10: invalid issue") {
+		t.Errorf("expected the bad line to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package migrate applies numbered schema migrations to the data bdb owns:
+// the tables it adds to a Dolt database and its state files.
+//
+// Each store defines its migrations as a list numbered from 1 and a Backend
+// that records the applied version and runs one migration atomically, e.g.
+// in a transaction that also updates the version. Migrations are never
+// edited once released; a schema change is a new migration at the end.
+//
+// Usage
+//
+//	status, err := migrate.GetStatus(ctx, backend, migrations)
+//	applied, err := migrate.Up(ctx, backend, migrations)
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Migration is one numbered schema change of a store whose migrations run
+// against a T, e.g. a SQL transaction.
+type Migration[T any] struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, target T) error
+}
+
+// Backend records the schema version of a store and applies migrations to
+// it.
+type Backend[T any] interface {
+	// Version returns the version of the last applied migration, or 0 if
+	// none was applied.
+	Version(ctx context.Context) (int, error)
+	// Apply runs m and records its version, both or neither.
+	Apply(ctx context.Context, m Migration[T]) error
+}
+
+// Info identifies a migration.
+type Info struct {
+	Version int
+	Name    string
+}
+
+// Status is the migration state of a store.
+type Status struct {
+	// Current is the version of the last applied migration.
+	Current int
+	// Latest is the version of the last known migration.
+	Latest int
+	// Applied and Pending list the known migrations by state, in order.
+	Applied []Info
+	Pending []Info
+}
+
+// UpToDate reports whether no migration is pending.
+func (s Status) UpToDate() bool {
+	return len(s.Pending) == 0
+}
+
+// ErrNewerSchema is returned for stores migrated by a newer bdb, whose
+// schema this one does not know.
+var ErrNewerSchema = errors.New("schema is newer than this version of bdb supports")
+
+// GetStatus returns the migration state of the store behind b.
+func GetStatus[T any](ctx context.Context, b Backend[T], migrations []Migration[T]) (Status, error) {
+	if err := validate(migrations); err != nil {
+		return Status{}, err
+	}
+	current, err := b.Version(ctx)
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	status := Status{Current: current, Latest: len(migrations)}
+	if current > status.Latest {
+		return status, fmt.Errorf("%w: version %d, latest known %d", ErrNewerSchema, current, status.Latest)
+	}
+	for _, m := range migrations {
+		info := Info{Version: m.Version, Name: m.Name}
+		if m.Version <= current {
+			status.Applied = append(status.Applied, info)
+		} else {
+			status.Pending = append(status.Pending, info)
+		}
+	}
+	return status, nil
+}
+
+// Up applies the pending migrations in order and returns those applied. It
+// stops at the first failure, keeping the migrations applied before it.
+func Up[T any](ctx context.Context, b Backend[T], migrations []Migration[T]) ([]Info, error) {
+	status, err := GetStatus(ctx, b, migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Info
+	for _, info := range status.Pending {
+		m := migrations[info.Version-1]
+		if err := b.Apply(ctx, m); err != nil {
+			return applied, fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+		applied = append(applied, info)
+	}
+	return applied, nil
+}
+
+// validate checks that migrations are numbered 1, 2, 3 and so on.
+func validate[T any](migrations []Migration[T]) error {
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return fmt.Errorf("migration %q has version %d, expected %d", m.Name, m.Version, i+1)
+		}
+		if m.Up == nil {
+			return fmt.Errorf("migration %d (%s) has no Up function", m.Version, m.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package migrate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// memBackend keeps the version in memory and records the applied steps.
+type memBackend struct {
+	version int
+	steps   *[]string
+	failAt  int
+}
+
+func (b *memBackend) Version(context.Context) (int, error) {
+	return b.version, nil
+}
+
+func (b *memBackend) Apply(ctx context.Context, m Migration[*[]string]) error {
+	if m.Version == b.failAt {
+		return errors.New("boom")
+	}
+	if err := m.Up(ctx, b.steps); err != nil {
+		return err
+	}
+	b.version = m.Version
+	return nil
+}
+
+func testMigrations() []Migration[*[]string] {
+	step := func(name string) func(context.Context, *[]string) error {
+		return func(_ context.Context, steps *[]string) error {
+			*steps = append(*steps, name)
+			return nil
+		}
+	}
+	return []Migration[*[]string]{
+		{Version: 1, Name: "one", Up: step("one")},
+		{Version: 2, Name: "two", Up: step("two")},
+		{Version: 3, Name: "three", Up: step("three")},
+	}
+}
+
+func TestGetStatus(t *testing.T) {
+	b := &memBackend{version: 1, steps: &[]string{}}
+	status, err := GetStatus(context.Background(), b, testMigrations())
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+
+	expected := Status{
+		Current: 1,
+		Latest:  3,
+		Applied: []Info{{1, "one"}},
+		Pending: []Info{{2, "two"}, {3, "three"}},
+	}
+	if !reflect.DeepEqual(status, expected) {
+		t.Errorf("expected %+v, got %+v", expected, status)
+	}
+	if status.UpToDate() {
+		t.Error("expected pending migrations")
+	}
+}
+
+func TestGetStatus_NewerSchema(t *testing.T) {
+	b := &memBackend{version: 4, steps: &[]string{}}
+	if _, err := GetStatus(context.Background(), b, testMigrations()); !errors.Is(err, ErrNewerSchema) {
+		t.Errorf("expected ErrNewerSchema, got %v", err)
+	}
+}
+
+func TestUp(t *testing.T) {
+	steps := []string{}
+	b := &memBackend{version: 1, steps: &steps}
+
+	applied, err := Up(context.Background(), b, testMigrations())
+	if err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if !reflect.DeepEqual(applied, []Info{{2, "two"}, {3, "three"}}) {
+		t.Errorf("unexpected applied migrations %+v", applied)
+	}
+	if !reflect.DeepEqual(steps, []string{"two", "three"}) {
+		t.Errorf("expected only the pending migrations to run, got %v", steps)
+	}
+
+	applied, err = Up(context.Background(), b, testMigrations())
+	if err != nil || len(applied) != 0 {
+		t.Errorf("expected nothing to apply, got %+v (%v)", applied, err)
+	}
+}
+
+func TestUp_StopsAtFailure(t *testing.T) {
+	steps := []string{}
+	b := &memBackend{steps: &steps, failAt: 2}
+
+	applied, err := Up(context.Background(), b, testMigrations())
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !reflect.DeepEqual(applied, []Info{{1, "one"}}) || b.version != 1 {
+		t.Errorf("expected only migration 1 to be applied, got %+v at version %d", applied, b.version)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	noop := func(context.Context, *[]string) error { return nil }
+	for name, migrations := range map[string][]Migration[*[]string]{
+		"gap":       {{Version: 1, Name: "a", Up: noop}, {Version: 3, Name: "b", Up: noop}},
+		"zero":      {{Version: 0, Name: "a", Up: noop}},
+		"no up":     {{Version: 1, Name: "a"}},
+		"reordered": {{Version: 2, Name: "b", Up: noop}, {Version: 1, Name: "a", Up: noop}},
+	} {
+		if err := validate(migrations); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParsePriorityRange parses a priority filter such as "1", "0-2", "P1-P3",
+// "2-" or "-1" into the bounds of TicketFilter. An empty string leaves both
+// ends open.
+func ParsePriorityRange(s string) (minPrio, maxPrio *int, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil, nil
+	}
+
+	lo, hi, isRange := strings.Cut(s, "-")
+	if !isRange {
+		hi = lo
+	}
+	if minPrio, err = parsePriority(lo); err != nil {
+		return nil, nil, fmt.Errorf("invalid priority range %q: %w", s, err)
+	}
+	if maxPrio, err = parsePriority(hi); err != nil {
+		return nil, nil, fmt.Errorf("invalid priority range %q: %w", s, err)
+	}
+	if minPrio == nil && maxPrio == nil {
+		return nil, nil, fmt.Errorf("invalid priority range %q: no bounds", s)
+	}
+	if minPrio != nil && maxPrio != nil && *minPrio > *maxPrio {
+		return nil, nil, fmt.Errorf("invalid priority range %q: %d is greater than %d", s, *minPrio, *maxPrio)
+	}
+	return minPrio, maxPrio, nil
+}
+
+func parsePriority(s string) (*int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "P"), "p")
+	p, err := strconv.Atoi(s)
+	if err != nil || p < 0 {
+		return nil, fmt.Errorf("%q is not a priority", s)
+	}
+	return &p, nil
+}
+
+// FormatPriorityRange is the inverse of ParsePriorityRange.
+func FormatPriorityRange(minPrio, maxPrio *int) string {
+	switch {
+	case minPrio == nil && maxPrio == nil:
+		return ""
+	case minPrio == nil:
+		return fmt.Sprintf("-%d", *maxPrio)
+	case maxPrio == nil:
+		return fmt.Sprintf("%d-", *minPrio)
+	case *minPrio == *maxPrio:
+		return strconv.Itoa(*minPrio)
+	default:
+		return fmt.Sprintf("%d-%d", *minPrio, *maxPrio)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package data_test
+
+import (
+	"testing"
+
+	"github.com/megatherium/blunderbust/internal/data"
+)
+
+func TestParsePriorityRange(t *testing.T) {
+	tests := []struct {
+		in       string
+		min, max int // -1 means open
+		wantErr  bool
+	}{
+		{in: "", min: -1, max: -1},
+		{in: "1", min: 1, max: 1},
+		{in: "0-2", min: 0, max: 2},
+		{in: "P1-P3", min: 1, max: 3},
+		{in: "2-", min: 2, max: -1},
+		{in: "-1", min: -1, max: 1},
+		{in: "3-1", wantErr: true},
+		{in: "high", wantErr: true},
+		{in: "-", wantErr: true},
+	}
+
+	bound := func(p *int) int {
+		if p == nil {
+			return -1
+		}
+		return *p
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			minPrio, maxPrio, err := data.ParsePriorityRange(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error for %q", tt.in)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if bound(minPrio) != tt.min || bound(maxPrio) != tt.max {
+				t.Errorf("got %d-%d, want %d-%d", bound(minPrio), bound(maxPrio), tt.min, tt.max)
+			}
+			if tt.in != "P1-P3" {
+				if got := data.FormatPriorityRange(minPrio, maxPrio); got != tt.in {
+					t.Errorf("FormatPriorityRange = %q, want %q", got, tt.in)
+				}
+			}
+		})
+	}
+}
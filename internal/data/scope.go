@@ -0,0 +1,48 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package data
+
+// TicketScope selects which tickets ListTickets considers before the other
+// filters apply.
+type TicketScope int
+
+const (
+	// ScopeReady keeps open tickets that nothing blocks, as beads' ready_issues
+	// view does. It is the zero value.
+	ScopeReady TicketScope = iota
+	// ScopeInProgress keeps tickets that are in progress.
+	ScopeInProgress
+	// ScopeBlocked keeps tickets that are not closed and are blocked by an
+	// open issue or have the blocked status.
+	ScopeBlocked
+	// ScopeAll keeps every ticket that is not closed.
+	ScopeAll
+)
+
+// String returns the name of the scope as shown in the TUI.
+func (s TicketScope) String() string {
+	switch s {
+	case ScopeReady:
+		return "ready"
+	case ScopeInProgress:
+		return "in progress"
+	case ScopeBlocked:
+		return "blocked"
+	case ScopeAll:
+		return "all open"
+	default:
+		return "unknown"
+	}
+}
+
+// Next returns the scope after s, wrapping around after ScopeAll.
+func (s TicketScope) Next() TicketScope {
+	if s >= ScopeAll || s < ScopeReady {
+		return ScopeReady
+	}
+	return s + 1
+}
@@ -0,0 +1,20 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package data
+
+import "testing"
+
+func TestTicketScope_NextCyclesThroughAllScopes(t *testing.T) {
+	want := []string{"in progress", "blocked", "all open", "ready"}
+	s := ScopeReady
+	for _, name := range want {
+		s = s.Next()
+		if s.String() != name {
+			t.Errorf("expected %q, got %q", name, s)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package search parses the ticket search syntax used by TicketFilter.Search
+// and compiles it for the ticket stores: to parameterized SQL for Dolt and to
+// an in-memory matcher for the others.
+//
+// A query is a list of terms that must all match:
+//
+//	login                 id, title, description, notes or a label contains "login"
+//	"token refresh"       the same for a phrase
+//	-flaky                excludes tickets containing "flaky"
+//	type:bug prio:0-1     field terms; see Parse for the fields
+//	-label:"won't fix"    field terms can be quoted and excluded too
+//
+// Matching is case-insensitive.
+package search
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/megatherium/blunderbust/internal/data"
+)
+
+// Field is what a term is matched against.
+type Field string
+
+const (
+	// FieldText matches id, title, description, notes and labels.
+	FieldText        Field = ""
+	FieldID          Field = "id"
+	FieldTitle       Field = "title"
+	FieldDescription Field = "desc"
+	FieldNotes       Field = "notes"
+	FieldLabel       Field = "label"
+	FieldType        Field = "type"
+	FieldStatus      Field = "status"
+	FieldAssignee    Field = "assignee"
+	FieldPriority    Field = "prio"
+)
+
+// fieldNames maps the prefixes accepted in queries to their fields.
+var fieldNames = map[string]Field{
+	"id":          FieldID,
+	"title":       FieldTitle,
+	"desc":        FieldDescription,
+	"description": FieldDescription,
+	"notes":       FieldNotes,
+	"label":       FieldLabel,
+	"type":        FieldType,
+	"status":      FieldStatus,
+	"assignee":    FieldAssignee,
+	"prio":        FieldPriority,
+	"priority":    FieldPriority,
+}
+
+// Term is a single condition of a query.
+type Term struct {
+	Field Field
+	// Value is lower-cased; it is unused for FieldPriority.
+	Value  string
+	Negate bool
+	// MinPriority and MaxPriority bound FieldPriority terms.
+	MinPriority *int
+	MaxPriority *int
+}
+
+// Query is a parsed search. The zero value matches everything.
+type Query struct {
+	Terms []Term
+}
+
+// IsZero reports whether the query has no terms.
+func (q Query) IsZero() bool {
+	return len(q.Terms) == 0
+}
+
+// Parse parses a search query. Terms are separated by whitespace; double
+// quotes group words into a phrase, a leading - excludes matches, and a
+// field prefix restricts a term to a field: id, title, desc (description),
+// notes, label, type, status, assignee or prio (priority, a single value or
+// a range like 0-2). Words with other prefixes, like URLs, are plain text.
+func Parse(s string) (Query, error) {
+	var q Query
+	for _, tok := range tokenize(s) {
+		term, err := parseTerm(tok)
+		if err != nil {
+			return Query{}, err
+		}
+		q.Terms = append(q.Terms, term)
+	}
+	return q, nil
+}
+
+// token is a whitespace separated part of a query with quotes removed.
+// colon is the index of the first colon outside quotes, or -1.
+type token struct {
+	text   string
+	colon  int
+	negate bool
+}
+
+func tokenize(s string) []token {
+	var tokens []token
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		tok := token{colon: -1}
+		if runes[i] == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+			tok.negate = true
+			i++
+		}
+
+		var b strings.Builder
+		inQuote, quoted := false, false
+	scan:
+		for ; i < len(runes); i++ {
+			r := runes[i]
+			switch {
+			case r == '"':
+				inQuote = !inQuote
+				quoted = true
+			case unicode.IsSpace(r) && !inQuote:
+				break scan
+			default:
+				if r == ':' && !quoted && tok.colon < 0 {
+					tok.colon = b.Len()
+				}
+				b.WriteRune(r)
+			}
+		}
+		tok.text = b.String()
+		if tok.text != "" {
+			tokens = append(tokens, tok)
+		}
+	}
+	return tokens
+}
+
+func parseTerm(tok token) (Term, error) {
+	term := Term{Value: strings.ToLower(tok.text), Negate: tok.negate}
+	if tok.colon < 0 {
+		return term, nil
+	}
+	field, ok := fieldNames[strings.ToLower(tok.text[:tok.colon])]
+	if !ok {
+		return term, nil
+	}
+
+	value := tok.text[tok.colon+1:]
+	if strings.TrimSpace(value) == "" {
+		return Term{}, fmt.Errorf("search term %q needs a value", tok.text)
+	}
+	term.Field = field
+	term.Value = strings.ToLower(value)
+	if field == FieldPriority {
+		minPrio, maxPrio, err := data.ParsePriorityRange(value)
+		if err != nil {
+			return Term{}, fmt.Errorf("search term %q: %w", tok.text, err)
+		}
+		term.MinPriority, term.MaxPriority = minPrio, maxPrio
+	}
+	return term, nil
+}
+
+// Document is the ticket data a query is matched against in memory.
+type Document struct {
+	ID          string
+	Title       string
+	Description string
+	Notes       string
+	Status      string
+	IssueType   string
+	Assignee    string
+	Priority    int
+	Labels      []string
+}
+
+// Match reports whether d matches every term of q.
+func (q Query) Match(d Document) bool {
+	for _, t := range q.Terms {
+		if t.match(d) == t.Negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (t Term) match(d Document) bool {
+	contains := func(s string) bool { return strings.Contains(strings.ToLower(s), t.Value) }
+	switch t.Field {
+	case FieldID:
+		return contains(d.ID)
+	case FieldTitle:
+		return contains(d.Title)
+	case FieldDescription:
+		return contains(d.Description)
+	case FieldNotes:
+		return contains(d.Notes)
+	case FieldLabel:
+		return slices.ContainsFunc(d.Labels, func(l string) bool { return strings.EqualFold(l, t.Value) })
+	case FieldType:
+		return strings.EqualFold(d.IssueType, t.Value)
+	case FieldStatus:
+		return strings.EqualFold(d.Status, t.Value)
+	case FieldAssignee:
+		return strings.EqualFold(d.Assignee, t.Value)
+	case FieldPriority:
+		return (t.MinPriority == nil || d.Priority >= *t.MinPriority) &&
+			(t.MaxPriority == nil || d.Priority <= *t.MaxPriority)
+	default:
+		return contains(d.ID) || contains(d.Title) || contains(d.Description) ||
+			contains(d.Notes) || slices.ContainsFunc(d.Labels, contains)
+	}
+}
+
+// SQL compiles q into a condition over the columns of the beads issues
+// table, with labels looked up in the labels table. It returns an empty
+// condition for an empty query.
+func (q Query) SQL() (cond string, args []any) {
+	conds := make([]string, 0, len(q.Terms))
+	for _, t := range q.Terms {
+		c, a := t.sql()
+		if t.Negate {
+			c = "NOT (" + c + ")"
+		}
+		conds = append(conds, c)
+		args = append(args, a...)
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+const (
+	labelContainsSQL = "id IN (SELECT issue_id FROM labels WHERE LOWER(label) LIKE ?)"
+	labelEqualsSQL   = "id IN (SELECT issue_id FROM labels WHERE LOWER(label) = ?)"
+)
+
+func (t Term) sql() (string, []any) {
+	like := "%" + escapeLike(t.Value) + "%"
+	switch t.Field {
+	case FieldID:
+		return "LOWER(id) LIKE ?", []any{like}
+	case FieldTitle:
+		return "LOWER(title) LIKE ?", []any{like}
+	case FieldDescription:
+		return "LOWER(COALESCE(description, '')) LIKE ?", []any{like}
+	case FieldNotes:
+		return "LOWER(COALESCE(notes, '')) LIKE ?", []any{like}
+	case FieldLabel:
+		return labelEqualsSQL, []any{t.Value}
+	case FieldType:
+		return "LOWER(issue_type) = ?", []any{t.Value}
+	case FieldStatus:
+		return "LOWER(status) = ?", []any{t.Value}
+	case FieldAssignee:
+		return "LOWER(COALESCE(assignee, '')) = ?", []any{t.Value}
+	case FieldPriority:
+		var conds []string
+		var args []any
+		if t.MinPriority != nil {
+			conds = append(conds, "priority >= ?")
+			args = append(args, *t.MinPriority)
+		}
+		if t.MaxPriority != nil {
+			conds = append(conds, "priority <= ?")
+			args = append(args, *t.MaxPriority)
+		}
+		return "(" + strings.Join(conds, " AND ") + ")", args
+	default:
+		return "(LOWER(id) LIKE ? OR LOWER(title) LIKE ? OR LOWER(COALESCE(description, '')) LIKE ? OR " +
+				"LOWER(COALESCE(notes, '')) LIKE ? OR " + labelContainsSQL + ")",
+			[]any{like, like, like, like, like}
+	}
+}
+
+// escapeLike escapes the LIKE wildcards in s, using MySQL's default escape
+// character.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package search_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/megatherium/blunderbust/internal/data/search"
+	"github.com/megatherium/blunderbust/internal/data/search/searchtest"
+)
+
+func TestQuery_SharedCases(t *testing.T) {
+	for _, tc := range searchtest.Cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			q, err := search.Parse(tc.Query)
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tc.Query, err)
+			}
+
+			var got []string
+			for _, d := range searchtest.Documents {
+				if q.Match(d) {
+					got = append(got, d.ID)
+				}
+			}
+			if !reflect.DeepEqual(got, tc.Want) {
+				t.Errorf("Match: got %v, want %v", got, tc.Want)
+			}
+
+			sql, args := q.SQL()
+			if sql != tc.SQL {
+				t.Errorf("SQL mismatch\nexpected: %s\ngot:      %s", tc.SQL, sql)
+			}
+			if !reflect.DeepEqual(args, tc.Args) {
+				t.Errorf("args mismatch: expected %v, got %v", tc.Args, args)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	q, err := search.Parse(`  title:"two words"  -"not this" - label:x `)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []search.Term{
+		{Field: search.FieldTitle, Value: "two words"},
+		{Field: search.FieldText, Value: "not this", Negate: true},
+		{Field: search.FieldText, Value: "-"},
+		{Field: search.FieldLabel, Value: "x"},
+	}
+	if !reflect.DeepEqual(q.Terms, want) {
+		t.Errorf("got %+v, want %+v", q.Terms, want)
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	q, err := search.Parse("   ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !q.IsZero() {
+		t.Errorf("expected an empty query, got %+v", q.Terms)
+	}
+	if !q.Match(searchtest.Documents[0]) {
+		t.Error("an empty query should match everything")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr string
+	}{
+		{"type:", `search term "type:" needs a value`},
+		{"prio:high", `search term "prio:high"`},
+		{"prio:3-1", "3 is greater than 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := search.Parse(tt.query)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package searchtest holds the search test cases shared by the ticket store
+// tests, so that the SQL and in-memory matchers stay equivalent.
+package searchtest
+
+import "github.com/megatherium/blunderbust/internal/data/search"
+
+// Documents are the tickets the cases are matched against.
+var Documents = []search.Document{
+	{
+		ID: "bb-1", Title: "Fix login redirect", Description: "SSO users end up on a blank page.",
+		Notes: "See internal/auth/session.go", Status: "open", IssueType: "bug", Assignee: "alice",
+		Priority: 0, Labels: []string{"auth", "Backend"},
+	},
+	{
+		ID: "bb-2", Title: "Add search", Description: "Search across 100% of the fields.",
+		Status: "open", IssueType: "feature", Assignee: "bob", Priority: 2, Labels: []string{"ui"},
+	},
+	{
+		ID: "bb-3", Title: "Crash on start", Description: "Token refresh panics when the login expired.",
+		Status: "in_progress", IssueType: "bug", Priority: 1,
+	},
+}
+
+// Case is a query with the documents it matches and its compiled SQL.
+type Case struct {
+	Name  string
+	Query string
+	// Want are the IDs of the matching Documents, in order.
+	Want []string
+	// SQL and Args are what search.Query.SQL returns.
+	SQL  string
+	Args []any
+}
+
+const textSQL = "(LOWER(id) LIKE ? OR LOWER(title) LIKE ? OR LOWER(COALESCE(description, '')) LIKE ? OR " +
+	"LOWER(COALESCE(notes, '')) LIKE ? OR id IN (SELECT issue_id FROM labels WHERE LOWER(label) LIKE ?))"
+
+func textArgs(like string) []any {
+	return []any{like, like, like, like, like}
+}
+
+// Cases are the shared search test cases.
+var Cases = []Case{
+	{
+		Name:  "word in title and description",
+		Query: "Login",
+		Want:  []string{"bb-1", "bb-3"},
+		SQL:   textSQL,
+		Args:  textArgs("%login%"),
+	},
+	{
+		Name:  "file name in notes",
+		Query: "session.go",
+		Want:  []string{"bb-1"},
+		SQL:   textSQL,
+		Args:  textArgs("%session.go%"),
+	},
+	{
+		Name:  "quoted phrase",
+		Query: `"token refresh"`,
+		Want:  []string{"bb-3"},
+		SQL:   textSQL,
+		Args:  textArgs("%token refresh%"),
+	},
+	{
+		Name:  "exclusion",
+		Query: "login -crash",
+		Want:  []string{"bb-1"},
+		SQL:   textSQL + " AND NOT (" + textSQL + ")",
+		Args:  append(textArgs("%login%"), textArgs("%crash%")...),
+	},
+	{
+		Name:  "id",
+		Query: "id:BB-2",
+		Want:  []string{"bb-2"},
+		SQL:   "LOWER(id) LIKE ?",
+		Args:  []any{"%bb-2%"},
+	},
+	{
+		Name:  "type and priority",
+		Query: "type:bug prio:0",
+		Want:  []string{"bb-1"},
+		SQL:   "LOWER(issue_type) = ? AND (priority >= ? AND priority <= ?)",
+		Args:  []any{"bug", 0, 0},
+	},
+	{
+		Name:  "open priority range",
+		Query: "priority:1-",
+		Want:  []string{"bb-2", "bb-3"},
+		SQL:   "(priority >= ?)",
+		Args:  []any{1},
+	},
+	{
+		Name:  "label is matched whole and case-insensitively",
+		Query: "label:backend",
+		Want:  []string{"bb-1"},
+		SQL:   "id IN (SELECT issue_id FROM labels WHERE LOWER(label) = ?)",
+		Args:  []any{"backend"},
+	},
+	{
+		Name:  "text matches labels",
+		Query: "ui",
+		Want:  []string{"bb-2"},
+		SQL:   textSQL,
+		Args:  textArgs("%ui%"),
+	},
+	{
+		Name:  "excluded field with quoted value",
+		Query: `-status:"in_progress" -assignee:bob`,
+		Want:  []string{"bb-1"},
+		SQL:   "NOT (LOWER(status) = ?) AND NOT (LOWER(COALESCE(assignee, '')) = ?)",
+		Args:  []any{"in_progress", "bob"},
+	},
+	{
+		Name:  "description and LIKE wildcards",
+		Query: "desc:100%",
+		Want:  []string{"bb-2"},
+		SQL:   "LOWER(COALESCE(description, '')) LIKE ?",
+		Args:  []any{`%100\%%`},
+	},
+	{
+		Name:  "unknown prefix is text",
+		Query: "http://example",
+		Want:  nil,
+		SQL:   textSQL,
+		Args:  textArgs("%http://example%"),
+	},
+}
@@ -8,8 +8,12 @@ package data
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/megatherium/blunderbust/internal/data/migrate"
 	"github.com/megatherium/blunderbust/internal/domain"
 )
 
@@ -19,10 +23,95 @@ type TicketStore interface {
 	LatestUpdate(ctx context.Context) (time.Time, error)
 }
 
+// TicketDetailStore is implemented by stores that can load the full issue
+// behind a ticket. It is optional; callers check for it with a type assertion.
+type TicketDetailStore interface {
+	TicketDetail(ctx context.Context, id string) (*domain.TicketDetail, error)
+}
+
+// ChangeTokenStore is implemented by stores that can report a token that
+// changes whenever their data does, including deletions and dependency
+// changes LatestUpdate misses. It is optional; use ChangeToken.
+type ChangeTokenStore interface {
+	ChangeToken(ctx context.Context) (string, error)
+}
+
+// ChangeToken returns an opaque token that changes when the tickets of store
+// change. Stores without a ChangeTokenStore implementation fall back to
+// their LatestUpdate.
+func ChangeToken(ctx context.Context, store TicketStore) (string, error) {
+	if cs, ok := store.(ChangeTokenStore); ok {
+		return cs.ChangeToken(ctx)
+	}
+	latest, err := store.LatestUpdate(ctx)
+	if err != nil {
+		return "", err
+	}
+	return latest.UTC().Format(time.RFC3339Nano), nil
+}
+
+// WritableTicketStore is implemented by stores that can update tickets, so
+// launches and their outcomes can be recorded in beads. It is optional;
+// callers check for it with a type assertion.
+type WritableTicketStore interface {
+	TicketStore
+	SetStatus(ctx context.Context, id, status string) error
+	SetAssignee(ctx context.Context, id, assignee string) error
+	AddComment(ctx context.Context, id, author, text string) error
+}
+
+// MigratableStore is implemented by stores holding data in a schema owned
+// by bdb, like the running_agents table, which changes through numbered
+// migrations.
+type MigratableStore interface {
+	MigrationStatus(ctx context.Context) (migrate.Status, error)
+	MigrateUp(ctx context.Context) ([]migrate.Info, error)
+}
+
+// ErrTicketNotFound is returned by TicketDetail and the WritableTicketStore
+// methods for unknown ticket IDs.
+var ErrTicketNotFound = errors.New("ticket not found")
+
 // TicketFilter controls which tickets are returned by ListTickets.
+// Zero values leave a field unfiltered, and list ready tickets.
 type TicketFilter struct {
+	Scope TicketScope
+	// Status and IssueType keep tickets matching one of their
+	// comma-separated values, e.g. "open, in_progress".
 	Status    string
 	IssueType string
-	Limit     int
-	Search    string
+	Assignee  string
+	// Label keeps tickets that carry this label.
+	Label string
+	// MinPriority and MaxPriority bound the priority range, inclusive.
+	// Nil leaves that end open, since 0 is the highest priority.
+	MinPriority *int
+	MaxPriority *int
+	Limit       int
+	Search      string
+}
+
+// IsZero reports whether the filter lets every ready ticket through.
+func (f TicketFilter) IsZero() bool {
+	return f.Scope == ScopeReady && f.Status == "" && f.IssueType == "" && f.Assignee == "" && f.Label == "" &&
+		f.MinPriority == nil && f.MaxPriority == nil && f.Limit == 0 && f.Search == ""
+}
+
+// FilterValues splits a comma-separated filter field like
+// TicketFilter.Status into its values, dropping blanks.
+func FilterValues(field string) []string {
+	var values []string
+	for _, v := range strings.Split(field, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// MatchesFilterValues reports whether value is one of the comma-separated
+// values of field. A field without values matches everything.
+func MatchesFilterValues(field, value string) bool {
+	values := FilterValues(field)
+	return len(values) == 0 || slices.Contains(values, value)
 }
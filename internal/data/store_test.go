@@ -0,0 +1,67 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package data
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+type latestOnlyStore struct{ latest time.Time }
+
+func (s latestOnlyStore) ListTickets(context.Context, TicketFilter) ([]domain.Ticket, error) {
+	return nil, nil
+}
+
+func (s latestOnlyStore) LatestUpdate(context.Context) (time.Time, error) { return s.latest, nil }
+
+type tokenStore struct{ latestOnlyStore }
+
+func (tokenStore) ChangeToken(context.Context) (string, error) { return "hash", nil }
+
+func TestChangeToken(t *testing.T) {
+	latest := time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	got, err := ChangeToken(context.Background(), latestOnlyStore{latest})
+	if err != nil || got != "2026-01-02T03:04:05.000000006Z" {
+		t.Errorf("expected the LatestUpdate fallback, got %q, %v", got, err)
+	}
+
+	got, err = ChangeToken(context.Background(), tokenStore{latestOnlyStore{latest}})
+	if err != nil || got != "hash" {
+		t.Errorf("expected the store's token, got %q, %v", got, err)
+	}
+}
+
+func TestFilterValues(t *testing.T) {
+	if got := FilterValues(" open, in_progress ,,"); !reflect.DeepEqual(got, []string{"open", "in_progress"}) {
+		t.Errorf("expected the trimmed values, got %v", got)
+	}
+	if got := FilterValues(" , "); got != nil {
+		t.Errorf("expected no values, got %v", got)
+	}
+
+	tests := []struct {
+		field, value string
+		want         bool
+	}{
+		{"", "open", true},
+		{"open", "open", true},
+		{"open", "closed", false},
+		{"open, in_progress", "in_progress", true},
+		{"open, in_progress", "blocked", false},
+	}
+	for _, tt := range tests {
+		if got := MatchesFilterValues(tt.field, tt.value); got != tt.want {
+			t.Errorf("MatchesFilterValues(%q, %q) = %v, want %v", tt.field, tt.value, got, tt.want)
+		}
+	}
+}
@@ -69,6 +69,27 @@ func (r *Registry) Load(ctx context.Context) error {
 	return nil
 }
 
+// LoadCache loads providers from the local cache without falling back to a
+// network refresh. It returns an error if the cache is missing or invalid.
+func (r *Registry) LoadCache() error {
+	data, err := os.ReadFile(r.cachePath)
+	if err != nil {
+		return fmt.Errorf("reading cache file: %w", err)
+	}
+
+	var parsed map[string]Provider
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return fmt.Errorf("parsing cache file: %w", err)
+	}
+
+	if err := validateProviders(parsed); err != nil {
+		return err
+	}
+
+	r.setProviders(parsed)
+	return nil
+}
+
 func (r *Registry) fetchProviders(ctx context.Context) (map[string]Provider, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, modelsAPIURL, http.NoBody)
 	if err != nil {
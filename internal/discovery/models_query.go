@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 )
 
 // GetActiveModels returns a list of model IDs from providers that have their required env vars set.
@@ -24,8 +25,12 @@ func (r *Registry) GetActiveModels() []string {
 }
 
 func (r *Registry) isProviderActive(provider Provider) bool {
+	return providerActive(provider, os.Getenv)
+}
+
+func providerActive(provider Provider, getenv func(key string) string) bool {
 	for _, envVar := range provider.Env {
-		if os.Getenv(envVar) == "" {
+		if getenv(envVar) == "" {
 			return false
 		}
 	}
@@ -47,6 +52,34 @@ func (r *Registry) GetModelsForProvider(providerID string) []string {
 	return models
 }
 
+// ExpandModels resolves provider: and discover:active entries into concrete
+// model IDs, preserving order and dropping duplicates. Plain model IDs are
+// passed through unchanged.
+func (r *Registry) ExpandModels(models []string) []string {
+	expanded := make([]string, 0, len(models))
+	seen := make(map[string]bool)
+	add := func(ids ...string) {
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				expanded = append(expanded, id)
+			}
+		}
+	}
+
+	for _, model := range models {
+		switch {
+		case model == KeywordDiscoverActive:
+			add(r.GetActiveModels()...)
+		case strings.HasPrefix(model, PrefixProvider):
+			add(r.GetModelsForProvider(strings.TrimPrefix(model, PrefixProvider))...)
+		default:
+			add(model)
+		}
+	}
+	return expanded
+}
+
 func formatProviderModels(provider Provider) []string {
 	models := make([]string, 0, len(provider.Models))
 	for _, model := range provider.Models {
@@ -65,3 +98,31 @@ func (r *Registry) setProviders(providers map[string]Provider) {
 	defer r.mu.Unlock()
 	r.providers = providers
 }
+
+// ProviderEnv returns the environment variables required to activate a
+// provider and whether the provider is known to the registry.
+func (r *Registry) ProviderEnv(providerID string) ([]string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	provider, ok := r.providers[providerID]
+	if !ok {
+		return nil, false
+	}
+	return provider.Env, true
+}
+
+// ActiveProviderCount returns how many providers have all their required env
+// vars set, looking the vars up with getenv.
+func (r *Registry) ActiveProviderCount(getenv func(key string) string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, provider := range r.providers {
+		if providerActive(provider, getenv) {
+			count++
+		}
+	}
+	return count
+}
@@ -261,3 +261,26 @@ type errorTransport struct{}
 func (t *errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return nil, fmt.Errorf("simulated network error")
 }
+
+func TestExpandModels(t *testing.T) {
+	registry, err := NewRegistry(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	registry.SetProviders(map[string]Provider{
+		"p1": {ID: "p1", Env: []string{"P1_KEY"}, Models: map[string]Model{"m1": {ID: "m1"}}},
+		"p2": {ID: "p2", Env: []string{"P2_KEY"}, Models: map[string]Model{"m2": {ID: "m2"}, "m3": {ID: "m3"}}},
+	})
+	t.Setenv("P1_KEY", "val")
+
+	got := registry.ExpandModels([]string{"custom", KeywordDiscoverActive, "provider:p2", "p1/m1", "provider:missing"})
+	want := []string{"custom", "p1/m1", "p2/m2", "p2/m3"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
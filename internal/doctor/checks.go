@@ -0,0 +1,336 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/data/dolt"
+	"github.com/megatherium/blunderbust/internal/discovery"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// doltProbeTimeout bounds how long the Dolt connectivity check may take.
+const doltProbeTimeout = 15 * time.Second
+
+var tmuxVersionPattern = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+func beadsDirFor(projectDir string) string {
+	return filepath.Join(projectDir, ".beads")
+}
+
+func (d *Doctor) checkTmuxBinary(ctx context.Context) Result {
+	res := Result{Name: "tmux"}
+	if _, err := d.lookPath("tmux"); err != nil {
+		res.Status = StatusFail
+		res.Detail = "tmux not found on PATH"
+		res.Fix = "install tmux (e.g. 'sudo apt-get install tmux' or 'brew install tmux')"
+		return res
+	}
+
+	out, err := d.runner.Run(ctx, "tmux", "-V")
+	if err != nil {
+		res.Status = StatusWarn
+		res.Detail = fmt.Sprintf("could not determine tmux version: %v", err)
+		res.Fix = "check that 'tmux -V' runs successfully"
+		return res
+	}
+
+	version := strings.TrimSpace(string(out))
+	major, minor, ok := parseTmuxVersion(version)
+	if !ok {
+		res.Status = StatusWarn
+		res.Detail = fmt.Sprintf("unrecognized tmux version %q", version)
+		res.Fix = fmt.Sprintf("make sure tmux %.1f or newer is installed", minTmuxVersion)
+		return res
+	}
+
+	if float64(major)+float64(minor)/10 < minTmuxVersion {
+		res.Status = StatusFail
+		res.Detail = fmt.Sprintf("%s is too old", version)
+		res.Fix = fmt.Sprintf("upgrade to tmux %.1f or newer", minTmuxVersion)
+		return res
+	}
+
+	res.Status = StatusPass
+	res.Detail = version
+	return res
+}
+
+// parseTmuxVersion extracts the major and minor version from `tmux -V`
+// output such as "tmux 3.3a" or "tmux next-3.4".
+func parseTmuxVersion(output string) (major, minor int, ok bool) {
+	m := tmuxVersionPattern.FindStringSubmatch(output)
+	if len(m) < 3 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(m[2])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+func (d *Doctor) checkTmuxSession() Result {
+	res := Result{Name: "tmux session"}
+	if d.getenv("TMUX") == "" {
+		res.Status = StatusFail
+		res.Detail = "TMUX is not set; bdb must run inside a tmux session"
+		res.Fix = "start tmux first: tmux"
+		return res
+	}
+	res.Status = StatusPass
+	res.Detail = "running inside tmux"
+	return res
+}
+
+func (d *Doctor) checkBdBinary() Result {
+	res := Result{Name: "bd"}
+	path, err := d.lookPath("bd")
+	if err != nil {
+		res.Status = StatusWarn
+		res.Detail = "bd not found on PATH; ticket details, port detection and server autostart are unavailable"
+		res.Fix = "install the beads CLI and make sure 'bd' is on PATH"
+		return res
+	}
+	res.Status = StatusPass
+	res.Detail = path
+	return res
+}
+
+func (d *Doctor) checkMetadata(beadsDir string) Result {
+	res := Result{Name: "metadata " + beadsDir}
+	metadata, err := dolt.LoadMetadata(beadsDir)
+	if err != nil {
+		res.Status = StatusFail
+		res.Detail = firstLine(err.Error())
+		res.Fix = "run 'bd init' in the project or pass --beads-dir"
+		return res
+	}
+	res.Status = StatusPass
+	res.Detail = fmt.Sprintf("database %q", metadata.DoltDatabase)
+	return res
+}
+
+func (d *Doctor) checkDolt(ctx context.Context, beadsDir string, conn domain.DoltConnection) Result {
+	res := Result{Name: "dolt " + beadsDir}
+	probeCtx, cancel := context.WithTimeout(ctx, doltProbeTimeout)
+	defer cancel()
+
+	if err := d.probeDolt(probeCtx, beadsDir, conn); err != nil {
+		res.Status = StatusFail
+		res.Detail = firstLine(err.Error())
+		if dolt.IsConnectionError(err) {
+			res.Fix = "start the server with 'bd dolt start' or set general.autostart_dolt in config"
+		} else {
+			res.Fix = "run 'bd init' to initialize or repair the beads schema"
+		}
+		return res
+	}
+	res.Status = StatusPass
+	res.Detail = "server reachable, ready_issues view present"
+	return res
+}
+
+func (d *Doctor) checkConfig(path string) (*domain.Config, Result) {
+	res := Result{Name: "config"}
+	cfg, err := d.loader.Load(path)
+	if err != nil {
+		res.Status = StatusFail
+		res.Detail = firstLine(err.Error())
+		res.Fix = "run 'bdb init' or copy config.example.yaml to " + path
+		return nil, res
+	}
+	res.Status = StatusPass
+	res.Detail = fmt.Sprintf("%s (%d harness(es))", path, len(cfg.Harnesses))
+	return cfg, res
+}
+
+func (d *Doctor) checkHarnessBinaries(cfg *domain.Config) []Result {
+	results := make([]Result, 0, len(cfg.Harnesses))
+	for _, h := range cfg.Harnesses {
+		res := Result{Name: "harness " + h.Name}
+		candidates := config.HarnessBinaryCandidates(h.Name)
+		if bin := config.ExtractCommandBinary(h.CommandTemplate); bin != "" && !strings.Contains(bin, "{{") {
+			candidates = appendUnique(candidates, bin)
+		}
+
+		found := ""
+		for _, candidate := range candidates {
+			if path, err := d.lookPath(candidate); err == nil {
+				found = path
+				break
+			}
+		}
+
+		if found == "" {
+			res.Status = StatusWarn
+			res.Detail = fmt.Sprintf("none of %s found on PATH", strings.Join(candidates, ", "))
+			res.Fix = fmt.Sprintf("install %s or remove the harness from config", h.Name)
+		} else {
+			res.Status = StatusPass
+			res.Detail = found
+		}
+		results = append(results, res)
+	}
+	return results
+}
+
+// checkModelsCache reports the state of the models.dev cache. The boolean
+// result is true when the cache was loaded into the registry.
+func (d *Doctor) checkModelsCache(maxAge time.Duration) (Result, bool) {
+	res := Result{Name: "models cache"}
+	if d.registry == nil {
+		res.Status = StatusWarn
+		res.Detail = "model registry unavailable"
+		res.Fix = "check that your home directory is accessible"
+		return res, false
+	}
+
+	path := d.registry.GetCachePath()
+	info, err := os.Stat(path)
+	if err != nil {
+		res.Status = StatusWarn
+		res.Detail = fmt.Sprintf("no cache at %s", path)
+		res.Fix = "run 'bdb update-models'"
+		return res, false
+	}
+
+	if err := d.registry.LoadCache(); err != nil {
+		res.Status = StatusFail
+		res.Detail = fmt.Sprintf("cache at %s is invalid: %v", path, err)
+		res.Fix = "run 'bdb update-models' to rebuild it"
+		return res, false
+	}
+
+	age := d.now().Sub(info.ModTime())
+	if age > maxAge {
+		res.Status = StatusWarn
+		res.Detail = fmt.Sprintf("last updated %s ago", age.Round(time.Hour))
+		res.Fix = "run 'bdb update-models'"
+		return res, true
+	}
+
+	res.Status = StatusPass
+	res.Detail = fmt.Sprintf("updated %s ago", age.Round(time.Minute))
+	return res, true
+}
+
+// checkProviderKeys verifies that every provider referenced by configured
+// models has its API key environment variables set.
+func (d *Doctor) checkProviderKeys(cfg *domain.Config) []Result {
+	providers := make(map[string]bool)
+	usesDiscover := false
+	for _, h := range cfg.Harnesses {
+		for _, model := range h.SupportedModels {
+			switch {
+			case model == discovery.KeywordDiscoverActive:
+				usesDiscover = true
+			case strings.HasPrefix(model, discovery.PrefixProvider):
+				providers[strings.TrimPrefix(model, discovery.PrefixProvider)] = true
+			case strings.Contains(model, "/"):
+				providerID, _, _ := strings.Cut(model, "/")
+				if _, known := d.registry.ProviderEnv(providerID); known {
+					providers[providerID] = true
+				}
+			}
+		}
+	}
+
+	var results []Result
+	if usesDiscover {
+		res := Result{Name: "providers discover:active"}
+		if n := d.registry.ActiveProviderCount(d.getenv); n == 0 {
+			res.Status = StatusWarn
+			res.Detail = "no provider has its API key set; discover:active expands to nothing"
+			res.Fix = "export the API key for at least one provider (e.g. ANTHROPIC_API_KEY)"
+		} else {
+			res.Status = StatusPass
+			res.Detail = fmt.Sprintf("%d active provider(s)", n)
+		}
+		results = append(results, res)
+	}
+
+	ids := make([]string, 0, len(providers))
+	for id := range providers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		results = append(results, d.checkProvider(id))
+	}
+	return results
+}
+
+func (d *Doctor) checkProvider(id string) Result {
+	res := Result{Name: "provider " + id}
+	env, known := d.registry.ProviderEnv(id)
+	if !known {
+		res.Status = StatusWarn
+		res.Detail = "unknown provider in models cache"
+		res.Fix = "check the provider ID or run 'bdb update-models'"
+		return res
+	}
+
+	var missing []string
+	for _, key := range env {
+		if d.getenv(key) == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		res.Status = StatusWarn
+		res.Detail = "missing " + strings.Join(missing, ", ")
+		res.Fix = "export " + strings.Join(missing, ", ") + " in your shell profile"
+		return res
+	}
+
+	res.Status = StatusPass
+	res.Detail = "API key set"
+	return res
+}
+
+func (d *Doctor) checkNerdFont() Result {
+	res := Result{Name: "nerd font"}
+	if d.detectNerdFont == nil || !d.detectNerdFont() {
+		res.Status = StatusWarn
+		res.Detail = "no Nerd Font detected; icons fall back to plain text"
+		res.Fix = "install a Nerd Font (https://www.nerdfonts.com) and use it in your terminal"
+		return res
+	}
+	res.Status = StatusPass
+	res.Detail = "Nerd Font detected"
+	return res
+}
+
+func appendUnique(values []string, v string) []string {
+	for _, existing := range values {
+		if existing == v {
+			return values
+		}
+	}
+	return append(values, v)
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
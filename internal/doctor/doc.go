@@ -0,0 +1,14 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package doctor implements environment diagnostics for bdb.
+//
+// Each check inspects one prerequisite (tmux, the bd CLI, the beads
+// metadata, Dolt connectivity, config, harness binaries, provider keys,
+// the models cache and fonts) and reports pass, warn or fail together
+// with a suggested fix. External interactions are injected so the checks
+// can be exercised in tests without touching the host system.
+package doctor
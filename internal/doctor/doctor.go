@@ -0,0 +1,224 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/data/dolt"
+	"github.com/megatherium/blunderbust/internal/discovery"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/exec/tmux"
+)
+
+// Status is the outcome of a single diagnostic check.
+type Status int
+
+const (
+	// StatusPass means the prerequisite is satisfied.
+	StatusPass Status = iota
+	// StatusWarn means bdb can run, but some functionality is degraded.
+	StatusWarn
+	// StatusFail means bdb will not work until the problem is fixed.
+	StatusFail
+)
+
+// String returns the label printed in the doctor report.
+func (s Status) String() string {
+	switch s {
+	case StatusPass:
+		return "PASS"
+	case StatusWarn:
+		return "WARN"
+	case StatusFail:
+		return "FAIL"
+	default:
+		return "????"
+	}
+}
+
+// Result describes the outcome of one check.
+type Result struct {
+	Name   string
+	Status Status
+	Detail string
+	// Fix is a suggested remedy; empty for passing checks.
+	Fix string
+}
+
+// Options selects what the doctor inspects.
+type Options struct {
+	// ConfigPath is the config file to validate.
+	ConfigPath string
+	// BeadsDirs lists the beads directories whose metadata and Dolt
+	// connectivity are checked. If the config defines workspace projects,
+	// their beads directories are checked as well.
+	BeadsDirs []string
+	// DSN overrides the Dolt DSN of every beads directory, like --dsn.
+	DSN string
+	// CacheMaxAge is how old the models cache may be before a warning is
+	// reported. Defaults to DefaultCacheMaxAge.
+	CacheMaxAge time.Duration
+}
+
+// DefaultCacheMaxAge is the models cache age after which doctor suggests a refresh.
+const DefaultCacheMaxAge = 7 * 24 * time.Hour
+
+// minTmuxVersion is the oldest tmux supporting `new-window -e`, which the launcher relies on.
+const minTmuxVersion = 3.0
+
+// Doctor runs environment diagnostics.
+type Doctor struct {
+	runner         tmux.CommandRunner
+	loader         config.Loader
+	registry       *discovery.Registry
+	lookPath       func(file string) (string, error)
+	getenv         func(key string) string
+	probeDolt      func(ctx context.Context, beadsDir string, conn domain.DoltConnection) error
+	detectNerdFont func() bool
+	now            func() time.Time
+}
+
+// New creates a Doctor that uses the real host environment.
+// registry may be nil, in which case model-related checks are skipped.
+func New(runner tmux.CommandRunner, loader config.Loader, registry *discovery.Registry, detectNerdFont func() bool) *Doctor {
+	return &Doctor{
+		runner:         runner,
+		loader:         loader,
+		registry:       registry,
+		lookPath:       osexec.LookPath,
+		getenv:         os.Getenv,
+		probeDolt:      dolt.Probe,
+		detectNerdFont: detectNerdFont,
+		now:            time.Now,
+	}
+}
+
+// Run executes every check and returns the results in report order.
+func (d *Doctor) Run(ctx context.Context, opts Options) []Result {
+	if opts.CacheMaxAge <= 0 {
+		opts.CacheMaxAge = DefaultCacheMaxAge
+	}
+
+	results := []Result{
+		d.checkTmuxBinary(ctx),
+		d.checkTmuxSession(),
+		d.checkBdBinary(),
+	}
+
+	cfg, cfgResult := d.checkConfig(opts.ConfigPath)
+	beadsDirs := collectBeadsDirs(opts.BeadsDirs, cfg)
+	for _, beadsDir := range beadsDirs {
+		metaResult := d.checkMetadata(beadsDir)
+		results = append(results, metaResult)
+		if metaResult.Status == StatusFail {
+			continue
+		}
+		conn := doltConnectionFor(beadsDir, cfg)
+		if opts.DSN != "" {
+			conn.DSN = opts.DSN
+		}
+		results = append(results, d.checkDolt(ctx, beadsDir, conn))
+	}
+
+	results = append(results, cfgResult)
+	if cfg != nil {
+		results = append(results, d.checkHarnessBinaries(cfg)...)
+	}
+
+	cacheResult, cacheLoaded := d.checkModelsCache(opts.CacheMaxAge)
+	results = append(results, cacheResult)
+	if cfg != nil && cacheLoaded {
+		results = append(results, d.checkProviderKeys(cfg)...)
+	}
+
+	results = append(results, d.checkNerdFont())
+	return results
+}
+
+// collectBeadsDirs merges explicit beads directories with those of workspace
+// projects, dropping duplicates while preserving order.
+func collectBeadsDirs(explicit []string, cfg *domain.Config) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	add := func(dir string) {
+		if dir == "" || seen[dir] {
+			return
+		}
+		seen[dir] = true
+		dirs = append(dirs, dir)
+	}
+
+	for _, dir := range explicit {
+		add(dir)
+	}
+	if cfg != nil {
+		for _, p := range cfg.Workspace.Projects {
+			add(beadsDirFor(p.Dir))
+		}
+	}
+	return dirs
+}
+
+// doltConnectionFor returns the connection profile of the workspace project
+// owning beadsDir, if any.
+func doltConnectionFor(beadsDir string, cfg *domain.Config) domain.DoltConnection {
+	if cfg == nil {
+		return domain.DoltConnection{}
+	}
+	beadsDir = absDir(beadsDir)
+	for _, p := range cfg.Workspace.Projects {
+		if absDir(beadsDirFor(p.Dir)) == beadsDir {
+			return p.Dolt
+		}
+	}
+	return domain.DoltConnection{}
+}
+
+// absDir returns dir as a clean absolute path, or just cleaned if the
+// working directory is unknown.
+func absDir(dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return filepath.Clean(dir)
+}
+
+// Summary counts results by status.
+func Summary(results []Result) (passed, warned, failed int) {
+	for _, r := range results {
+		switch r.Status {
+		case StatusPass:
+			passed++
+		case StatusWarn:
+			warned++
+		case StatusFail:
+			failed++
+		}
+	}
+	return passed, warned, failed
+}
+
+// Print writes a human-readable report of results to w.
+func Print(w io.Writer, results []Result) {
+	for _, r := range results {
+		fmt.Fprintf(w, "[%s] %s: %s\n", r.Status, r.Name, r.Detail)
+		if r.Fix != "" && r.Status != StatusPass {
+			fmt.Fprintf(w, "       fix: %s\n", r.Fix)
+		}
+	}
+
+	passed, warned, failed := Summary(results)
+	fmt.Fprintf(w, "\n%d passed, %d warnings, %d failed\n", passed, warned, failed)
+}
@@ -0,0 +1,355 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/discovery"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/exec/tmux"
+)
+
+type stubLoader struct {
+	cfg *domain.Config
+	err error
+}
+
+func (s stubLoader) Load(string) (*domain.Config, error) { return s.cfg, s.err }
+func (s stubLoader) Save(string, *domain.Config) error   { return nil }
+
+func lookPathIn(found ...string) func(string) (string, error) {
+	set := make(map[string]bool, len(found))
+	for _, f := range found {
+		set[f] = true
+	}
+	return func(file string) (string, error) {
+		if set[file] {
+			return "/usr/bin/" + file, nil
+		}
+		return "", errors.New("not found")
+	}
+}
+
+func newTestDoctor(t *testing.T, cfg *domain.Config) (*Doctor, *tmux.FakeRunner) {
+	t.Helper()
+	runner := tmux.NewFakeRunner()
+	runner.SetOutput("tmux", []string{"-V"}, []byte("tmux 3.3a\n"))
+
+	registry, err := discovery.NewRegistry(t.TempDir())
+	require.NoError(t, err)
+
+	d := New(runner, stubLoader{cfg: cfg}, registry, func() bool { return true })
+	d.lookPath = lookPathIn("tmux", "bd", "opencode")
+	d.getenv = func(key string) string {
+		if key == "TMUX" {
+			return "/tmp/tmux-1000/default,1,0"
+		}
+		return ""
+	}
+	d.probeDolt = func(context.Context, string, domain.DoltConnection) error { return nil }
+	return d, runner
+}
+
+func writeMetadata(t *testing.T, projectDir, content string) string {
+	t.Helper()
+	beadsDir := filepath.Join(projectDir, ".beads")
+	require.NoError(t, os.MkdirAll(beadsDir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(beadsDir, "metadata.json"), []byte(content), 0o600))
+	return beadsDir
+}
+
+func writeModelsCache(t *testing.T, registry *discovery.Registry, modTime time.Time) {
+	t.Helper()
+	cache := `{"anthropic":{"id":"anthropic","env":["ANTHROPIC_API_KEY"],"models":{"claude":{"id":"claude"}}}}`
+	path := registry.GetCachePath()
+	require.NoError(t, os.WriteFile(path, []byte(cache), 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func findResult(t *testing.T, results []Result, name string) Result {
+	t.Helper()
+	for _, r := range results {
+		if r.Name == name {
+			return r
+		}
+	}
+	t.Fatalf("no result named %q in %+v", name, results)
+	return Result{}
+}
+
+func TestParseTmuxVersion(t *testing.T) {
+	tests := []struct {
+		input        string
+		major, minor int
+		ok           bool
+	}{
+		{"tmux 3.3a", 3, 3, true},
+		{"tmux 2.9", 2, 9, true},
+		{"tmux next-3.4", 3, 4, true},
+		{"tmux master", 0, 0, false},
+	}
+	for _, tt := range tests {
+		major, minor, ok := parseTmuxVersion(tt.input)
+		assert.Equal(t, tt.ok, ok, tt.input)
+		assert.Equal(t, tt.major, major, tt.input)
+		assert.Equal(t, tt.minor, minor, tt.input)
+	}
+}
+
+func TestCheckTmuxBinary(t *testing.T) {
+	t.Run("missing binary fails", func(t *testing.T) {
+		d, _ := newTestDoctor(t, nil)
+		d.lookPath = lookPathIn()
+		res := d.checkTmuxBinary(context.Background())
+		assert.Equal(t, StatusFail, res.Status)
+		assert.NotEmpty(t, res.Fix)
+	})
+
+	t.Run("old version fails", func(t *testing.T) {
+		d, runner := newTestDoctor(t, nil)
+		runner.SetOutput("tmux", []string{"-V"}, []byte("tmux 2.9a"))
+		res := d.checkTmuxBinary(context.Background())
+		assert.Equal(t, StatusFail, res.Status)
+	})
+
+	t.Run("supported version passes", func(t *testing.T) {
+		d, _ := newTestDoctor(t, nil)
+		res := d.checkTmuxBinary(context.Background())
+		assert.Equal(t, StatusPass, res.Status)
+		assert.Equal(t, "tmux 3.3a", res.Detail)
+	})
+}
+
+func TestCheckTmuxSession(t *testing.T) {
+	d, _ := newTestDoctor(t, nil)
+	assert.Equal(t, StatusPass, d.checkTmuxSession().Status)
+
+	d.getenv = func(string) string { return "" }
+	assert.Equal(t, StatusFail, d.checkTmuxSession().Status)
+}
+
+func TestCheckDolt_ClassifiesErrors(t *testing.T) {
+	d, _ := newTestDoctor(t, nil)
+
+	d.probeDolt = func(context.Context, string, domain.DoltConnection) error {
+		return errors.New("cannot connect to Dolt server at 127.0.0.1:3307: dial tcp: connection refused")
+	}
+	res := d.checkDolt(context.Background(), ".beads", domain.DoltConnection{})
+	assert.Equal(t, StatusFail, res.Status)
+	assert.Contains(t, res.Fix, "bd dolt start")
+
+	d.probeDolt = func(context.Context, string, domain.DoltConnection) error {
+		return errors.New("schema verification failed: unable to query ready_issues view")
+	}
+	res = d.checkDolt(context.Background(), ".beads", domain.DoltConnection{})
+	assert.Equal(t, StatusFail, res.Status)
+	assert.Contains(t, res.Fix, "bd init")
+}
+
+func TestCheckHarnessBinaries(t *testing.T) {
+	cfg := &domain.Config{Harnesses: []domain.Harness{
+		{Name: "opencode", CommandTemplate: "opencode --model {{.Model}}"},
+		{Name: "kilocode", CommandTemplate: "kilo"},
+	}}
+	d, _ := newTestDoctor(t, cfg)
+
+	results := d.checkHarnessBinaries(cfg)
+	require.Len(t, results, 2)
+	assert.Equal(t, StatusPass, results[0].Status)
+	assert.Equal(t, StatusWarn, results[1].Status)
+	assert.Contains(t, results[1].Detail, "kilocode-cli")
+}
+
+func TestCheckModelsCache(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("missing cache warns", func(t *testing.T) {
+		d, _ := newTestDoctor(t, nil)
+		res, loaded := d.checkModelsCache(DefaultCacheMaxAge)
+		assert.Equal(t, StatusWarn, res.Status)
+		assert.False(t, loaded)
+	})
+
+	t.Run("stale cache warns but loads", func(t *testing.T) {
+		d, _ := newTestDoctor(t, nil)
+		d.now = func() time.Time { return now }
+		writeModelsCache(t, d.registry, now.Add(-30*24*time.Hour))
+
+		res, loaded := d.checkModelsCache(DefaultCacheMaxAge)
+		assert.Equal(t, StatusWarn, res.Status)
+		assert.True(t, loaded)
+	})
+
+	t.Run("fresh cache passes", func(t *testing.T) {
+		d, _ := newTestDoctor(t, nil)
+		d.now = func() time.Time { return now }
+		writeModelsCache(t, d.registry, now.Add(-time.Hour))
+
+		res, loaded := d.checkModelsCache(DefaultCacheMaxAge)
+		assert.Equal(t, StatusPass, res.Status)
+		assert.True(t, loaded)
+	})
+}
+
+func TestCheckProviderKeys(t *testing.T) {
+	cfg := &domain.Config{Harnesses: []domain.Harness{{
+		Name:            "opencode",
+		SupportedModels: []string{"provider:anthropic", "anthropic/claude", "custom-model", "provider:nope"},
+	}}}
+	d, _ := newTestDoctor(t, cfg)
+	writeModelsCache(t, d.registry, time.Now())
+	require.NoError(t, d.registry.LoadCache())
+
+	results := d.checkProviderKeys(cfg)
+	require.Len(t, results, 2)
+	assert.Equal(t, StatusWarn, findResult(t, results, "provider anthropic").Status)
+	assert.Contains(t, findResult(t, results, "provider anthropic").Detail, "ANTHROPIC_API_KEY")
+	assert.Equal(t, StatusWarn, findResult(t, results, "provider nope").Status)
+
+	d.getenv = func(key string) string {
+		if key == "ANTHROPIC_API_KEY" {
+			return "sk-test"
+		}
+		return ""
+	}
+	results = d.checkProviderKeys(cfg)
+	assert.Equal(t, StatusPass, findResult(t, results, "provider anthropic").Status)
+}
+
+func TestCheckProviderKeys_DiscoverActive(t *testing.T) {
+	cfg := &domain.Config{Harnesses: []domain.Harness{{
+		Name:            "opencode",
+		SupportedModels: []string{discovery.KeywordDiscoverActive},
+	}}}
+	d, _ := newTestDoctor(t, cfg)
+	writeModelsCache(t, d.registry, time.Now())
+	require.NoError(t, d.registry.LoadCache())
+
+	// The process environment must not leak into the result.
+	t.Setenv("ANTHROPIC_API_KEY", "sk-process")
+	results := d.checkProviderKeys(cfg)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusWarn, results[0].Status)
+
+	d.getenv = func(key string) string {
+		if key == "ANTHROPIC_API_KEY" {
+			return "sk-test"
+		}
+		return ""
+	}
+	results = d.checkProviderKeys(cfg)
+	assert.Equal(t, StatusPass, results[0].Status)
+	assert.Equal(t, "1 active provider(s)", results[0].Detail)
+}
+
+func TestRun_ChecksWorkspaceProjects(t *testing.T) {
+	projectDir := t.TempDir()
+	writeMetadata(t, projectDir, `{"backend":"dolt","dolt_database":"beads_bb"}`)
+	missingDir := t.TempDir()
+
+	cfg := &domain.Config{
+		Harnesses: []domain.Harness{{Name: "opencode", CommandTemplate: "opencode"}},
+		Workspace: domain.Workspace{Projects: []domain.Project{{Dir: projectDir, Name: "bb"}}},
+	}
+	d, _ := newTestDoctor(t, cfg)
+
+	var probed []string
+	d.probeDolt = func(_ context.Context, beadsDir string, _ domain.DoltConnection) error {
+		probed = append(probed, beadsDir)
+		return nil
+	}
+
+	results := d.Run(context.Background(), Options{BeadsDirs: []string{filepath.Join(missingDir, ".beads")}})
+
+	assert.Equal(t, StatusFail, findResult(t, results, "metadata "+filepath.Join(missingDir, ".beads")).Status)
+	assert.Equal(t, StatusPass, findResult(t, results, "metadata "+filepath.Join(projectDir, ".beads")).Status)
+	assert.Equal(t, []string{filepath.Join(projectDir, ".beads")}, probed, "dolt is only probed when metadata is valid")
+	assert.Equal(t, StatusPass, findResult(t, results, "config").Status)
+	assert.Equal(t, StatusPass, findResult(t, results, "harness opencode").Status)
+	assert.Equal(t, StatusPass, findResult(t, results, "nerd font").Status)
+}
+
+func TestRun_ProbesWithConnectionProfiles(t *testing.T) {
+	projectDir := t.TempDir()
+	writeMetadata(t, projectDir, `{"backend":"dolt","dolt_database":"beads_bb"}`)
+	otherDir := t.TempDir()
+	writeMetadata(t, otherDir, `{"backend":"dolt","dolt_database":"beads_other"}`)
+
+	cfg := &domain.Config{
+		Harnesses: []domain.Harness{{Name: "opencode", CommandTemplate: "opencode"}},
+		Workspace: domain.Workspace{Projects: []domain.Project{
+			{Dir: projectDir, Name: "bb", Dolt: domain.DoltConnection{Socket: "/run/dolt.sock"}},
+		}},
+	}
+	d, _ := newTestDoctor(t, cfg)
+
+	conns := map[string]domain.DoltConnection{}
+	d.probeDolt = func(_ context.Context, beadsDir string, conn domain.DoltConnection) error {
+		conns[beadsDir] = conn
+		return nil
+	}
+
+	d.Run(context.Background(), Options{BeadsDirs: []string{filepath.Join(otherDir, ".beads")}})
+	assert.Equal(t, domain.DoltConnection{Socket: "/run/dolt.sock"}, conns[filepath.Join(projectDir, ".beads")])
+	assert.True(t, conns[filepath.Join(otherDir, ".beads")].IsZero())
+
+	d.Run(context.Background(), Options{DSN: "root@tcp(db:3306)/"})
+	assert.Equal(t, domain.DoltConnection{Socket: "/run/dolt.sock", DSN: "root@tcp(db:3306)/"}, conns[filepath.Join(projectDir, ".beads")])
+}
+
+func TestDoltConnectionFor_NormalizesPaths(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	conn := domain.DoltConnection{Socket: "/run/dolt.sock"}
+	cfg := &domain.Config{Workspace: domain.Workspace{Projects: []domain.Project{
+		{Dir: "app", Dolt: conn},
+		{Dir: filepath.Join(dir, "lib") + "/", Dolt: domain.DoltConnection{DSN: "root@tcp(db:3306)/"}},
+	}}}
+
+	assert.Equal(t, conn, doltConnectionFor(filepath.Join(dir, "app", ".beads"), cfg), "relative project dir")
+	assert.Equal(t, conn, doltConnectionFor("./app/.beads/", cfg), "unclean beads dir")
+	assert.Equal(t, "root@tcp(db:3306)/", doltConnectionFor("lib/.beads", cfg).DSN, "trailing slash on the project dir")
+	assert.True(t, doltConnectionFor("other/.beads", cfg).IsZero())
+}
+
+func TestRun_ConfigErrorSkipsHarnessChecks(t *testing.T) {
+	d, _ := newTestDoctor(t, nil)
+	d.loader = stubLoader{err: errors.New("failed to read config file: not found")}
+
+	results := d.Run(context.Background(), Options{ConfigPath: "missing.yaml"})
+
+	assert.Equal(t, StatusFail, findResult(t, results, "config").Status)
+	for _, r := range results {
+		assert.NotContains(t, r.Name, "harness ")
+	}
+}
+
+func TestPrint(t *testing.T) {
+	var buf bytes.Buffer
+	Print(&buf, []Result{
+		{Name: "tmux", Status: StatusPass, Detail: "tmux 3.3a", Fix: "ignored"},
+		{Name: "bd", Status: StatusWarn, Detail: "bd not found", Fix: "install bd"},
+		{Name: "config", Status: StatusFail, Detail: "parse error", Fix: "fix yaml"},
+	})
+
+	out := buf.String()
+	assert.Contains(t, out, "[PASS] tmux: tmux 3.3a")
+	assert.NotContains(t, out, "ignored")
+	assert.Contains(t, out, "[WARN] bd: bd not found\n       fix: install bd")
+	assert.Contains(t, out, "[FAIL] config: parse error")
+	assert.Contains(t, out, "1 passed, 1 warnings, 1 failed")
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package domain
+
+import "time"
+
+// AutopilotConfig controls unattended dispatch of ready tickets.
+type AutopilotConfig struct {
+	// PollInterval is how often each project's LatestUpdate is checked.
+	PollInterval time.Duration
+	// MaxConcurrent caps running agents across all projects (0 = unlimited).
+	MaxConcurrent int
+	// MaxPerProject caps running agents per project (0 = unlimited).
+	MaxPerProject int
+	// PauseFile suspends dispatching while it exists.
+	PauseFile string
+	// Rules are evaluated in order; the first match decides the launch.
+	Rules []AutopilotRule
+}
+
+// AutopilotRule maps tickets to a harness/model/agent selection.
+// Empty match fields match every ticket.
+type AutopilotRule struct {
+	Name        string
+	IssueTypes  []string
+	PriorityMin *int
+	PriorityMax *int
+	TitleRegex  string
+	Harness     string
+	Model       string
+	Agent       string
+}
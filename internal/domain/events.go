@@ -0,0 +1,29 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package domain
+
+import "time"
+
+// EventsConfig controls the lifecycle event log and shell hooks.
+type EventsConfig struct {
+	// LogFile is the JSONL event log; empty means the default state path.
+	LogFile string
+	// DisableLog turns the event log off.
+	DisableLog bool
+	// HookTimeout bounds each hook invocation.
+	HookTimeout time.Duration
+	// Hooks run for matching events, in order.
+	Hooks []EventHook
+}
+
+// EventHook is a shell command run for lifecycle events.
+// An empty Events list matches every event.
+type EventHook struct {
+	Name    string
+	Events  []string
+	Command string
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // LauncherType represents the type of launcher that started an agent.
 type LauncherType int
@@ -40,3 +43,40 @@ type PersistedRunningAgent struct {
 	StartedAt     time.Time
 	LastSeen      time.Time
 }
+
+// AgentRun is a finished agent in the run history.
+type AgentRun struct {
+	ID           int
+	ProjectDir   string
+	WorktreePath string
+	LauncherID   string
+	Ticket       string
+	TicketTitle  string
+	HarnessName  string
+	Model        string
+	Agent        string
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	// Status is AgentCompleted or AgentFailed, or AgentUnknown when the
+	// agent ended while bdb was not watching it. ExitCode is nil when the
+	// agent's exit status is unknown.
+	Status   AgentStatus
+	ExitCode *int
+	// LinesAdded and LinesRemoved count the changes in the worktree when
+	// the run finished.
+	LinesAdded   int
+	LinesRemoved int
+}
+
+// Duration returns how long the run took.
+func (r AgentRun) Duration() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// Outcome describes how the run ended, e.g. "failed with exit code 2".
+func (r AgentRun) Outcome() string {
+	if r.ExitCode == nil {
+		return r.Status.String()
+	}
+	return fmt.Sprintf("%s with exit code %d", r.Status, *r.ExitCode)
+}
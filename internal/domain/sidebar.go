@@ -42,6 +42,9 @@ const (
 	AgentRunning AgentStatus = iota
 	AgentCompleted
 	AgentFailed
+	// AgentUnknown is the status of a run that ended while bdb was not
+	// watching it, so whether it succeeded is not known.
+	AgentUnknown
 )
 
 // String returns the string representation of the agent status.
@@ -58,6 +61,17 @@ func (s AgentStatus) String() string {
 	}
 }
 
+// ParseAgentStatus returns the status named s by String, and false for
+// unknown names.
+func ParseAgentStatus(s string) (AgentStatus, bool) {
+	for _, status := range []AgentStatus{AgentRunning, AgentCompleted, AgentFailed, AgentUnknown} {
+		if status.String() == s {
+			return status, true
+		}
+	}
+	return AgentRunning, false
+}
+
 // SidebarNode represents a node in the sidebar tree hierarchy.
 // Nodes can be projects (containing worktrees), worktrees, harnesses, or agents.
 type SidebarNode struct {
@@ -106,4 +120,6 @@ type AgentInfo struct {
 	HarnessName  string
 	ModelName    string
 	AgentName    string
+	FinishedAt   time.Time // zero while running
+	ExitCode     *int      // nil unless the exit status is known
 }
@@ -6,7 +6,10 @@
 
 package domain
 
-import "time"
+import (
+	"log/slog"
+	"time"
+)
 
 // Ticket represents a beads issue for display and context in the TUI.
 // Fields are cherry-picked from the beads issues table schema.
@@ -20,6 +23,41 @@ type Ticket struct {
 	Assignee    string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	// Blockers are the IDs of the open issues blocking this one. They are
+	// only loaded outside the ready scope, where no ticket has any.
+	Blockers []string
+}
+
+// IsBlocked reports whether the ticket has open blockers or the blocked status.
+func (t Ticket) IsBlocked() bool {
+	return t.Status == "blocked" || len(t.Blockers) > 0
+}
+
+// TicketDetail is the full beads issue behind a Ticket, including the
+// fields, labels, dependencies and comments shown in the detail pane.
+type TicketDetail struct {
+	Ticket
+	Design             string
+	AcceptanceCriteria string
+	Notes              string
+	Labels             []string
+	Dependencies       []TicketDependency
+	Comments           []TicketComment
+}
+
+// TicketDependency is an issue the ticket depends on.
+type TicketDependency struct {
+	ID     string
+	Title  string
+	Status string
+	Type   string // Dependency type, e.g. "blocks" or "parent-child"
+}
+
+// TicketComment is a comment on a ticket.
+type TicketComment struct {
+	Author    string
+	Text      string
+	CreatedAt time.Time
 }
 
 // Harness defines a development environment configuration that can be
@@ -67,8 +105,21 @@ type LauncherConfig struct {
 // GeneralConfig holds general application settings.
 type GeneralConfig struct {
 	AutostartDolt bool
+	// AgentStore is where running agents are persisted: AgentStoreDolt or
+	// AgentStoreFile.
+	AgentStore string
 }
 
+// Agent store backends selectable with GeneralConfig.AgentStore.
+const (
+	// AgentStoreDolt keeps running agents in the project's Dolt database,
+	// falling back to AgentStoreFile for projects without one.
+	AgentStoreDolt = "dolt"
+	// AgentStoreFile keeps running agents in a file in the user's state
+	// directory.
+	AgentStoreFile = "file"
+)
+
 // Defaults holds optional default selections for quickdraw/blitzdraw modes.
 type Defaults struct {
 	Harness string
@@ -83,6 +134,9 @@ type Config struct {
 	Defaults  *Defaults
 	General   *GeneralConfig
 	Workspace Workspace
+	Autopilot *AutopilotConfig
+	Events    *EventsConfig
+	Writeback *WritebackConfig
 }
 
 // Workspace represents a collection of projects defined in configuration.
@@ -95,18 +149,55 @@ type Workspace struct {
 type Project struct {
 	Dir  string
 	Name string
+	// Dolt overrides how the project connects to its Dolt server.
+	Dolt DoltConnection
+}
+
+// DoltConnection is a connection profile for a Dolt sql-server. Empty fields
+// fall back to the project's metadata.json and then to the defaults.
+type DoltConnection struct {
+	// DSN is a go-sql-driver/mysql DSN. It replaces Host, Port, Socket, User
+	// and Database; a DSN without user or database takes them from the
+	// fields below and metadata.json.
+	DSN      string
+	Host     string
+	Port     int
+	Socket   string // Unix socket path; wins over Host and Port
+	User     string
+	Database string
+	// PasswordFile and PasswordCommand supply the password when the DSN has
+	// none. The command runs through sh and its trimmed stdout is used.
+	// Without either, BEADS_DOLT_PASSWORD is used.
+	PasswordFile    string
+	PasswordCommand string
+	// TLS is the driver's TLS mode: "true", "false", "skip-verify" or
+	// "preferred". TLSCA, TLSCert and TLSKey name PEM files and imply "true".
+	TLS           string
+	TLSCA         string
+	TLSCert       string
+	TLSKey        string
+	TLSServerName string
+}
+
+// IsZero reports whether the profile overrides nothing.
+func (c DoltConnection) IsZero() bool {
+	return c == DoltConnection{}
 }
 
 // AppOptions configure the application at a global level.
 type AppOptions struct {
-	DryRun        bool
-	ConfigPath    string
-	TUIConfigPath string
-	Debug         bool
-	BeadsDir      string
-	DSN           string
-	Demo          bool
-	AutostartDolt bool
-	TargetProject string // Optional: project path from CLI positional arg
-	Theme         string // UI Theme preference
+	DryRun         bool
+	ConfigPath     string
+	TUIConfigPath  string
+	Debug          bool
+	BeadsDir       string
+	DSN            string         // Overrides the DSN of every project's Dolt connection
+	DoltConnection DoltConnection // Connection profile of the project being opened
+	Demo           bool
+	AutostartDolt  bool
+	AgentStore     string       // AgentStoreDolt or AgentStoreFile; empty means AgentStoreDolt
+	TargetProject  string       // Optional: project path from CLI positional arg
+	Theme          string       // UI Theme preference
+	ThemesDir      string       // Directory of user theme YAML files
+	Logger         *slog.Logger // Structured file logger (nil = discard)
 }
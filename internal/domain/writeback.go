@@ -0,0 +1,32 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package domain
+
+// WritebackConfig controls what is written back to beads when agents are
+// launched and finish. Every step is off unless configured.
+type WritebackConfig struct {
+	// ClaimOnLaunch sets launched tickets to in_progress.
+	ClaimOnLaunch bool
+	// Assignee is set on launched tickets; empty leaves the assignee alone.
+	Assignee string
+	// CommentOnLaunch comments the harness, model and worktree on launch.
+	CommentOnLaunch bool
+	// CommentOnFinish comments the outcome when the agent exits or fails.
+	CommentOnFinish bool
+	// Author is the author of the comments.
+	Author string
+}
+
+// OnLaunch reports whether anything is written when an agent is launched.
+func (c *WritebackConfig) OnLaunch() bool {
+	return c != nil && (c.ClaimOnLaunch || c.Assignee != "" || c.CommentOnLaunch)
+}
+
+// OnFinish reports whether anything is written when an agent finishes.
+func (c *WritebackConfig) OnFinish() bool {
+	return c != nil && c.CommentOnFinish
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package events
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// Sink receives events from a Bus.
+type Sink interface {
+	Handle(ctx context.Context, ev Event) error
+}
+
+// Bus fans events out to its sinks. A nil Bus discards events.
+type Bus struct {
+	sinks []Sink
+}
+
+// NewBus creates a Bus delivering to sinks in order.
+func NewBus(sinks ...Sink) *Bus {
+	return &Bus{sinks: sinks}
+}
+
+// FromConfig builds a Bus with the event log and hooks configured in cfg.
+// A nil cfg enables only the event log at its default path.
+func FromConfig(cfg *domain.EventsConfig) (*Bus, error) {
+	if cfg == nil {
+		cfg = &domain.EventsConfig{}
+	}
+
+	var sinks []Sink
+	if !cfg.DisableLog {
+		path := cfg.LogFile
+		if path == "" {
+			var err error
+			if path, err = DefaultLogFile(); err != nil {
+				return nil, err
+			}
+		}
+		sinks = append(sinks, NewLog(path))
+	}
+	if len(cfg.Hooks) > 0 {
+		sinks = append(sinks, NewHooks(cfg.Hooks, cfg.HookTimeout))
+	}
+	return NewBus(sinks...), nil
+}
+
+// Emit stamps ev with the current time if unset and delivers it to every
+// sink, even when an earlier one fails. Sink errors are joined.
+func (b *Bus) Emit(ctx context.Context, ev Event) error {
+	if b == nil {
+		return nil
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	var errs []error
+	for _, sink := range b.sinks {
+		if err := sink.Handle(ctx, ev); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,13 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package events delivers agent, ticket and project lifecycle events.
+//
+// The TUI emits events such as agent.launched or agent.failed on a Bus,
+// which fans them out to sinks: a JSONL event log and user-configured
+// shell hooks that receive the event as environment variables and as
+// JSON on stdin.
+package events
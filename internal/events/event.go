@@ -0,0 +1,134 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package events
+
+import (
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// Type names a lifecycle event.
+type Type string
+
+// Lifecycle event types.
+const (
+	AgentLaunched   Type = "agent.launched"
+	AgentExited     Type = "agent.exited"
+	AgentFailed     Type = "agent.failed"
+	TicketRefreshed Type = "ticket.refreshed"
+	ProjectAdded    Type = "project.added"
+)
+
+// Types lists every event type, for validating hook configuration.
+var Types = []Type{AgentLaunched, AgentExited, AgentFailed, TicketRefreshed, ProjectAdded}
+
+// Event is one lifecycle event. Agent and Launch are set for agent events.
+type Event struct {
+	Type    Type      `json:"type"`
+	Time    time.Time `json:"time"`
+	Project string    `json:"project,omitempty"`
+	Agent   *Agent    `json:"agent,omitempty"`
+	Launch  *Launch   `json:"launch,omitempty"`
+	// Error is set on agent.failed events for launches that failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Agent is the JSON form of domain.AgentInfo.
+type Agent struct {
+	ID          string    `json:"id"`
+	LauncherID  string    `json:"launcher_id,omitempty"`
+	Worktree    string    `json:"worktree,omitempty"`
+	Status      string    `json:"status"`
+	StartedAt   time.Time `json:"started_at"`
+	Ticket      string    `json:"ticket,omitempty"`
+	TicketTitle string    `json:"ticket_title,omitempty"`
+	Harness     string    `json:"harness,omitempty"`
+	Model       string    `json:"model,omitempty"`
+	AgentName   string    `json:"agent,omitempty"`
+	ExitCode    *int      `json:"exit_code,omitempty"`
+}
+
+// Launch is the JSON form of domain.LaunchSpec. Harness environment
+// variables are left out so secrets never reach the log.
+type Launch struct {
+	Ticket     string `json:"ticket"`
+	Harness    string `json:"harness"`
+	Model      string `json:"model,omitempty"`
+	Agent      string `json:"agent,omitempty"`
+	Command    string `json:"command"`
+	Prompt     string `json:"prompt,omitempty"`
+	WorkDir    string `json:"workdir,omitempty"`
+	LauncherID string `json:"launcher_id,omitempty"`
+}
+
+// AgentFromInfo converts a domain agent to its event payload.
+func AgentFromInfo(info *domain.AgentInfo) *Agent {
+	if info == nil {
+		return nil
+	}
+	return &Agent{
+		ID:          info.ID,
+		LauncherID:  info.LauncherID,
+		Worktree:    info.WorktreePath,
+		Status:      info.Status.String(),
+		StartedAt:   info.StartedAt,
+		Ticket:      info.TicketID,
+		TicketTitle: info.TicketTitle,
+		Harness:     info.HarnessName,
+		Model:       info.ModelName,
+		AgentName:   info.AgentName,
+		ExitCode:    info.ExitCode,
+	}
+}
+
+// LaunchFromSpec converts a launch spec to its event payload.
+func LaunchFromSpec(spec *domain.LaunchSpec) *Launch {
+	if spec == nil {
+		return nil
+	}
+	return &Launch{
+		Ticket:     spec.Selection.Ticket.ID,
+		Harness:    spec.Selection.Harness.Name,
+		Model:      spec.Selection.Model,
+		Agent:      spec.Selection.Agent,
+		Command:    spec.RenderedCommand,
+		Prompt:     spec.RenderedPrompt,
+		WorkDir:    spec.WorkDir,
+		LauncherID: spec.LauncherID,
+	}
+}
+
+// Env returns the event as BDB_* environment variables for hooks.
+func (e Event) Env() []string {
+	env := []string{
+		"BDB_EVENT=" + string(e.Type),
+		"BDB_EVENT_TIME=" + e.Time.Format(time.RFC3339),
+	}
+	add := func(key, value string) {
+		if value != "" {
+			env = append(env, key+"="+value)
+		}
+	}
+	add("BDB_PROJECT", e.Project)
+	add("BDB_ERROR", e.Error)
+	if a := e.Agent; a != nil {
+		add("BDB_AGENT_ID", a.ID)
+		add("BDB_AGENT_STATUS", a.Status)
+		add("BDB_TICKET", a.Ticket)
+		add("BDB_TICKET_TITLE", a.TicketTitle)
+		add("BDB_HARNESS", a.Harness)
+		add("BDB_MODEL", a.Model)
+		add("BDB_AGENT", a.AgentName)
+		add("BDB_WORKTREE", a.Worktree)
+	}
+	if l := e.Launch; l != nil {
+		add("BDB_COMMAND", l.Command)
+		add("BDB_WORKDIR", l.WorkDir)
+	}
+	return env
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+type recordingSink struct {
+	events []Event
+	err    error
+}
+
+func (s *recordingSink) Handle(_ context.Context, ev Event) error {
+	s.events = append(s.events, ev)
+	return s.err
+}
+
+func testAgentEvent() Event {
+	return Event{
+		Type:    AgentExited,
+		Project: "/src/app",
+		Agent: AgentFromInfo(&domain.AgentInfo{
+			ID:          "bb-1",
+			Status:      domain.AgentCompleted,
+			TicketID:    "bb-1",
+			TicketTitle: "Fix crash",
+			HarnessName: "opencode",
+			ModelName:   "gpt-5",
+		}),
+	}
+}
+
+func TestBus_DeliversToAllSinks(t *testing.T) {
+	failing := &recordingSink{err: errors.New("boom")}
+	ok := &recordingSink{}
+	bus := NewBus(failing, ok)
+
+	err := bus.Emit(context.Background(), Event{Type: ProjectAdded, Project: "/src/app"})
+	require.EqualError(t, err, "boom")
+	require.Len(t, ok.events, 1, "later sinks still receive the event")
+	assert.False(t, ok.events[0].Time.IsZero())
+
+	var nilBus *Bus
+	assert.NoError(t, nilBus.Emit(context.Background(), Event{Type: ProjectAdded}))
+}
+
+func TestLog_AppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "events.jsonl")
+	bus := NewBus(NewLog(path))
+
+	require.NoError(t, bus.Emit(context.Background(), testAgentEvent()))
+	require.NoError(t, bus.Emit(context.Background(), Event{Type: TicketRefreshed, Project: "/src/app"}))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var got []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var ev Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &ev))
+		got = append(got, ev)
+	}
+	require.Len(t, got, 2)
+	assert.Equal(t, AgentExited, got[0].Type)
+	assert.Equal(t, "completed", got[0].Agent.Status)
+	assert.Equal(t, TicketRefreshed, got[1].Type)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestHooks_ReceiveEnvAndPayload(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "hook.out")
+	hooks := NewHooks([]domain.EventHook{
+		{Name: "record", Events: []string{"agent.exited"}, Command: `printf '%s %s\n' "$BDB_EVENT" "$BDB_TICKET" > "$OUT"; cat >> "$OUT"`},
+		{Name: "other", Events: []string{"project.added"}, Command: `echo wrong > "$OUT"`},
+	}, time.Second)
+	t.Setenv("OUT", out)
+
+	require.NoError(t, hooks.Handle(context.Background(), testAgentEvent()))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	firstLine, payload, _ := strings.Cut(string(data), "\n")
+	assert.Equal(t, "agent.exited bb-1", firstLine)
+
+	var ev Event
+	require.NoError(t, json.Unmarshal([]byte(payload), &ev))
+	assert.Equal(t, "Fix crash", ev.Agent.TicketTitle)
+}
+
+func TestHooks_ReportsFailures(t *testing.T) {
+	hooks := NewHooks([]domain.EventHook{
+		{Name: "broken", Command: "echo nope >&2; exit 3"},
+		{Command: "sleep 5"},
+	}, 200*time.Millisecond)
+
+	err := hooks.Handle(context.Background(), Event{Type: ProjectAdded})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "hook broken: exit status 3: nope")
+	assert.Contains(t, err.Error(), "hook #1: timed out after 200ms")
+}
+
+func TestFromConfig(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/state")
+	bus, err := FromConfig(nil)
+	require.NoError(t, err)
+	require.Len(t, bus.sinks, 1)
+	assert.Equal(t, "/state/blunderbust/events.jsonl", bus.sinks[0].(*Log).Path())
+
+	bus, err = FromConfig(&domain.EventsConfig{DisableLog: true, Hooks: []domain.EventHook{{Command: "true"}}})
+	require.NoError(t, err)
+	require.Len(t, bus.sinks, 1)
+	assert.IsType(t, &Hooks{}, bus.sinks[0])
+}
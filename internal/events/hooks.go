@@ -0,0 +1,100 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// DefaultHookTimeout is used when events.hook_timeout is omitted.
+const DefaultHookTimeout = 10 * time.Second
+
+// maxHookStderr caps how much of a failing hook's stderr is reported.
+const maxHookStderr = 512
+
+// Hooks runs shell commands for matching events. Each hook gets the event
+// as BDB_* environment variables and as JSON on stdin.
+type Hooks struct {
+	hooks   []domain.EventHook
+	timeout time.Duration
+}
+
+// Verify interface compliance at compile time.
+var _ Sink = (*Hooks)(nil)
+
+// NewHooks creates a hook runner. A zero timeout uses DefaultHookTimeout.
+func NewHooks(hooks []domain.EventHook, timeout time.Duration) *Hooks {
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+	return &Hooks{hooks: hooks, timeout: timeout}
+}
+
+// Handle runs every hook subscribed to ev.Type, one after another.
+func (h *Hooks) Handle(ctx context.Context, ev Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	var errs []error
+	for i, hook := range h.hooks {
+		if len(hook.Events) > 0 && !slices.Contains(hook.Events, string(ev.Type)) {
+			continue
+		}
+		if err := h.run(ctx, hook, ev, payload); err != nil {
+			errs = append(errs, fmt.Errorf("hook %s: %w", hookLabel(hook, i), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h *Hooks) run(ctx context.Context, hook domain.EventHook, ev Event, payload []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	cmd := osexec.CommandContext(ctx, "sh", "-c", hook.Command)
+	cmd.Env = append(os.Environ(), ev.Env()...)
+	cmd.Stdin = bytes.NewReader(payload)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	// Background children may keep stderr open; don't wait on them forever.
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s", h.timeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			if len(msg) > maxHookStderr {
+				msg = msg[len(msg)-maxHookStderr:]
+			}
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+func hookLabel(hook domain.EventHook, index int) string {
+	if hook.Name != "" {
+		return hook.Name
+	}
+	return fmt.Sprintf("#%d", index)
+}
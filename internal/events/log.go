@@ -0,0 +1,72 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/megatherium/blunderbust/internal/logging"
+)
+
+// Log appends events to a JSONL file, one event per line.
+type Log struct {
+	mu   sync.Mutex
+	path string
+}
+
+// Verify interface compliance at compile time.
+var _ Sink = (*Log)(nil)
+
+// NewLog creates a Log writing to path. The file and its directory are
+// created on the first event.
+func NewLog(path string) *Log {
+	return &Log{path: path}
+}
+
+// DefaultLogFile returns events.jsonl in bdb's state directory.
+func DefaultLogFile() (string, error) {
+	dir, err := logging.StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "events.jsonl"), nil
+}
+
+// Path returns the file the log writes to.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Handle appends ev to the log file.
+func (l *Log) Handle(_ context.Context, ev Event) error {
+	line, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create event log directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write event log: %w", err)
+	}
+	return f.Close()
+}
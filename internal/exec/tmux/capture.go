@@ -40,12 +40,28 @@ func (c *OutputCapture) cleanup() error {
 	return nil
 }
 
-// ReadOutput captures the current content of the tmux pane.
+// ReadOutput captures the full scrollback of the tmux pane, keeping the
+// escape sequences for colors and text attributes.
 func (c *OutputCapture) ReadOutput() ([]byte, error) {
 	if c.windowID == "" {
 		return nil, fmt.Errorf("window string is empty")
 	}
 
+	out, err := c.runner.Run(context.Background(), "tmux", "capture-pane", "-p", "-e", "-S", "-", "-t", c.windowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture pane: %w", err)
+	}
+
+	return []byte(out), nil
+}
+
+// ReadScreen captures the visible part of the tmux pane as plain text. It is
+// much cheaper than ReadOutput when only the latest lines are of interest.
+func (c *OutputCapture) ReadScreen() ([]byte, error) {
+	if c.windowID == "" {
+		return nil, fmt.Errorf("window string is empty")
+	}
+
 	out, err := c.runner.Run(context.Background(), "tmux", "capture-pane", "-p", "-t", c.windowID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to capture pane: %w", err)
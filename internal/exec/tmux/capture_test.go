@@ -56,7 +56,8 @@ func TestOutputCapture_ReadOutput(t *testing.T) {
 
 	foundCapturePane := false
 	for _, cmd := range fake.Commands {
-		if strings.Contains(cmd, "capture-pane") && strings.Contains(cmd, "-t @123") && strings.Contains(cmd, "-p") {
+		if strings.Contains(cmd, "capture-pane") && strings.Contains(cmd, "-t @123") && strings.Contains(cmd, "-p") &&
+			strings.Contains(cmd, "-e") && strings.Contains(cmd, "-S -") {
 			foundCapturePane = true
 			break
 		}
@@ -65,3 +66,21 @@ func TestOutputCapture_ReadOutput(t *testing.T) {
 		t.Error("capture-pane command not found in executed commands")
 	}
 }
+
+func TestOutputCapture_ReadScreen(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetOutput("tmux", []string{"capture-pane", "-p", "-t", "@123"}, []byte("$ make\nok\n"))
+	capture := NewOutputCapture(fake, "@123")
+
+	content, err := capture.ReadScreen()
+	if err != nil {
+		t.Fatalf("ReadScreen() error = %v", err)
+	}
+	if string(content) != "$ make\nok\n" {
+		t.Errorf("ReadScreen() = %q", string(content))
+	}
+
+	if _, err := NewOutputCapture(fake, "").ReadScreen(); err == nil {
+		t.Error("Expected error for empty window")
+	}
+}
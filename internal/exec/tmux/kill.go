@@ -0,0 +1,36 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package tmux
+
+import (
+	"context"
+	"fmt"
+)
+
+// KillWindow closes the tmux window with the given name, terminating the
+// agent running in it.
+func KillWindow(ctx context.Context, runner CommandRunner, windowName string) error {
+	if windowName == "" {
+		return fmt.Errorf("window name is empty")
+	}
+	if _, err := runner.Run(ctx, "tmux", "kill-window", "-t", windowName); err != nil {
+		return fmt.Errorf("failed to kill tmux window %s: %w", windowName, err)
+	}
+	return nil
+}
+
+// SelectWindow makes the tmux window with the given name the current window
+// of its session.
+func SelectWindow(ctx context.Context, runner CommandRunner, windowName string) error {
+	if windowName == "" {
+		return fmt.Errorf("window name is empty")
+	}
+	if _, err := runner.Run(ctx, "tmux", "select-window", "-t", windowName); err != nil {
+		return fmt.Errorf("failed to select tmux window %s: %w", windowName, err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package tmux
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestKillWindow(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetOutput("tmux", []string{"kill-window", "-t", "bb-abc"}, nil)
+	fake.SetError("tmux", []string{"kill-window", "-t", "bb-gone"}, errors.New("can't find window"))
+
+	if err := KillWindow(context.Background(), fake, "bb-abc"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if err := KillWindow(context.Background(), fake, "bb-gone"); err == nil {
+		t.Error("Expected error for missing window")
+	}
+	if err := KillWindow(context.Background(), fake, ""); err == nil {
+		t.Error("Expected error for empty window name")
+	}
+}
+
+func TestSelectWindow(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetOutput("tmux", []string{"select-window", "-t", "bb-abc"}, nil)
+	fake.SetError("tmux", []string{"select-window", "-t", "bb-gone"}, errors.New("can't find window"))
+
+	if err := SelectWindow(context.Background(), fake, "bb-abc"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if err := SelectWindow(context.Background(), fake, "bb-gone"); err == nil {
+		t.Error("Expected error for missing window")
+	}
+	if err := SelectWindow(context.Background(), fake, ""); err == nil {
+		t.Error("Expected error for empty window name")
+	}
+}
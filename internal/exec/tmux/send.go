@@ -0,0 +1,73 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package tmux
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SendText types text into the pane of the target window and presses Enter.
+// A single line is sent literally with send-keys. Multi-line text goes
+// through a tmux buffer (load-buffer, then a bracketed paste-buffer) so the
+// program in the pane receives it as one paste rather than several Enters.
+func SendText(ctx context.Context, runner CommandRunner, target, text string) error {
+	if target == "" {
+		return fmt.Errorf("window name is empty")
+	}
+
+	if strings.Contains(text, "\n") {
+		if err := pasteText(ctx, runner, target, text); err != nil {
+			return err
+		}
+	} else if text != "" {
+		if _, err := runner.Run(ctx, "tmux", "send-keys", "-t", target, "-l", "--", text); err != nil {
+			return fmt.Errorf("failed to send input to tmux window %s: %w", target, err)
+		}
+	}
+
+	return SendKey(ctx, runner, target, "Enter")
+}
+
+// SendKey presses a single key in the pane of the target window, using tmux
+// key names such as Enter or C-c.
+func SendKey(ctx context.Context, runner CommandRunner, target, key string) error {
+	if target == "" {
+		return fmt.Errorf("window name is empty")
+	}
+	if _, err := runner.Run(ctx, "tmux", "send-keys", "-t", target, key); err != nil {
+		return fmt.Errorf("failed to send %s to tmux window %s: %w", key, target, err)
+	}
+	return nil
+}
+
+func pasteText(ctx context.Context, runner CommandRunner, target, text string) error {
+	f, err := os.CreateTemp("", "blunderbust-input-*")
+	if err != nil {
+		return fmt.Errorf("failed to create input buffer file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(text); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write input buffer file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write input buffer file: %w", err)
+	}
+
+	buffer := "blunderbust-" + target
+	if _, err := runner.Run(ctx, "tmux", "load-buffer", "-b", buffer, f.Name()); err != nil {
+		return fmt.Errorf("failed to load input into tmux buffer: %w", err)
+	}
+	if _, err := runner.Run(ctx, "tmux", "paste-buffer", "-d", "-p", "-b", buffer, "-t", target); err != nil {
+		return fmt.Errorf("failed to paste input into tmux window %s: %w", target, err)
+	}
+	return nil
+}
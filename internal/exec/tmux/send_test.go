@@ -0,0 +1,119 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package tmux
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// bufferReadingRunner records the content of files loaded into tmux buffers,
+// since SendText removes them once the paste is done.
+type bufferReadingRunner struct {
+	*FakeRunner
+	loaded []string
+}
+
+func (r *bufferReadingRunner) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	if len(args) > 0 && args[0] == "load-buffer" {
+		data, err := os.ReadFile(args[len(args)-1])
+		if err != nil {
+			return nil, err
+		}
+		r.loaded = append(r.loaded, string(data))
+	}
+	return r.FakeRunner.Run(ctx, name, args...)
+}
+
+func TestSendText_SingleLine(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.AlwaysReturn = []byte{}
+
+	if err := SendText(context.Background(), fake, "bb-1", "continue"); err != nil {
+		t.Fatalf("SendText() error = %v", err)
+	}
+
+	want := []string{
+		"tmux send-keys -t bb-1 -l -- continue",
+		"tmux send-keys -t bb-1 Enter",
+	}
+	if !reflect.DeepEqual(fake.Commands, want) {
+		t.Errorf("commands = %q, want %q", fake.Commands, want)
+	}
+}
+
+func TestSendText_EmptyLinePressesEnter(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.AlwaysReturn = []byte{}
+
+	if err := SendText(context.Background(), fake, "bb-1", ""); err != nil {
+		t.Fatalf("SendText() error = %v", err)
+	}
+	if want := []string{"tmux send-keys -t bb-1 Enter"}; !reflect.DeepEqual(fake.Commands, want) {
+		t.Errorf("commands = %q, want %q", fake.Commands, want)
+	}
+}
+
+func TestSendText_MultiLineUsesPasteBuffer(t *testing.T) {
+	runner := &bufferReadingRunner{FakeRunner: NewFakeRunner()}
+	runner.AlwaysReturn = []byte{}
+
+	text := "first line\nsecond line"
+	if err := SendText(context.Background(), runner, "bb-1", text); err != nil {
+		t.Fatalf("SendText() error = %v", err)
+	}
+
+	cmds := runner.Commands
+	if len(cmds) != 3 {
+		t.Fatalf("commands = %q, want load-buffer, paste-buffer and Enter", cmds)
+	}
+	if !strings.HasPrefix(cmds[0], "tmux load-buffer -b blunderbust-bb-1 ") {
+		t.Errorf("commands[0] = %q, want load-buffer", cmds[0])
+	}
+	if want := "tmux paste-buffer -d -p -b blunderbust-bb-1 -t bb-1"; cmds[1] != want {
+		t.Errorf("commands[1] = %q, want %q", cmds[1], want)
+	}
+	if want := "tmux send-keys -t bb-1 Enter"; cmds[2] != want {
+		t.Errorf("commands[2] = %q, want %q", cmds[2], want)
+	}
+	if len(runner.loaded) != 1 || runner.loaded[0] != text {
+		t.Errorf("loaded buffer = %q, want %q", runner.loaded, text)
+	}
+
+	file := strings.TrimPrefix(cmds[0], "tmux load-buffer -b blunderbust-bb-1 ")
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Errorf("buffer file %s should be removed, stat error = %v", file, err)
+	}
+}
+
+func TestSendText_Errors(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.AlwaysError = errors.New("can't find window")
+
+	if err := SendText(context.Background(), fake, "bb-gone", "y"); err == nil {
+		t.Error("Expected error for missing window")
+	}
+	if err := SendText(context.Background(), fake, "", "y"); err == nil {
+		t.Error("Expected error for empty window name")
+	}
+}
+
+func TestSendKey(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetOutput("tmux", []string{"send-keys", "-t", "bb-1", "C-c"}, nil)
+
+	if err := SendKey(context.Background(), fake, "bb-1", "C-c"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if err := SendKey(context.Background(), fake, "", "C-c"); err == nil {
+		t.Error("Expected error for empty window name")
+	}
+}
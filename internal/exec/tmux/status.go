@@ -8,6 +8,7 @@ package tmux
 
 import (
 	"context"
+	"strconv"
 	"strings"
 )
 
@@ -73,3 +74,41 @@ func (c *StatusChecker) CheckStatus(ctx context.Context, windowName string) Tmux
 
 	return Dead
 }
+
+// PaneStatus is the state of a window's pane, including the exit status of
+// its program once it has exited.
+type PaneStatus struct {
+	Status   TmuxWindowStatus
+	Exited   bool // ExitCode is known
+	ExitCode int
+}
+
+// CheckPane determines if the program in a tmux window is still running.
+// Exit codes are only known while tmux keeps the dead pane around, which
+// requires the remain-on-exit option; otherwise the window disappears with
+// its program and the status is Dead without an exit code.
+func (c *StatusChecker) CheckPane(ctx context.Context, windowName string) PaneStatus {
+	output, err := c.runner.Run(ctx, "tmux", "list-panes", "-s", "-F", "#{window_name} #{pane_dead} #{pane_dead_status}")
+	if err != nil {
+		return PaneStatus{Status: Unknown}
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		parts := strings.Fields(line)
+		if len(parts) < 2 || parts[0] != windowName {
+			continue
+		}
+		if parts[1] != "1" {
+			return PaneStatus{Status: Running}
+		}
+		status := PaneStatus{Status: Dead}
+		if len(parts) > 2 {
+			if code, err := strconv.Atoi(parts[2]); err == nil {
+				status.Exited, status.ExitCode = true, code
+			}
+		}
+		return status
+	}
+
+	return PaneStatus{Status: Dead}
+}
@@ -108,3 +108,30 @@ type fakeError struct {
 func (e *fakeError) Error() string {
 	return e.msg
 }
+
+func TestStatusChecker_CheckPane(t *testing.T) {
+	args := []string{"list-panes", "-s", "-F", "#{window_name} #{pane_dead} #{pane_dead_status}"}
+	fake := NewFakeRunner()
+	fake.SetOutput("tmux", args, []byte("bdb 0 \nbb-run 0 \nbb-ok 1 0\nbb-fail 1 2\n"))
+	checker := NewStatusChecker(fake)
+
+	tests := []struct {
+		window string
+		want   PaneStatus
+	}{
+		{"bb-run", PaneStatus{Status: Running}},
+		{"bb-ok", PaneStatus{Status: Dead, Exited: true, ExitCode: 0}},
+		{"bb-fail", PaneStatus{Status: Dead, Exited: true, ExitCode: 2}},
+		{"bb-gone", PaneStatus{Status: Dead}},
+	}
+	for _, tt := range tests {
+		if got := checker.CheckPane(context.Background(), tt.window); got != tt.want {
+			t.Errorf("CheckPane(%q) = %+v, want %+v", tt.window, got, tt.want)
+		}
+	}
+
+	fake.SetError("tmux", args, &fakeError{"tmux command failed"})
+	if got := checker.CheckPane(context.Background(), "bb-run"); got.Status != Unknown {
+		t.Errorf("Expected Unknown on error, got %v", got.Status)
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package logging sets up bdb's structured file log.
+//
+// The TUI owns the terminal, so diagnostics go to a size-rotated log file
+// under the state directory instead of stderr. Loggers are tagged with a
+// subsystem attribute (dolt, tmux, ui, discovery) so the file can be
+// filtered, and `bdb logs` tails it.
+package logging
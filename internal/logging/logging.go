@@ -0,0 +1,84 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Subsystem names used as the value of the "subsystem" attribute.
+const (
+	Dolt      = "dolt"
+	Tmux      = "tmux"
+	UI        = "ui"
+	Discovery = "discovery"
+)
+
+// SubsystemKey is the attribute key identifying the emitting subsystem.
+const SubsystemKey = "subsystem"
+
+// StateDir returns $XDG_STATE_HOME/blunderbust, falling back to
+// ~/.local/state when XDG_STATE_HOME is unset. bdb keeps its logs, the
+// event log, the running agents file and the autopilot pause file there.
+func StateDir() (string, error) {
+	stateDir := os.Getenv("XDG_STATE_HOME")
+	if stateDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("could not determine user home directory: %w", err)
+		}
+		stateDir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(stateDir, "blunderbust"), nil
+}
+
+// DefaultFile returns bdb.log in the StateDir.
+func DefaultFile() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "bdb.log"), nil
+}
+
+// ParseLevel parses debug, info, warn or error (case-insensitive).
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("invalid log level %q (must be debug, info, warn or error)", s)
+}
+
+// New returns a logger writing text records at level and above to w.
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// Discard returns a logger that drops every record.
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// For returns logger tagged with subsystem. A nil logger yields Discard().
+func For(logger *slog.Logger, subsystem string) *slog.Logger {
+	if logger == nil {
+		logger = Discard()
+	}
+	return logger.With(SubsystemKey, subsystem)
+}
@@ -0,0 +1,179 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	for input, want := range map[string]slog.Level{
+		"debug": slog.LevelDebug,
+		"INFO":  slog.LevelInfo,
+		"":      slog.LevelInfo,
+		"warn":  slog.LevelWarn,
+		"error": slog.LevelError,
+	} {
+		got, err := ParseLevel(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := ParseLevel("loud")
+	assert.ErrorContains(t, err, `invalid log level "loud"`)
+}
+
+func TestFor_TagsSubsystemAndLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	For(logger, Dolt).Info("connected", "port", 3307)
+	For(logger, Tmux).Debug("hidden")
+
+	out := buf.String()
+	assert.Contains(t, out, "subsystem=dolt")
+	assert.Contains(t, out, "port=3307")
+	assert.NotContains(t, out, "hidden")
+
+	assert.NotPanics(t, func() { For(nil, UI).Info("dropped") })
+}
+
+func TestDefaultFile(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/state")
+	path, err := DefaultFile()
+	require.NoError(t, err)
+	assert.Equal(t, "/state/blunderbust/bdb.log", path)
+}
+
+func TestStateDir_FallsBackToHome(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("HOME", "/home/alice")
+	dir, err := StateDir()
+	require.NoError(t, err)
+	assert.Equal(t, "/home/alice/.local/state/blunderbust", dir)
+}
+
+func TestRotatingFile_Rotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "bdb.log")
+	f, err := OpenRotatingFile(path, 10, 2)
+	require.NoError(t, err)
+	defer f.Close()
+
+	for _, line := range []string{"aaaaaaa\n", "bbbbbbb\n", "ccccccc\n", "ddddddd\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	read := func(p string) string {
+		data, err := os.ReadFile(p)
+		require.NoError(t, err)
+		return string(data)
+	}
+	assert.Equal(t, "ddddddd\n", read(path))
+	assert.Equal(t, "ccccccc\n", read(path+".1"))
+	assert.Equal(t, "bbbbbbb\n", read(path+".2"))
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err), "only maxBackups backups are kept")
+}
+
+func TestRotatingFile_AppendsToExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bdb.log")
+	require.NoError(t, os.WriteFile(path, []byte("old\n"), 0o600))
+
+	f, err := OpenRotatingFile(path, 0, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("new\n"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "old\nnew\n", string(data))
+
+	_, err = f.Write([]byte("late\n"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
+
+func TestLastLines(t *testing.T) {
+	data := []byte("one\ntwo\nthree\n")
+	assert.Equal(t, "two\nthree\n", string(lastLines(data, 2)))
+	assert.Equal(t, "one\ntwo\nthree\n", string(lastLines(data, 5)))
+	assert.Equal(t, "three", string(lastLines([]byte("one\nthree"), 1)))
+	assert.Empty(t, lastLines(data, 0))
+}
+
+func TestTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bdb.log")
+	require.NoError(t, os.WriteFile(path, []byte("a\nb\nc\n"), 0o600))
+
+	var buf bytes.Buffer
+	offset, err := Tail(&buf, path, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "c\n", buf.String())
+	assert.Equal(t, int64(6), offset)
+
+	_, err = Tail(&buf, filepath.Join(t.TempDir(), "missing"), 1)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// syncBuffer is a bytes.Buffer safe for a concurrent writer and reader.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestFollow_PrintsAppendedAndRotatedData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bdb.log")
+	require.NoError(t, os.WriteFile(path, []byte("before\n"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := &syncBuffer{}
+	done := make(chan error, 1)
+	go func() { done <- Follow(ctx, out, path, int64(len("before\n")), 5*time.Millisecond) }()
+
+	appendLine := func(line string) {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+		require.NoError(t, err)
+		_, err = f.WriteString(line)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+
+	appendLine("first record\n")
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "first record") }, time.Second, 5*time.Millisecond)
+
+	// Simulate rotation: the file is replaced by a smaller one.
+	require.NoError(t, os.WriteFile(path, []byte("x\n"), 0o600))
+	require.Eventually(t, func() bool { return strings.HasSuffix(out.String(), "x\n") }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.NotContains(t, out.String(), "before", "existing content is not repeated")
+}
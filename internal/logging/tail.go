@@ -0,0 +1,96 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// Tail writes the last n lines of the file at path to w and returns the
+// offset it read up to, for a subsequent Follow.
+func Tail(w io.Writer, path string, n int) (int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	_, err = w.Write(lastLines(data, n))
+	return int64(len(data)), err
+}
+
+// lastLines returns the trailing n lines of data, including their newlines.
+func lastLines(data []byte, n int) []byte {
+	if n <= 0 {
+		return nil
+	}
+	end := len(data)
+	// A trailing newline terminates the last line rather than starting a new one.
+	if end > 0 && data[end-1] == '\n' {
+		end--
+	}
+	start := end
+	for i := 0; i < n; i++ {
+		idx := bytes.LastIndexByte(data[:start], '\n')
+		if idx < 0 {
+			return data
+		}
+		start = idx
+	}
+	return data[start+1:]
+}
+
+// Follow copies data written to the file at path after offset to w,
+// polling every interval until ctx is done. When the file shrinks or is
+// replaced, as on rotation, it starts over from the beginning of the new file.
+func Follow(ctx context.Context, w io.Writer, path string, offset int64, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			offset = 0
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if info.Size() < offset {
+			offset = 0
+		}
+		if info.Size() == offset {
+			continue
+		}
+
+		n, err := copyFrom(w, path, offset)
+		if err != nil {
+			return err
+		}
+		offset += n
+	}
+}
+
+func copyFrom(w io.Writer, path string, offset int64) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return io.Copy(w, f)
+}
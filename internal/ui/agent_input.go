@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// quickReply is a canned answer for harnesses waiting on a confirmation or
+// a follow-up. It either types text and presses Enter or presses a tmux key.
+type quickReply struct {
+	binding func(k KeyMap) key.Binding
+	label   string
+	text    string
+	tmuxKey string
+}
+
+var quickReplies = []quickReply{
+	{binding: func(k KeyMap) key.Binding { return k.ReplyYes }, label: "y", text: "y"},
+	{binding: func(k KeyMap) key.Binding { return k.ReplyContinue }, label: "continue", text: "continue"},
+	{binding: func(k KeyMap) key.Binding { return k.Interrupt }, label: "Ctrl-C", tmuxKey: "C-c"},
+}
+
+var quickReplyStyle = lipgloss.NewStyle().Reverse(true).Padding(0, 1)
+
+func newMessageTextarea() textarea.Model {
+	ta := textarea.New()
+	ta.Placeholder = "Message for the agent (enter sends, alt+enter adds a line, esc cancels)"
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	ta.SetHeight(sendInputLines)
+	ta.KeyMap.InsertNewline = key.NewBinding(key.WithKeys("alt+enter", "ctrl+j"))
+	return ta
+}
+
+// inputTarget returns the viewed agent if it can receive input, or the
+// reason it cannot.
+func (m UIModel) inputTarget() (*RunningAgent, string) {
+	agent, ok := m.agents[m.viewingAgentID]
+	switch {
+	case !ok:
+		return nil, "Agent not found"
+	case agent.Info.Status != domain.AgentRunning:
+		return nil, "Agent is not running"
+	case agent.Capture == nil || m.app == nil || m.app.Runner() == nil:
+		return nil, "Agent has no tmux pane to send input to"
+	}
+	return agent, ""
+}
+
+func (m UIModel) startSendInput() (tea.Model, tea.Cmd, bool) {
+	v := &m.outputViewer
+	if _, reason := m.inputTarget(); reason != "" {
+		v.status = reason
+		return m, nil, true
+	}
+	v.inputMode = outputInputSend
+	v.status = ""
+	v.message.Reset()
+	v.message.Focus()
+	v.resize()
+	return m, nil, true
+}
+
+func (m UIModel) sendQuickReply(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	for _, reply := range quickReplies {
+		if !key.Matches(msg, reply.binding(m.keys)) {
+			continue
+		}
+		agent, reason := m.inputTarget()
+		if reason != "" {
+			m.outputViewer.status = reason
+			return m, nil, true
+		}
+		return m, m.sendAgentInput(agent, reply.label, reply.text, reply.tmuxKey), true
+	}
+	return m, nil, false
+}
+
+// handleSendInputKeyMsg drives the message box: enter sends, esc cancels and
+// everything else edits the message.
+func (m UIModel) handleSendInputKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	v := &m.outputViewer
+	switch {
+	case msg.Type == tea.KeyEsc:
+		v.stopInput()
+		return m, nil, true
+	case msg.Type == tea.KeyEnter && !msg.Alt:
+		text := strings.TrimRight(v.message.Value(), "\n")
+		v.stopInput()
+		agent, reason := m.inputTarget()
+		if reason != "" {
+			v.status = reason
+			return m, nil, true
+		}
+		return m, m.sendAgentInput(agent, text, text, ""), true
+	}
+
+	var cmd tea.Cmd
+	v.message, cmd = v.message.Update(msg)
+	return m, cmd, true
+}
+
+func (m UIModel) sendAgentInput(agent *RunningAgent, label, text, tmuxKey string) tea.Cmd {
+	m.logger().Debug("sending input to agent", "agent", agent.Info.ID, "window", agent.Info.LauncherID,
+		"input", label)
+	return sendAgentInputCmd(m.app.Runner(), agent.Info.ID, agent.Info.LauncherID, label, text, tmuxKey)
+}
+
+// HandleAgentInputSent records input that reached the agent's pane in its
+// history and reports the outcome in the viewer.
+func (m UIModel) HandleAgentInputSent(msg agentInputSentMsg) (tea.Model, tea.Cmd) {
+	agent, ok := m.agents[msg.agentID]
+	if !ok {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.logger().Warn("failed to send input to agent", "agent", msg.agentID, "err", msg.err)
+		if m.viewingAgentID == msg.agentID {
+			m.outputViewer.status = fmt.Sprintf("Send failed: %v", msg.err)
+		}
+		return m, nil
+	}
+
+	agent.History = append(agent.History, msg.input)
+	if m.viewingAgentID == msg.agentID {
+		m.outputViewer.status = "Sent " + summarizeInput(msg.input.Text, 40)
+		if !m.outputViewer.follow {
+			m.outputViewer.ToggleFollow()
+		}
+	}
+	return m, nil
+}
+
+// summarizeInput quotes input on a single line for status and history lines.
+func summarizeInput(text string, width int) string {
+	first, _, multi := strings.Cut(text, "\n")
+	if multi {
+		first += " …"
+	}
+	return fmt.Sprintf("%q", ansi.Truncate(first, width, "…"))
+}
+
+// renderInputHistory summarizes what has been sent to the agent so far.
+func renderInputHistory(history []SentInput) string {
+	if len(history) == 0 {
+		return ""
+	}
+	last := history[len(history)-1]
+	return fmt.Sprintf("Sent %d message(s), last %s at %s", len(history),
+		summarizeInput(last.Text, 40), last.At.Format(time.TimeOnly))
+}
+
+// renderQuickReplies draws the quick reply buttons with their keys.
+func renderQuickReplies(keys KeyMap) string {
+	buttons := make([]string, 0, len(quickReplies))
+	for _, reply := range quickReplies {
+		k := reply.binding(keys).Help().Key
+		if k == "" {
+			continue
+		}
+		buttons = append(buttons, quickReplyStyle.Render(k+" "+reply.label))
+	}
+	return strings.Join(buttons, " ")
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/exec/tmux"
+)
+
+func sendingModel(t *testing.T) (UIModel, *tmux.FakeRunner) {
+	t.Helper()
+	fake := tmux.NewFakeRunner()
+	fake.AlwaysReturn = []byte{}
+	application, err := app.NewApp(&mockConfigLoader{}, &mockLauncher{}, nil, fake, nil, domain.AppOptions{Demo: true})
+	require.NoError(t, err)
+
+	m := viewingModel(t, domain.AgentRunning)
+	m.app = application
+	agent := m.agents["agent-1"]
+	agent.Info.LauncherID = "bb-7"
+	agent.Capture = tmux.NewOutputCapture(fake, "bb-7")
+	return m, fake
+}
+
+func deliver(t *testing.T, m UIModel, cmd tea.Cmd) UIModel {
+	t.Helper()
+	require.NotNil(t, cmd)
+	newModel, _ := m.HandleAgentInputSent(cmd().(agentInputSentMsg))
+	return newModel.(UIModel)
+}
+
+func TestSendInput_SingleLine(t *testing.T) {
+	m, fake := sendingModel(t)
+
+	m = pressKey(t, m, runeKey("i"))
+	require.Equal(t, outputInputSend, m.outputViewer.inputMode)
+	assert.False(t, m.canOpenPalette())
+	for _, r := range "looks good" {
+		m = pressKey(t, m, runeKey(string(r)))
+	}
+	newModel, cmd, _ := m.handleAgentOutputKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	m = deliver(t, newModel.(UIModel), cmd)
+
+	assert.Equal(t, outputInputNone, m.outputViewer.inputMode)
+	assert.Equal(t, []string{
+		"tmux send-keys -t bb-7 -l -- looks good",
+		"tmux send-keys -t bb-7 Enter",
+	}, fake.Commands)
+
+	history := m.agents["agent-1"].History
+	require.Len(t, history, 1)
+	assert.Equal(t, "looks good", history[0].Text)
+	assert.False(t, history[0].At.IsZero())
+	assert.Equal(t, `Sent "looks good"`, m.outputViewer.status)
+}
+
+func TestSendInput_MultiLinePastes(t *testing.T) {
+	m, fake := sendingModel(t)
+
+	m = pressKey(t, m, runeKey("i"))
+	m = pressKey(t, m, runeKey("a"))
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter, Alt: true})
+	m = pressKey(t, m, runeKey("b"))
+	require.Equal(t, "a\nb", m.outputViewer.message.Value())
+
+	newModel, cmd, _ := m.handleAgentOutputKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	m = deliver(t, newModel.(UIModel), cmd)
+
+	require.Len(t, fake.Commands, 3)
+	assert.Contains(t, fake.Commands[0], "tmux load-buffer")
+	assert.Contains(t, fake.Commands[1], "tmux paste-buffer")
+	assert.Equal(t, "a\nb", m.agents["agent-1"].History[0].Text)
+}
+
+func TestSendInput_EscCancels(t *testing.T) {
+	m, fake := sendingModel(t)
+	height := m.outputViewer.viewport.Height
+
+	m = pressKey(t, m, runeKey("i"))
+	assert.Less(t, m.outputViewer.viewport.Height, height, "message box takes space from the output")
+	m = pressKey(t, m, runeKey("x"))
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+
+	assert.Equal(t, outputInputNone, m.outputViewer.inputMode)
+	assert.Equal(t, height, m.outputViewer.viewport.Height)
+	assert.Empty(t, fake.Commands)
+}
+
+func TestSendInput_QuickReplies(t *testing.T) {
+	m, fake := sendingModel(t)
+
+	newModel, cmd, handled := m.handleAgentOutputKeyMsg(runeKey("1"))
+	require.True(t, handled)
+	m = deliver(t, newModel.(UIModel), cmd)
+	newModel, cmd, _ = m.handleAgentOutputKeyMsg(runeKey("3"))
+	m = deliver(t, newModel.(UIModel), cmd)
+
+	assert.Equal(t, []string{
+		"tmux send-keys -t bb-7 -l -- y",
+		"tmux send-keys -t bb-7 Enter",
+		"tmux send-keys -t bb-7 C-c",
+	}, fake.Commands)
+
+	history := m.agents["agent-1"].History
+	require.Len(t, history, 2)
+	assert.Equal(t, "y", history[0].Text)
+	assert.Equal(t, "Ctrl-C", history[1].Text)
+}
+
+func TestSendInput_FailureIsNotRecorded(t *testing.T) {
+	m, fake := sendingModel(t)
+	fake.AlwaysError = errors.New("can't find window")
+
+	newModel, cmd, _ := m.handleAgentOutputKeyMsg(runeKey("2"))
+	m = deliver(t, newModel.(UIModel), cmd)
+
+	assert.Empty(t, m.agents["agent-1"].History)
+	assert.Contains(t, m.outputViewer.status, "Send failed")
+}
+
+func TestSendInput_RequiresRunningAgent(t *testing.T) {
+	m, fake := sendingModel(t)
+	m.agents["agent-1"].Info.Status = domain.AgentCompleted
+
+	m = pressKey(t, m, runeKey("i"))
+	assert.Equal(t, outputInputNone, m.outputViewer.inputMode)
+	assert.Equal(t, "Agent is not running", m.outputViewer.status)
+
+	newModel, cmd, _ := m.handleAgentOutputKeyMsg(runeKey("1"))
+	assert.Nil(t, cmd)
+	assert.Equal(t, "Agent is not running", newModel.(UIModel).outputViewer.status)
+	assert.Empty(t, fake.Commands)
+}
+
+func TestRenderAgentOutput_QuickRepliesAndHistory(t *testing.T) {
+	m, _ := sendingModel(t)
+	cfg := AgentConfig{Agent: m.agents["agent-1"], Viewer: m.outputViewer, Keys: m.keys, Width: 80, Height: 30, Theme: MatrixTheme}
+
+	s := ansi.Strip(RenderAgentOutput(cfg))
+	assert.Contains(t, s, "1 y")
+	assert.Contains(t, s, "3 Ctrl-C")
+	assert.NotContains(t, s, "Sent 1 message")
+
+	cfg.Agent.History = []SentInput{{Text: "continue"}}
+	s = ansi.Strip(RenderAgentOutput(cfg))
+	assert.Contains(t, s, `Sent 1 message(s), last "continue"`)
+
+	cfg.Agent.Info.Status = domain.AgentCompleted
+	assert.NotContains(t, ansi.Strip(RenderAgentOutput(cfg)), "3 Ctrl-C")
+}
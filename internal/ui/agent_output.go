@@ -0,0 +1,415 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// agentOutputPollInterval is how often the viewed agent's pane is captured.
+const agentOutputPollInterval = time.Second
+
+// outputInputMode is what the viewer's input line is collecting.
+type outputInputMode int
+
+const (
+	outputInputNone outputInputMode = iota
+	outputInputSearch
+	outputInputSave
+	outputInputSend
+)
+
+// sendInputLines is the height of the message box, which takes its space
+// from the output box while open.
+const sendInputLines = 3
+
+var (
+	outputMatchStyle        = lipgloss.NewStyle().Reverse(true)
+	outputCurrentMatchStyle = lipgloss.NewStyle().Background(lipgloss.Color("11")).Foreground(lipgloss.Color("0"))
+)
+
+// agentOutputViewer is the scrollable output pane of ViewStateAgentOutput.
+// It displays the captured scrollback with its colors and keeps a plain copy
+// for searching and saving.
+type agentOutputViewer struct {
+	viewport viewport.Model
+	width    int
+	height   int      // viewport height with no message box open
+	content  string   // captured scrollback, with escape sequences
+	plain    []string // content without escape sequences, by line
+	follow   bool     // keep the newest output in view
+	pollSeq  int      // identifies the polling loop feeding this viewer
+
+	input     textinput.Model
+	message   textarea.Model
+	inputMode outputInputMode
+
+	query   string
+	pattern *regexp.Regexp
+	matches []int // lines matching pattern
+	match   int   // index into matches of the current match
+
+	status string
+}
+
+func newAgentOutputViewer(keys KeyMap, pollSeq int) agentOutputViewer {
+	vp := viewport.New(0, 0)
+	vp.KeyMap.Up = keys.Up
+	vp.KeyMap.Down = keys.Down
+	vp.KeyMap.Left = keys.Left
+	vp.KeyMap.Right = keys.Right
+
+	return agentOutputViewer{
+		viewport: vp,
+		follow:   true,
+		pollSeq:  pollSeq,
+		input:    textinput.New(),
+		message:  newMessageTextarea(),
+	}
+}
+
+// agentOutputViewportSize returns the viewport size that fits the output box
+// of RenderAgentOutput.
+func agentOutputViewportSize(width, height int) (int, int) {
+	return max(width-6, 1), max(height-10, 1)
+}
+
+// SetSize resizes the viewport, keeping the newest output in view when
+// following.
+func (v *agentOutputViewer) SetSize(width, height int) {
+	v.width, v.height = width, height
+	v.input.Width = max(width-12, 1)
+	v.message.SetWidth(max(width, 1))
+	v.resize()
+}
+
+// resize fits the viewport around the message box, if it is open.
+func (v *agentOutputViewer) resize() {
+	v.viewport.Width = v.width
+	v.viewport.Height = v.height
+	if v.inputMode == outputInputSend {
+		v.viewport.Height = max(v.height-(sendInputLines-1), 1)
+	}
+	if v.follow {
+		v.viewport.GotoBottom()
+	}
+}
+
+// SetContent replaces the captured output. Trailing blank lines, which tmux
+// reports for the unused part of the pane, are dropped.
+func (v *agentOutputViewer) SetContent(content string) {
+	v.content = strings.TrimRight(content, "\n")
+	v.plain = strings.Split(ansi.Strip(v.content), "\n")
+	v.findMatches()
+	v.render()
+	if v.follow {
+		v.viewport.GotoBottom()
+	}
+}
+
+// HasContent reports whether any output has been captured yet.
+func (v agentOutputViewer) HasContent() bool {
+	return v.content != ""
+}
+
+// render fills the viewport, highlighting search matches. Matched lines are
+// drawn from the plain copy, so they lose their colors while highlighted.
+func (v *agentOutputViewer) render() {
+	if v.pattern == nil || len(v.matches) == 0 {
+		v.viewport.SetContent(v.content)
+		return
+	}
+
+	lines := strings.Split(v.content, "\n")
+	for i, n := range v.matches {
+		if n >= len(lines) {
+			break
+		}
+		style := outputMatchStyle
+		if i == v.match {
+			style = outputCurrentMatchStyle
+		}
+		lines[n] = highlightMatches(v.plain[n], v.pattern, style)
+	}
+	v.viewport.SetContent(strings.Join(lines, "\n"))
+}
+
+func highlightMatches(line string, pattern *regexp.Regexp, style lipgloss.Style) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range pattern.FindAllStringIndex(line, -1) {
+		b.WriteString(line[last:loc[0]])
+		b.WriteString(style.Render(line[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(line[last:])
+	return b.String()
+}
+
+// Search sets the query, matched case-insensitively, and jumps to the first
+// match at or below the top of the view. An empty query clears the search.
+func (v *agentOutputViewer) Search(query string) {
+	v.query = query
+	v.pattern = nil
+	v.matches = nil
+	v.match = 0
+	v.status = ""
+	if query != "" {
+		v.pattern = regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+		v.findMatches()
+		if len(v.matches) == 0 {
+			v.status = fmt.Sprintf("No matches for %q", query)
+		}
+		for i, n := range v.matches {
+			if n >= v.viewport.YOffset {
+				v.match = i
+				break
+			}
+		}
+	}
+	v.render()
+	v.showMatch()
+}
+
+func (v *agentOutputViewer) findMatches() {
+	if v.pattern == nil {
+		return
+	}
+	v.matches = nil
+	for i, line := range v.plain {
+		if v.pattern.MatchString(line) {
+			v.matches = append(v.matches, i)
+		}
+	}
+	if v.match >= len(v.matches) {
+		v.match = max(len(v.matches)-1, 0)
+	}
+}
+
+// JumpMatch moves to the next (delta 1) or previous (delta -1) match,
+// wrapping around at either end.
+func (v *agentOutputViewer) JumpMatch(delta int) {
+	if len(v.matches) == 0 {
+		return
+	}
+	v.match = (v.match + delta + len(v.matches)) % len(v.matches)
+	v.render()
+	v.showMatch()
+}
+
+// showMatch scrolls the current match into view, a third from the top, and
+// stops following so new output does not scroll it away.
+func (v *agentOutputViewer) showMatch() {
+	if len(v.matches) == 0 {
+		return
+	}
+	v.follow = false
+	line := v.matches[v.match]
+	if line < v.viewport.YOffset || line >= v.viewport.YOffset+v.viewport.Height {
+		v.viewport.SetYOffset(line - v.viewport.Height/3)
+	}
+}
+
+// ToggleFollow switches follow mode; turning it on jumps to the newest output.
+func (v *agentOutputViewer) ToggleFollow() {
+	v.follow = !v.follow
+	if v.follow {
+		v.viewport.GotoBottom()
+	}
+}
+
+func (v *agentOutputViewer) startInput(mode outputInputMode, prompt, value string) {
+	v.inputMode = mode
+	v.status = ""
+	v.input.Prompt = prompt
+	v.input.SetValue(value)
+	v.input.CursorEnd()
+	v.input.Focus()
+}
+
+func (v *agentOutputViewer) stopInput() {
+	wasSending := v.inputMode == outputInputSend
+	v.inputMode = outputInputNone
+	v.input.Blur()
+	v.message.Blur()
+	if wasSending {
+		v.resize()
+	}
+}
+
+// PlainText returns the captured output without escape sequences.
+func (v agentOutputViewer) PlainText() string {
+	if !v.HasContent() {
+		return ""
+	}
+	return strings.Join(v.plain, "\n") + "\n"
+}
+
+// Header summarizes the scroll position, follow mode and search state.
+func (v agentOutputViewer) Header() string {
+	parts := []string{"Output:"}
+	if v.follow {
+		parts = append(parts, "[following]")
+	} else if v.HasContent() {
+		parts = append(parts, fmt.Sprintf("[%d%%]", int(v.viewport.ScrollPercent()*100)))
+	}
+	if len(v.matches) > 0 {
+		parts = append(parts, fmt.Sprintf("[%q %d/%d]", v.query, v.match+1, len(v.matches)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Footer shows the input line while searching or saving, otherwise the last
+// status message or the available keys.
+func (v agentOutputViewer) Footer(keys KeyMap) string {
+	switch v.inputMode {
+	case outputInputSend:
+		return v.message.View()
+	case outputInputSearch, outputInputSave:
+		return v.input.View()
+	}
+	if v.status != "" {
+		return v.status
+	}
+
+	var hints []string
+	for _, b := range []key.Binding{keys.SendInput, keys.Search, keys.NextMatch, keys.PrevMatch, keys.Follow, keys.SaveOutput} {
+		if h := b.Help(); h.Key != "" {
+			hints = append(hints, h.Key+" "+h.Desc)
+		}
+	}
+	hints = append(hints, "Press Enter to return to matrix")
+	return "[" + strings.Join(hints, " • ") + "]"
+}
+
+// isViewingOutput reports whether the agent's output is on screen, fed by
+// the polling loop seq.
+func (m UIModel) isViewingOutput(agentID string, seq int) bool {
+	return m.state == ViewStateAgentOutput && m.viewingAgentID == agentID && m.outputViewer.pollSeq == seq
+}
+
+// HandleAgentOutputTick captures the viewed agent's pane again. Ticks from a
+// viewer that has since closed end their polling loop here.
+func (m UIModel) HandleAgentOutputTick(msg agentOutputTickMsg) (tea.Model, tea.Cmd) {
+	agent, ok := m.agents[msg.agentID]
+	if !ok || !m.isViewingOutput(msg.agentID, msg.seq) {
+		return m, nil
+	}
+	return m, readAgentOutputCmd(msg.agentID, agent.Capture, msg.seq)
+}
+
+// HandleAgentOutputSaved reports the result of saving the output to a file.
+func (m UIModel) HandleAgentOutputSaved(msg agentOutputSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.outputViewer.status = fmt.Sprintf("Save failed: %v", msg.err)
+		return m, nil
+	}
+	path := msg.path
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	m.outputViewer.status = "Saved to " + path
+	return m, nil
+}
+
+// handleAgentOutputKeyMsg handles the viewer keys. Keys it does not use fall
+// through so back, quit and enter keep leaving the view.
+func (m UIModel) handleAgentOutputKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if m.state != ViewStateAgentOutput {
+		return m, nil, false
+	}
+	v := &m.outputViewer
+	if v.inputMode != outputInputNone {
+		return m.handleAgentOutputInputKeyMsg(msg)
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Search):
+		v.startInput(outputInputSearch, "/", v.query)
+		return m, nil, true
+	case key.Matches(msg, m.keys.NextMatch):
+		v.JumpMatch(1)
+		return m, nil, true
+	case key.Matches(msg, m.keys.PrevMatch):
+		v.JumpMatch(-1)
+		return m, nil, true
+	case key.Matches(msg, m.keys.Follow):
+		v.ToggleFollow()
+		return m, nil, true
+	case key.Matches(msg, m.keys.SendInput):
+		return m.startSendInput()
+	case key.Matches(msg, m.keys.ReplyYes, m.keys.ReplyContinue, m.keys.Interrupt):
+		return m.sendQuickReply(msg)
+	case key.Matches(msg, m.keys.SaveOutput):
+		if !v.HasContent() {
+			v.status = "No output to save"
+			return m, nil, true
+		}
+		v.startInput(outputInputSave, "Save to: ", m.defaultOutputPath(time.Now()))
+		return m, nil, true
+	}
+
+	km := v.viewport.KeyMap
+	if !key.Matches(msg, km.PageDown, km.PageUp, km.HalfPageDown, km.HalfPageUp, km.Down, km.Up, km.Left, km.Right) {
+		return m, nil, false
+	}
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	v.follow = v.viewport.AtBottom()
+	v.status = ""
+	return m, cmd, true
+}
+
+func (m UIModel) handleAgentOutputInputKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	v := &m.outputViewer
+	if v.inputMode == outputInputSend {
+		return m.handleSendInputKeyMsg(msg)
+	}
+	switch msg.Type {
+	case tea.KeyEsc:
+		v.stopInput()
+		return m, nil, true
+	case tea.KeyEnter:
+		mode, value := v.inputMode, strings.TrimSpace(v.input.Value())
+		v.stopInput()
+		switch mode {
+		case outputInputSearch:
+			v.Search(value)
+		case outputInputSave:
+			if value == "" {
+				return m, nil, true
+			}
+			return m, saveAgentOutputCmd(value, v.PlainText()), true
+		}
+		return m, nil, true
+	}
+
+	var cmd tea.Cmd
+	v.input, cmd = v.input.Update(msg)
+	return m, cmd, true
+}
+
+// defaultOutputPath suggests a file name for saving the viewed agent's output
+// in the working directory.
+func (m UIModel) defaultOutputPath(now time.Time) string {
+	name := "agent"
+	if agent, ok := m.agents[m.viewingAgentID]; ok && agent.Info.Name != "" {
+		name = strings.Map(func(r rune) rune {
+			if r == '/' || r == filepath.Separator || r == ' ' {
+				return '-'
+			}
+			return r
+		}, agent.Info.Name)
+	}
+	return fmt.Sprintf("%s-%s.log", name, now.Format("20060102-150405"))
+}
@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func numberedOutput(n int) string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("\x1b[32mline %d\x1b[0m", i)
+	}
+	return strings.Join(lines, "\n") + "\n\n\n"
+}
+
+func viewingModel(t *testing.T, status domain.AgentStatus) UIModel {
+	t.Helper()
+	m := NewTestModel()
+	m.layout = LayoutDimensions{Width: 80, Height: 30}
+	m.agents = map[string]*RunningAgent{
+		"agent-1": {Info: &domain.AgentInfo{ID: "agent-1", Name: "bd-7", Status: status}},
+	}
+	newModel, _ := m.HandleAgentSelected(AgentSelectedMsg{AgentID: "agent-1"})
+	return newModel.(UIModel)
+}
+
+func pressKey(t *testing.T, m UIModel, msg tea.KeyMsg) UIModel {
+	t.Helper()
+	newModel, _, handled := m.handleAgentOutputKeyMsg(msg)
+	require.True(t, handled, msg.String())
+	return newModel.(UIModel)
+}
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestAgentOutputViewer_KeepsColorsAndFollows(t *testing.T) {
+	m := viewingModel(t, domain.AgentRunning)
+	newModel, cmd := m.HandleAgentOutput(agentOutputMsg{agentID: "agent-1", content: numberedOutput(100), seq: m.outputViewer.pollSeq})
+	m = newModel.(UIModel)
+
+	assert.NotNil(t, cmd, "running agent on screen should schedule the next capture")
+	assert.Len(t, m.outputViewer.plain, 100, "trailing blank lines are dropped")
+	assert.True(t, m.outputViewer.viewport.AtBottom())
+
+	view := m.outputViewer.viewport.View()
+	assert.Contains(t, view, "\x1b[32m")
+	assert.Contains(t, ansi.Strip(view), "line 99")
+	assert.NotContains(t, m.agents["agent-1"].LastOutput, "\x1b[")
+}
+
+func TestAgentOutputViewer_ScrollingUpStopsFollowing(t *testing.T) {
+	m := viewingModel(t, domain.AgentRunning)
+	newModel, _ := m.HandleAgentOutput(agentOutputMsg{agentID: "agent-1", content: numberedOutput(100), seq: m.outputViewer.pollSeq})
+	m = newModel.(UIModel)
+
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyPgUp})
+	assert.False(t, m.outputViewer.follow)
+	offset := m.outputViewer.viewport.YOffset
+
+	newModel, _ = m.HandleAgentOutput(agentOutputMsg{agentID: "agent-1", content: numberedOutput(120), seq: m.outputViewer.pollSeq})
+	m = newModel.(UIModel)
+	assert.Equal(t, offset, m.outputViewer.viewport.YOffset, "new output must not move a scrolled view")
+
+	m = pressKey(t, m, runeKey("F"))
+	assert.True(t, m.outputViewer.follow)
+	assert.True(t, m.outputViewer.viewport.AtBottom())
+}
+
+func TestAgentOutputViewer_Search(t *testing.T) {
+	m := viewingModel(t, domain.AgentRunning)
+	newModel, _ := m.HandleAgentOutput(agentOutputMsg{agentID: "agent-1", content: numberedOutput(100), seq: m.outputViewer.pollSeq})
+	m = newModel.(UIModel)
+	m.outputViewer.viewport.GotoTop()
+
+	m = pressKey(t, m, runeKey("/"))
+	assert.False(t, m.canOpenPalette(), "palette stays closed while typing a query")
+	for _, r := range "LINE 1" {
+		m = pressKey(t, m, runeKey(string(r)))
+	}
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	v := m.outputViewer
+	assert.Equal(t, outputInputNone, v.inputMode)
+	// line 1, line 10-19
+	require.Len(t, v.matches, 11)
+	assert.Equal(t, 1, v.matches[v.match])
+	assert.False(t, v.follow)
+
+	m = pressKey(t, m, runeKey("N"))
+	assert.Equal(t, 19, m.outputViewer.matches[m.outputViewer.match], "previous wraps to the last match")
+	m = pressKey(t, m, runeKey("n"))
+	assert.Equal(t, 1, m.outputViewer.matches[m.outputViewer.match])
+	assert.Contains(t, m.outputViewer.Header(), `"LINE 1" 1/11`)
+
+	m = pressKey(t, m, runeKey("/"))
+	m = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, outputInputNone, m.outputViewer.inputMode)
+	assert.Len(t, m.outputViewer.matches, 11, "cancelling keeps the current search")
+}
+
+func TestAgentOutputViewer_SearchWithoutMatches(t *testing.T) {
+	m := viewingModel(t, domain.AgentRunning)
+	m.outputViewer.SetContent("hello\nworld\n")
+	m.outputViewer.Search("absent")
+
+	assert.Empty(t, m.outputViewer.matches)
+	assert.Equal(t, `No matches for "absent"`, m.outputViewer.Footer(m.keys))
+}
+
+func TestAgentOutputViewer_Save(t *testing.T) {
+	m := viewingModel(t, domain.AgentCompleted)
+	m.outputViewer.SetContent("\x1b[31mfailed\x1b[0m\ndone\n")
+
+	m = pressKey(t, m, runeKey("s"))
+	require.Equal(t, outputInputSave, m.outputViewer.inputMode)
+	assert.True(t, strings.HasPrefix(m.outputViewer.input.Value(), "bd-7-"))
+
+	path := filepath.Join(t.TempDir(), "out.log")
+	m.outputViewer.input.SetValue(path)
+	newModel, cmd, _ := m.handleAgentOutputKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(UIModel)
+	require.NotNil(t, cmd)
+
+	newModel, _ = m.HandleAgentOutputSaved(cmd().(agentOutputSavedMsg))
+	m = newModel.(UIModel)
+	assert.Equal(t, "Saved to "+path, m.outputViewer.Footer(m.keys))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "failed\ndone\n", string(data))
+}
+
+func TestAgentOutputViewer_PollsOnlyWhileVisible(t *testing.T) {
+	m := viewingModel(t, domain.AgentRunning)
+	seq := m.outputViewer.pollSeq
+
+	_, cmd := m.HandleAgentOutputTick(agentOutputTickMsg{agentID: "agent-1", seq: seq})
+	assert.NotNil(t, cmd)
+
+	_, cmd = m.HandleAgentOutputTick(agentOutputTickMsg{agentID: "agent-1", seq: seq - 1})
+	assert.Nil(t, cmd, "ticks of an earlier viewer end their loop")
+
+	back, _, _ := m.handleBackKeyMsg()
+	m = back.(UIModel)
+	_, cmd = m.HandleAgentOutputTick(agentOutputTickMsg{agentID: "agent-1", seq: seq})
+	assert.Nil(t, cmd)
+	_, cmd = m.HandleAgentOutput(agentOutputMsg{agentID: "agent-1", content: "x", seq: seq})
+	assert.Nil(t, cmd)
+}
+
+func TestAgentOutputViewer_StopsPollingStoppedAgent(t *testing.T) {
+	m := viewingModel(t, domain.AgentCompleted)
+	_, cmd := m.HandleAgentOutput(agentOutputMsg{agentID: "agent-1", content: "done", seq: m.outputViewer.pollSeq})
+	assert.Nil(t, cmd)
+}
+
+func TestAgentOutputViewer_KeepsPollingAfterCaptureError(t *testing.T) {
+	m := viewingModel(t, domain.AgentRunning)
+	m.outputViewer.SetContent("before")
+	newModel, cmd := m.HandleAgentOutput(agentOutputMsg{agentID: "agent-1", seq: m.outputViewer.pollSeq, err: assert.AnError})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "before", newModel.(UIModel).outputViewer.content)
+}
+
+func TestAgentOutputViewer_UnusedKeysFallThrough(t *testing.T) {
+	m := viewingModel(t, domain.AgentRunning)
+	for _, msg := range []tea.KeyMsg{{Type: tea.KeyEsc}, {Type: tea.KeyEnter}, runeKey("q"), runeKey("t")} {
+		_, _, handled := m.handleAgentOutputKeyMsg(msg)
+		assert.False(t, handled, msg.String())
+	}
+}
+
+func TestDefaultOutputPath(t *testing.T) {
+	m := viewingModel(t, domain.AgentRunning)
+	m.agents["agent-1"].Info.Name = "feature/bd 7"
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	assert.Equal(t, "feature-bd-7-20260304-050607.log", m.defaultOutputPath(now))
+}
@@ -2,13 +2,16 @@ package ui
 
 import (
 	"fmt"
-	"os"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/x/ansi"
 
+	"github.com/megatherium/blunderbust/internal/control"
 	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/events"
 	"github.com/megatherium/blunderbust/internal/exec/tmux"
 )
 
@@ -186,23 +189,73 @@ func (m UIModel) HandleAgentHoverEnded(msg AgentHoverEndedMsg) (tea.Model, tea.C
 
 // HandleAgentSelected transitions to agent output view
 func (m UIModel) HandleAgentSelected(msg AgentSelectedMsg) (tea.Model, tea.Cmd) {
+	m.outputReturnState = ViewStateMatrix
+	if m.state == ViewStateDashboard {
+		m.outputReturnState = ViewStateDashboard
+	}
 	m.state = ViewStateAgentOutput
 	m.viewingAgentID = msg.AgentID
 	m.hoveredAgentID = ""
 
+	// A fresh viewer starts a new polling loop; the seq bump retires the
+	// loop of any viewer opened before.
+	m.outputViewer = newAgentOutputViewer(m.keys, m.outputViewer.pollSeq+1)
+	m.outputViewer.SetSize(agentOutputViewportSize(m.layout.Width, m.layout.Height))
+
 	var readOutputCmd tea.Cmd
 	if agent, ok := m.agents[msg.AgentID]; ok {
-		readOutputCmd = readAgentOutputCmd(msg.AgentID, agent.Capture)
+		readOutputCmd = readAgentOutputCmd(msg.AgentID, agent.Capture, m.outputViewer.pollSeq)
 	}
 
 	return m, readOutputCmd
 }
 
+// closeAgentOutput leaves the agent output view for the view it was opened
+// from, resuming the dashboard's polling when returning there.
+func (m *UIModel) closeAgentOutput() tea.Cmd {
+	m.viewingAgentID = ""
+	m.state = m.outputReturnState
+	m.outputReturnState = ViewStateMatrix
+	if m.state == ViewStateDashboard {
+		return m.startDashboardPolling()
+	}
+	return nil
+}
+
 // HandleAgentStatus updates an agent's status in both the agents map and sidebar
 func (m UIModel) HandleAgentStatus(msg AgentStatusMsg) (tea.Model, tea.Cmd) {
-	if agent, ok := m.agents[msg.AgentID]; ok {
-		agent.Info.Status = msg.Status
-		UpdateAgentNodeStatus(&m, msg.AgentID, msg.Status)
+	agent, ok := m.agents[msg.AgentID]
+	if !ok {
+		return m, nil
+	}
+	changed := agent.Info.Status != msg.Status
+	agent.Info.Status = msg.Status
+	if msg.Status != domain.AgentRunning && agent.Info.FinishedAt.IsZero() {
+		agent.Info.FinishedAt = time.Now()
+	}
+	if msg.ExitCode != nil {
+		agent.Info.ExitCode = msg.ExitCode
+	}
+	UpdateAgentNodeStatus(&m, msg.AgentID, msg.Status)
+	if !changed {
+		return m, nil
+	}
+
+	m.publishAgentEvent(control.EventAgentStatus, agent.Info)
+	if eventType, ok := agentStatusEvent(msg.Status); ok {
+		projectDir := agent.ProjectDir
+		if projectDir == "" {
+			projectDir = eventProject(m.app)
+		}
+		return m, tea.Batch(
+			m.emitEventCmd(events.Event{
+				Type:    eventType,
+				Project: projectDir,
+				Agent:   events.AgentFromInfo(agent.Info),
+			}),
+			m.writebackFinishCmd(projectDir, *agent.Info),
+			recordAgentRunCmd(m.app, projectDir, *agent.Info),
+		)
 	}
 	return m, nil
 }
@@ -212,67 +265,79 @@ func (m UIModel) HandleAgentTick(msg agentTickMsg) (tea.Model, tea.Cmd) {
 	agentID := msg.agentID
 	agent, ok := m.agents[agentID]
 	if !ok {
-		if m.app.Opts.Debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG][i29d] HandleAgentTick: orphan tick for gone agent %s (total agents=%d)\n", agentID, len(m.agents))
-		}
+		m.logger().Debug("orphan tick for removed agent", "agent", agentID, "agents", len(m.agents))
 		return m, nil
 	}
 
-	if m.app.Opts.Debug {
-		fmt.Fprintf(os.Stderr, "[DEBUG][i29d] HandleAgentTick: agent=%s status=%v viewing=%v (total agents=%d)\n",
-			agentID, agent.Info.Status, m.viewingAgentID == agentID, len(m.agents))
-	}
-
-	var readOutputCmd tea.Cmd
-	if m.viewingAgentID == agentID {
-		readOutputCmd = readAgentOutputCmd(agentID, agent.Capture)
-	}
+	m.logger().Debug("agent tick", "agent", agentID, "status", agent.Info.Status.String(),
+		"viewing", m.viewingAgentID == agentID, "agents", len(m.agents))
 
+	// Output is polled by the viewer itself, only while it is visible.
 	if agent.Info.Status == domain.AgentRunning {
 		return m, tea.Batch(
 			pollAgentStatusCmd(m.app, agentID, agent.Info.LauncherID),
 			startAgentMonitoringCmd(agentID),
-			readOutputCmd,
 		)
 	}
 
-	return m, readOutputCmd
+	return m, nil
 }
 
-// HandleAgentOutput processes output from an agent
+// HandleAgentOutput stores a capture of an agent's pane and, while the agent
+// is on screen and still running, schedules the next capture.
 func (m UIModel) HandleAgentOutput(msg agentOutputMsg) (tea.Model, tea.Cmd) {
-	if agent, ok := m.agents[msg.agentID]; ok {
-		clean := ansi.Strip(msg.content)
-		clean = strings.ReplaceAll(clean, "\r\n", "\n")
-		clean = strings.ReplaceAll(clean, "\r", "\n")
-		agent.LastOutput = clean
+	agent, ok := m.agents[msg.agentID]
+	if !ok {
+		return m, nil
+	}
+	visible := m.isViewingOutput(msg.agentID, msg.seq)
+
+	if msg.err != nil {
+		m.logger().Debug("capture agent output failed", "agent", msg.agentID, "err", msg.err)
+	} else {
+		content := strings.ReplaceAll(msg.content, "\r\n", "\n")
+		content = strings.ReplaceAll(content, "\r", "\n")
+		agent.LastOutput = ansi.Strip(content)
+		if visible {
+			m.outputViewer.SetContent(content)
+		}
+	}
+
+	if visible && agent.Info.Status == domain.AgentRunning {
+		return m, agentOutputTickCmd(msg.agentID, msg.seq)
 	}
 	return m, nil
 }
 
 // HandleAgentCleared removes an agent from the UI when cleared
 func (m UIModel) HandleAgentCleared(msg AgentClearedMsg) (tea.Model, tea.Cmd) {
+	if agent, ok := m.agents[msg.AgentID]; ok {
+		m.publishAgentEvent(control.EventAgentRemoved, agent.Info)
+	}
 	delete(m.agents, msg.AgentID)
 	if m.hoveredAgentID == msg.AgentID {
 		m.hoveredAgentID = ""
 	}
 
+	var cmd tea.Cmd
 	if m.state == ViewStateAgentOutput && m.viewingAgentID == msg.AgentID {
-		m.viewingAgentID = ""
-		m.state = ViewStateMatrix
+		cmd = m.closeAgentOutput()
 	}
 
 	RemoveAgentNodeFromSidebar(&m, msg.AgentID)
-	return m, nil
+	return m, cmd
 }
 
 // HandleAllStoppedAgentsCleared clears all stopped agents from the UI
 func (m UIModel) HandleAllStoppedAgentsCleared(msg AllStoppedAgentsClearedMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
 	for _, id := range msg.ClearedIDs {
+		if agent, ok := m.agents[id]; ok {
+			m.publishAgentEvent(control.EventAgentRemoved, agent.Info)
+		}
 		delete(m.agents, id)
 		if m.state == ViewStateAgentOutput && m.viewingAgentID == id {
-			m.viewingAgentID = ""
-			m.state = ViewStateMatrix
+			cmd = m.closeAgentOutput()
 		}
 		if m.hoveredAgentID == id {
 			m.hoveredAgentID = ""
@@ -280,7 +345,7 @@ func (m UIModel) HandleAllStoppedAgentsCleared(msg AllStoppedAgentsClearedMsg) (
 	}
 
 	RebuildAgentNodesInSidebar(&m)
-	return m, nil
+	return m, cmd
 }
 
 // HandleSidebarAgentKeysMsg handles key presses when sidebar is focused
@@ -289,8 +354,8 @@ func (m UIModel) HandleSidebarAgentKeysMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd,
 		return m, nil, false
 	}
 
-	switch msg.String() {
-	case "c":
+	switch {
+	case key.Matches(msg, m.keys.ClearAgent):
 		node := m.sidebar.State().CurrentNode()
 		if node != nil && node.Type == domain.NodeTypeAgent && node.AgentInfo != nil {
 			var capture *tmux.OutputCapture
@@ -299,18 +364,24 @@ func (m UIModel) HandleSidebarAgentKeysMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd,
 			}
 			return m, clearAgentCmd(node.AgentInfo.ID, capture), true
 		}
-	case "C":
-		var toClear []agentToClear
-		for id, agent := range m.agents {
-			if agent.Info.Status != domain.AgentRunning {
-				toClear = append(toClear, agentToClear{id: id, capture: agent.Capture})
-			}
-		}
-		if len(toClear) > 0 {
-			return m, clearAllStoppedAgentsCmd(toClear), true
-		}
-		return m, nil, true
+	case key.Matches(msg, m.keys.ClearStoppedAgents):
+		return m, m.clearStoppedAgentsCmd(), true
 	}
 
 	return m, nil, false
 }
+
+// clearStoppedAgentsCmd returns a command clearing every agent that is no
+// longer running, or nil when there is none.
+func (m UIModel) clearStoppedAgentsCmd() tea.Cmd {
+	var toClear []agentToClear
+	for id, agent := range m.agents {
+		if agent.Info.Status != domain.AgentRunning {
+			toClear = append(toClear, agentToClear{id: id, capture: agent.Capture})
+		}
+	}
+	if len(toClear) == 0 {
+		return nil
+	}
+	return clearAllStoppedAgentsCmd(toClear)
+}
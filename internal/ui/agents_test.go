@@ -1,11 +1,15 @@
 package ui
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/domain"
 )
 
@@ -284,6 +288,55 @@ func TestHandleAgentStatus(t *testing.T) {
 	assert.Equal(t, domain.AgentCompleted, newModel.(UIModel).agents["agent-123"].Info.Status)
 }
 
+func TestRecordAgentRunCmd(t *testing.T) {
+	m, _ := writebackModel(nil)
+	store, err := m.app.AgentStoreForProject(context.Background(), "/src/app")
+	require.NoError(t, err)
+	require.NoError(t, store.UpsertRunningAgent(context.Background(), domain.PersistedRunningAgent{
+		ProjectDir: "/src/app", WorktreePath: "/src/app", PID: 42, LauncherID: "@1", HarnessName: "claude",
+	}))
+
+	exitCode := 0
+	started := time.Now().Add(-time.Minute)
+	msg := recordAgentRunCmd(m.app, "/src/app", domain.AgentInfo{
+		LauncherID: "@1", TicketID: "bb-1", HarnessName: "claude", ModelName: "sonnet",
+		Status: domain.AgentCompleted, ExitCode: &exitCode, StartedAt: started, FinishedAt: started.Add(time.Minute),
+	})()
+	assert.Nil(t, msg)
+
+	agents, err := store.ValidateAndPruneRunningAgents(context.Background(), []string{"/src/app"}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, agents, "the finished agent leaves the running agents")
+
+	runs, err := store.ListAgentRuns(context.Background(), data.AgentRunFilter{Ticket: "bb-1"})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "/src/app", runs[0].WorktreePath, "the project stands in for a missing worktree")
+	assert.Equal(t, "sonnet", runs[0].Model)
+	assert.Equal(t, "completed with exit code 0", runs[0].Outcome())
+
+	assert.Nil(t, recordAgentRunCmd(nil, "/src/app", domain.AgentInfo{}), "no app, no command")
+}
+
+func TestRecordAgentRun_UsesTheAgentsProject(t *testing.T) {
+	m, _ := writebackModel(nil)
+	m.app.AddProject(domain.Project{Dir: "/src/lib"})
+
+	newModel, _ := m.handleRunningAgentsLoaded(runningAgentsLoadedMsg{agents: []domain.PersistedRunningAgent{
+		{ProjectDir: "/src/lib", WorktreePath: "/src/lib", LauncherID: "@2", Ticket: "bb-2", HarnessName: "claude"},
+	}})
+	_, cmd := newModel.(UIModel).HandleAgentStatus(AgentStatusMsg{AgentID: "@2", Status: domain.AgentCompleted})
+	require.NotNil(t, cmd, "without events or writeback only the run is recorded")
+	assert.Nil(t, cmd())
+
+	store, err := m.app.AgentStoreForProject(context.Background(), "/src/lib")
+	require.NoError(t, err)
+	runs, err := store.ListAgentRuns(context.Background(), data.AgentRunFilter{Ticket: "bb-2"})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "/src/lib", runs[0].ProjectDir, "the run is recorded under the agent's project, not the active one")
+}
+
 func TestUpdateAgentNodeStatus(t *testing.T) {
 	m := NewTestModel()
 	m.agents = make(map[string]*RunningAgent)
@@ -377,8 +430,8 @@ func TestHandleAgentTick(t *testing.T) {
 		msg := agentTickMsg{agentID: "agent-456"}
 		newModel, cmd := m.HandleAgentTick(msg)
 
-		// Stopped agent returns readOutputCmd (not nil)
-		assert.NotNil(t, cmd)
+		// Stopped agents are no longer polled; output is read by the viewer
+		assert.Nil(t, cmd)
 		_ = newModel.(UIModel)
 	})
 
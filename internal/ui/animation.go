@@ -61,7 +61,10 @@ type AnimationState struct {
 	// Color cycling state
 	ColorCycleIndex int       // Current position in color palette
 	ColorCycleStart time.Time // When current cycle started
-	CurrentThemeIdx int       // Index into AvailableThemes
+	CurrentThemeIdx int       // Index into themes()
+
+	// Themes cycled by the toggle theme key; nil means AvailableThemes.
+	Themes []*ThemePalette
 }
 
 // animationTickMsg is sent periodically to update animations
@@ -128,15 +131,24 @@ func getPulsingColor(phase float64) lipgloss.Color {
 
 // getCurrentTheme returns the currently active theme
 func (a AnimationState) getCurrentTheme() *ThemePalette {
-	if a.CurrentThemeIdx < 0 || a.CurrentThemeIdx >= len(AvailableThemes) {
+	themes := a.themes()
+	if a.CurrentThemeIdx < 0 || a.CurrentThemeIdx >= len(themes) {
 		return &MatrixTheme // Default to Matrix
 	}
-	return AvailableThemes[a.CurrentThemeIdx]
+	return themes[a.CurrentThemeIdx]
 }
 
 // nextTheme cycles to the next theme
 func (a *AnimationState) nextTheme() {
-	a.CurrentThemeIdx = (a.CurrentThemeIdx + 1) % len(AvailableThemes)
+	a.CurrentThemeIdx = (a.CurrentThemeIdx + 1) % len(a.themes())
+}
+
+// themes returns the themes available for cycling.
+func (a AnimationState) themes() []*ThemePalette {
+	if len(a.Themes) == 0 {
+		return AvailableThemes
+	}
+	return a.Themes
 }
 
 // newGradientDelegate creates a list delegate with gradient-colored selected items
@@ -21,19 +21,7 @@ func (m UIModel) handleAnimationTick(msg animationTickMsg) (tea.Model, tea.Cmd)
 	// Handle color cycling - change palette every ColorCycleInterval
 	cycleElapsed := msg.Time.Sub(m.animState.ColorCycleStart).Seconds()
 	if cycleElapsed >= ColorCycleInterval.Seconds() {
-		var cycleCount int
-		if m.currentTheme == nil {
-			cycleCount = len(MatrixThemeColorCycles)
-		} else {
-			switch m.currentTheme.Name {
-			case CyberpunkTheme.Name:
-				cycleCount = len(CyberpunkThemeColorCycles)
-			case TokyoNightTheme.Name:
-				cycleCount = len(TokyoNightThemeColorCycles)
-			default:
-				cycleCount = len(MatrixThemeColorCycles)
-			}
-		}
+		cycleCount := len(themeColorCycles(m.currentTheme))
 		if cycleCount < 1 {
 			cycleCount = 1
 		}
@@ -19,7 +19,9 @@ var (
 				MarginBottom(1)
 )
 
-func confirmView(selection domain.Selection, renderer *config.Renderer, dryRun bool, workDir string, theme ThemePalette) string {
+// confirmView renders the confirm screen. confirmBlocked shows the override
+// prompt for launching a blocked ticket.
+func confirmView(selection domain.Selection, renderer *config.Renderer, dryRun bool, workDir string, theme ThemePalette, confirmBlocked bool, keys KeyMap) string {
 	// Arcade-style styles using theme colors
 	readyTextStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -102,6 +104,14 @@ func confirmView(selection domain.Selection, renderer *config.Renderer, dryRun b
 		launchButtonStyle.Render("LAUNCH"),
 	)
 	s += readyPanelStyle.Render(readyBlock) + "\n"
+
+	if selection.Ticket.IsBlocked() {
+		warningStyle := lipgloss.NewStyle().Bold(true).Foreground(ThemeWarning)
+		s += warningStyle.Render(fmt.Sprintf("%s is %s.", selection.Ticket.ID, blockedReason(selection.Ticket))) + "\n"
+		if confirmBlocked {
+			return s + warningStyle.Render(fmt.Sprintf("Launch it anyway? [%s/N]", keys.Confirm.Help().Key))
+		}
+	}
 	s += lipgloss.NewStyle().Faint(true).Render("[Press Enter to launch, e to edit, esc to go back]")
 	return s
 }
@@ -0,0 +1,352 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/control"
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/exec/tmux"
+	"github.com/megatherium/blunderbust/internal/logging"
+)
+
+// ControlPublisher receives agent lifecycle events for control API subscribers.
+type ControlPublisher interface {
+	Publish(ev control.Event)
+}
+
+// WithControl returns a copy of the model that publishes agent events to p.
+func (m UIModel) WithControl(p ControlPublisher) UIModel {
+	m.control = p
+	return m
+}
+
+func (m UIModel) publishAgentEvent(eventType control.EventType, info *domain.AgentInfo) {
+	if m.control == nil || info == nil {
+		return
+	}
+	m.control.Publish(control.Event{Type: eventType, Agent: control.AgentFromInfo(info)})
+}
+
+// Control API requests that need UI state. Each carries a buffered reply
+// channel that the handler answers exactly once.
+type controlHarnessesMsg struct {
+	reply chan []control.Harness
+}
+
+type controlAgentsMsg struct {
+	reply chan []control.Agent
+}
+
+type controlLaunchMsg struct {
+	req   control.LaunchRequest
+	reply chan controlLaunchReply
+}
+
+type controlLaunchReply struct {
+	res *control.LaunchResponse
+	err error
+}
+
+type controlKillMsg struct {
+	agentID string
+	reply   chan error
+}
+
+// controlLaunchResultMsg is a launchResultMsg for a launch requested over
+// the control API; it must not take over the screen the user is on.
+type controlLaunchResultMsg struct {
+	launchResultMsg
+}
+
+func (m UIModel) handleControlMsgs(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case controlHarnessesMsg:
+		msg.reply <- m.controlHarnesses()
+		return m, nil, true
+	case controlAgentsMsg:
+		msg.reply <- m.controlAgents()
+		return m, nil, true
+	case controlLaunchMsg:
+		cmd, err := m.controlLaunchCmd(msg.req, msg.reply)
+		if err != nil {
+			msg.reply <- controlLaunchReply{err: err}
+			return m, nil, true
+		}
+		return m, cmd, true
+	case controlLaunchResultMsg:
+		newM, cmd := m.handleControlLaunchResult(msg)
+		return newM, cmd, true
+	case controlKillMsg:
+		cmd, err := m.controlKillCmd(msg.agentID, msg.reply)
+		if err != nil {
+			msg.reply <- err
+			return m, nil, true
+		}
+		return m, cmd, true
+	}
+	return m, nil, false
+}
+
+func (m UIModel) controlHarnesses() []control.Harness {
+	harnesses := make([]control.Harness, 0, len(m.harnesses))
+	for _, h := range m.harnesses {
+		models := h.SupportedModels
+		if m.app != nil && m.app.Registry != nil {
+			models = m.app.Registry.ExpandModels(models)
+		}
+		harnesses = append(harnesses, control.Harness{
+			Name:   h.Name,
+			Models: models,
+			Agents: h.SupportedAgents,
+		})
+	}
+	return harnesses
+}
+
+func (m UIModel) controlAgents() []control.Agent {
+	agents := make([]control.Agent, 0, len(m.agents))
+	for _, agent := range m.agents {
+		agents = append(agents, control.AgentFromInfo(agent.Info))
+	}
+	slices.SortFunc(agents, func(a, b control.Agent) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+	return agents
+}
+
+// controlLaunchCmd validates a launch request against the loaded harnesses
+// and returns a command that looks up the ticket and launches it through
+// the same pipeline as the matrix.
+func (m UIModel) controlLaunchCmd(req control.LaunchRequest, reply chan controlLaunchReply) (tea.Cmd, error) {
+	idx := slices.IndexFunc(m.harnesses, func(h domain.Harness) bool { return h.Name == req.Harness })
+	if idx < 0 {
+		return nil, fmt.Errorf("harness %q: %w", req.Harness, control.ErrNotFound)
+	}
+	harness := m.harnesses[idx]
+	if req.Agent != "" && len(harness.SupportedAgents) > 0 && !slices.Contains(harness.SupportedAgents, req.Agent) {
+		return nil, fmt.Errorf("%w: harness %q does not support agent %q", control.ErrInvalidRequest, req.Harness, req.Agent)
+	}
+
+	projectDir, err := resolveControlProject(m.app, req.Project)
+	if err != nil {
+		return nil, err
+	}
+	workDir := req.Worktree
+	if workDir == "" {
+		workDir = projectDir
+	}
+
+	myApp := m.app
+	return func() tea.Msg {
+		ctx := context.Background()
+		ticket, err := findReadyTicket(ctx, myApp, projectDir, req.Ticket)
+		if err != nil {
+			reply <- controlLaunchReply{err: err}
+			return nil
+		}
+
+		selection := domain.Selection{Ticket: ticket, Harness: harness, Model: req.Model, Agent: req.Agent}
+		spec, res, err := renderAndLaunch(ctx, myApp, selection, workDir)
+		if err == nil && res == nil {
+			err = fmt.Errorf("launcher returned no result")
+		}
+		if err != nil {
+			reply <- controlLaunchReply{err: err}
+			return controlLaunchResultMsg{launchResultMsg{spec: spec, err: err, project: projectDir}}
+		}
+
+		reply <- controlLaunchReply{res: &control.LaunchResponse{
+			AgentID:    res.LauncherID,
+			LauncherID: res.LauncherID,
+			PID:        res.PID,
+			Command:    spec.RenderedCommand,
+		}}
+		return controlLaunchResultMsg{launchResultMsg{res: res, spec: spec, worktree: workDir, project: projectDir}}
+	}, nil
+}
+
+// handleControlLaunchResult registers a remotely launched agent while
+// keeping the current view, and reports failures as warnings.
+func (m UIModel) handleControlLaunchResult(msg controlLaunchResultMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.warnings = append(m.warnings, fmt.Sprintf("Control API launch failed: %v", msg.err))
+		return m, m.emitEventCmd(launchFailedEvent(msg.project, msg.spec, msg.err))
+	}
+
+	state, launchResult := m.state, m.launchResult
+	newM, cmd := m.handleLaunchResult(msg.launchResultMsg)
+	updated, ok := newM.(UIModel)
+	if !ok {
+		return newM, cmd
+	}
+	updated.state = state
+	updated.launchResult = launchResult
+	return updated, cmd
+}
+
+// controlKillCmd returns a command that closes the agent's tmux window.
+// Killed agents are reported as failed.
+func (m UIModel) controlKillCmd(agentID string, reply chan error) (tea.Cmd, error) {
+	agent, ok := m.agents[agentID]
+	if !ok {
+		return nil, fmt.Errorf("agent %q: %w", agentID, control.ErrNotFound)
+	}
+	if agent.Info.Status != domain.AgentRunning {
+		return nil, fmt.Errorf("%w: agent %q is not running", control.ErrInvalidRequest, agentID)
+	}
+	if m.app == nil || m.app.Runner() == nil {
+		return nil, fmt.Errorf("no tmux runner available")
+	}
+
+	runner := m.app.Runner()
+	launcherID := agent.Info.LauncherID
+	logger := m.app.Logger(logging.Tmux).With("agent", agentID, "window", launcherID)
+	return func() tea.Msg {
+		logger.Info("killing agent window on control API request")
+		if err := tmux.KillWindow(context.Background(), runner, launcherID); err != nil {
+			logger.Error("failed to kill agent window", "err", err)
+			reply <- err
+			return nil
+		}
+		reply <- nil
+		return AgentStatusMsg{AgentID: agentID, Status: domain.AgentFailed}
+	}, nil
+}
+
+// resolveControlProject returns the project directory a request refers to,
+// defaulting to the active project.
+func resolveControlProject(myApp *app.App, project string) (string, error) {
+	if project == "" {
+		if myApp.ActiveProject != "" {
+			return myApp.ActiveProject, nil
+		}
+		return app.ExtractRepoRoot(myApp.Opts.BeadsDir), nil
+	}
+	if project != myApp.ActiveProject && !myApp.IsProjectInWorkspace(project) {
+		return "", fmt.Errorf("project %q is not in the workspace: %w", project, control.ErrNotFound)
+	}
+	return project, nil
+}
+
+func findReadyTicket(ctx context.Context, myApp *app.App, projectDir, ticketID string) (domain.Ticket, error) {
+	store, err := myApp.StoreForProject(ctx, projectDir)
+	if err != nil {
+		return domain.Ticket{}, err
+	}
+	tickets, err := store.ListTickets(ctx, data.TicketFilter{})
+	if err != nil {
+		return domain.Ticket{}, err
+	}
+	for _, t := range tickets {
+		if t.ID == ticketID {
+			return t, nil
+		}
+	}
+	return domain.Ticket{}, fmt.Errorf("ticket %q is not a ready ticket of %s: %w", ticketID, projectDir, control.ErrNotFound)
+}
+
+// ControlBackend answers control API requests for a running TUI. Requests
+// that need UI state are forwarded to the program as tea messages, so the
+// TUI updates live.
+type ControlBackend struct {
+	app  *app.App
+	send func(tea.Msg)
+}
+
+// Verify interface compliance at compile time.
+var _ control.Backend = (*ControlBackend)(nil)
+
+// NewControlBackend creates a backend that delivers messages with send,
+// typically (*tea.Program).Send.
+func NewControlBackend(myApp *app.App, send func(tea.Msg)) *ControlBackend {
+	return &ControlBackend{app: myApp, send: send}
+}
+
+// Projects lists the workspace projects.
+func (b *ControlBackend) Projects(_ context.Context) ([]control.Project, error) {
+	projects := b.app.GetProjects()
+	out := make([]control.Project, 0, len(projects))
+	for _, p := range projects {
+		out = append(out, control.Project{Name: p.Name, Dir: p.Dir, Active: p.Dir == b.app.ActiveProject})
+	}
+	return out, nil
+}
+
+// Tickets lists the ready tickets of a project.
+func (b *ControlBackend) Tickets(ctx context.Context, project string) ([]control.Ticket, error) {
+	projectDir, err := resolveControlProject(b.app, project)
+	if err != nil {
+		return nil, err
+	}
+	store, err := b.app.StoreForProject(ctx, projectDir)
+	if err != nil {
+		return nil, err
+	}
+	tickets, err := store.ListTickets(ctx, data.TicketFilter{})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]control.Ticket, 0, len(tickets))
+	for _, t := range tickets {
+		out = append(out, control.TicketFromDomain(t))
+	}
+	return out, nil
+}
+
+// Harnesses lists the harnesses currently loaded in the TUI.
+func (b *ControlBackend) Harnesses(ctx context.Context) ([]control.Harness, error) {
+	return askUI(ctx, b.send, func(reply chan []control.Harness) tea.Msg {
+		return controlHarnessesMsg{reply: reply}
+	})
+}
+
+// Agents lists the agents tracked by the TUI.
+func (b *ControlBackend) Agents(ctx context.Context) ([]control.Agent, error) {
+	return askUI(ctx, b.send, func(reply chan []control.Agent) tea.Msg {
+		return controlAgentsMsg{reply: reply}
+	})
+}
+
+// Launch starts an agent and adds it to the TUI.
+func (b *ControlBackend) Launch(ctx context.Context, req control.LaunchRequest) (*control.LaunchResponse, error) {
+	reply, err := askUI(ctx, b.send, func(reply chan controlLaunchReply) tea.Msg {
+		return controlLaunchMsg{req: req, reply: reply}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.res, reply.err
+}
+
+// Kill closes a running agent's tmux window.
+func (b *ControlBackend) Kill(ctx context.Context, agentID string) error {
+	err, ctxErr := askUI(ctx, b.send, func(reply chan error) tea.Msg {
+		return controlKillMsg{agentID: agentID, reply: reply}
+	})
+	if ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// askUI sends a request message built around a reply channel and waits for
+// the answer or for ctx to end.
+func askUI[T any](ctx context.Context, send func(tea.Msg), build func(chan T) tea.Msg) (T, error) {
+	reply := make(chan T, 1)
+	// Send blocks until the program has started, so never hold up the caller on it.
+	go send(build(reply))
+
+	select {
+	case v := <-reply:
+		return v, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
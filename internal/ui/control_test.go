@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/control"
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/events"
+)
+
+type fakePublisher struct {
+	events []control.Event
+}
+
+func (p *fakePublisher) Publish(ev control.Event) {
+	p.events = append(p.events, ev)
+}
+
+func TestControl_AgentsSortedByStart(t *testing.T) {
+	m := *NewTestModel()
+	now := time.Now()
+	m.agents = map[string]*RunningAgent{
+		"bb-2": {Info: &domain.AgentInfo{ID: "bb-2", StartedAt: now, Status: domain.AgentRunning}},
+		"bb-1": {Info: &domain.AgentInfo{ID: "bb-1", StartedAt: now.Add(-time.Minute), Status: domain.AgentCompleted}},
+	}
+
+	reply := make(chan []control.Agent, 1)
+	_, _, handled := m.handleControlMsgs(controlAgentsMsg{reply: reply})
+	require.True(t, handled)
+
+	agents := <-reply
+	require.Len(t, agents, 2)
+	assert.Equal(t, "bb-1", agents[0].ID)
+	assert.Equal(t, "bb-2", agents[1].ID)
+}
+
+func TestControl_KillUnknownAgent(t *testing.T) {
+	m := *NewTestModel()
+	m.agents = make(map[string]*RunningAgent)
+
+	reply := make(chan error, 1)
+	_, cmd, _ := m.handleControlMsgs(controlKillMsg{agentID: "nope", reply: reply})
+	assert.Nil(t, cmd)
+	assert.ErrorIs(t, <-reply, control.ErrNotFound)
+}
+
+func TestControl_KillFinishedAgent(t *testing.T) {
+	m := *NewTestModel()
+	m.agents = map[string]*RunningAgent{
+		"bb-1": {Info: &domain.AgentInfo{ID: "bb-1", Status: domain.AgentCompleted}},
+	}
+
+	reply := make(chan error, 1)
+	m.handleControlMsgs(controlKillMsg{agentID: "bb-1", reply: reply})
+	assert.ErrorIs(t, <-reply, control.ErrInvalidRequest)
+}
+
+func TestControl_LaunchValidatesHarnessAndAgent(t *testing.T) {
+	m := *NewTestModel()
+	m.harnesses = []domain.Harness{{Name: "opencode", SupportedAgents: []string{"build"}}}
+
+	reply := make(chan controlLaunchReply, 1)
+	m.handleControlMsgs(controlLaunchMsg{req: control.LaunchRequest{Ticket: "bb-1", Harness: "missing"}, reply: reply})
+	assert.ErrorIs(t, (<-reply).err, control.ErrNotFound)
+
+	m.handleControlMsgs(controlLaunchMsg{
+		req:   control.LaunchRequest{Ticket: "bb-1", Harness: "opencode", Agent: "plan"},
+		reply: reply,
+	})
+	assert.ErrorIs(t, (<-reply).err, control.ErrInvalidRequest)
+}
+
+func TestControl_LaunchResultKeepsView(t *testing.T) {
+	m := *NewTestModel()
+	m.app = newTestApp()
+	m.agents = make(map[string]*RunningAgent)
+	m.state = ViewStateMatrix
+	publisher := &fakePublisher{}
+	m = m.WithControl(publisher)
+
+	res := &domain.LaunchResult{LauncherID: "bb-1", LauncherType: domain.LauncherTypeTmux}
+	spec := &domain.LaunchSpec{Selection: domain.Selection{Ticket: domain.Ticket{ID: "bb-1"}}}
+	newModel, _ := m.handleControlLaunchResult(controlLaunchResultMsg{launchResultMsg{res: res, spec: spec, worktree: "/src/app"}})
+
+	updated := newModel.(UIModel)
+	assert.Equal(t, ViewStateMatrix, updated.state)
+	assert.Nil(t, updated.launchResult)
+	assert.Contains(t, updated.agents, "bb-1")
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, control.EventAgentLaunched, publisher.events[0].Type)
+}
+
+// runBatch runs the commands of a batch concurrently and returns the
+// messages they produced within a second. Ticks are left behind.
+func runBatch(t *testing.T, cmd tea.Cmd) []tea.Msg {
+	t.Helper()
+	require.NotNil(t, cmd)
+	batch, ok := cmd().(tea.BatchMsg)
+	require.True(t, ok)
+
+	results := make(chan tea.Msg, len(batch))
+	pending := 0
+	for _, c := range batch {
+		if c == nil {
+			continue
+		}
+		pending++
+		go func() { results <- c() }()
+	}
+	var msgs []tea.Msg
+	timeout := time.After(time.Second)
+	for ; pending > 0; pending-- {
+		select {
+		case msg := <-results:
+			msgs = append(msgs, msg)
+		case <-timeout:
+			return msgs
+		}
+	}
+	return msgs
+}
+
+func TestControl_LaunchIsRecordedUnderItsProject(t *testing.T) {
+	sink := &recordingSink{}
+	m := NewTestModel().WithEvents(events.NewBus(sink))
+	m.app = newTestApp()
+	m.app.ActiveProject = "/src/app"
+	m.app.AddProject(domain.Project{Dir: "/src/other"})
+	m.app.Stores = map[string]data.TicketStore{
+		"/src/app":   &fake.TicketStore{},
+		"/src/other": &fake.TicketStore{Tickets: []domain.Ticket{{ID: "bb-7", Title: "Other"}}},
+	}
+	m.agents = make(map[string]*RunningAgent)
+	m.harnesses = []domain.Harness{{Name: "opencode", CommandTemplate: "opencode"}}
+
+	reply := make(chan controlLaunchReply, 1)
+	_, cmd, _ := m.handleControlMsgs(controlLaunchMsg{
+		req:   control.LaunchRequest{Project: "/src/other", Ticket: "bb-7", Harness: "opencode"},
+		reply: reply,
+	})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(controlLaunchResultMsg)
+	require.True(t, ok)
+	require.NoError(t, (<-reply).err)
+	assert.Equal(t, "/src/other", msg.project)
+
+	msg.res.PID = 42
+	newModel, cmd := m.handleControlLaunchResult(msg)
+	for _, msg := range runBatch(t, cmd) {
+		_, isWarning := msg.(warningMsg)
+		assert.False(t, isWarning, "%v", msg)
+	}
+
+	assert.Equal(t, "/src/other", newModel.(UIModel).agents[msg.res.LauncherID].ProjectDir)
+	store, err := m.app.AgentStoreForProject(context.Background(), "/src/other")
+	require.NoError(t, err)
+	agents := store.(*fake.AgentStore).Agents
+	require.Len(t, agents, 1)
+	assert.Equal(t, "/src/other", agents[0].ProjectDir)
+	assert.Equal(t, "bb-7", agents[0].Ticket)
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, events.AgentLaunched, sink.events[0].Type)
+	assert.Equal(t, "/src/other", sink.events[0].Project)
+}
+
+func TestControl_StatusChangePublishesOnce(t *testing.T) {
+	m := *NewTestModel()
+	m.agents = map[string]*RunningAgent{
+		"bb-1": {Info: &domain.AgentInfo{ID: "bb-1", Status: domain.AgentRunning}},
+	}
+	publisher := &fakePublisher{}
+	m = m.WithControl(publisher)
+
+	newModel, _ := m.HandleAgentStatus(AgentStatusMsg{AgentID: "bb-1", Status: domain.AgentCompleted})
+	newModel.(UIModel).HandleAgentStatus(AgentStatusMsg{AgentID: "bb-1", Status: domain.AgentCompleted})
+
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, control.EventAgentStatus, publisher.events[0].Type)
+	assert.Equal(t, "completed", publisher.events[0].Agent.Status)
+}
+
+func TestControlBackend_AsksUI(t *testing.T) {
+	m := *NewTestModel()
+	m.harnesses = []domain.Harness{{Name: "opencode", SupportedModels: []string{"gpt-5"}}}
+	backend := NewControlBackend(nil, func(msg tea.Msg) { m.handleControlMsgs(msg) })
+
+	harnesses, err := backend.Harnesses(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []control.Harness{{Name: "opencode", Models: []string{"gpt-5"}}}, harnesses)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	silent := NewControlBackend(nil, func(tea.Msg) {})
+	_, err = silent.Agents(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,399 @@
+package ui
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/exec/tmux"
+)
+
+// dashboardPollInterval is how often the dashboard refreshes the last output
+// line of running agents.
+const dashboardPollInterval = 2 * time.Second
+
+// dashboardRow is one agent in the dashboard table.
+type dashboardRow struct {
+	agent    *RunningAgent
+	project  string
+	runtime  time.Duration
+	lastLine string
+}
+
+// dashboardColumn describes a table column. Flexible columns (width 0) share
+// the space left by the fixed ones.
+type dashboardColumn struct {
+	title   string
+	width   int
+	value   func(r dashboardRow) string
+	compare func(a, b dashboardRow) int
+}
+
+func compareByValue(value func(r dashboardRow) string) func(a, b dashboardRow) int {
+	return func(a, b dashboardRow) int {
+		return strings.Compare(strings.ToLower(value(a)), strings.ToLower(value(b)))
+	}
+}
+
+func stringColumn(title string, width int, value func(r dashboardRow) string) dashboardColumn {
+	return dashboardColumn{title: title, width: width, value: value, compare: compareByValue(value)}
+}
+
+var dashboardColumns = []dashboardColumn{
+	stringColumn("Ticket", 10, func(r dashboardRow) string { return r.agent.Info.TicketID }),
+	stringColumn("Title", 0, func(r dashboardRow) string { return r.agent.Info.TicketTitle }),
+	stringColumn("Harness", 9, func(r dashboardRow) string { return r.agent.Info.HarnessName }),
+	stringColumn("Model", 12, func(r dashboardRow) string { return r.agent.Info.ModelName }),
+	stringColumn("Agent", 9, func(r dashboardRow) string { return r.agent.Info.AgentName }),
+	stringColumn("Worktree", 12, func(r dashboardRow) string { return filepath.Base(r.agent.Info.WorktreePath) }),
+	{
+		title: "Status",
+		width: 9,
+		value: func(r dashboardRow) string { s, _ := getAgentStatus(r.agent.Info.Status); return s },
+		compare: func(a, b dashboardRow) int {
+			return cmp.Compare(statusRank(a.agent.Info.Status), statusRank(b.agent.Info.Status))
+		},
+	},
+	{
+		title:   "Runtime",
+		width:   8,
+		value:   func(r dashboardRow) string { return formatRuntime(r.runtime) },
+		compare: func(a, b dashboardRow) int { return cmp.Compare(a.runtime, b.runtime) },
+	},
+	stringColumn("Last output", 0, func(r dashboardRow) string { return r.lastLine }),
+	{
+		title: "Exit",
+		width: 4,
+		value: func(r dashboardRow) string {
+			if r.agent.Info.ExitCode == nil {
+				return "-"
+			}
+			return strconv.Itoa(*r.agent.Info.ExitCode)
+		},
+		compare: func(a, b dashboardRow) int {
+			// Unknown exit codes sort after known ones.
+			ea, eb := a.agent.Info.ExitCode, b.agent.Info.ExitCode
+			switch {
+			case ea == nil && eb == nil:
+				return 0
+			case ea == nil:
+				return 1
+			case eb == nil:
+				return -1
+			}
+			return cmp.Compare(*ea, *eb)
+		},
+	},
+}
+
+// dashboardStatusColumn is the default sort column: running agents first.
+const dashboardStatusColumn = 6
+
+// statusRank orders statuses by how much attention they need.
+func statusRank(s domain.AgentStatus) int {
+	switch s {
+	case domain.AgentRunning:
+		return 0
+	case domain.AgentFailed:
+		return 1
+	case domain.AgentCompleted:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// dashboardStatusFilters are cycled through by the status filter key; nil
+// shows every agent.
+var dashboardStatusFilters = []*domain.AgentStatus{
+	nil,
+	statusPtr(domain.AgentRunning),
+	statusPtr(domain.AgentCompleted),
+	statusPtr(domain.AgentFailed),
+}
+
+func statusPtr(s domain.AgentStatus) *domain.AgentStatus { return &s }
+
+// dashboardState is the cursor, sorting and filters of ViewStateDashboard.
+type dashboardState struct {
+	cursor       int
+	sortColumn   int
+	sortDesc     bool
+	statusFilter int    // index into dashboardStatusFilters
+	project      string // "" shows all projects
+	pollSeq      int    // identifies the polling loop of the open dashboard
+	status       string
+}
+
+func newDashboardState() dashboardState {
+	return dashboardState{sortColumn: dashboardStatusColumn}
+}
+
+// dashboardRows returns the agents matching the filters in table order.
+func (m UIModel) dashboardRows(now time.Time) []dashboardRow {
+	d := m.dashboard
+	filter := dashboardStatusFilters[d.statusFilter]
+
+	rows := make([]dashboardRow, 0, len(m.agents))
+	for _, agent := range m.agents {
+		if agent == nil || agent.Info == nil {
+			continue
+		}
+		if filter != nil && agent.Info.Status != *filter {
+			continue
+		}
+		project := m.agentProject(agent.Info)
+		if d.project != "" && project != d.project {
+			continue
+		}
+		rows = append(rows, dashboardRow{
+			agent:    agent,
+			project:  project,
+			runtime:  agentRuntime(agent.Info, now),
+			lastLine: lastOutputLine(agent.LastOutput),
+		})
+	}
+
+	column := dashboardColumns[d.sortColumn]
+	slices.SortStableFunc(rows, func(a, b dashboardRow) int {
+		c := column.compare(a, b)
+		if d.sortDesc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+		if c := a.agent.Info.StartedAt.Compare(b.agent.Info.StartedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.agent.Info.ID, b.agent.Info.ID)
+	})
+	return rows
+}
+
+// agentProject returns the name of the sidebar project whose worktree the
+// agent runs in.
+func (m UIModel) agentProject(info *domain.AgentInfo) string {
+	for i := range m.sidebar.State().Nodes {
+		project := &m.sidebar.State().Nodes[i]
+		found := false
+		forEachWorktree(project, func(wt *domain.SidebarNode) {
+			if wt.Path == info.WorktreePath {
+				found = true
+			}
+		})
+		if found {
+			return project.Name
+		}
+	}
+	return filepath.Base(info.WorktreePath)
+}
+
+// dashboardProjects lists the projects that have agents, for the project
+// filter.
+func (m UIModel) dashboardProjects() []string {
+	var projects []string
+	for _, agent := range m.agents {
+		if agent == nil || agent.Info == nil {
+			continue
+		}
+		if p := m.agentProject(agent.Info); !slices.Contains(projects, p) {
+			projects = append(projects, p)
+		}
+	}
+	slices.Sort(projects)
+	return projects
+}
+
+func agentRuntime(info *domain.AgentInfo, now time.Time) time.Duration {
+	if info.StartedAt.IsZero() {
+		return 0
+	}
+	end := now
+	if !info.FinishedAt.IsZero() {
+		end = info.FinishedAt
+	}
+	return max(end.Sub(info.StartedAt), 0)
+}
+
+func formatRuntime(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// lastOutputLine returns the last non-blank line of an agent's output.
+func lastOutputLine(output string) string {
+	lines := strings.Split(output, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+func (m UIModel) openDashboard() (tea.Model, tea.Cmd, bool) {
+	m.state = ViewStateDashboard
+	m.dashboard.status = ""
+	return m, m.startDashboardPolling(), true
+}
+
+// startDashboardPolling captures the screens of running agents right away and
+// keeps doing so while the dashboard stays open. The seq bump retires any
+// loop left from an earlier visit.
+func (m *UIModel) startDashboardPolling() tea.Cmd {
+	m.dashboard.pollSeq++
+	return tea.Batch(m.captureAgentScreensCmd(), dashboardTickCmd(m.dashboard.pollSeq))
+}
+
+func (m UIModel) captureAgentScreensCmd() tea.Cmd {
+	var cmds []tea.Cmd
+	for id, agent := range m.agents {
+		if agent != nil && agent.Capture != nil && agent.Info.Status == domain.AgentRunning {
+			cmds = append(cmds, readAgentScreenCmd(id, agent.Capture))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// HandleDashboardTick refreshes the agents' last output lines while the
+// dashboard is open.
+func (m UIModel) HandleDashboardTick(msg dashboardTickMsg) (tea.Model, tea.Cmd) {
+	if m.state != ViewStateDashboard || msg.seq != m.dashboard.pollSeq {
+		return m, nil
+	}
+	return m, tea.Batch(m.captureAgentScreensCmd(), dashboardTickCmd(msg.seq))
+}
+
+// HandleAgentWindowSelected reports a failed jump to an agent's window.
+func (m UIModel) HandleAgentWindowSelected(msg agentWindowSelectedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.dashboard.status = fmt.Sprintf("Jump failed: %v", msg.err)
+	}
+	return m, nil
+}
+
+// handleDashboardKeyMsg handles the dashboard keys; back and quit return to
+// the matrix.
+func (m UIModel) handleDashboardKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if m.state != ViewStateDashboard {
+		return m, nil, false
+	}
+	d := &m.dashboard
+	rows := m.dashboardRows(time.Now())
+	d.cursor = min(d.cursor, max(len(rows)-1, 0))
+
+	switch {
+	case key.Matches(msg, m.keys.Back, m.keys.Quit, m.keys.Dashboard):
+		m.state = ViewStateMatrix
+		return m, nil, true
+	case key.Matches(msg, m.keys.Up):
+		d.cursor = max(d.cursor-1, 0)
+	case key.Matches(msg, m.keys.Down):
+		d.cursor = min(d.cursor+1, max(len(rows)-1, 0))
+	case key.Matches(msg, m.keys.Enter):
+		if d.cursor < len(rows) {
+			newModel, cmd := m.HandleAgentSelected(AgentSelectedMsg{AgentID: rows[d.cursor].agent.Info.ID})
+			return newModel, cmd, true
+		}
+	case key.Matches(msg, m.keys.JumpToWindow):
+		if d.cursor < len(rows) {
+			return m, m.jumpToWindowCmd(rows[d.cursor].agent), true
+		}
+	case key.Matches(msg, m.keys.SortColumn):
+		d.sortColumn = (d.sortColumn + 1) % len(dashboardColumns)
+	case key.Matches(msg, m.keys.SortReverse):
+		d.sortDesc = !d.sortDesc
+	case key.Matches(msg, m.keys.FilterStatus):
+		d.statusFilter = (d.statusFilter + 1) % len(dashboardStatusFilters)
+		d.cursor = 0
+	case key.Matches(msg, m.keys.FilterProject):
+		d.project = nextProject(m.dashboardProjects(), d.project)
+		d.cursor = 0
+	default:
+		return m, nil, false
+	}
+	d.status = ""
+	return m, nil, true
+}
+
+// nextProject cycles through all projects ("") and each project in turn.
+func nextProject(projects []string, current string) string {
+	if current == "" {
+		if len(projects) == 0 {
+			return ""
+		}
+		return projects[0]
+	}
+	i := slices.Index(projects, current)
+	if i < 0 || i+1 >= len(projects) {
+		return ""
+	}
+	return projects[i+1]
+}
+
+func (m *UIModel) jumpToWindowCmd(agent *RunningAgent) tea.Cmd {
+	if agent.Info.Status != domain.AgentRunning || agent.Capture == nil || m.app == nil || m.app.Runner() == nil {
+		m.dashboard.status = "Agent has no tmux window to jump to"
+		return nil
+	}
+	runner, window := m.app.Runner(), agent.Info.LauncherID
+	return func() tea.Msg {
+		return agentWindowSelectedMsg{err: tmux.SelectWindow(context.Background(), runner, window)}
+	}
+}
+
+func (m UIModel) buildDashboardConfig() DashboardConfig {
+	if m.state != ViewStateDashboard {
+		return DashboardConfig{}
+	}
+	rows := m.dashboardRows(time.Now())
+	return DashboardConfig{
+		Rows:       rows,
+		Cursor:     m.dashboard.cursor,
+		SortColumn: m.dashboard.sortColumn,
+		SortDesc:   m.dashboard.sortDesc,
+		Summary:    m.dashboardSummary(len(rows)),
+		Status:     m.dashboard.status,
+		Keys:       m.keys,
+		Width:      m.layout.Width,
+		Height:     m.layout.Height,
+		Theme:      m.getThemeValue(),
+	}
+}
+
+// dashboardSummary describes the active filters and sorting.
+func (m UIModel) dashboardSummary(shown int) string {
+	d := m.dashboard
+	status := "all"
+	if f := dashboardStatusFilters[d.statusFilter]; f != nil {
+		status, _ = getAgentStatus(*f)
+		status = strings.ToLower(status)
+	}
+	project := "all"
+	if d.project != "" {
+		project = d.project
+	}
+	order := "▲"
+	if d.sortDesc {
+		order = "▼"
+	}
+	return fmt.Sprintf("Status: %s • Project: %s • Sort: %s %s • %d of %d agents",
+		status, project, strings.ToLower(dashboardColumns[d.sortColumn].title), order, shown, len(m.agents))
+}
@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/exec/tmux"
+)
+
+func intPtr(i int) *int { return &i }
+
+// dashboardModel returns a model on the dashboard with agents in two
+// projects: alpha (two worktrees) and beta.
+func dashboardModel(t *testing.T) UIModel {
+	t.Helper()
+	m := NewTestModel()
+	m.layout = LayoutDimensions{Width: 160, Height: 30}
+	m.sidebar.State().SetNodes([]domain.SidebarNode{
+		{Name: "alpha", Type: domain.NodeTypeProject, Children: []domain.SidebarNode{
+			{Name: "main", Path: "/src/alpha", Type: domain.NodeTypeWorktree},
+			{Name: "feature", Path: "/src/alpha-feature", Type: domain.NodeTypeWorktree},
+		}},
+		{Name: "beta", Type: domain.NodeTypeProject, Children: []domain.SidebarNode{
+			{Name: "main", Path: "/src/beta", Type: domain.NodeTypeWorktree},
+		}},
+	})
+
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	m.agents = map[string]*RunningAgent{
+		"a1": {Info: &domain.AgentInfo{
+			ID: "a1", Name: "bd-1", TicketID: "bd-1", TicketTitle: "Fix login", WorktreePath: "/src/alpha",
+			Status: domain.AgentCompleted, StartedAt: start, FinishedAt: start.Add(90 * time.Second),
+			ExitCode: intPtr(0),
+		}, LastOutput: "building\nall tests passed\n\n"},
+		"a2": {Info: &domain.AgentInfo{
+			ID: "a2", Name: "bd-2", TicketID: "bd-2", TicketTitle: "Add search", WorktreePath: "/src/alpha-feature",
+			Status: domain.AgentRunning, StartedAt: start.Add(time.Minute),
+		}},
+		"b1": {Info: &domain.AgentInfo{
+			ID: "b1", Name: "bd-3", TicketID: "bd-3", TicketTitle: "Crash on start", WorktreePath: "/src/beta",
+			Status: domain.AgentFailed, StartedAt: start.Add(2 * time.Minute), FinishedAt: start.Add(3 * time.Minute),
+			ExitCode: intPtr(2),
+		}},
+	}
+
+	newModel, _, handled := m.handleGlobalKeyMsg(runeKey("D"))
+	require.True(t, handled)
+	return newModel.(UIModel)
+}
+
+func dashboardKey(t *testing.T, m UIModel, msg tea.KeyMsg) (UIModel, tea.Cmd) {
+	t.Helper()
+	newModel, cmd, handled := m.handleDashboardKeyMsg(msg)
+	require.True(t, handled, msg.String())
+	return newModel.(UIModel), cmd
+}
+
+func rowIDs(rows []dashboardRow) []string {
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.agent.Info.ID
+	}
+	return ids
+}
+
+func TestDashboard_RowsAcrossProjects(t *testing.T) {
+	m := dashboardModel(t)
+	require.Equal(t, ViewStateDashboard, m.state)
+
+	rows := m.dashboardRows(time.Now())
+	assert.Equal(t, []string{"a2", "b1", "a1"}, rowIDs(rows), "running first, then failed, then completed")
+	assert.Equal(t, "alpha", rows[0].project)
+	assert.Equal(t, "beta", rows[1].project)
+	assert.Equal(t, 90*time.Second, rows[2].runtime, "finished agents stop the clock")
+	assert.Equal(t, "all tests passed", rows[2].lastLine)
+}
+
+func TestDashboard_SortAndFilter(t *testing.T) {
+	m := dashboardModel(t)
+
+	// Runtime is the column after status; reversing puts the longest first.
+	m, _ = dashboardKey(t, m, runeKey("s"))
+	assert.Equal(t, "Runtime", dashboardColumns[m.dashboard.sortColumn].title)
+	m, _ = dashboardKey(t, m, runeKey("S"))
+	assert.Equal(t, []string{"a2", "a1", "b1"}, rowIDs(m.dashboardRows(time.Now())))
+
+	m, _ = dashboardKey(t, m, runeKey("f"))
+	assert.Equal(t, []string{"a2"}, rowIDs(m.dashboardRows(time.Now())), "running only")
+
+	m, _ = dashboardKey(t, m, runeKey("f"))
+	m, _ = dashboardKey(t, m, runeKey("f"))
+	m, _ = dashboardKey(t, m, runeKey("f"))
+	assert.Len(t, m.dashboardRows(time.Now()), 3, "status filter cycles back to all")
+
+	m, _ = dashboardKey(t, m, runeKey("p"))
+	assert.Equal(t, "alpha", m.dashboard.project)
+	assert.Len(t, m.dashboardRows(time.Now()), 2)
+	m, _ = dashboardKey(t, m, runeKey("p"))
+	assert.Equal(t, []string{"b1"}, rowIDs(m.dashboardRows(time.Now())))
+	m, _ = dashboardKey(t, m, runeKey("p"))
+	assert.Empty(t, m.dashboard.project)
+}
+
+func TestDashboard_OpenOutputAndReturn(t *testing.T) {
+	m := dashboardModel(t)
+	m, _ = dashboardKey(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = dashboardKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	assert.Equal(t, ViewStateAgentOutput, m.state)
+	assert.Equal(t, "b1", m.viewingAgentID)
+
+	newModel, cmd, handled := m.handleGlobalKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	require.True(t, handled)
+	m = newModel.(UIModel)
+	assert.Equal(t, ViewStateDashboard, m.state)
+	assert.Empty(t, m.viewingAgentID)
+	assert.NotNil(t, cmd, "returning to the dashboard resumes polling")
+
+	m, _ = dashboardKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Equal(t, ViewStateMatrix, m.state)
+}
+
+func TestDashboard_TickStopsWhenClosed(t *testing.T) {
+	m := dashboardModel(t)
+	seq := m.dashboard.pollSeq
+
+	_, cmd := m.HandleDashboardTick(dashboardTickMsg{seq: seq})
+	assert.NotNil(t, cmd)
+	_, cmd = m.HandleDashboardTick(dashboardTickMsg{seq: seq - 1})
+	assert.Nil(t, cmd, "stale loops end")
+
+	m, _ = dashboardKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	_, cmd = m.HandleDashboardTick(dashboardTickMsg{seq: seq})
+	assert.Nil(t, cmd)
+}
+
+func TestDashboard_JumpToWindow(t *testing.T) {
+	fake := tmux.NewFakeRunner()
+	fake.AlwaysReturn = []byte{}
+	application, err := app.NewApp(&mockConfigLoader{}, &mockLauncher{}, nil, fake, nil, domain.AppOptions{Demo: true})
+	require.NoError(t, err)
+
+	m := dashboardModel(t)
+	m.app = application
+	agent := m.agents["a2"]
+	agent.Info.LauncherID = "bb-2"
+	agent.Capture = tmux.NewOutputCapture(fake, "bb-2")
+
+	m, cmd := dashboardKey(t, m, runeKey("w"))
+	require.NotNil(t, cmd)
+	newModel, _ := m.HandleAgentWindowSelected(cmd().(agentWindowSelectedMsg))
+	m = newModel.(UIModel)
+	assert.Equal(t, []string{"tmux select-window -t bb-2"}, fake.Commands)
+	assert.Empty(t, m.dashboard.status)
+
+	newModel, _ = m.HandleAgentWindowSelected(agentWindowSelectedMsg{err: errors.New("no window")})
+	assert.Contains(t, newModel.(UIModel).dashboard.status, "Jump failed")
+
+	// Finished agents have no window left to jump to.
+	m, _ = dashboardKey(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = dashboardKey(t, m, runeKey("w"))
+	assert.Nil(t, cmd)
+}
+
+func TestRenderDashboard(t *testing.T) {
+	m := dashboardModel(t)
+	s := ansi.Strip(RenderDashboard(m.buildDashboardConfig()))
+
+	for _, want := range []string{
+		"Agent Dashboard", "Status ▲", "Last output", "Fix login", "alpha-featu…",
+		"1m30s", "all tests passed", "Failed", "▶ bd-2", "3 of 3 agents",
+	} {
+		assert.Contains(t, s, want)
+	}
+}
+
+func TestFormatRuntime(t *testing.T) {
+	assert.Equal(t, "45s", formatRuntime(45*time.Second))
+	assert.Equal(t, "12m05s", formatRuntime(12*time.Minute+5*time.Second))
+	assert.Equal(t, "2h03m", formatRuntime(2*time.Hour+3*time.Minute+59*time.Second))
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/events"
+)
+
+// WithEvents returns a copy of the model that emits lifecycle events on bus.
+func (m UIModel) WithEvents(bus *events.Bus) UIModel {
+	m.eventBus = bus
+	return m
+}
+
+// emitEventCmd delivers ev off the UI loop, since hooks may take a while.
+// Delivery failures are surfaced as warnings.
+func (m UIModel) emitEventCmd(ev events.Event) tea.Cmd {
+	if m.eventBus == nil {
+		return nil
+	}
+	bus := m.eventBus
+	return func() tea.Msg {
+		if err := bus.Emit(context.Background(), ev); err != nil {
+			return warningMsg{err: fmt.Errorf("%s event: %w", ev.Type, err)}
+		}
+		return nil
+	}
+}
+
+// agentStatusEvent maps a finished agent status to its event type.
+func agentStatusEvent(status domain.AgentStatus) (events.Type, bool) {
+	switch status {
+	case domain.AgentCompleted:
+		return events.AgentExited, true
+	case domain.AgentFailed:
+		return events.AgentFailed, true
+	}
+	return "", false
+}
+
+// launchFailedEvent describes a launch that failed in projectDir. spec is
+// nil when the launch failed before the command was rendered.
+func launchFailedEvent(projectDir string, spec *domain.LaunchSpec, err error) events.Event {
+	ev := events.Event{
+		Type:    events.AgentFailed,
+		Project: projectDir,
+		Launch:  events.LaunchFromSpec(spec),
+		Error:   err.Error(),
+	}
+	if spec != nil {
+		ev.Agent = events.AgentFromInfo(&domain.AgentInfo{
+			ID:           spec.LauncherID,
+			Name:         spec.Selection.Ticket.ID,
+			LauncherID:   spec.LauncherID,
+			WorktreePath: spec.WorkDir,
+			Status:       domain.AgentFailed,
+			TicketID:     spec.Selection.Ticket.ID,
+			TicketTitle:  spec.Selection.Ticket.Title,
+			HarnessName:  spec.Selection.Harness.Name,
+			ModelName:    spec.Selection.Model,
+			AgentName:    spec.Selection.Agent,
+		})
+	}
+	return ev
+}
+
+// eventProject returns the project directory events are attributed to.
+func eventProject(myApp *app.App) string {
+	if myApp == nil {
+		return ""
+	}
+	if myApp.ActiveProject != "" {
+		return myApp.ActiveProject
+	}
+	return app.ExtractRepoRoot(myApp.Opts.BeadsDir)
+}
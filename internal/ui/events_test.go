@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/events"
+)
+
+type recordingSink struct {
+	events []events.Event
+	err    error
+}
+
+func (s *recordingSink) Handle(_ context.Context, ev events.Event) error {
+	s.events = append(s.events, ev)
+	return s.err
+}
+
+func TestHandleAgentStatus_EmitsFinishEvents(t *testing.T) {
+	sink := &recordingSink{}
+	m := NewTestModel().WithEvents(events.NewBus(sink))
+	m.agents = map[string]*RunningAgent{
+		"bb-1": {Info: &domain.AgentInfo{ID: "bb-1", TicketID: "bb-1", Status: domain.AgentRunning}},
+	}
+
+	newModel, cmd := m.HandleAgentStatus(AgentStatusMsg{AgentID: "bb-1", Status: domain.AgentFailed})
+	require.NotNil(t, cmd)
+	assert.Nil(t, cmd())
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, events.AgentFailed, sink.events[0].Type)
+	assert.Equal(t, "failed", sink.events[0].Agent.Status)
+
+	// Repeated polls with the same status do not re-emit.
+	_, cmd = newModel.(UIModel).HandleAgentStatus(AgentStatusMsg{AgentID: "bb-1", Status: domain.AgentFailed})
+	assert.Nil(t, cmd)
+}
+
+func TestHandleAgentStatus_EventCarriesAgentProject(t *testing.T) {
+	sink := &recordingSink{}
+	m := NewTestModel().WithEvents(events.NewBus(sink))
+	m.app = newTestApp()
+	m.app.ActiveProject = "/src/app"
+	m.agents = map[string]*RunningAgent{
+		"bb-1": {Info: &domain.AgentInfo{ID: "bb-1", TicketID: "bb-1", Status: domain.AgentRunning}, ProjectDir: "/src/other"},
+	}
+
+	_, cmd := m.HandleAgentStatus(AgentStatusMsg{AgentID: "bb-1", Status: domain.AgentCompleted})
+	runBatch(t, cmd)
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, events.AgentExited, sink.events[0].Type)
+	assert.Equal(t, "/src/other", sink.events[0].Project)
+}
+
+func TestHandleLaunchResult_EmitsFailedEvent(t *testing.T) {
+	sink := &recordingSink{}
+	m := NewTestModel().WithEvents(events.NewBus(sink))
+	spec := &domain.LaunchSpec{
+		Selection:  domain.Selection{Ticket: domain.Ticket{ID: "bb-1"}, Harness: domain.Harness{Name: "claude"}},
+		LauncherID: "bb-1",
+	}
+
+	_, cmd := m.handleLaunchResult(launchResultMsg{spec: spec, err: errors.New("no tmux"), project: "/src/other"})
+	require.NotNil(t, cmd)
+	assert.Nil(t, cmd())
+	require.Len(t, sink.events, 1)
+	ev := sink.events[0]
+	assert.Equal(t, events.AgentFailed, ev.Type)
+	assert.Equal(t, "/src/other", ev.Project)
+	assert.Equal(t, "no tmux", ev.Error)
+	require.NotNil(t, ev.Agent)
+	assert.Equal(t, "bb-1", ev.Agent.Ticket)
+	assert.Equal(t, "failed", ev.Agent.Status)
+	assert.Equal(t, "claude", ev.Launch.Harness)
+}
+
+func TestHandleControlLaunchResult_EmitsFailedEvent(t *testing.T) {
+	sink := &recordingSink{}
+	m := NewTestModel().WithEvents(events.NewBus(sink))
+
+	_, cmd := m.handleControlLaunchResult(controlLaunchResultMsg{launchResultMsg{err: errors.New("render failed"), project: "/src/other"}})
+	require.NotNil(t, cmd)
+	cmd()
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, events.AgentFailed, sink.events[0].Type)
+	assert.Equal(t, "/src/other", sink.events[0].Project)
+	assert.Equal(t, "render failed", sink.events[0].Error)
+	assert.Nil(t, sink.events[0].Agent, "nothing was rendered")
+}
+
+func TestEmitEventCmd_ReportsSinkErrors(t *testing.T) {
+	m := NewTestModel().WithEvents(events.NewBus(&recordingSink{err: errors.New("hook notify: exit status 1")}))
+
+	msg := m.emitEventCmd(events.Event{Type: events.ProjectAdded})()
+	warning, ok := msg.(warningMsg)
+	require.True(t, ok)
+	assert.EqualError(t, warning.err, "project.added event: hook notify: exit status 1")
+
+	assert.Nil(t, NewTestModel().emitEventCmd(events.Event{Type: events.ProjectAdded}), "no bus, no command")
+}
@@ -101,7 +101,7 @@ func DefaultKeyMap() KeyMap {
 		Up:            key.NewBinding(key.WithKeys("k", "up", "ctrl+p"), key.WithHelp("k", "up")),
 		PageUp:        key.NewBinding(key.WithKeys("K", "pgup"), key.WithHelp("pgup", "page up")),
 		PageDown:      key.NewBinding(key.WithKeys("J", "pgdown"), key.WithHelp("pgdown", "page down")),
-		Back:          key.NewBinding(key.WithKeys("h", "backspace", "left", "esc"), key.WithHelp("h", "back")),
+		Back:          key.NewBinding(key.WithKeys("h", "backspace", "left"), key.WithHelp("h", "back")),
 		Open:          key.NewBinding(key.WithKeys("right", "enter"), key.WithHelp("enter", "open")),
 		Select:        key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
 		SwapView:      key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "swap view")),
@@ -111,6 +111,26 @@ func DefaultKeyMap() KeyMap {
 	}
 }
 
+// Bindings returns pointers to the bindings in k keyed by their action name,
+// as used in the filepicker_keys section of tui_config.yaml.
+func (k *KeyMap) Bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"go_to_top":       &k.GoToTop,
+		"go_to_last":      &k.GoToLast,
+		"down":            &k.Down,
+		"up":              &k.Up,
+		"page_up":         &k.PageUp,
+		"page_down":       &k.PageDown,
+		"back":            &k.Back,
+		"open":            &k.Open,
+		"select":          &k.Select,
+		"swap_view":       &k.SwapView,
+		"toggle_all_exts": &k.ToggleAllExts,
+		"toggle_hidden":   &k.ToggleHidden,
+		"edit_cwd":        &k.EditCwd,
+	}
+}
+
 // Styles defines the possible customizations for styles in the file picker.
 type Styles struct {
 	DisabledCursor   lipgloss.Style
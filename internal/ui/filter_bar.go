@@ -0,0 +1,355 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/search"
+	"github.com/megatherium/blunderbust/internal/logging"
+)
+
+// defaultTicketListTitle is the title of the ticket column without filters.
+const defaultTicketListTitle = "Select a Ticket"
+
+// filterField indexes the inputs of the ticket filter bar.
+type filterField int
+
+const (
+	filterFieldStatus filterField = iota
+	filterFieldType
+	filterFieldPriority
+	filterFieldAssignee
+	filterFieldLabel
+	filterFieldSearch
+	filterFieldName
+	filterFieldCount
+)
+
+var filterFieldLabels = [filterFieldCount]string{
+	"Status", "Type", "Priority", "Assignee", "Label", "Search", "Save as",
+}
+
+var filterFieldPlaceholders = [filterFieldCount]string{
+	"open, in_progress", "bug, feature, task", "1 or 0-2", "name", "label",
+	`text, "a phrase", -word, type:bug prio:0`, "preset name, saved with %s",
+}
+
+// ticketFilterBar edits the filter pushed down to the ticket store. The
+// list's own fuzzy filter (/) still narrows the result further.
+type ticketFilterBar struct {
+	inputs [filterFieldCount]textinput.Model
+	focus  filterField
+	err    string
+}
+
+func newTicketFilterBar(f data.TicketFilter, presetName string, keys KeyMap) ticketFilterBar {
+	values := [filterFieldCount]string{
+		f.Status, f.IssueType, data.FormatPriorityRange(f.MinPriority, f.MaxPriority),
+		f.Assignee, f.Label, f.Search, presetName,
+	}
+	var b ticketFilterBar
+	for i := range b.inputs {
+		ti := textinput.New()
+		ti.Prompt = ""
+		ti.Placeholder = filterFieldPlaceholders[i]
+		if filterField(i) == filterFieldName {
+			ti.Placeholder = fmt.Sprintf(ti.Placeholder, keys.FilterSave.Help().Key)
+		}
+		ti.SetValue(values[i])
+		b.inputs[i] = ti
+	}
+	b.setFocus(filterFieldStatus)
+	return b
+}
+
+func (b *ticketFilterBar) setFocus(field filterField) {
+	b.inputs[b.focus].Blur()
+	b.focus = (field + filterFieldCount) % filterFieldCount
+	b.inputs[b.focus].Focus()
+}
+
+func (b ticketFilterBar) value(field filterField) string {
+	return strings.TrimSpace(b.inputs[field].Value())
+}
+
+// Filter returns the filter described by the inputs.
+func (b ticketFilterBar) Filter() (data.TicketFilter, error) {
+	minPrio, maxPrio, err := data.ParsePriorityRange(b.value(filterFieldPriority))
+	if err != nil {
+		return data.TicketFilter{}, err
+	}
+	if _, err := search.Parse(b.value(filterFieldSearch)); err != nil {
+		return data.TicketFilter{}, err
+	}
+	return data.TicketFilter{
+		Status:      b.value(filterFieldStatus),
+		IssueType:   b.value(filterFieldType),
+		Assignee:    b.value(filterFieldAssignee),
+		Label:       b.value(filterFieldLabel),
+		MinPriority: minPrio,
+		MaxPriority: maxPrio,
+		Search:      b.value(filterFieldSearch),
+	}, nil
+}
+
+func (b ticketFilterBar) View(width int, keys KeyMap, theme ThemePalette) string {
+	boxWidth := min(max(width-20, 40), 80)
+	labelWidth := 10
+
+	titleStyle := lipgloss.NewStyle().Foreground(theme.TitleColor).Bold(true)
+	labelStyle := lipgloss.NewStyle().Width(labelWidth).Foreground(ThemeInactive)
+	focusedLabelStyle := labelStyle.Foreground(theme.FocusIndicator).Bold(true)
+
+	lines := []string{titleStyle.Render("Filter Tickets"), ""}
+	for i := range b.inputs {
+		style := labelStyle
+		if filterField(i) == b.focus {
+			style = focusedLabelStyle
+		}
+		input := b.inputs[i]
+		input.Width = boxWidth - labelWidth - 8
+		lines = append(lines, style.Render(filterFieldLabels[i])+input.View())
+	}
+	if b.err != "" {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(ThemeWarning).Render(b.err))
+	}
+	var hints []string
+	for _, b := range []key.Binding{keys.FilterNextField, keys.FilterApply, keys.FilterSave, keys.FilterCancel} {
+		hints = append(hints, b.Help().Key+" "+b.Help().Desc)
+	}
+	hints = append(hints, keys.FilterPreset.Help().Key+" presets")
+	lines = append(lines, "", lipgloss.NewStyle().Foreground(ThemeInactive).Render(strings.Join(hints, " · ")))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.TitleColor).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(strings.Join(lines, "\n"))
+}
+
+// presetTicketFilter returns the ticket filter a preset describes.
+func presetTicketFilter(p config.FilterPreset) data.TicketFilter {
+	return data.TicketFilter{
+		Status:      p.Status,
+		IssueType:   p.IssueType,
+		Assignee:    p.Assignee,
+		Label:       p.Label,
+		MinPriority: p.MinPriority,
+		MaxPriority: p.MaxPriority,
+		Search:      p.Search,
+	}
+}
+
+func newFilterPreset(name string, f data.TicketFilter) config.FilterPreset {
+	return config.FilterPreset{
+		Name:        name,
+		Status:      f.Status,
+		IssueType:   f.IssueType,
+		MinPriority: f.MinPriority,
+		MaxPriority: f.MaxPriority,
+		Assignee:    f.Assignee,
+		Label:       f.Label,
+		Search:      f.Search,
+	}
+}
+
+func sameTicketFilter(a, b data.TicketFilter) bool {
+	if data.FormatPriorityRange(a.MinPriority, a.MaxPriority) != data.FormatPriorityRange(b.MinPriority, b.MaxPriority) {
+		return false
+	}
+	a.MinPriority, a.MaxPriority, b.MinPriority, b.MaxPriority = nil, nil, nil, nil
+	return a == b
+}
+
+// ticketFilterSummary describes a filter compactly, e.g. for the title of
+// the ticket column.
+func ticketFilterSummary(f data.TicketFilter) string {
+	var parts []string
+	for _, p := range []struct{ name, value string }{
+		{"status", f.Status},
+		{"type", f.IssueType},
+		{"prio", data.FormatPriorityRange(f.MinPriority, f.MaxPriority)},
+		{"assignee", f.Assignee},
+		{"label", f.Label},
+	} {
+		if p.value != "" {
+			parts = append(parts, p.name+":"+p.value)
+		}
+	}
+	// The search is in the same syntax already.
+	if f.Search != "" {
+		parts = append(parts, f.Search)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ticketListTitle names the scope, unless it is the ready scope, and the
+// active preset or filter in the ticket column.
+func (m UIModel) ticketListTitle() string {
+	title := defaultTicketListTitle
+	if m.ticketFilter.Scope != data.ScopeReady {
+		title += " (" + m.ticketFilter.Scope.String() + ")"
+	}
+	switch {
+	case m.activePreset != "":
+		return fmt.Sprintf("%s [%s]", title, m.activePreset)
+	case m.filterActive():
+		return fmt.Sprintf("%s [%s]", title, ticketFilterSummary(m.ticketFilter))
+	default:
+		return title
+	}
+}
+
+func (m UIModel) openFilterBar() (tea.Model, tea.Cmd, bool) {
+	m.filterBar = newTicketFilterBar(m.ticketFilter, m.activePreset, m.keys)
+	m.showFilterBar = true
+	return m, nil, true
+}
+
+// applyTicketFilter makes f the active filter and reloads the tickets of the
+// active project with it. The ticket scope is kept; it is switched separately.
+func (m *UIModel) applyTicketFilter(f data.TicketFilter, presetName string) tea.Cmd {
+	f.Scope = m.ticketFilter.Scope
+	m.ticketFilter = f
+	m.activePreset = presetName
+	m.ticketList.Title = m.ticketListTitle()
+	m.dirtyTicket = true
+	if m.app == nil {
+		return nil
+	}
+	return loadTicketsCmd(m.app.Project(), m.ticketFilter, m.app.Logger(logging.Dolt))
+}
+
+// applyFilterPreset applies the preset at index i, if there is one.
+func (m UIModel) applyFilterPreset(i int) (tea.Model, tea.Cmd, bool) {
+	if i < 0 || i >= len(m.filterPresets) {
+		return m, nil, true
+	}
+	p := m.filterPresets[i]
+	return m, m.applyTicketFilter(presetTicketFilter(p), p.Name), true
+}
+
+// saveFilterPreset stores p in the TUI config, replacing a preset of the
+// same name.
+func (m *UIModel) saveFilterPreset(p config.FilterPreset) error {
+	idx := slices.IndexFunc(m.filterPresets, func(e config.FilterPreset) bool { return e.Name == p.Name })
+	presets := slices.Clone(m.filterPresets)
+	if idx >= 0 {
+		presets[idx] = p
+	} else {
+		presets = append(presets, p)
+	}
+
+	if m.app == nil || m.app.Opts.TUIConfigPath == "" {
+		return errors.New("no TUI config file to save presets to")
+	}
+	cfg, err := config.LoadTUIConfig(m.app.Opts.TUIConfigPath)
+	if err != nil {
+		return err
+	}
+	cfg.FilterPresets = presets
+	if err := config.SaveTUIConfig(m.app.Opts.TUIConfigPath, cfg); err != nil {
+		return err
+	}
+	m.filterPresets = presets
+	return nil
+}
+
+// handleFilterBarKeyMsg drives the open filter bar.
+func (m UIModel) handleFilterBarKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if !m.showFilterBar {
+		return m, nil, false
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.FilterCancel):
+		m.showFilterBar = false
+		return m, nil, true
+	case key.Matches(msg, m.keys.FilterNextField):
+		m.filterBar.setFocus(m.filterBar.focus + 1)
+		return m, nil, true
+	case key.Matches(msg, m.keys.FilterPrevField):
+		m.filterBar.setFocus(m.filterBar.focus - 1)
+		return m, nil, true
+	case key.Matches(msg, m.keys.FilterApply, m.keys.FilterSave):
+		f, err := m.filterBar.Filter()
+		if err != nil {
+			m.filterBar.err = err.Error()
+			return m, nil, true
+		}
+		name := m.filterBar.value(filterFieldName)
+		if key.Matches(msg, m.keys.FilterSave) {
+			if name == "" {
+				m.filterBar.err = "Enter a name to save the preset"
+				m.filterBar.setFocus(filterFieldName)
+				return m, nil, true
+			}
+			if err := m.saveFilterPreset(newFilterPreset(name, f)); err != nil {
+				m.filterBar.err = fmt.Sprintf("Saving the preset failed: %v", err)
+				return m, nil, true
+			}
+		} else if i := slices.IndexFunc(m.filterPresets, func(p config.FilterPreset) bool { return p.Name == name }); i < 0 || !sameTicketFilter(presetTicketFilter(m.filterPresets[i]), f) {
+			// An edited preset is no longer that preset.
+			name = ""
+		}
+		m.showFilterBar = false
+		return m, m.applyTicketFilter(f, name), true
+	}
+
+	var cmd tea.Cmd
+	m.filterBar.inputs[m.filterBar.focus], cmd = m.filterBar.inputs[m.filterBar.focus].Update(msg)
+	m.filterBar.err = ""
+	return m, cmd, true
+}
+
+// handleFilterKeyMsg handles the filter bar, preset and scope keys of the matrix.
+func (m UIModel) handleFilterKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if m.state != ViewStateMatrix {
+		return m, nil, false
+	}
+	switch {
+	case key.Matches(msg, m.keys.FilterBar):
+		return m.openFilterBar()
+	case key.Matches(msg, m.keys.FilterPreset):
+		return m.applyFilterPreset(slices.Index(m.keys.FilterPreset.Keys(), msg.String()))
+	case key.Matches(msg, m.keys.ClearFilter):
+		return m, m.applyTicketFilter(data.TicketFilter{}, ""), true
+	case key.Matches(msg, m.keys.TicketScope):
+		return m.cycleTicketScope()
+	}
+	return m, nil, false
+}
+
+// HandleFilterPresetSelected applies a preset chosen in the palette.
+func (m UIModel) HandleFilterPresetSelected(msg filterPresetSelectedMsg) (tea.Model, tea.Cmd) {
+	newM, cmd, _ := m.applyFilterPreset(msg.index)
+	return newM, cmd
+}
+
+// filterPresetPaletteActions offers applying any saved filter preset.
+func filterPresetPaletteActions(m UIModel) []paletteAction {
+	if m.state != ViewStateMatrix {
+		return nil
+	}
+	actions := make([]paletteAction, 0, len(m.filterPresets))
+	for i, p := range m.filterPresets {
+		index := i
+		actions = append(actions, paletteAction{
+			title: "Apply filter preset " + p.Name,
+			run: func(UIModel) tea.Cmd {
+				return func() tea.Msg { return filterPresetSelectedMsg{index: index} }
+			},
+		})
+	}
+	return actions
+}
@@ -0,0 +1,220 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// filterModel returns a matrix model whose active project is a fake store
+// with three tickets.
+func filterModel(t *testing.T) UIModel {
+	t.Helper()
+	application := newTestApp()
+	application.ActiveProject = "/src/app"
+	application.Stores = map[string]data.TicketStore{"/src/app": &fake.TicketStore{
+		Tickets: []domain.Ticket{
+			{ID: "bb-1", Title: "Fix login", Status: "open", Priority: 0, IssueType: "bug", Assignee: "alice"},
+			{ID: "bb-2", Title: "Add search", Status: "open", Priority: 2, IssueType: "feature", Assignee: "bob"},
+			{ID: "bb-3", Title: "Crash on start", Status: "open", Priority: 1, IssueType: "bug", Assignee: "bob"},
+		},
+	}}
+
+	m := NewUIModel(application, nil)
+	m.state = ViewStateMatrix
+	m.focus = FocusTickets
+	m, _ = m.handleWindowSizeMsg(tea.WindowSizeMsg{Width: 160, Height: 40})
+	return m
+}
+
+// loadFiltered runs a ticket load command and delivers its result.
+func loadFiltered(t *testing.T, m UIModel, cmd tea.Cmd) UIModel {
+	t.Helper()
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(ticketsLoadedMsg)
+	require.True(t, ok)
+	newModel, _ := m.handleTicketsLoaded(msg)
+	return newModel.(UIModel)
+}
+
+func visibleTicketIDs(m UIModel) []string {
+	var ids []string
+	for _, item := range m.ticketList.Items() {
+		if ti, ok := item.(ticketItem); ok {
+			ids = append(ids, ti.ticket.ID)
+		}
+	}
+	return ids
+}
+
+func filterBarKey(t *testing.T, m UIModel, msg tea.KeyMsg) (UIModel, tea.Cmd) {
+	t.Helper()
+	newModel, cmd, handled := m.handleKeyMsg(msg)
+	require.True(t, handled, msg.String())
+	return newModel.(UIModel), cmd
+}
+
+func typeText(t *testing.T, m UIModel, s string) UIModel {
+	t.Helper()
+	for _, r := range s {
+		m, _ = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestFilterBar_PushesFilterToStore(t *testing.T) {
+	m := filterModel(t)
+
+	m, _ = filterBarKey(t, m, runeKey("F"))
+	require.True(t, m.showFilterBar)
+	assert.Contains(t, ansi.Strip(m.View()), "Filter Tickets")
+
+	m, _ = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	m = typeText(t, m, "bug")
+	m, _ = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	m = typeText(t, m, "0-1")
+	m, _ = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	m = typeText(t, m, "bob")
+
+	m, cmd := filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, m.showFilterBar)
+	assert.Equal(t, "bug", m.ticketFilter.IssueType)
+
+	m = loadFiltered(t, m, cmd)
+	assert.Equal(t, []string{"bb-3"}, visibleTicketIDs(m))
+	assert.Equal(t, "Select a Ticket [type:bug prio:0-1 assignee:bob]", m.ticketList.Title)
+
+	// Clearing brings every ticket back.
+	m, cmd = filterBarKey(t, m, runeKey("0"))
+	m = loadFiltered(t, m, cmd)
+	assert.Len(t, visibleTicketIDs(m), 3)
+	assert.Equal(t, "Select a Ticket", m.ticketList.Title)
+}
+
+func TestFilterBar_InvalidPriorityKeepsBarOpen(t *testing.T) {
+	m := filterModel(t)
+	m, _ = filterBarKey(t, m, runeKey("F"))
+	m.filterBar.setFocus(filterFieldPriority)
+	m = typeText(t, m, "high")
+
+	m, cmd := filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.True(t, m.showFilterBar)
+	assert.Contains(t, m.filterBar.err, "not a priority")
+
+	m, _ = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, m.showFilterBar)
+	assert.True(t, m.ticketFilter.IsZero())
+}
+
+func TestFilterBar_UsesKeyMap(t *testing.T) {
+	m := filterModel(t)
+	m.keys.FilterNextField = key.NewBinding(key.WithKeys("ctrl+n"), key.WithHelp("ctrl+n", "next"))
+	m.keys.FilterApply = key.NewBinding(key.WithKeys("ctrl+a"), key.WithHelp("ctrl+a", "apply"))
+	m.keys.FilterSave = key.NewBinding(key.WithKeys("ctrl+w"), key.WithHelp("ctrl+w", "save preset"))
+	m.keys.FilterCancel = key.NewBinding(key.WithKeys("ctrl+g"), key.WithHelp("ctrl+g", "cancel"))
+
+	m, _ = filterBarKey(t, m, runeKey("F"))
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "ctrl+n next · ctrl+a apply · ctrl+w save preset · ctrl+g cancel")
+	assert.Contains(t, view, "preset name, saved with ctrl+w")
+
+	m, _ = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, m.showFilterBar, "esc is no longer bound")
+	m, _ = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyCtrlN})
+	assert.Equal(t, filterFieldType, m.filterBar.focus)
+	m = typeText(t, m, "bug")
+
+	m, cmd := filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.True(t, m.showFilterBar, "enter is no longer bound")
+	m, cmd = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyCtrlA})
+	assert.False(t, m.showFilterBar)
+	assert.Equal(t, "bug", m.ticketFilter.IssueType)
+	assert.NotNil(t, cmd)
+
+	m, _ = filterBarKey(t, m, runeKey("F"))
+	m, _ = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyCtrlG})
+	assert.False(t, m.showFilterBar)
+}
+
+func TestFilterBar_SearchSyntax(t *testing.T) {
+	m := filterModel(t)
+	m, _ = filterBarKey(t, m, runeKey("F"))
+	m.filterBar.setFocus(filterFieldSearch)
+	m = typeText(t, m, "type:")
+
+	m, cmd := filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Contains(t, m.filterBar.err, "needs a value")
+
+	m = typeText(t, m, "bug -crash")
+	m, cmd = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = loadFiltered(t, m, cmd)
+	assert.Equal(t, []string{"bb-1"}, visibleTicketIDs(m))
+	assert.Equal(t, "Select a Ticket [type:bug -crash]", m.ticketList.Title)
+}
+
+func TestFilterPresets_NumberKeysAndSave(t *testing.T) {
+	m := filterModel(t)
+	m.app.Opts.TUIConfigPath = filepath.Join(t.TempDir(), "tui_config.yaml")
+	zero := 0
+	m.filterPresets = []config.FilterPreset{
+		{Name: "urgent", MaxPriority: &zero},
+		{Name: "bob's bugs", IssueType: "bug", Assignee: "bob"},
+	}
+
+	m, cmd := filterBarKey(t, m, runeKey("2"))
+	m = loadFiltered(t, m, cmd)
+	assert.Equal(t, []string{"bb-3"}, visibleTicketIDs(m))
+	assert.Equal(t, "Select a Ticket [bob's bugs]", m.ticketList.Title)
+
+	_, cmd = filterBarKey(t, m, runeKey("9"))
+	assert.Nil(t, cmd, "keys without a preset do nothing")
+
+	// Editing a preset in the bar and saving it under a new name.
+	m, _ = filterBarKey(t, m, runeKey("F"))
+	m.filterBar.setFocus(filterFieldType)
+	m.filterBar.inputs[filterFieldType].SetValue("feature")
+	m.filterBar.setFocus(filterFieldName)
+	m.filterBar.inputs[filterFieldName].SetValue("bob's features")
+	m, cmd = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	require.False(t, m.showFilterBar, m.filterBar.err)
+	m = loadFiltered(t, m, cmd)
+	assert.Equal(t, []string{"bb-2"}, visibleTicketIDs(m))
+	assert.Equal(t, "bob's features", m.activePreset)
+
+	cfg, err := config.LoadTUIConfig(m.app.Opts.TUIConfigPath)
+	require.NoError(t, err)
+	require.Len(t, cfg.FilterPresets, 3)
+	assert.Equal(t, "feature", cfg.FilterPresets[2].IssueType)
+
+	// Applying an edited preset with enter drops the preset name.
+	m, _ = filterBarKey(t, m, runeKey("F"))
+	m.filterBar.inputs[filterFieldStatus].SetValue("open")
+	m, _ = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Empty(t, m.activePreset)
+}
+
+func TestFilterPresets_InPalette(t *testing.T) {
+	m := filterModel(t)
+	m.filterPresets = []config.FilterPreset{{Name: "bugs", IssueType: "bug"}}
+
+	actions := filterPresetPaletteActions(m)
+	require.Len(t, actions, 1)
+	assert.Equal(t, "Apply filter preset bugs", actions[0].title)
+
+	newModel, cmd := m.HandleFilterPresetSelected(actions[0].cmd(m)().(filterPresetSelectedMsg))
+	m = loadFiltered(t, newModel.(UIModel), cmd)
+	assert.Equal(t, []string{"bb-1", "bb-3"}, visibleTicketIDs(m))
+}
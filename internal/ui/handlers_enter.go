@@ -10,15 +10,18 @@ import (
 func (m UIModel) handleEnterKey() (tea.Model, tea.Cmd) {
 	// Exit agent output view when Enter is pressed
 	if m.state == ViewStateAgentOutput {
-		m.viewingAgentID = ""
-		m.state = ViewStateMatrix
-		return m, nil
+		return m, m.closeAgentOutput()
 	}
 
 	switch m.state {
 	case ViewStateMatrix:
 		return m.handleMatrixEnterKey()
 	case ViewStateConfirm:
+		if m.selection.Ticket.IsBlocked() {
+			// Blocked tickets are only launched once the override is confirmed.
+			m.confirmBlocked = true
+			return m, nil
+		}
 		m.state = ViewStateMatrix
 		return m, m.launchCmd()
 	}
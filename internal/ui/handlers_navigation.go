@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -15,12 +16,12 @@ func (m UIModel) handleNavigationKeysMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bo
 		return m, nil, false
 	}
 
-	switch msg.String() {
-	case "left", "h":
+	switch {
+	case key.Matches(msg, m.keys.Left):
 		return m.handleLeftNavigation()
-	case "right", "l":
+	case key.Matches(msg, m.keys.Right):
 		return m.handleRightNavigation()
-	case "tab":
+	case key.Matches(msg, m.keys.NextColumn):
 		if m.focus < FocusAgent {
 			m.advanceFocus()
 		} else {
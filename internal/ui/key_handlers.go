@@ -10,22 +10,13 @@ import (
 	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/data/dolt"
 	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/logging"
 )
 
-func (m UIModel) handleModalKeyMsg() (tea.Model, tea.Cmd, bool) {
-	if m.showModal {
-		m.showModal = false
-		return m, nil, true
-	}
-	return m, nil, false
-}
-
 func (m UIModel) handleQuitKeyMsg() (tea.Model, tea.Cmd, bool) {
 	switch m.state {
 	case ViewStateAgentOutput:
-		m.viewingAgentID = ""
-		m.state = ViewStateMatrix
-		return m, nil, true
+		return m, m.closeAgentOutput(), true
 	default:
 		return m, tea.Quit, true
 	}
@@ -35,7 +26,7 @@ func (m UIModel) handleRefreshKeyMsg() (tea.Model, tea.Cmd, bool) {
 	if m.state == ViewStateMatrix && m.focus == FocusTickets {
 		m.state = ViewStateLoading
 		return m, tea.Batch(
-			loadTicketsCmd(m.app.Project(), m.app.Opts.Debug),
+			loadTicketsCmd(m.app.Project(), m.ticketFilter, m.app.Logger(logging.Dolt)),
 			discoverWorktreesCmd(m.app),
 			m.reloadTemplates(), // Also reload templates on refresh
 		), true
@@ -49,9 +40,7 @@ func (m UIModel) handleBackKeyMsg() (tea.Model, tea.Cmd, bool) {
 		return m, nil, true
 	}
 	if m.state == ViewStateAgentOutput {
-		m.viewingAgentID = ""
-		m.state = ViewStateMatrix
-		return m, nil, true
+		return m, m.closeAgentOutput(), true
 	}
 	if m.state == ViewStateMatrix && m.focus > FocusTickets {
 		m.focus--
@@ -62,15 +51,7 @@ func (m UIModel) handleBackKeyMsg() (tea.Model, tea.Cmd, bool) {
 
 func (m UIModel) handleInfoKeyMsg() (tea.Model, tea.Cmd, bool) {
 	if m.state == ViewStateMatrix && m.focus == FocusTickets {
-		if i, ok := m.ticketList.SelectedItem().(ticketItem); ok {
-			m.showModal = true
-			m.modalContent = "Loading bd show..."
-			beadsDir := ""
-			if i.project != nil {
-				beadsDir = i.project.BeadsDir()
-			}
-			return m, loadModalCmd(i.ticket.ID, beadsDir), true
-		}
+		return m.openTicketDetailModal()
 	}
 	return m, nil, false
 }
@@ -125,8 +106,8 @@ func (m UIModel) handleFilePickerKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, boo
 	if m.state != ViewStateFilePicker {
 		return m, nil, false
 	}
-	switch msg.String() {
-	case "a":
+	switch {
+	case key.Matches(msg, m.keys.AddDirectory) && !m.filepicker.EditingCwd:
 		if m.filePickerPurpose == fpPurposeAddProject {
 			currentDir := m.filepicker.CurrentDirectory
 			if currentDir != "" {
@@ -134,7 +115,7 @@ func (m UIModel) handleFilePickerKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, boo
 			}
 			return m, nil, true
 		}
-	case "esc":
+	case key.Matches(msg, m.keys.ClosePicker):
 		if m.filepicker.EditingCwd {
 			// Let the filepicker handle Esc to exit edit mode
 			break
@@ -166,12 +147,12 @@ func (m UIModel) handleAddProjectModalKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd
 	if m.state != ViewStateAddProjectModal {
 		return m, nil, false
 	}
-	switch msg.String() {
-	case "y", "Y":
+	switch {
+	case key.Matches(msg, m.keys.Confirm, m.keys.Enter):
 		return m, func() tea.Msg {
 			return addProjectConfirmedMsg{path: m.pendingProjectPath}
 		}, true
-	case "n", "N", "q", "esc":
+	case key.Matches(msg, m.keys.Cancel):
 		return m, func() tea.Msg {
 			return addProjectCancelledMsg{}
 		}, true
@@ -194,11 +175,11 @@ func (m UIModel) handleErrorStateKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, boo
 				beadsDir := filepath.Join(activeProject, ".beads")
 				projectCtx, err := data.NewProjectContext(m.retryStore, beadsDir, activeProject)
 				if err != nil {
-					return m, loadTicketsCmd(nil, m.app.Opts.Debug), true
+					return m, loadTicketsCmd(nil, m.ticketFilter, m.app.Logger(logging.Dolt)), true
 				}
-				return m, loadTicketsCmd(projectCtx, m.app.Opts.Debug), true
+				return m, loadTicketsCmd(projectCtx, m.ticketFilter, m.app.Logger(logging.Dolt)), true
 			}
-			return m, loadTicketsCmd(nil, m.app.Opts.Debug), true
+			return m, loadTicketsCmd(nil, m.ticketFilter, m.app.Logger(logging.Dolt)), true
 		}
 	case "s", "S":
 		if m.retryStore != nil {
@@ -253,6 +234,18 @@ func (m UIModel) handleGlobalKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 		return m.handleToggleThemeKeyMsg()
 	}
 
+	if key.Matches(msg, m.keys.TicketDetail) && m.state == ViewStateMatrix && m.focus == FocusTickets {
+		return m.toggleTicketDetailPane()
+	}
+
+	if key.Matches(msg, m.keys.Dashboard) && m.state == ViewStateMatrix {
+		return m.openDashboard()
+	}
+
+	if model, cmd, handled := m.handleFilterKeyMsg(msg); handled {
+		return model, cmd, true
+	}
+
 	if key.Matches(msg, m.keys.Zoom) {
 		// Only enable zoom when ticket column is focused
 		if m.focus == FocusTickets {
@@ -15,7 +15,71 @@ type KeyMap struct {
 	Refresh       key.Binding
 	PickTemplate  key.Binding
 	EditTemplate  key.Binding
+	Palette       key.Binding
 	Quit          key.Binding
+
+	// Column navigation in the matrix view.
+	Left       key.Binding
+	Right      key.Binding
+	NextColumn key.Binding
+
+	// Sidebar actions, only active while the sidebar is focused.
+	AddProject         key.Binding
+	ClearAgent         key.Binding
+	ClearStoppedAgents key.Binding
+
+	// File picker actions handled outside the picker: adding the current
+	// directory as a project and closing the picker.
+	AddDirectory key.Binding
+	ClosePicker  key.Binding
+
+	// Answers to yes/no prompts such as the add project confirmation.
+	Confirm key.Binding
+	Cancel  key.Binding
+
+	// Agent output viewer actions.
+	Search     key.Binding
+	NextMatch  key.Binding
+	PrevMatch  key.Binding
+	Follow     key.Binding
+	SaveOutput key.Binding
+
+	// Input for the viewed agent: a free-form message and quick replies.
+	SendInput     key.Binding
+	ReplyYes      key.Binding
+	ReplyContinue key.Binding
+	Interrupt     key.Binding
+
+	// Cross-project agent dashboard: open it from the matrix, then sort,
+	// filter and jump to an agent's tmux window.
+	Dashboard     key.Binding
+	SortColumn    key.Binding
+	SortReverse   key.Binding
+	FilterStatus  key.Binding
+	FilterProject key.Binding
+	JumpToWindow  key.Binding
+
+	// Ticket detail side panel next to the ticket column.
+	TicketDetail key.Binding
+	DetailDown   key.Binding
+	DetailUp     key.Binding
+
+	// Ticket filter bar and the filter presets from tui_config.yaml. The
+	// n-th key of FilterPreset applies the n-th preset.
+	FilterBar    key.Binding
+	FilterPreset key.Binding
+	ClearFilter  key.Binding
+
+	// Actions inside the open filter bar. Other keys edit the focused field.
+	FilterNextField key.Binding
+	FilterPrevField key.Binding
+	FilterApply     key.Binding
+	FilterSave      key.Binding
+	FilterCancel    key.Binding
+
+	// TicketScope cycles the ticket column through ready, in progress,
+	// blocked and all open tickets.
+	TicketScope key.Binding
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view.
@@ -27,7 +91,7 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Enter, k.Info, k.ToggleSidebar, k.ToggleTheme, k.Zoom, k.PickTemplate, k.EditTemplate},
-		{k.Back, k.Refresh, k.Quit},
+		{k.Back, k.Refresh, k.Dashboard, k.Palette, k.Quit},
 	}
 }
 
@@ -46,7 +110,7 @@ var keys = KeyMap{
 	),
 	Info: key.NewBinding(
 		key.WithKeys("i"),
-		key.WithHelp("i", "info (bd show)"),
+		key.WithHelp("i", "ticket details"),
 	),
 	ToggleSidebar: key.NewBinding(
 		key.WithKeys("p"),
@@ -76,10 +140,162 @@ var keys = KeyMap{
 		key.WithKeys("e"),
 		key.WithHelp("e", "edit template"),
 	),
+	Palette: key.NewBinding(
+		key.WithKeys("ctrl+p"),
+		key.WithHelp("ctrl+p", "commands"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
 	),
+	Left: key.NewBinding(
+		key.WithKeys("left", "h"),
+		key.WithHelp("←/h", "previous column"),
+	),
+	Right: key.NewBinding(
+		key.WithKeys("right", "l"),
+		key.WithHelp("→/l", "next column"),
+	),
+	NextColumn: key.NewBinding(
+		key.WithKeys("tab"),
+		key.WithHelp("tab", "cycle columns"),
+	),
+	AddProject: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "add project"),
+	),
+	ClearAgent: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "clear agent"),
+	),
+	ClearStoppedAgents: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "clear stopped agents"),
+	),
+	AddDirectory: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "add directory"),
+	),
+	ClosePicker: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "cancel"),
+	),
+	Confirm: key.NewBinding(
+		key.WithKeys("y", "Y"),
+		key.WithHelp("y", "yes"),
+	),
+	Cancel: key.NewBinding(
+		key.WithKeys("n", "N", "q", "esc"),
+		key.WithHelp("n/esc", "no"),
+	),
+	Search: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search"),
+	),
+	NextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next match"),
+	),
+	PrevMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "previous match"),
+	),
+	Follow: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "follow"),
+	),
+	SaveOutput: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "save"),
+	),
+	SendInput: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "send input"),
+	),
+	ReplyYes: key.NewBinding(
+		key.WithKeys("1"),
+		key.WithHelp("1", "y"),
+	),
+	ReplyContinue: key.NewBinding(
+		key.WithKeys("2"),
+		key.WithHelp("2", "continue"),
+	),
+	Interrupt: key.NewBinding(
+		key.WithKeys("3"),
+		key.WithHelp("3", "Ctrl-C"),
+	),
+	Dashboard: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "agent dashboard"),
+	),
+	SortColumn: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "sort column"),
+	),
+	SortReverse: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "reverse sort"),
+	),
+	FilterStatus: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "filter status"),
+	),
+	FilterProject: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "filter project"),
+	),
+	JumpToWindow: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "jump to window"),
+	),
+	TicketDetail: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "detail pane"),
+	),
+	DetailDown: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "scroll details down"),
+	),
+	DetailUp: key.NewBinding(
+		key.WithKeys("K"),
+		key.WithHelp("K", "scroll details up"),
+	),
+	FilterBar: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "filter tickets"),
+	),
+	FilterPreset: key.NewBinding(
+		key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+		key.WithHelp("1-9", "filter preset"),
+	),
+	ClearFilter: key.NewBinding(
+		key.WithKeys("0"),
+		key.WithHelp("0", "clear filter"),
+	),
+	FilterNextField: key.NewBinding(
+		key.WithKeys("tab", "down"),
+		key.WithHelp("tab", "next"),
+	),
+	FilterPrevField: key.NewBinding(
+		key.WithKeys("shift+tab", "up"),
+		key.WithHelp("shift+tab", "previous"),
+	),
+	FilterApply: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "apply"),
+	),
+	FilterSave: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "save preset"),
+	),
+	FilterCancel: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "cancel"),
+	),
+	TicketScope: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "ticket scope"),
+	),
 }
 
 // DefaultKeyMap returns the default keybindings for the UI.
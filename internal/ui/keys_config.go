@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/ui/filepicker"
+)
+
+// Bindings returns pointers to the bindings in k keyed by their action name,
+// as used in the keys section of tui_config.yaml.
+func (k *KeyMap) Bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":                   &k.Up,
+		"down":                 &k.Down,
+		"enter":                &k.Enter,
+		"info":                 &k.Info,
+		"toggle_sidebar":       &k.ToggleSidebar,
+		"toggle_theme":         &k.ToggleTheme,
+		"zoom":                 &k.Zoom,
+		"back":                 &k.Back,
+		"refresh":              &k.Refresh,
+		"pick_template":        &k.PickTemplate,
+		"edit_template":        &k.EditTemplate,
+		"command_palette":      &k.Palette,
+		"quit":                 &k.Quit,
+		"left":                 &k.Left,
+		"right":                &k.Right,
+		"next_column":          &k.NextColumn,
+		"add_project":          &k.AddProject,
+		"clear_agent":          &k.ClearAgent,
+		"clear_stopped_agents": &k.ClearStoppedAgents,
+		"add_directory":        &k.AddDirectory,
+		"close_picker":         &k.ClosePicker,
+		"confirm":              &k.Confirm,
+		"cancel":               &k.Cancel,
+		"search":               &k.Search,
+		"next_match":           &k.NextMatch,
+		"prev_match":           &k.PrevMatch,
+		"follow":               &k.Follow,
+		"save_output":          &k.SaveOutput,
+		"send_input":           &k.SendInput,
+		"reply_yes":            &k.ReplyYes,
+		"reply_continue":       &k.ReplyContinue,
+		"interrupt":            &k.Interrupt,
+		"dashboard":            &k.Dashboard,
+		"sort_column":          &k.SortColumn,
+		"sort_reverse":         &k.SortReverse,
+		"filter_status":        &k.FilterStatus,
+		"filter_project":       &k.FilterProject,
+		"jump_to_window":       &k.JumpToWindow,
+		"ticket_detail":        &k.TicketDetail,
+		"detail_down":          &k.DetailDown,
+		"detail_up":            &k.DetailUp,
+		"filter_bar":           &k.FilterBar,
+		"filter_preset":        &k.FilterPreset,
+		"clear_filter":         &k.ClearFilter,
+		"filter_next_field":    &k.FilterNextField,
+		"filter_prev_field":    &k.FilterPrevField,
+		"filter_apply":         &k.FilterApply,
+		"filter_save":          &k.FilterSave,
+		"filter_cancel":        &k.FilterCancel,
+		"ticket_scope":         &k.TicketScope,
+	}
+}
+
+// keyScopes lists the actions that can be triggered at the same time. A key
+// may be reused across scopes (c picks a template on the confirm screen and
+// clears an agent in the sidebar) but not within one.
+var keyScopes = []struct {
+	name    string
+	actions []string
+}{
+	{"matrix", []string{
+		"up", "down", "enter", "info", "toggle_sidebar", "toggle_theme", "zoom",
+		"back", "refresh", "quit", "left", "right", "next_column", "command_palette",
+		"dashboard", "ticket_detail", "detail_down", "detail_up", "filter_bar",
+		"filter_preset", "clear_filter", "ticket_scope",
+	}},
+	{"sidebar", []string{
+		"up", "down", "enter", "toggle_sidebar", "toggle_theme", "quit", "left",
+		"right", "next_column", "add_project", "clear_agent", "clear_stopped_agents",
+		"command_palette", "dashboard", "filter_bar", "filter_preset", "clear_filter",
+		"ticket_scope",
+	}},
+	{"confirm", []string{
+		"enter", "back", "quit", "toggle_theme", "pick_template", "edit_template",
+		"command_palette",
+	}},
+	{"agent output", []string{
+		"up", "down", "left", "right", "enter", "back", "quit", "toggle_theme",
+		"command_palette", "search", "next_match", "prev_match", "follow", "save_output",
+		"send_input", "reply_yes", "reply_continue", "interrupt",
+	}},
+	{"dashboard", []string{
+		"up", "down", "enter", "back", "quit", "toggle_theme", "command_palette",
+		"dashboard", "sort_column", "sort_reverse", "filter_status", "filter_project",
+		"jump_to_window",
+	}},
+	{"add project prompt", []string{"confirm", "enter", "cancel"}},
+	{"filter bar", []string{
+		"filter_next_field", "filter_prev_field", "filter_apply", "filter_save",
+		"filter_cancel",
+	}},
+}
+
+// filePickerScope lists the file picker actions, together with the
+// add_directory and close_picker actions of keys. Open and select share
+// enter by design: the picker opens directories and selects files.
+var filePickerScope = []string{
+	"go_to_top", "go_to_last", "down", "up", "page_up", "page_down", "back",
+	"open", "select", "swap_view", "toggle_all_exts", "toggle_hidden", "edit_cwd",
+	"add_directory", "close_picker",
+}
+
+// ApplyKeyBindings returns the default UI and file picker key maps with the
+// overrides from cfg applied. Help text follows the new keys, so the help
+// view reflects remapped bindings. It fails on unknown actions and on keys
+// bound to two actions that are active at the same time.
+func ApplyKeyBindings(cfg *config.TUIConfig) (KeyMap, filepicker.KeyMap, error) {
+	km := DefaultKeyMap()
+	fpKeys := filepicker.DefaultKeyMap()
+	if cfg == nil {
+		return km, fpKeys, nil
+	}
+
+	if err := overrideBindings("keys", km.Bindings(), cfg.Keys); err != nil {
+		return km, fpKeys, err
+	}
+	if err := overrideBindings("filepicker_keys", fpKeys.Bindings(), cfg.FilePickerKeys); err != nil {
+		return km, fpKeys, err
+	}
+
+	bindings := km.Bindings()
+	for _, scope := range keyScopes {
+		if err := checkKeyConflicts("keys", scope.name, bindings, scope.actions, nil); err != nil {
+			return km, fpKeys, err
+		}
+	}
+	pickerBindings := fpKeys.Bindings()
+	pickerBindings["add_directory"] = bindings["add_directory"]
+	pickerBindings["close_picker"] = bindings["close_picker"]
+	sharedEnter := map[[2]string]bool{{"open", "select"}: true}
+	if err := checkKeyConflicts("filepicker_keys", "file picker", pickerBindings, filePickerScope, sharedEnter); err != nil {
+		return km, fpKeys, err
+	}
+	return km, fpKeys, nil
+}
+
+func overrideBindings(section string, bindings map[string]*key.Binding, overrides map[string][]string) error {
+	actions := make([]string, 0, len(overrides))
+	for action := range overrides {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	for _, action := range actions {
+		b, ok := bindings[action]
+		if !ok {
+			return fmt.Errorf("%s.%s: unknown action", section, action)
+		}
+		keys := overrides[action]
+		b.SetKeys(keys...)
+		b.SetHelp(strings.Join(keys, "/"), b.Help().Desc)
+	}
+	return nil
+}
+
+// checkKeyConflicts reports the first key bound to two actions in scope,
+// skipping action pairs listed in allowed.
+func checkKeyConflicts(section, scope string, bindings map[string]*key.Binding, actions []string, allowed map[[2]string]bool) error {
+	owner := make(map[string]string)
+	for _, action := range actions {
+		for _, k := range bindings[action].Keys() {
+			prev, taken := owner[k]
+			if !taken {
+				owner[k] = action
+				continue
+			}
+			if prev == action || allowed[[2]string{prev, action}] || allowed[[2]string{action, prev}] {
+				continue
+			}
+			return fmt.Errorf("%s: key %q is bound to both %s and %s in the %s view", section, k, prev, action, scope)
+		}
+	}
+	return nil
+}
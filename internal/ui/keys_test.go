@@ -1,9 +1,13 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/megatherium/blunderbust/internal/config"
 )
 
 func TestKeyMapShortHelp(t *testing.T) {
@@ -18,8 +22,8 @@ func TestKeyMapFullHelp(t *testing.T) {
 	if len(help) != 2 {
 		t.Errorf("FullHelp() returned %d rows, want 2", len(help))
 	}
-	if len(help[0]) != 9 || len(help[1]) != 3 {
-		t.Errorf("FullHelp() rows have wrong length: got %d, %d, want 9, 3", len(help[0]), len(help[1]))
+	if len(help[0]) != 9 || len(help[1]) != 5 {
+		t.Errorf("FullHelp() rows have wrong length: got %d, %d, want 9, 5", len(help[0]), len(help[1]))
 	}
 }
 
@@ -53,3 +57,143 @@ func TestKeyMapSetEnabled(t *testing.T) {
 		t.Error("Back should be enabled after SetEnabled(true)")
 	}
 }
+
+func TestApplyKeyBindings_Overrides(t *testing.T) {
+	cfg := &config.TUIConfig{
+		Keys:           map[string][]string{"quit": {"x", "ctrl+c"}, "clear_agent": {"d"}},
+		FilePickerKeys: map[string][]string{"up": {"e"}},
+	}
+
+	km, fpKeys, err := ApplyKeyBindings(cfg)
+	if err != nil {
+		t.Fatalf("ApplyKeyBindings() error = %v", err)
+	}
+	if got := km.Quit.Keys(); len(got) != 2 || got[0] != "x" {
+		t.Errorf("Quit keys = %v, want [x ctrl+c]", got)
+	}
+	if help := km.Quit.Help(); help.Key != "x/ctrl+c" || help.Desc != "quit" {
+		t.Errorf("Quit help = %+v, want key x/ctrl+c desc quit", help)
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")}, km.ClearAgent) {
+		t.Error("ClearAgent should match remapped key d")
+	}
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")}, fpKeys.Up) {
+		t.Error("file picker Up should match remapped key e")
+	}
+	if got := DefaultKeyMap().Quit.Keys(); got[0] != "q" {
+		t.Errorf("defaults were modified: Quit keys = %v", got)
+	}
+}
+
+func TestApplyKeyBindings_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *config.TUIConfig
+		want string
+	}{
+		{
+			name: "unknown action",
+			cfg:  &config.TUIConfig{Keys: map[string][]string{"launch": {"l"}}},
+			want: "keys.launch: unknown action",
+		},
+		{
+			name: "conflict in matrix",
+			cfg:  &config.TUIConfig{Keys: map[string][]string{"refresh": {"q"}}},
+			want: `key "q" is bound to both refresh and quit`,
+		},
+		{
+			name: "conflict in sidebar",
+			cfg:  &config.TUIConfig{Keys: map[string][]string{"clear_agent": {"tab"}}},
+			want: `key "tab" is bound to both next_column and clear_agent in the sidebar view`,
+		},
+		{
+			name: "conflict in file picker",
+			cfg:  &config.TUIConfig{FilePickerKeys: map[string][]string{"edit_cwd": {"g"}}},
+			want: `filepicker_keys: key "g" is bound to both go_to_top and edit_cwd`,
+		},
+		{
+			name: "picker action of keys conflicts in file picker",
+			cfg:  &config.TUIConfig{Keys: map[string][]string{"add_directory": {"tab"}}},
+			want: `filepicker_keys: key "tab" is bound to both swap_view and add_directory`,
+		},
+		{
+			name: "conflict in add project prompt",
+			cfg:  &config.TUIConfig{Keys: map[string][]string{"cancel": {"y"}}},
+			want: `key "y" is bound to both confirm and cancel in the add project prompt view`,
+		},
+		{
+			name: "conflict in filter bar",
+			cfg:  &config.TUIConfig{Keys: map[string][]string{"filter_save": {"enter"}}},
+			want: `key "enter" is bound to both filter_apply and filter_save in the filter bar view`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ApplyKeyBindings(tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ApplyKeyBindings() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyKeyBindings_SharedAcrossViews(t *testing.T) {
+	// refresh is disabled while the sidebar is focused, so it may share a key
+	// with a sidebar-only action.
+	cfg := &config.TUIConfig{Keys: map[string][]string{"clear_agent": {"r"}}}
+	if _, _, err := ApplyKeyBindings(cfg); err != nil {
+		t.Errorf("ApplyKeyBindings() error = %v, want nil", err)
+	}
+	if _, _, err := ApplyKeyBindings(nil); err != nil {
+		t.Errorf("ApplyKeyBindings(nil) error = %v, want nil", err)
+	}
+}
+
+func TestSidebarAgentKeys_UseKeyMap(t *testing.T) {
+	m := NewTestModel()
+	m.focus = FocusSidebar
+	m.keys.ClearStoppedAgents = key.NewBinding(key.WithKeys("X"))
+
+	_, _, handled := m.HandleSidebarAgentKeysMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	if handled {
+		t.Error("default key C should no longer clear stopped agents")
+	}
+	_, _, handled = m.HandleSidebarAgentKeysMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	if !handled {
+		t.Error("remapped key X should clear stopped agents")
+	}
+}
+
+func TestPromptAndPickerKeys_UseKeyMap(t *testing.T) {
+	m := NewTestModel()
+	m.state = ViewStateAddProjectModal
+	m.keys.Confirm = key.NewBinding(key.WithKeys("o"))
+	m.keys.Cancel = key.NewBinding(key.WithKeys("x"))
+
+	_, cmd, _ := m.handleAddProjectModalKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd != nil {
+		t.Error("default key y should no longer confirm")
+	}
+	_, cmd, _ = m.handleAddProjectModalKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if cmd == nil {
+		t.Fatal("remapped key o should confirm")
+	}
+	if _, ok := cmd().(addProjectConfirmedMsg); !ok {
+		t.Error("remapped key o should confirm the project")
+	}
+	_, cmd, _ = m.handleAddProjectModalKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if cmd == nil {
+		t.Fatal("remapped key x should cancel")
+	}
+	if _, ok := cmd().(addProjectCancelledMsg); !ok {
+		t.Error("remapped key x should cancel the prompt")
+	}
+
+	m.state = ViewStateFilePicker
+	m.keys.ClosePicker = key.NewBinding(key.WithKeys("ctrl+g"))
+	newModel, _, _ := m.handleFilePickerKeyMsg(tea.KeyMsg{Type: tea.KeyCtrlG})
+	if state := newModel.(UIModel).state; state != ViewStateMatrix {
+		t.Errorf("remapped ctrl+g should close the picker, state = %v", state)
+	}
+}
@@ -3,7 +3,6 @@ package ui
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 	"runtime"
 	"time"
@@ -11,12 +10,15 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/megatherium/blunderbust/internal/control"
 	"github.com/megatherium/blunderbust/internal/discovery"
 	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/events"
 	"github.com/megatherium/blunderbust/internal/exec/tmux"
+	"github.com/megatherium/blunderbust/internal/logging"
 )
 
-// Performance counters for debug instrumentation (--debug flag).
+// Performance counters for debug instrumentation (--log-level debug).
 // These track the ticket refresh cycle to diagnose CPU burn issues.
 var (
 	perfTicketCheckCount   int64
@@ -30,11 +32,9 @@ var (
 func (m UIModel) handleTicketsLoaded(msg ticketsLoadedMsg) (tea.Model, tea.Cmd) {
 	perfTicketsLoadedCount++
 	now := time.Now()
-	if m.app.Opts.Debug {
-		sinceLast := now.Sub(perfLastLoadedTime)
-		fmt.Fprintf(os.Stderr, "[DEBUG][perf] ticketsLoaded #%d lastLoadAgo=%v count=%d goroutines=%d\n",
-			perfTicketsLoadedCount, sinceLast.Round(time.Millisecond), len(msg.tickets), runtime.NumGoroutine())
-	}
+	m.logger().Debug("tickets loaded", "n", perfTicketsLoadedCount,
+		"since_last", now.Sub(perfLastLoadedTime).Round(time.Millisecond),
+		"count", len(msg.tickets), "goroutines", runtime.NumGoroutine())
 	perfLastLoadedTime = now
 
 	var prevTicketID string
@@ -88,7 +88,7 @@ func (m UIModel) handleTicketsLoaded(msg ticketsLoadedMsg) (tea.Model, tea.Cmd)
 		m.ticketList = list.New(items, m.ticketDel, 0, 0)
 		m.sidebar.SetStoreError(false)
 	}
-	initList(&m.ticketList, 0, 0, "Select a Ticket")
+	initList(&m.ticketList, 0, 0, m.ticketListTitle())
 	if m.state == ViewStateLoading {
 		m.state = ViewStateMatrix
 	}
@@ -180,9 +180,7 @@ func (m UIModel) handleWarningMsg(msg warningMsg) (tea.Model, tea.Cmd) {
 	if len(m.warnings) > maxWarnings {
 		m.warnings = m.warnings[len(m.warnings)-maxWarnings:]
 	}
-	if m.app != nil && m.app.Opts.Debug {
-		fmt.Fprintf(os.Stderr, "[DEBUG][i29d] handleWarningMsg: warnings count=%d (latest: %s)\n", len(m.warnings), msg.err.Error())
-	}
+	m.logger().Warn(msg.err.Error(), "warnings", len(m.warnings))
 	return m, nil
 }
 
@@ -191,9 +189,7 @@ func (m UIModel) handleInfoMsg(msg infoMsg) (tea.Model, tea.Cmd) {
 	if len(m.warnings) > maxWarnings {
 		m.warnings = m.warnings[len(m.warnings)-maxWarnings:]
 	}
-	if m.app != nil && m.app.Opts.Debug {
-		fmt.Fprintf(os.Stderr, "[DEBUG][i29d] handleInfoMsg: warnings count=%d (latest: %s)\n", len(m.warnings), msg.message)
-	}
+	m.logger().Info(msg.message, "warnings", len(m.warnings))
 	return m, nil
 }
 
@@ -201,9 +197,15 @@ func (m UIModel) handleLaunchResult(msg launchResultMsg) (tea.Model, tea.Cmd) {
 	m.launchResult = msg.res
 	m.err = msg.err
 
+	projectDir := msg.project
+	if projectDir == "" {
+		projectDir = eventProject(m.app)
+	}
+
 	if msg.err != nil {
+		m.logger().Error("launch failed", "err", msg.err)
 		m.state = ViewStateError
-		return m, nil
+		return m, m.emitEventCmd(launchFailedEvent(projectDir, msg.spec, msg.err))
 	}
 
 	if msg.res != nil && msg.res.LauncherID != "" {
@@ -212,12 +214,17 @@ func (m UIModel) handleLaunchResult(msg launchResultMsg) (tea.Model, tea.Cmd) {
 			selection = msg.spec.Selection
 		}
 
+		worktree := m.selectedWorktree
+		if msg.worktree != "" {
+			worktree = msg.worktree
+		}
+
 		agentID := msg.res.LauncherID
 		agentInfo := &domain.AgentInfo{
 			ID:           agentID,
 			Name:         selection.Ticket.ID,
 			LauncherID:   msg.res.LauncherID,
-			WorktreePath: m.selectedWorktree,
+			WorktreePath: worktree,
 			Status:       domain.AgentRunning,
 			StartedAt:    time.Now(),
 			TicketID:     selection.Ticket.ID,
@@ -233,6 +240,7 @@ func (m UIModel) handleLaunchResult(msg launchResultMsg) (tea.Model, tea.Cmd) {
 			capture = tmux.NewOutputCapture(m.app.Runner(), launcherID)
 			path, captureErr := capture.Start(context.Background())
 			if captureErr != nil {
+				m.app.Logger(logging.Tmux).Warn("failed to capture agent output", "window", launcherID, "err", captureErr)
 				m.warnings = append(m.warnings, fmt.Sprintf("Failed to capture output: %v", captureErr))
 				capture = nil
 			}
@@ -240,18 +248,29 @@ func (m UIModel) handleLaunchResult(msg launchResultMsg) (tea.Model, tea.Cmd) {
 		}
 
 		m.agents[agentID] = &RunningAgent{
-			Info:    agentInfo,
-			Capture: capture,
+			Info:       agentInfo,
+			ProjectDir: projectDir,
+			Capture:    capture,
 		}
 
 		AddAgentNodeToSidebar(&m, agentInfo)
+		m.logger().Info("agent launched", "agent", agentID, "ticket", selection.Ticket.ID,
+			"harness", selection.Harness.Name, "model", selection.Model, "pid", msg.res.PID)
+		m.publishAgentEvent(control.EventAgentLaunched, agentInfo)
 
 		m.state = ViewStateMatrix
 
 		return m, tea.Batch(
 			pollAgentStatusCmd(m.app, agentID, msg.res.LauncherID),
 			startAgentMonitoringCmd(agentID),
-			saveRunningAgentCmd(m.app, msg.spec, msg.res, m.selectedWorktree),
+			saveRunningAgentCmd(m.app, projectDir, msg.spec, msg.res, worktree),
+			m.emitEventCmd(events.Event{
+				Type:    events.AgentLaunched,
+				Project: projectDir,
+				Agent:   events.AgentFromInfo(agentInfo),
+				Launch:  events.LaunchFromSpec(msg.spec),
+			}),
+			m.writebackLaunchCmd(projectDir, *agentInfo),
 		)
 	}
 
@@ -348,7 +367,11 @@ func (m UIModel) handleRunningAgentsLoaded(msg runningAgentsLoadedMsg) (tea.Mode
 			ModelName:    persisted.Model,
 			AgentName:    persisted.Agent,
 		}
-		m.agents[agentID] = &RunningAgent{Info: info}
+		projectDir := persisted.ProjectDir
+		if projectDir == "" {
+			projectDir = eventProject(m.app)
+		}
+		m.agents[agentID] = &RunningAgent{Info: info, ProjectDir: projectDir}
 		AddAgentNodeToSidebar(&m, info)
 
 		if persisted.LauncherID != "" {
@@ -375,54 +398,135 @@ func (m UIModel) handleWorktreeSelected(msg WorktreeSelectedMsg) (tea.Model, tea
 	return m, nil
 }
 
-func (m UIModel) handleTicketUpdateCheck() (tea.Model, tea.Cmd) {
+// watchConnectedProjects starts change detection for every connected
+// project that has none yet.
+func (m UIModel) watchConnectedProjects() (UIModel, tea.Cmd) {
+	if m.app == nil {
+		return m, nil
+	}
+	if m.ticketWatches == nil {
+		m.ticketWatches = make(map[string]*ticketWatch)
+	}
+
+	var cmds []tea.Cmd
+	for _, projectDir := range m.app.ConnectedProjects() {
+		if _, ok := m.ticketWatches[projectDir]; ok {
+			continue
+		}
+		store, ok := m.app.ConnectedStore(projectDir)
+		if !ok {
+			continue
+		}
+		m.ticketWatches[projectDir] = &ticketWatch{interval: ticketPollingInterval}
+		cmds = append(cmds, checkTicketChangesCmd(projectDir, store, m.app.Logger(logging.Dolt)))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleProjectStoresOpened starts watching the projects opened at startup.
+// Projects that failed to open are retried when they are selected.
+func (m UIModel) handleProjectStoresOpened(msg projectStoresOpenedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.logger().Warn("failed to open projects", "err", msg.err)
+		m.warnings = append(m.warnings, fmt.Sprintf("Opening projects: %v", msg.err))
+	}
+	return m.watchConnectedProjects()
+}
+
+func (m UIModel) handleTicketUpdateCheck(msg ticketUpdateCheckMsg) (tea.Model, tea.Cmd) {
 	perfTicketCheckCount++
 	now := time.Now()
-	if m.app.Opts.Debug {
-		sinceLast := now.Sub(perfLastCheckTime)
-		fmt.Fprintf(os.Stderr, "[DEBUG][perf] ticketUpdateCheck #%d lastCheckAgo=%v lastDBUpdate=%v goroutines=%d\n",
-			perfTicketCheckCount, sinceLast.Round(time.Millisecond), m.lastTicketUpdate, runtime.NumGoroutine())
-	}
+	m.logger().Debug("ticket update check", "n", perfTicketCheckCount,
+		"since_last", now.Sub(perfLastCheckTime).Round(time.Millisecond),
+		"project", msg.projectDir, "goroutines", runtime.NumGoroutine())
 	perfLastCheckTime = now
 
-	if m.app.Project() == nil {
-		return m, tea.Tick(ticketPollingInterval, func(t time.Time) tea.Msg {
-			return ticketUpdateCheckMsg{}
-		})
+	if _, ok := m.ticketWatches[msg.projectDir]; !ok {
+		return m, nil
+	}
+	store, ok := m.app.ConnectedStore(msg.projectDir)
+	if !ok {
+		// The project was closed; stop watching it.
+		delete(m.ticketWatches, msg.projectDir)
+		return m, nil
+	}
+	return m, checkTicketChangesCmd(msg.projectDir, store, m.app.Logger(logging.Dolt))
+}
+
+// handleTicketChangeChecked compares a project's change token with the last
+// one. A change reloads the project's sidebar count, and its tickets if it
+// is active, and resets the polling interval; otherwise the interval backs
+// off up to ticketPollingMaxInterval.
+func (m UIModel) handleTicketChangeChecked(msg ticketChangeCheckedMsg) (tea.Model, tea.Cmd) {
+	w, ok := m.ticketWatches[msg.projectDir]
+	if !ok {
+		return m, nil
+	}
+	active := msg.projectDir == m.app.ActiveProject
+
+	if msg.err != nil {
+		if msg.retryable && active {
+			// Polling resumes with the next ticket load after recovery.
+			delete(m.ticketWatches, msg.projectDir)
+			err := msg.err
+			return m, func() tea.Msg { return errMsg{err: err, showRetryOptions: true} }
+		}
+		w.interval = nextTicketPollingInterval(w.interval)
+		return m, ticketUpdateCheckTick(msg.projectDir, w.interval)
+	}
+
+	store, ok := m.app.ConnectedStore(msg.projectDir)
+	if !ok {
+		delete(m.ticketWatches, msg.projectDir)
+		return m, nil
 	}
 
-	store := m.app.Project().Store()
-	if store == nil {
-		return m, tea.Tick(ticketPollingInterval, func(t time.Time) tea.Msg {
-			return ticketUpdateCheckMsg{}
-		})
+	switch {
+	case !w.checked:
+		w.token, w.checked = msg.token, true
+		return m, tea.Batch(
+			loadProjectTicketCountCmd(msg.projectDir, store),
+			ticketUpdateCheckTick(msg.projectDir, w.interval),
+		)
+	case msg.token == w.token:
+		w.interval = nextTicketPollingInterval(w.interval)
+		return m, ticketUpdateCheckTick(msg.projectDir, w.interval)
+	}
+
+	w.token, w.interval = msg.token, ticketPollingInterval
+	cmds := []tea.Cmd{
+		loadProjectTicketCountCmd(msg.projectDir, store),
+		ticketUpdateCheckTick(msg.projectDir, w.interval),
+	}
+	if active {
+		newM, refreshCmd := m.handleTicketsAutoRefreshed()
+		m = newM.(UIModel)
+		cmds = append(cmds, refreshCmd)
 	}
-	return m, checkTicketUpdatesCmd(store, m.lastTicketUpdate, m.app.Opts.Debug)
+	return m, tea.Batch(cmds...)
 }
 
-func (m UIModel) handleTicketUpdateCheckNeeded() (tea.Model, tea.Cmd) {
-	return m, tea.Tick(ticketPollingInterval, func(t time.Time) tea.Msg {
-		return ticketUpdateCheckMsg{}
-	})
+func (m UIModel) handleProjectTicketCount(msg projectTicketCountMsg) (tea.Model, tea.Cmd) {
+	m.sidebar.SetTicketCount(msg.projectDir, msg.count)
+	return m, nil
 }
 
-func (m UIModel) handleTicketsAutoRefreshed(msg ticketsAutoRefreshedMsg) (tea.Model, tea.Cmd) {
+func (m UIModel) handleTicketsAutoRefreshed() (tea.Model, tea.Cmd) {
 	perfAutoRefreshCount++
 	now := time.Now()
-	if m.app.Opts.Debug {
-		sinceLast := now.Sub(perfLastRefreshTime)
-		fmt.Fprintf(os.Stderr, "[DEBUG][perf] ticketsAutoRefreshed #%d lastRefreshAgo=%v dbUpdatedAt=%v goroutines=%d\n",
-			perfAutoRefreshCount, sinceLast.Round(time.Millisecond), msg.dbUpdatedAt, runtime.NumGoroutine())
-	}
+	m.logger().Debug("tickets auto-refreshed", "n", perfAutoRefreshCount,
+		"since_last", now.Sub(perfLastRefreshTime).Round(time.Millisecond),
+		"project", m.app.ActiveProject, "goroutines", runtime.NumGoroutine())
 	perfLastRefreshTime = now
 
-	if !msg.dbUpdatedAt.IsZero() {
-		m.lastTicketUpdate = msg.dbUpdatedAt
-	}
 	m.refreshedRecently = true
 	m.refreshAnimationFrame = 0
 
-	cmds := []tea.Cmd{loadTicketsCmd(m.app.Project(), m.app.Opts.Debug), discoverWorktreesCmd(m.app)}
+	cmds := []tea.Cmd{
+		loadTicketsCmd(m.app.Project(), m.ticketFilter, m.app.Logger(logging.Dolt)),
+		discoverWorktreesCmd(m.app),
+		m.emitEventCmd(events.Event{Type: events.TicketRefreshed, Project: eventProject(m.app)}),
+	}
 
 	if m.app.Fonts.HasNerdFont {
 		cmds = append(cmds, tea.Tick(animationTickInterval, func(t time.Time) tea.Msg {
@@ -433,9 +537,6 @@ func (m UIModel) handleTicketsAutoRefreshed(msg ticketsAutoRefreshedMsg) (tea.Mo
 	cmds = append(cmds,
 		tea.Tick(ticketPollingInterval, func(t time.Time) tea.Msg {
 			return clearRefreshIndicatorMsg{}
-		}),
-		tea.Tick(ticketPollingInterval, func(t time.Time) tea.Msg {
-			return ticketUpdateCheckMsg{}
 		}))
 
 	return m, tea.Batch(cmds...)
@@ -492,6 +593,7 @@ func (m UIModel) handleAddProjectConfirmed(msg addProjectConfirmedMsg) (tea.Mode
 		func() tea.Msg {
 			return warningMsg{fmt.Errorf("added project: %s", projectDir)}
 		},
+		m.emitEventCmd(events.Event{Type: events.ProjectAdded, Project: projectDir}),
 	)
 }
 
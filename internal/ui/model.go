@@ -17,6 +17,7 @@ import (
 	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/discovery"
 	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/logging"
 	"github.com/megatherium/blunderbust/internal/ui/filepicker"
 )
 
@@ -31,14 +32,29 @@ func initList(l *list.Model, width, height int, title string) {
 }
 
 func NewUIModel(blunderbustApp *app.App, harnesses []domain.Harness) UIModel {
-	// Initialize default keys
-	keys := DefaultKeyMap()
-
-	themeName := "default"
-	if blunderbustApp != nil && blunderbustApp.Opts.Theme != "" {
-		themeName = blunderbustApp.Opts.Theme
+	var warnings []string
+	themes := AvailableThemes
+	theme := GetTheme("default")
+	if blunderbustApp != nil {
+		if loaded, err := LoadThemes(blunderbustApp.Opts.ThemesDir); err != nil {
+			warnings = append(warnings, fmt.Sprintf("User themes ignored: %v", err))
+		} else {
+			themes = loaded
+		}
+		if name := blunderbustApp.Opts.Theme; name != "" {
+			if t, ok := FindTheme(themes, name); ok {
+				theme = t
+			} else {
+				warnings = append(warnings, fmt.Sprintf("Unknown theme %q, using %s", name, theme.Name))
+			}
+		}
+	}
+	themeIdx := 0
+	for i, t := range themes {
+		if t == theme {
+			themeIdx = i
+		}
 	}
-	theme := GetTheme(themeName)
 
 	var registry *discovery.Registry
 	if blunderbustApp != nil {
@@ -70,14 +86,26 @@ func NewUIModel(blunderbustApp *app.App, harnesses []domain.Harness) UIModel {
 
 	var recents []string
 	var maxRecents int
+	var filterPresets []config.FilterPreset
+	keys, fpKeys := DefaultKeyMap(), filepicker.DefaultKeyMap()
 	if blunderbustApp != nil && blunderbustApp.Opts.TUIConfigPath != "" {
 		if cfg, err := config.LoadTUIConfig(blunderbustApp.Opts.TUIConfigPath); err == nil && cfg != nil {
 			recents = cfg.FilePickerRecents
 			maxRecents = cfg.FilePickerMaxRecents
+			filterPresets = cfg.FilterPresets
+			if k, fk, err := ApplyKeyBindings(cfg); err != nil {
+				warnings = append(warnings, fmt.Sprintf("Key bindings ignored: %v", err))
+			} else {
+				keys, fpKeys = k, fk
+			}
 		}
 	}
 
+	sidebar := NewSidebarModel()
+	sidebar.SetKeys(keys)
+
 	fp := filepicker.New()
+	fp.KeyMap = fpKeys
 
 	fp.ShowRecents = true
 	fp.Recents = recents
@@ -99,13 +127,19 @@ func NewUIModel(blunderbustApp *app.App, harnesses []domain.Harness) UIModel {
 		modelList:    ml,
 		agentList:    al,
 		filepicker:   fp,
-		sidebar:      NewSidebarModel(),
+		sidebar:      sidebar,
 		help:         h,
 		keys:         keys,
+		warnings:     warnings,
 		showModal:    false,
 		showSidebar:  true,
 		agents:       make(map[string]*RunningAgent),
-		currentTheme: theme, // Default to TokyoNight theme
+		outputViewer: newAgentOutputViewer(keys, 0),
+		dashboard:    newDashboardState(),
+		ticketDetail: newTicketDetailView(),
+		currentTheme: theme,
+
+		filterPresets: filterPresets,
 
 		dirtyTicket:  true, // Initial build needed
 		dirtyHarness: true,
@@ -115,7 +149,8 @@ func NewUIModel(blunderbustApp *app.App, harnesses []domain.Harness) UIModel {
 		animState: AnimationState{
 			StartTime:       time.Now(),
 			ColorCycleStart: time.Now(),
-			CurrentThemeIdx: 2, // TokyoNight theme index (2)
+			CurrentThemeIdx: themeIdx,
+			Themes:          themes,
 		},
 	}.initSidebar()
 }
@@ -216,20 +251,18 @@ func (m UIModel) handleCoreMsgs(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 		}
 		return m, m.continueInitAfterRegistry(), true
 	case ticketsLoadedMsg:
-		m.lastTicketUpdate = latestTicketUpdate(msg)
 		updatedM, _ := m.handleTicketsLoaded(msg)
-		if !updatedM.(UIModel).pollStarted {
-			um := updatedM.(UIModel)
+		um, watchCmd := updatedM.(UIModel).watchConnectedProjects()
+		if !um.pollStarted {
 			um.pollStarted = true
 			return um, tea.Batch(
-				tea.Tick(ticketPollingInterval, func(t time.Time) tea.Msg {
-					return ticketUpdateCheckMsg{}
-				}),
+				watchCmd,
 				loadRunningAgentsCmd(m.app),
 				discoverWorktreesCmd(m.app),
+				openProjectStoresCmd(m.app),
 			), true
 		}
-		return updatedM, nil, true
+		return um, watchCmd, true
 	case errMsg:
 		newM, cmd := m.handleErrMsg(msg)
 		return newM, cmd, true
@@ -239,9 +272,15 @@ func (m UIModel) handleCoreMsgs(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 	case warningMsg:
 		newM, cmd := m.handleWarningMsg(msg)
 		return newM, cmd, true
-	case modalContentMsg:
-		m.modalContent = string(msg)
-		return m, nil, true
+	case ticketDetailLoadedMsg:
+		newM, cmd := m.HandleTicketDetailLoaded(msg)
+		return newM, cmd, true
+	case ticketRunsLoadedMsg:
+		newM, cmd := m.HandleTicketRunsLoaded(msg)
+		return newM, cmd, true
+	case filterPresetSelectedMsg:
+		newM, cmd := m.HandleFilterPresetSelected(msg)
+		return newM, cmd, true
 	case tea.WindowSizeMsg:
 		newM, cmd := m.handleWindowSizeMsg(msg)
 		return newM, cmd, true
@@ -293,11 +332,11 @@ func (m UIModel) handleProjectMsgs(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 			beadsDir := filepath.Join(activeProject, ".beads")
 			projectCtx, err := data.NewProjectContext(msg.store, beadsDir, activeProject)
 			if err != nil {
-				return m, loadTicketsCmd(nil, m.app.Opts.Debug), true
+				return m, loadTicketsCmd(nil, m.ticketFilter, m.app.Logger(logging.Dolt)), true
 			}
-			return m, loadTicketsCmd(projectCtx, m.app.Opts.Debug), true
+			return m, loadTicketsCmd(projectCtx, m.ticketFilter, m.app.Logger(logging.Dolt)), true
 		}
-		return m, loadTicketsCmd(nil, m.app.Opts.Debug), true
+		return m, loadTicketsCmd(nil, m.ticketFilter, m.app.Logger(logging.Dolt)), true
 	case OpenFilePickerMsg:
 		m.filepicker.PruneRecents()
 		m.state = ViewStateFilePicker
@@ -320,7 +359,7 @@ func (m UIModel) handleProjectMsgs(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 			if err == nil && cfg != nil {
 				cfg.FilePickerRecents = msg.Recents
 				if err := config.SaveTUIConfig(m.app.Opts.TUIConfigPath, cfg); err != nil {
-					fmt.Fprintf(os.Stderr, "Failed to save recents: %v\n", err)
+					m.logger().Error("failed to save file picker recents", "err", err)
 				}
 			}
 		}
@@ -352,6 +391,21 @@ func (m UIModel) handleAgentMsgs(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 	case agentOutputMsg:
 		newM, cmd := m.HandleAgentOutput(msg)
 		return newM, cmd, true
+	case agentOutputTickMsg:
+		newM, cmd := m.HandleAgentOutputTick(msg)
+		return newM, cmd, true
+	case agentOutputSavedMsg:
+		newM, cmd := m.HandleAgentOutputSaved(msg)
+		return newM, cmd, true
+	case agentInputSentMsg:
+		newM, cmd := m.HandleAgentInputSent(msg)
+		return newM, cmd, true
+	case dashboardTickMsg:
+		newM, cmd := m.HandleDashboardTick(msg)
+		return newM, cmd, true
+	case agentWindowSelectedMsg:
+		newM, cmd := m.HandleAgentWindowSelected(msg)
+		return newM, cmd, true
 	case animationTickMsg:
 		newM, cmd := m.handleAnimationTick(msg)
 		return newM, cmd, true
@@ -372,13 +426,16 @@ func (m UIModel) handleAgentMsgs(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 		newM, cmd := m.HandleAllStoppedAgentsCleared(msg)
 		return newM, cmd, true
 	case ticketUpdateCheckMsg:
-		newM, cmd := m.handleTicketUpdateCheck()
+		newM, cmd := m.handleTicketUpdateCheck(msg)
 		return newM, cmd, true
-	case ticketUpdateCheckNeededMsg:
-		newM, cmd := m.handleTicketUpdateCheckNeeded()
+	case ticketChangeCheckedMsg:
+		newM, cmd := m.handleTicketChangeChecked(msg)
 		return newM, cmd, true
-	case ticketsAutoRefreshedMsg:
-		newM, cmd := m.handleTicketsAutoRefreshed(msg)
+	case projectTicketCountMsg:
+		newM, cmd := m.handleProjectTicketCount(msg)
+		return newM, cmd, true
+	case projectStoresOpenedMsg:
+		newM, cmd := m.handleProjectStoresOpened(msg)
 		return newM, cmd, true
 	case clearRefreshIndicatorMsg:
 		newM, cmd := m.handleClearRefreshIndicator()
@@ -414,7 +471,7 @@ func (m UIModel) handleSidebarFocusUpdate(msg tea.Msg) (UIModel, tea.Cmd) {
 					m.dirtyTicket = true
 					m.dirtyModel = true
 					m.dirtyAgent = true
-					cmd = tea.Batch(cmd, loadTicketsCmd(m.app.Project(), m.app.Opts.Debug))
+					cmd = tea.Batch(cmd, loadTicketsCmd(m.app.Project(), m.ticketFilter, m.app.Logger(logging.Dolt)))
 				}
 			}
 		}
@@ -456,6 +513,9 @@ func (m UIModel) handleFocusUpdate(msg tea.Msg) (UIModel, tea.Cmd) {
 	case FocusTickets:
 		m.ticketList, cmd = m.ticketList.Update(msg)
 		m.dirtyTicket = true
+		if m.showTicketDetail {
+			cmd = tea.Batch(cmd, m.loadSelectedTicketDetail())
+		}
 	case FocusHarness:
 		return m.handleHarnessFocusUpdate(msg)
 	case FocusModel:
@@ -482,6 +542,7 @@ func (m UIModel) continueNormalInit() tea.Cmd {
 // before any model discovery happens.
 func (m UIModel) continueInitAfterRegistry() tea.Cmd {
 	myApp := m.app
+	filter := m.ticketFilter
 	// NOTE: discoverWorktreesCmd cannot run in parallel here because
 	// CreateProjectContext sets myApp.projects which discover needs.
 	// Instead, discover is triggered from the ticketsLoadedMsg handler
@@ -492,7 +553,7 @@ func (m UIModel) continueInitAfterRegistry() tea.Cmd {
 			return errMsg{err: err, showRetryOptions: true}
 		}
 
-		tickets, err := projectCtx.Store().ListTickets(context.Background(), data.TicketFilter{})
+		tickets, err := projectCtx.Store().ListTickets(context.Background(), filter)
 		if err != nil {
 			return errMsg{err: err, showRetryOptions: true}
 		}
@@ -513,13 +574,3 @@ func (m UIModel) loadRegistryCmd() tea.Cmd {
 		return registryLoadedMsg{}
 	}
 }
-
-func latestTicketUpdate(msg ticketsLoadedMsg) time.Time {
-	var latest time.Time
-	for _, ticket := range msg.tickets {
-		if ticket.UpdatedAt.After(latest) {
-			latest = ticket.UpdatedAt
-		}
-	}
-	return latest
-}
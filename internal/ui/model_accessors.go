@@ -1,19 +1,25 @@
 package ui
 
 import (
+	"log/slog"
 	"time"
 
 	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/logging"
 )
 
 // NewTestModel creates a minimal UIModel for testing purposes
 func NewTestModel() *UIModel {
 	m := UIModel{
-		app:       nil,
-		state:     ViewStateMatrix,
-		focus:     FocusSidebar,
-		selection: domain.Selection{},
-		sidebar:   NewSidebarModel(),
+		app:          nil,
+		state:        ViewStateMatrix,
+		focus:        FocusSidebar,
+		selection:    domain.Selection{},
+		sidebar:      NewSidebarModel(),
+		keys:         DefaultKeyMap(),
+		outputViewer: newAgentOutputViewer(DefaultKeyMap(), 0),
+		dashboard:    newDashboardState(),
+		ticketDetail: newTicketDetailView(),
 		animState: AnimationState{
 			StartTime:       time.Now(),
 			ColorCycleStart: time.Now(),
@@ -22,3 +28,9 @@ func NewTestModel() *UIModel {
 	}
 	return &m
 }
+
+// logger returns the UI subsystem logger; it discards records when no
+// logger is configured.
+func (m UIModel) logger() *slog.Logger {
+	return m.app.Logger(logging.UI)
+}
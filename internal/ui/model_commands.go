@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
-	osexec "os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -19,6 +19,7 @@ import (
 	"github.com/megatherium/blunderbust/internal/data/dolt"
 	"github.com/megatherium/blunderbust/internal/domain"
 	"github.com/megatherium/blunderbust/internal/exec/tmux"
+	"github.com/megatherium/blunderbust/internal/logging"
 	"github.com/megatherium/blunderbust/internal/ui/sidebar"
 )
 
@@ -38,23 +39,16 @@ func startServerAndRetryCmd(myApp *app.App, store *dolt.Store) tea.Cmd {
 	}
 }
 
-func loadTicketsCmd(project *data.ProjectContext, debug bool) tea.Cmd {
+func loadTicketsCmd(project *data.ProjectContext, filter data.TicketFilter, logger *slog.Logger) tea.Cmd {
 	return func() tea.Msg {
 		start := time.Now()
 		if project == nil {
 			return errMsg{err: fmt.Errorf("no project context available"), showRetryOptions: true}
 		}
-		tickets, err := project.Store().ListTickets(context.Background(), data.TicketFilter{})
+		tickets, err := project.Store().ListTickets(context.Background(), filter)
 		elapsed := time.Since(start)
 
-		if debug {
-			count := -1
-			if err == nil {
-				count = len(tickets)
-			}
-			fmt.Fprintf(os.Stderr, "[DEBUG][perf] ListTickets() took %v count=%d err=%v\n",
-				elapsed.Round(time.Microsecond), count, err)
-		}
+		logger.Debug("ListTickets", "took", elapsed.Round(time.Microsecond), "count", len(tickets), "err", err)
 
 		if err != nil {
 			return errMsg{err: err, showRetryOptions: true}
@@ -63,20 +57,6 @@ func loadTicketsCmd(project *data.ProjectContext, debug bool) tea.Cmd {
 	}
 }
 
-func loadModalCmd(ticketID string, beadsDir string) tea.Cmd {
-	return func() tea.Msg {
-		cmd := osexec.Command("bd", "show", ticketID)
-		if beadsDir != "" {
-			cmd.Env = append(cmd.Env, "BEADS_DIR="+beadsDir)
-		}
-		out, err := cmd.CombinedOutput()
-		if err != nil {
-			return modalContentMsg(fmt.Sprintf("Error loading bd show:\n%v\n%s", err, string(out)))
-		}
-		return modalContentMsg(string(out))
-	}
-}
-
 // extractRepoRoot extracts the repository root path from a beadsDir path.
 // It handles both "/path/to/.beads" and "/path/to/.beads/" patterns.
 func extractRepoRoot(beadsDir string) string {
@@ -91,33 +71,26 @@ func extractRepoRoot(beadsDir string) string {
 
 func discoverWorktreesCmd(myApp *app.App) tea.Cmd {
 	return func() tea.Msg {
+		logger := myApp.Logger(logging.Discovery)
 		start := time.Now()
-		if myApp.Opts.Debug {
-			defer func() {
-				fmt.Fprintf(os.Stderr, "[DEBUG][perf] discoverWorktreesCmd took %v\n", time.Since(start).Round(time.Millisecond))
-			}()
-		}
 
 		projects := myApp.GetProjects()
-		if myApp.Opts.Debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG] discoverWorktreesCmd: found %d projects\n", len(projects))
-			for i, p := range projects {
-				fmt.Fprintf(os.Stderr, "[DEBUG]   project[%d]: dir=%s, name=%s\n", i, p.Dir, p.Name)
-			}
+		for _, p := range projects {
+			logger.Debug("discovering worktrees", "project", p.Dir, "name", p.Name)
 		}
 
 		if len(projects) == 0 {
-			if myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] discoverWorktreesCmd: no projects configured, using fallback\n")
-			}
+			logger.Debug("no projects configured for worktree discovery")
 			return worktreesDiscoveredMsg{err: fmt.Errorf("no projects configured")}
 		}
 
 		builder := sidebar.NewTreeBuilderDefault()
 		nodes, errs := builder.BuildFromProjects(context.Background(), projects)
 
-		if myApp.Opts.Debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG] discoverWorktreesCmd: discovered %d nodes, %d errors\n", len(nodes), len(errs))
+		logger.Debug("worktree discovery finished", "projects", len(projects), "nodes", len(nodes),
+			"errors", len(errs), "took", time.Since(start).Round(time.Millisecond))
+		for _, e := range errs {
+			logger.Warn("worktree discovery error", "err", e)
 		}
 
 		var cmds []tea.Cmd
@@ -149,37 +122,37 @@ func (m UIModel) launchCmd() tea.Cmd {
 			workDir = app.ExtractRepoRoot(m.app.Opts.BeadsDir)
 		}
 
-		spec, err := m.app.Renderer.RenderSelection(m.selection, workDir)
-		if err != nil {
-			return launchResultMsg{
-				res: nil,
-				err: fmt.Errorf("failed to render launch spec: %w", err),
-			}
-		}
-
-		spec.LauncherID = m.selection.Ticket.ID
+		spec, res, err := renderAndLaunch(context.Background(), m.app, m.selection, workDir)
+		return launchResultMsg{res: res, spec: spec, err: err, project: eventProject(m.app)}
+	}
+}
 
-		res, err := m.app.Launcher.Launch(context.Background(), *spec)
-		return launchResultMsg{res: res, spec: spec, err: err}
+// renderAndLaunch renders the selection for workDir and launches it in a
+// window named after the ticket. It is shared by the TUI and the control API.
+func renderAndLaunch(ctx context.Context, myApp *app.App, selection domain.Selection, workDir string) (*domain.LaunchSpec, *domain.LaunchResult, error) {
+	spec, err := myApp.Renderer.RenderSelection(selection, workDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to render launch spec: %w", err)
 	}
+
+	spec.LauncherID = selection.Ticket.ID
+
+	res, err := myApp.Launcher.Launch(ctx, *spec)
+	return spec, res, err
 }
 
 func loadRunningAgentsCmd(myApp *app.App) tea.Cmd {
 	return func() tea.Msg {
-		project := myApp.Project()
-		if project == nil || project.Store() == nil {
-			if myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] loadRunningAgentsCmd: no project or store\n")
-			}
+		logger := myApp.Logger(logging.Dolt)
+		if myApp.Project() == nil {
+			logger.Debug("not loading running agents: no project")
 			return runningAgentsLoadedMsg{}
 		}
 
-		store, ok := project.Store().(*dolt.Store)
-		if !ok {
-			if myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] loadRunningAgentsCmd: store is not dolt.Store\n")
-			}
-			return runningAgentsLoadedMsg{}
+		store, err := myApp.AgentStoreForProject(context.Background(), myApp.ActiveProject)
+		if err != nil {
+			logger.Error("failed to open agent store", "err", err)
+			return runningAgentsLoadedMsg{err: err}
 		}
 
 		projectDirs := make([]string, 0, len(myApp.GetProjects()))
@@ -190,59 +163,42 @@ func loadRunningAgentsCmd(myApp *app.App) tea.Cmd {
 			projectDirs = append(projectDirs, myApp.ActiveProject)
 		}
 
-		if myApp.Opts.Debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG] loadRunningAgentsCmd: querying projectDirs=%v\n", projectDirs)
-		}
+		logger.Debug("loading running agents", "projects", projectDirs)
 
-		if err := store.DeleteStaleRunningAgents(context.Background(), time.Hour); err != nil {
-			if myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] loadRunningAgentsCmd: DeleteStaleRunningAgents error: %v\n", err)
-			}
+		if err := store.DeleteStaleRunningAgents(context.Background(), data.DefaultRunningAgentMaxAge); err != nil {
+			logger.Error("failed to delete stale running agents", "err", err)
 			return runningAgentsLoadedMsg{err: err}
 		}
 
 		agents, err := store.ValidateAndPruneRunningAgents(context.Background(), projectDirs, nil)
 		if err != nil {
-			if myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] loadRunningAgentsCmd: ValidateAndPruneRunningAgents error: %v\n", err)
-			}
+			logger.Error("failed to validate running agents", "err", err)
 			return runningAgentsLoadedMsg{err: err}
 		}
 
-		if myApp.Opts.Debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG] loadRunningAgentsCmd: loaded %d valid agents\n", len(agents))
-			for _, a := range agents {
-				fmt.Fprintf(os.Stderr, "[DEBUG]   - %s: PID=%d, harness=%s, binary=%s, worktree=%s\n",
-					a.Ticket, a.PID, a.HarnessName, a.HarnessBinary, a.WorktreePath)
-			}
+		logger.Debug("loaded running agents", "count", len(agents))
+		for _, a := range agents {
+			logger.Debug("running agent", "ticket", a.Ticket, "pid", a.PID, "harness", a.HarnessName,
+				"binary", a.HarnessBinary, "worktree", a.WorktreePath)
 		}
 
 		return runningAgentsLoadedMsg{agents: agents}
 	}
 }
 
-func saveRunningAgentCmd(myApp *app.App, spec *domain.LaunchSpec, result *domain.LaunchResult, worktreePath string) tea.Cmd {
+// saveRunningAgentCmd persists a launched agent in the agent store of
+// projectDir, the project it was launched in.
+func saveRunningAgentCmd(myApp *app.App, projectDir string, spec *domain.LaunchSpec, result *domain.LaunchResult, worktreePath string) tea.Cmd {
 	return func() tea.Msg {
+		logger := myApp.Logger(logging.Dolt)
 		if myApp == nil || spec == nil || result == nil {
-			if myApp != nil && myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] saveRunningAgentCmd: nil check failed (app=%v, spec=%v, result=%v)\n",
-					myApp != nil, spec != nil, result != nil)
-			}
+			logger.Debug("not saving running agent: missing launch data",
+				"app", myApp != nil, "spec", spec != nil, "result", result != nil)
 			return nil
 		}
 
-		project := myApp.Project()
-		if project == nil || project.Store() == nil {
-			if myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] saveRunningAgentCmd: no project or store\n")
-			}
-			return nil
-		}
-		store, ok := project.Store().(*dolt.Store)
-		if !ok {
-			if myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] saveRunningAgentCmd: store is not dolt.Store\n")
-			}
+		if myApp.Project() == nil {
+			logger.Debug("not saving running agent: no project")
 			return nil
 		}
 
@@ -254,34 +210,33 @@ func saveRunningAgentCmd(myApp *app.App, spec *domain.LaunchSpec, result *domain
 			}
 		}
 
-		projectDir := myApp.ActiveProject
 		if projectDir == "" {
-			projectDir = app.ExtractRepoRoot(myApp.Opts.BeadsDir)
+			projectDir = eventProject(myApp)
 		}
 		if worktreePath == "" {
 			worktreePath = projectDir
 		}
 		if result.PID <= 0 {
-			if myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] saveRunningAgentCmd: invalid PID %d, not saving\n", result.PID)
-			}
+			logger.Debug("not saving running agent: invalid PID", "pid", result.PID)
 			return nil
 		}
 
-		if myApp.Opts.Debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG] saveRunningAgentCmd: saving agent\n")
-			fmt.Fprintf(os.Stderr, "[DEBUG]   projectDir=%s\n", projectDir)
-			fmt.Fprintf(os.Stderr, "[DEBUG]   worktreePath=%s\n", worktreePath)
-			fmt.Fprintf(os.Stderr, "[DEBUG]   PID=%d\n", result.PID)
-			fmt.Fprintf(os.Stderr, "[DEBUG]   launcherID=%s\n", result.LauncherID)
-			fmt.Fprintf(os.Stderr, "[DEBUG]   launcherType=%d\n", result.LauncherType)
-			fmt.Fprintf(os.Stderr, "[DEBUG]   ticket=%s\n", spec.Selection.Ticket.ID)
-			fmt.Fprintf(os.Stderr, "[DEBUG]   harness=%s\n", spec.Selection.Harness.Name)
-			fmt.Fprintf(os.Stderr, "[DEBUG]   harnessBinary=%s\n", harnessBinary)
-			fmt.Fprintf(os.Stderr, "[DEBUG]   renderedCommand=%s\n", spec.RenderedCommand)
-		}
+		logger = logger.With("ticket", spec.Selection.Ticket.ID, "pid", result.PID)
+		logger.Debug("saving running agent",
+			"project", projectDir,
+			"worktree", worktreePath,
+			"launcher_id", result.LauncherID,
+			"launcher_type", result.LauncherType,
+			"harness", spec.Selection.Harness.Name,
+			"binary", harnessBinary,
+			"command", spec.RenderedCommand)
 
-		err := store.UpsertRunningAgent(context.Background(), domain.PersistedRunningAgent{
+		store, err := myApp.AgentStoreForProject(context.Background(), projectDir)
+		if err != nil {
+			logger.Error("failed to open agent store", "err", err)
+			return warningMsg{err: fmt.Errorf("failed to persist running agent: %w", err)}
+		}
+		err = store.UpsertRunningAgent(context.Background(), domain.PersistedRunningAgent{
 			ProjectDir:    projectDir,
 			WorktreePath:  worktreePath,
 			PID:           result.PID,
@@ -295,15 +250,11 @@ func saveRunningAgentCmd(myApp *app.App, spec *domain.LaunchSpec, result *domain
 			Agent:         spec.Selection.Agent,
 		})
 		if err != nil {
-			if myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] saveRunningAgentCmd: UpsertRunningAgent error: %v\n", err)
-			}
+			logger.Error("failed to save running agent", "err", err)
 			return warningMsg{err: fmt.Errorf("failed to persist running agent: %w", err)}
 		}
 
-		if myApp.Opts.Debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG] saveRunningAgentCmd: agent saved successfully\n")
-		}
+		logger.Debug("saved running agent")
 
 		return nil
 	}
@@ -311,24 +262,75 @@ func saveRunningAgentCmd(myApp *app.App, spec *domain.LaunchSpec, result *domain
 
 // Agent monitoring commands
 
+// recordAgentRunCmd moves a finished agent from the running agents to the
+// run history, with the lines changed in its worktree.
+func recordAgentRunCmd(myApp *app.App, projectDir string, info domain.AgentInfo) tea.Cmd {
+	if myApp == nil || projectDir == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx := context.Background()
+		logger := myApp.Logger(logging.Dolt).With("ticket", info.TicketID, "launcher_id", info.LauncherID)
+
+		worktreePath := info.WorktreePath
+		if worktreePath == "" {
+			worktreePath = projectDir
+		}
+		run := domain.AgentRun{
+			ProjectDir:   projectDir,
+			WorktreePath: worktreePath,
+			LauncherID:   info.LauncherID,
+			Ticket:       info.TicketID,
+			TicketTitle:  info.TicketTitle,
+			HarnessName:  info.HarnessName,
+			Model:        info.ModelName,
+			Agent:        info.AgentName,
+			StartedAt:    info.StartedAt,
+			FinishedAt:   info.FinishedAt,
+			Status:       info.Status,
+			ExitCode:     info.ExitCode,
+		}
+		var err error
+		run.LinesAdded, run.LinesRemoved, err = data.LinesChanged(ctx, worktreePath)
+		if err != nil {
+			logger.Debug("failed to count lines changed", "worktree", worktreePath, "err", err)
+		}
+
+		store, err := myApp.AgentStoreForProject(ctx, projectDir)
+		if err == nil {
+			err = store.FinishRunningAgent(ctx, run)
+		}
+		if err != nil {
+			logger.Error("failed to record agent run", "err", err)
+			return warningMsg{err: fmt.Errorf("failed to record agent run: %w", err)}
+		}
+
+		logger.Debug("recorded agent run", "status", run.Status.String(),
+			"lines_added", run.LinesAdded, "lines_removed", run.LinesRemoved)
+		return nil
+	}
+}
+
 func pollAgentStatusCmd(myApp *app.App, agentID, launcherID string) tea.Cmd {
 	return func() tea.Msg {
 		if myApp.StatusChecker() == nil {
 			return AgentStatusMsg{AgentID: agentID, Status: domain.AgentRunning}
 		}
 
-		status := myApp.StatusChecker().CheckStatus(context.Background(), launcherID)
-		var agentStatus domain.AgentStatus
-		switch status {
-		case tmux.Running:
-			agentStatus = domain.AgentRunning
-		case tmux.Dead:
-			agentStatus = domain.AgentCompleted
-		default:
-			agentStatus = domain.AgentRunning
+		pane := myApp.StatusChecker().CheckPane(context.Background(), launcherID)
+		msg := AgentStatusMsg{AgentID: agentID, Status: domain.AgentRunning}
+		if pane.Status == tmux.Dead {
+			msg.Status = domain.AgentCompleted
+			if pane.Exited {
+				code := pane.ExitCode
+				msg.ExitCode = &code
+				if code != 0 {
+					msg.Status = domain.AgentFailed
+				}
+			}
 		}
 
-		return AgentStatusMsg{AgentID: agentID, Status: agentStatus}
+		return msg
 	}
 }
 
@@ -338,7 +340,7 @@ func startAgentMonitoringCmd(agentID string) tea.Cmd {
 	})
 }
 
-func readAgentOutputCmd(agentID string, capture *tmux.OutputCapture) tea.Cmd {
+func readAgentOutputCmd(agentID string, capture *tmux.OutputCapture, seq int) tea.Cmd {
 	return func() tea.Msg {
 		if capture == nil {
 			return nil
@@ -346,13 +348,66 @@ func readAgentOutputCmd(agentID string, capture *tmux.OutputCapture) tea.Cmd {
 
 		content, err := capture.ReadOutput()
 		if err != nil {
-			return nil
+			return agentOutputMsg{agentID: agentID, seq: seq, err: err}
 		}
 
+		return agentOutputMsg{agentID: agentID, content: string(content), seq: seq}
+	}
+}
+
+// agentOutputTickCmd schedules the next capture of the viewed agent's pane.
+func agentOutputTickCmd(agentID string, seq int) tea.Cmd {
+	return tea.Tick(agentOutputPollInterval, func(time.Time) tea.Msg {
+		return agentOutputTickMsg{agentID: agentID, seq: seq}
+	})
+}
+
+// readAgentScreenCmd captures the visible screen of an agent's pane for the
+// dashboard. The capture is not tied to a viewer, so its seq is zero.
+func readAgentScreenCmd(agentID string, capture *tmux.OutputCapture) tea.Cmd {
+	return func() tea.Msg {
+		content, err := capture.ReadScreen()
+		if err != nil {
+			return agentOutputMsg{agentID: agentID, err: err}
+		}
 		return agentOutputMsg{agentID: agentID, content: string(content)}
 	}
 }
 
+// dashboardTickCmd schedules the next refresh of the dashboard.
+func dashboardTickCmd(seq int) tea.Cmd {
+	return tea.Tick(dashboardPollInterval, func(time.Time) tea.Msg {
+		return dashboardTickMsg{seq: seq}
+	})
+}
+
+// sendAgentInputCmd types text into the agent's pane, or presses tmuxKey
+// instead when it is set. label is what the agent's history records.
+func sendAgentInputCmd(runner tmux.CommandRunner, agentID, target, label, text, tmuxKey string) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if tmuxKey != "" {
+			err = tmux.SendKey(context.Background(), runner, target, tmuxKey)
+		} else {
+			err = tmux.SendText(context.Background(), runner, target, text)
+		}
+		return agentInputSentMsg{
+			agentID: agentID,
+			input:   SentInput{At: time.Now(), Text: label},
+			err:     err,
+		}
+	}
+}
+
+func saveAgentOutputCmd(path, content string) tea.Cmd {
+	return func() tea.Msg {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			return agentOutputSavedMsg{path: path, err: err}
+		}
+		return agentOutputSavedMsg{path: path}
+	}
+}
+
 // Agent clearing commands
 
 func clearAgentCmd(agentID string, capture *tmux.OutputCapture) tea.Cmd {
@@ -390,43 +445,55 @@ func clearAllStoppedAgentsCmd(agents []agentToClear) tea.Cmd {
 
 // Ticket auto-refresh commands
 
-// ticketNeedsRefresh returns true when the database has newer data than what we've loaded.
-// Uses After() to prevent false positives from stale DB timestamps, but also handles
-// the zero-time edge case: when lastUpdate is zero (empty DB), any non-zero dbUpdate means new data.
-func ticketNeedsRefresh(dbUpdate, lastUpdate time.Time) bool {
-	return dbUpdate.After(lastUpdate) || (lastUpdate.IsZero() && !dbUpdate.IsZero())
-}
-
-func checkTicketUpdatesCmd(store data.TicketStore, lastUpdate time.Time, debug bool) tea.Cmd {
+// checkTicketChangesCmd fetches the change token of a project's store.
+func checkTicketChangesCmd(projectDir string, store data.TicketStore, logger *slog.Logger) tea.Cmd {
 	return func() tea.Msg {
 		start := time.Now()
-		dbUpdate, err := store.LatestUpdate(context.Background())
-		elapsed := time.Since(start)
+		token, err := data.ChangeToken(context.Background(), store)
+		logger.Debug("ChangeToken", "project", projectDir, "took", time.Since(start).Round(time.Microsecond),
+			"token", token, "err", err)
 
-		if debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG][perf] LatestUpdate() took %v dbUpdate=%v lastUpdate=%v newer=%v err=%v\n",
-				elapsed.Round(time.Microsecond), dbUpdate, lastUpdate,
-				err == nil && ticketNeedsRefresh(dbUpdate, lastUpdate), err)
+		msg := ticketChangeCheckedMsg{projectDir: projectDir, token: token, err: err}
+		if doltStore, ok := store.(*dolt.Store); ok && err != nil {
+			msg.retryable = doltStore.CanRetryConnection() && dolt.IsConnectionError(err)
 		}
+		return msg
+	}
+}
 
-		if err != nil {
-			// Check if this is a connection error for server-mode stores
-			if doltStore, ok := store.(*dolt.Store); ok &&
-				doltStore.CanRetryConnection() &&
-				dolt.IsConnectionError(err) {
-				return errMsg{err: err, showRetryOptions: true}
-			}
-			// If not retryable or non-server-mode, continue polling silently
-			return ticketUpdateCheckNeededMsg{}
-		}
+// ticketUpdateCheckTick schedules the next change check of a project.
+func ticketUpdateCheckTick(projectDir string, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return ticketUpdateCheckMsg{projectDir: projectDir}
+	})
+}
 
-		// Only refresh when the database has NEWER data than what we've already loaded.
-		// ticketNeedsRefresh handles both the normal case (After) and the empty-DB edge case.
-		if ticketNeedsRefresh(dbUpdate, lastUpdate) {
-			return ticketsAutoRefreshedMsg{dbUpdatedAt: dbUpdate}
+// nextTicketPollingInterval backs off while a project does not change.
+func nextTicketPollingInterval(interval time.Duration) time.Duration {
+	return min(2*interval, ticketPollingMaxInterval)
+}
+
+// loadProjectTicketCountCmd counts the ready tickets of a project for the
+// sidebar. Failures leave the previous count in place.
+func loadProjectTicketCountCmd(projectDir string, store data.TicketStore) tea.Cmd {
+	return func() tea.Msg {
+		tickets, err := store.ListTickets(context.Background(), data.TicketFilter{})
+		if err != nil {
+			return nil
 		}
+		return projectTicketCountMsg{projectDir: projectDir, count: len(tickets)}
+	}
+}
 
-		return ticketUpdateCheckNeededMsg{}
+// openProjectStoresCmd opens the stores of all workspace projects in the
+// background, so their tickets are watched and counted before they are
+// first selected.
+func openProjectStoresCmd(myApp *app.App) tea.Cmd {
+	if myApp == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return projectStoresOpenedMsg{err: myApp.OpenProjectStores(context.Background())}
 	}
 }
 
@@ -1,8 +1,6 @@
 package ui
 
 import (
-	"time"
-
 	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/domain"
 )
@@ -25,9 +23,30 @@ type launchResultMsg struct {
 	res  *domain.LaunchResult
 	spec *domain.LaunchSpec
 	err  error
+	// worktree overrides the selected worktree for launches that did not
+	// come from the matrix (e.g. the control API).
+	worktree string
+	// project is the project directory the agent was launched in, which
+	// need not be the active project for control API launches.
+	project string
+}
+
+type ticketDetailLoadedMsg struct {
+	ticketID string
+	detail   *domain.TicketDetail
+	err      error
 }
 
-type modalContentMsg string
+type ticketRunsLoadedMsg struct {
+	ticketID string
+	runs     []domain.AgentRun
+	err      error
+}
+
+// filterPresetSelectedMsg applies a filter preset chosen in the palette.
+type filterPresetSelectedMsg struct {
+	index int
+}
 
 // addProjectConfirmedMsg is emitted when user confirms adding a project.
 type addProjectConfirmedMsg struct {
@@ -54,8 +73,9 @@ type runningAgentsLoadedMsg struct {
 
 // Agent-related messages
 type AgentStatusMsg struct {
-	AgentID string
-	Status  domain.AgentStatus
+	AgentID  string
+	Status   domain.AgentStatus
+	ExitCode *int // set when the agent exited and tmux reported its status
 }
 
 type AgentHoveredMsg struct {
@@ -77,18 +97,65 @@ type agentTickMsg struct {
 	agentID string
 }
 
+// agentOutputMsg carries a capture of the viewed agent's pane. seq ties it to
+// the polling loop of the viewer that requested it.
 type agentOutputMsg struct {
 	agentID string
 	content string
+	seq     int
+	err     error
+}
+
+type agentOutputTickMsg struct {
+	agentID string
+	seq     int
+}
+
+type agentOutputSavedMsg struct {
+	path string
+	err  error
+}
+
+type dashboardTickMsg struct {
+	seq int
+}
+
+type agentWindowSelectedMsg struct {
+	err error
+}
+
+type agentInputSentMsg struct {
+	agentID string
+	input   SentInput
+	err     error
 }
 
 // Auto-refresh messages
-type ticketUpdateCheckMsg struct{}
 
-type ticketUpdateCheckNeededMsg struct{}
+// ticketUpdateCheckMsg asks for a change check of one connected project.
+type ticketUpdateCheckMsg struct {
+	projectDir string
+}
+
+// ticketChangeCheckedMsg carries the change token of a project. retryable
+// marks lost Dolt connections the user can recover from.
+type ticketChangeCheckedMsg struct {
+	projectDir string
+	token      string
+	err        error
+	retryable  bool
+}
 
-type ticketsAutoRefreshedMsg struct {
-	dbUpdatedAt time.Time
+// projectTicketCountMsg carries the number of ready tickets of a project.
+type projectTicketCountMsg struct {
+	projectDir string
+	count      int
+}
+
+// projectStoresOpenedMsg reports that the stores of all workspace projects
+// were opened at startup. err joins the projects that failed to open.
+type projectStoresOpenedMsg struct {
+	err error
 }
 
 type clearRefreshIndicatorMsg struct{}
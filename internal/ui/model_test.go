@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -9,9 +10,11 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/megatherium/blunderbust/internal/app"
 	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/fake"
 	"github.com/megatherium/blunderbust/internal/discovery"
 	"github.com/megatherium/blunderbust/internal/domain"
 )
@@ -161,10 +164,11 @@ func TestUIModel_Update_Messages(t *testing.T) {
 	warnM := newModel.(UIModel)
 	assert.Len(t, warnM.warnings, 1)
 
-	modalMsg := modalContentMsg("test content")
-	newModel, _ = m.Update(modalMsg)
+	m.ticketDetail.Load("bb-001")
+	detailMsg := ticketDetailLoadedMsg{ticketID: "bb-001", detail: &domain.TicketDetail{Ticket: domain.Ticket{ID: "bb-001"}}}
+	newModel, _ = m.Update(detailMsg)
 	modM := newModel.(UIModel)
-	assert.Equal(t, "test content", modM.modalContent)
+	assert.Equal(t, detailMsg.detail, modM.ticketDetail.detail)
 
 	res := &domain.LaunchResult{LauncherID: "test-window", LauncherType: domain.LauncherTypeTmux}
 	launchMsg := launchResultMsg{res: res, err: nil}
@@ -720,29 +724,154 @@ func TestUIModel_BothColumnsDisabled_Navigation(t *testing.T) {
 
 // Ticket Auto-Refresh Tests
 
+// tokenStore is a mockStore with a settable change token.
+type tokenStore struct {
+	mockStore
+	token string
+}
+
+func (s *tokenStore) ChangeToken(context.Context) (string, error) {
+	return s.token, nil
+}
+
+func TestWatchConnectedProjects(t *testing.T) {
+	app := newTestApp()
+	app.Stores = map[string]data.TicketStore{"/a": &tokenStore{}, "/b": &tokenStore{}}
+	m := NewUIModel(app, nil)
+
+	m, cmd := m.watchConnectedProjects()
+	assert.NotNil(t, cmd, "should check every new project")
+	assert.Len(t, m.ticketWatches, 2)
+	assert.Equal(t, ticketPollingInterval, m.ticketWatches["/a"].interval)
+
+	m, cmd = m.watchConnectedProjects()
+	assert.Nil(t, cmd, "watched projects are not checked again")
+	assert.Len(t, m.ticketWatches, 2)
+}
+
+func TestOpenProjectStores_WatchesEveryProject(t *testing.T) {
+	app := newTestApp()
+	app.AddProject(domain.Project{Dir: "/a"})
+	app.AddProject(domain.Project{Dir: "/b"})
+	app.Stores = map[string]data.TicketStore{"/a": &tokenStore{}}
+	m := NewUIModel(app, nil)
+	m, _ = m.watchConnectedProjects()
+	require.Len(t, m.ticketWatches, 1)
+
+	cmd := openProjectStoresCmd(app)
+	require.NotNil(t, cmd)
+	msg := cmd()
+	assert.Equal(t, projectStoresOpenedMsg{}, msg)
+
+	newM, cmd := m.Update(msg)
+	assert.NotNil(t, cmd, "the newly opened project is checked")
+	assert.Contains(t, newM.(UIModel).ticketWatches, "/b")
+
+	newM, _ = m.Update(projectStoresOpenedMsg{err: errors.New("failed to open project /c")})
+	assert.Contains(t, newM.(UIModel).warnings, "Opening projects: failed to open project /c")
+}
+
 func TestHandleTicketUpdateCheck(t *testing.T) {
 	app := newTestApp()
+	store := &tokenStore{token: "hash1"}
+	app.Stores = map[string]data.TicketStore{"/a": store}
 	m := NewUIModel(app, nil)
-	m.lastTicketUpdate = time.Now()
+	m.ticketWatches = map[string]*ticketWatch{"/a": {interval: ticketPollingInterval}}
 
-	newM, cmd := m.handleTicketUpdateCheck()
-	updatedM := newM.(UIModel)
+	_, cmd := m.handleTicketUpdateCheck(ticketUpdateCheckMsg{projectDir: "/unwatched"})
+	assert.Nil(t, cmd, "unwatched projects are not checked")
+
+	_, cmd = m.handleTicketUpdateCheck(ticketUpdateCheckMsg{projectDir: "/a"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, ticketChangeCheckedMsg{projectDir: "/a", token: "hash1"}, cmd())
 
-	assert.NotNil(t, cmd, "checkTicketUpdatesCmd should return a command")
-	assert.Equal(t, m.lastTicketUpdate, updatedM.lastTicketUpdate)
+	delete(app.Stores, "/a")
+	newM, cmd := m.handleTicketUpdateCheck(ticketUpdateCheckMsg{projectDir: "/a"})
+	assert.Nil(t, cmd)
+	assert.NotContains(t, newM.(UIModel).ticketWatches, "/a", "closed projects are no longer watched")
 }
 
-func TestHandleTicketUpdateCheck_WithNilStore(t *testing.T) {
+func TestHandleTicketChangeChecked_BacksOffWhileUnchanged(t *testing.T) {
 	app := newTestApp()
-	app.ActiveProject = ".beads"
-	app.Stores = map[string]data.TicketStore{".beads": &mockStore{}}
+	app.Stores = map[string]data.TicketStore{"/a": &tokenStore{}}
 	m := NewUIModel(app, nil)
+	m.ticketWatches = map[string]*ticketWatch{"/a": {interval: ticketPollingInterval}}
 
-	newM, cmd := m.handleTicketUpdateCheck()
-	updatedM := newM.(UIModel)
+	// The first check records the token without refreshing.
+	newM, cmd := m.handleTicketChangeChecked(ticketChangeCheckedMsg{projectDir: "/a", token: "hash1"})
+	m = newM.(UIModel)
+	assert.NotNil(t, cmd)
+	assert.False(t, m.refreshedRecently)
+	assert.Equal(t, ticketPollingInterval, m.ticketWatches["/a"].interval)
+
+	want := []time.Duration{6 * time.Second, 12 * time.Second, 24 * time.Second, ticketPollingMaxInterval, ticketPollingMaxInterval}
+	for _, interval := range want {
+		newM, _ = m.handleTicketChangeChecked(ticketChangeCheckedMsg{projectDir: "/a", token: "hash1"})
+		m = newM.(UIModel)
+		assert.Equal(t, interval, m.ticketWatches["/a"].interval)
+	}
+	assert.False(t, m.refreshedRecently)
+
+	newM, _ = m.handleTicketChangeChecked(ticketChangeCheckedMsg{projectDir: "/a", token: "hash2"})
+	m = newM.(UIModel)
+	assert.Equal(t, ticketPollingInterval, m.ticketWatches["/a"].interval, "a change resets the interval")
+	assert.Equal(t, "hash2", m.ticketWatches["/a"].token)
+}
+
+func TestHandleTicketChangeChecked_RefreshesOnlyActiveProject(t *testing.T) {
+	app := newTestApp()
+	app.ActiveProject = "/a"
+	app.Stores = map[string]data.TicketStore{"/a": &tokenStore{}, "/b": &tokenStore{}}
+	m := NewUIModel(app, nil)
+	m.ticketWatches = map[string]*ticketWatch{
+		"/a": {token: "hash1", checked: true, interval: ticketPollingInterval},
+		"/b": {token: "hash1", checked: true, interval: ticketPollingInterval},
+	}
+
+	newM, cmd := m.handleTicketChangeChecked(ticketChangeCheckedMsg{projectDir: "/b", token: "hash2"})
+	assert.NotNil(t, cmd, "the sidebar count of an inactive project is reloaded")
+	assert.False(t, newM.(UIModel).refreshedRecently, "inactive projects do not reload the ticket list")
 
-	assert.NotNil(t, cmd, "Should return tick command even with nil store")
-	assert.Equal(t, time.Time{}, updatedM.lastTicketUpdate)
+	newM, cmd = m.handleTicketChangeChecked(ticketChangeCheckedMsg{projectDir: "/a", token: "hash2"})
+	assert.NotNil(t, cmd)
+	assert.True(t, newM.(UIModel).refreshedRecently, "the active project's tickets are reloaded")
+}
+
+func TestHandleTicketChangeChecked_Errors(t *testing.T) {
+	app := newTestApp()
+	app.ActiveProject = "/a"
+	app.Stores = map[string]data.TicketStore{"/a": &tokenStore{}, "/b": &tokenStore{}}
+	m := NewUIModel(app, nil)
+	m.ticketWatches = map[string]*ticketWatch{
+		"/a": {interval: ticketPollingInterval},
+		"/b": {interval: ticketPollingInterval},
+	}
+	lost := errors.New("cannot connect to Dolt server")
+
+	newM, cmd := m.handleTicketChangeChecked(ticketChangeCheckedMsg{projectDir: "/b", err: lost, retryable: true})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, 2*ticketPollingInterval, newM.(UIModel).ticketWatches["/b"].interval, "inactive projects back off quietly")
+
+	newM, cmd = m.handleTicketChangeChecked(ticketChangeCheckedMsg{projectDir: "/a", err: lost, retryable: true})
+	require.NotNil(t, cmd)
+	assert.Equal(t, errMsg{err: lost, showRetryOptions: true}, cmd())
+	assert.NotContains(t, newM.(UIModel).ticketWatches, "/a", "polling resumes after recovery")
+}
+
+func TestProjectTicketCounts(t *testing.T) {
+	store := fake.NewWithSampleData()
+	ready, err := store.ListTickets(context.Background(), data.TicketFilter{})
+	require.NoError(t, err)
+
+	msg := loadProjectTicketCountCmd("/a", store)()
+	assert.Equal(t, projectTicketCountMsg{projectDir: "/a", count: len(ready)}, msg)
+
+	m := NewUIModel(newTestApp(), nil)
+	newM, _ := m.handleProjectTicketCount(msg.(projectTicketCountMsg))
+	m = newM.(UIModel)
+	count, ok := m.sidebar.TicketCount("/a")
+	assert.True(t, ok)
+	assert.Equal(t, len(ready), count)
 }
 
 func TestHandleTicketsAutoRefreshed(t *testing.T) {
@@ -751,13 +880,11 @@ func TestHandleTicketsAutoRefreshed(t *testing.T) {
 	app.Stores = map[string]data.TicketStore{"test-project": &mockStore{}}
 	m := NewUIModel(app, nil)
 
-	dbUpdatedAt := time.Now().UTC()
-	newM, cmd := m.handleTicketsAutoRefreshed(ticketsAutoRefreshedMsg{dbUpdatedAt: dbUpdatedAt})
+	newM, cmd := m.handleTicketsAutoRefreshed()
 	updatedM := newM.(UIModel)
 
 	assert.True(t, updatedM.refreshedRecently, "refreshedRecently should be set to true")
 	assert.Equal(t, 0, updatedM.refreshAnimationFrame, "Animation frame should reset to 0")
-	assert.Equal(t, dbUpdatedAt, updatedM.lastTicketUpdate)
 	assert.NotNil(t, cmd, "Should return batch commands")
 }
 
@@ -819,57 +946,6 @@ func TestRefreshAnimationTick_StopsWhenNotRefreshedRecently(t *testing.T) {
 	assert.Nil(t, cmd, "Should not return tick command when not refreshed recently")
 }
 
-func TestHandleTicketUpdateCheckNeeded(t *testing.T) {
-	app := newTestApp()
-	app.ActiveProject = "test-project"
-	app.Stores = map[string]data.TicketStore{"test-project": &mockStore{}}
-	m := NewUIModel(app, nil)
-
-	newM, cmd := m.handleTicketUpdateCheckNeeded()
-	updatedM := newM.(UIModel)
-
-	assert.NotNil(t, cmd, "Should return tick command")
-	assert.Equal(t, m.lastTicketUpdate, updatedM.lastTicketUpdate)
-}
-
-func TestCheckTicketUpdatesCmd_DemoMode(t *testing.T) {
-	app := newTestApp()
-	app.ActiveProject = "test-project"
-	app.Stores = map[string]data.TicketStore{"test-project": &mockStore{}}
-	m := NewUIModel(app, nil)
-
-	cmd := checkTicketUpdatesCmd(app.Stores["test-project"], m.lastTicketUpdate, false)
-	assert.NotNil(t, cmd)
-
-	msg := cmd()
-	assert.IsType(t, ticketUpdateCheckNeededMsg{}, msg)
-}
-
-func TestTicketNeedsRefresh(t *testing.T) {
-	now := time.Now()
-	older := now.Add(-1 * time.Hour)
-	newer := now.Add(1 * time.Hour)
-	zero := time.Time{}
-
-	// Normal case: newer data triggers refresh
-	assert.True(t, ticketNeedsRefresh(newer, now), "newer dbUpdate should trigger refresh")
-
-	// Normal case: same time does not trigger refresh
-	assert.False(t, ticketNeedsRefresh(now, now), "equal times should not trigger refresh")
-
-	// Normal case: older data does not trigger refresh
-	assert.False(t, ticketNeedsRefresh(older, now), "older dbUpdate should not trigger refresh")
-
-	// Edge case: empty DB (zero lastUpdate) with new data should trigger refresh
-	assert.True(t, ticketNeedsRefresh(now, zero), "first data from empty DB should trigger refresh")
-
-	// Edge case: both zero should not trigger refresh
-	assert.False(t, ticketNeedsRefresh(zero, zero), "both zero should not trigger refresh")
-
-	// Edge case: zero dbUpdate with non-zero lastUpdate should not trigger refresh
-	assert.False(t, ticketNeedsRefresh(zero, now), "zero dbUpdate should not trigger refresh")
-}
-
 type mockStore struct{}
 
 func (m *mockStore) ListTickets(ctx context.Context, filter data.TicketFilter) ([]domain.Ticket, error) {
@@ -1531,6 +1607,30 @@ func TestHandleRunningAgentsLoaded_RestoresMaterializedLaunchContext(t *testing.
 	assert.Equal(t, "a1", info.AgentName)
 }
 
+func TestRunningAgents_PersistedThroughAgentStore(t *testing.T) {
+	app := newTestApp()
+	app.ActiveProject = "/src/app"
+	app.Stores = map[string]data.TicketStore{"/src/app": fake.NewWithSampleData()}
+
+	spec := &domain.LaunchSpec{
+		Selection: domain.Selection{
+			Ticket:  domain.Ticket{ID: "bb-002", Title: "Define core domain types"},
+			Harness: domain.Harness{Name: "h1"},
+		},
+		RenderedCommand: "codex exec",
+	}
+	res := &domain.LaunchResult{LauncherID: "agent-window", LauncherType: domain.LauncherTypeTmux, PID: 42}
+	assert.Nil(t, saveRunningAgentCmd(app, "", spec, res, "/src/app/wt")())
+
+	msg, ok := loadRunningAgentsCmd(app)().(runningAgentsLoadedMsg)
+	require.True(t, ok)
+	require.NoError(t, msg.err)
+	require.Len(t, msg.agents, 1)
+	assert.Equal(t, "bb-002", msg.agents[0].Ticket)
+	assert.Equal(t, "/src/app/wt", msg.agents[0].WorktreePath)
+	assert.Equal(t, "codex", msg.agents[0].HarnessBinary)
+}
+
 func TestHandleAgentSelected_ClearsHoveredAgentID(t *testing.T) {
 	app := newTestApp()
 	app.ActiveProject = "."
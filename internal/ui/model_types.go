@@ -9,8 +9,10 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/config"
 	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/events"
 	"github.com/megatherium/blunderbust/internal/exec/tmux"
 	"github.com/megatherium/blunderbust/internal/ui/filepicker"
 )
@@ -37,10 +39,18 @@ const (
 
 	// Auto-refresh constants
 	ticketPollingInterval    = 3 * time.Second
+	ticketPollingMaxInterval = 30 * time.Second // backoff cap while a project does not change
 	refreshIndicatorDuration = 3 * time.Second
 	animationTickInterval    = 500 * time.Millisecond
 )
 
+// ticketWatch is the change detection state of one connected project.
+type ticketWatch struct {
+	token    string // change token at the last check
+	checked  bool   // whether token holds a first result yet
+	interval time.Duration
+}
+
 type FocusColumn int
 
 const (
@@ -62,6 +72,7 @@ const (
 	ViewStateConfirm
 	ViewStateInlineEdit
 	ViewStateError
+	ViewStateDashboard
 )
 
 // UIModel represents the complete state of the TUI application.
@@ -75,9 +86,10 @@ const (
 //   - ViewStateMatrix: Main matrix view (ticket/harness/model/agent columns)
 //   - ViewStateConfirm: Launch confirmation
 //   - ViewStateError: Error display with retry options
+//   - ViewStateDashboard: Table of all agents across projects
 //
-// Note: showModal is a separate overlay system used for error/info messages
-// and is composited on top of the main content.
+// Note: showModal is a separate overlay showing the ticket details and is
+// composited on top of the main content.
 //
 // Valid State Transitions (Add Project flow):
 //
@@ -103,6 +115,13 @@ const (
 //	→ Agent output view
 //	→ Enter or Back → state = ViewStateMatrix → back to matrix
 //
+// Valid State Transitions (Agent dashboard):
+//
+//	Matrix view + 'D' key → state = ViewStateDashboard
+//	→ Enter on an agent → state = ViewStateAgentOutput (outputReturnState = ViewStateDashboard)
+//	→ Enter or Back in the output → state = ViewStateDashboard
+//	→ Back or 'D' in the dashboard → state = ViewStateMatrix
+//
 // Column Disable Logic:
 //
 //	modelColumnDisabled = true when harness has no models
@@ -131,8 +150,23 @@ type UIModel struct {
 	warnings     []string
 	launchResult *domain.LaunchResult
 
-	showModal    bool
-	modalContent string
+	// Ticket details: showModal is the modal opened with Info,
+	// showTicketDetail the side panel next to the ticket column.
+	showModal        bool
+	showTicketDetail bool
+	ticketDetail     ticketDetailView
+
+	// Ticket filter pushed down to the store, edited in the filter bar or
+	// switched to one of filterPresets.
+	ticketFilter  data.TicketFilter
+	activePreset  string // name of the applied preset, "" for none
+	filterPresets []config.FilterPreset
+	showFilterBar bool
+	filterBar     ticketFilterBar
+
+	// confirmBlocked is set while the launch of a blocked ticket awaits its
+	// override confirmation on the confirm screen.
+	confirmBlocked bool
 
 	showSidebar      bool
 	selectedWorktree string
@@ -141,6 +175,12 @@ type UIModel struct {
 	agents         map[string]*RunningAgent // Keyed by agent ID
 	viewingAgentID string                   // Which agent output is displayed ("" = show matrix)
 	hoveredAgentID string                   // Agent currently hovered in sidebar ("" = no hover)
+	outputViewer   agentOutputViewer        // Scrollback of the viewed agent
+	// outputReturnState is where closing the agent output view leads
+	outputReturnState ViewState
+
+	// Cross-project agent dashboard
+	dashboard dashboardState
 
 	// Column disable state - set based on harness configuration
 	modelColumnDisabled bool // true when harness has no models
@@ -173,8 +213,8 @@ type UIModel struct {
 	// Current theme for visual styling
 	currentTheme *ThemePalette
 
-	// Ticket auto-refresh tracking
-	lastTicketUpdate      time.Time
+	// Ticket auto-refresh tracking, per connected project directory
+	ticketWatches         map[string]*ticketWatch
 	refreshedRecently     bool
 	refreshAnimationFrame int
 
@@ -198,6 +238,20 @@ type UIModel struct {
 	inlineEditTextarea textarea.Model
 	inlineEditMode     editMode
 	inlineEditError    string
+
+	// control receives agent events for control API subscribers (nil = disabled)
+	control ControlPublisher
+	// eventBus receives lifecycle events for the event log and hooks (nil = disabled)
+	eventBus *events.Bus
+
+	// Command palette overlay
+	showPalette bool
+	palette     commandPalette
+
+	// defaults are the configured default harness, model and agent (nil = none)
+	defaults *domain.Defaults
+	// writeback controls what is recorded in beads on launch and exit (nil = nothing)
+	writeback *domain.WritebackConfig
 }
 
 type filePickerPurpose int
@@ -217,6 +271,14 @@ const (
 // RunningAgent tracks a launched agent session
 type RunningAgent struct {
 	Info       *domain.AgentInfo
+	ProjectDir string // Project whose ticket store holds the agent's ticket
 	Capture    *tmux.OutputCapture
 	LastOutput string
+	History    []SentInput // Input sent from the TUI, oldest first
+}
+
+// SentInput is a message or key sent to an agent's pane from the TUI.
+type SentInput struct {
+	At   time.Time
+	Text string // The text sent, or a label such as "Ctrl-C" for keys
 }
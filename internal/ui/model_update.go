@@ -1,8 +1,8 @@
 package ui
 
 import (
-	"fmt"
-	"os"
+	"context"
+	"log/slog"
 	"runtime"
 	"time"
 
@@ -24,7 +24,9 @@ import (
 //    - registryLoadedMsg: Initial registry load
 //    - ticketsLoadedMsg: Ticket data loaded
 //    - errMsg/warningMsg: Error/warning display
-//    - modalContentMsg: Modal content updates
+//    - ticketDetailLoadedMsg: Ticket details for the modal and side panel
+//    - ticketRunsLoadedMsg: Past agent runs for the modal and side panel
+//    - filterPresetSelectedMsg: Filter preset chosen in the palette
 //    - tea.WindowSizeMsg: Window resize events
 //    - tea.KeyMsg: Keyboard input (dispatched via handleKeyMsg)
 //
@@ -44,14 +46,22 @@ import (
 //    - AgentSelectedMsg: Agent selection
 //    - AgentStatusMsg: Agent status updates
 //    - agentTickMsg: Agent periodic updates
-//    - agentOutputMsg: Agent output
+//    - agentOutputMsg/agentOutputTickMsg: Output polling of the viewed agent
+//    - agentOutputSavedMsg: Agent output saved to a file
+//    - agentInputSentMsg: Input sent to the viewed agent's pane
+//    - dashboardTickMsg: Dashboard refresh of the agents' last output lines
+//    - agentWindowSelectedMsg: Result of jumping to an agent's tmux window
 //    - animationTickMsg: Animation ticks
 //    - lockInMsg: Column lock-in animation
 //    - AgentClearedMsg/AllStoppedAgentsClearedMsg: Agent clearing
-//    - ticketUpdateCheckMsg/ticketUpdateCheckNeededMsg: Ticket updates
-//    - ticketsAutoRefreshedMsg/clearRefreshIndicatorMsg/refreshAnimationTickMsg: Refresh handling
+//    - ticketUpdateCheckMsg/ticketChangeCheckedMsg: Per-project change detection
+//    - projectTicketCountMsg: Ready ticket counts in the sidebar
+//    - clearRefreshIndicatorMsg/refreshAnimationTickMsg: Refresh handling
 //
-// 6. Focus Update: handleFocusUpdate() handles focus-specific updates based on current focus
+// 6. Control Messages: handleControlMsgs() answers control API requests
+//    forwarded by ControlBackend (harnesses, agents, launch, kill)
+//
+// 7. Focus Update: handleFocusUpdate() handles focus-specific updates based on current focus
 //    - FocusSidebar: Sidebar cursor and selection
 //    - FocusTickets: Ticket list cursor
 //    - FocusHarness: Harness list cursor and selection
@@ -61,14 +71,20 @@ import (
 // Key Handler Dispatch:
 //
 // Key messages are dispatched through handleKeyMsg() in priority order:
+// 0. Command palette (handlePaletteKeyMsg while open, ctrl+p to open)
+//    and the ticket filter bar (handleFilterBarKeyMsg while open)
 // 1. File picker keys (handleFilePickerKeyMsg)
 // 2. Add project modal keys (handleAddProjectModalKeyMsg)
 // 3. Error state keys (handleErrorStateKeyMsg)
-// 4. Modal keys (handleModalKeyMsg)
-// 5. Global keys (handleGlobalKeyMsg)
-// 6. Navigation keys (handleNavigationKeysMsg)
-// 7. Enter key (special handling with lock-in animation)
-// 8. Sidebar agent keys (HandleSidebarAgentKeysMsg)
+// 4. Agent output viewer keys (handleAgentOutputKeyMsg)
+// 5. Agent dashboard keys (handleDashboardKeyMsg)
+// 6. Ticket detail modal keys (handleTicketDetailModalKeyMsg)
+// 7. Ticket detail pane keys (handleTicketDetailPaneKeyMsg)
+// 8. Global keys (handleGlobalKeyMsg), including the filter keys
+//    (handleFilterKeyMsg)
+// 9. Navigation keys (handleNavigationKeysMsg)
+// 10. Enter key (special handling with lock-in animation)
+// 11. Sidebar agent keys (HandleSidebarAgentKeysMsg)
 //
 // Caching Strategy:
 //
@@ -89,6 +105,19 @@ import (
 // - Other states: Minimal key bindings enabled
 
 func (m UIModel) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if m.showPalette {
+		return m.handlePaletteKeyMsg(msg)
+	}
+	if m.showFilterBar {
+		return m.handleFilterBarKeyMsg(msg)
+	}
+	if model, cmd, handled := m.handleBlockedLaunchKeyMsg(msg); handled {
+		return model, cmd, handled
+	}
+	if key.Matches(msg, m.keys.Palette) && m.canOpenPalette() {
+		return m.openPalette()
+	}
+
 	if model, cmd, handled := m.handleFilePickerKeyMsg(msg); handled {
 		return model, cmd, handled
 	}
@@ -101,7 +130,19 @@ func (m UIModel) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 		return model, cmd, handled
 	}
 
-	if model, cmd, handled := m.handleModalKeyMsg(); handled {
+	if model, cmd, handled := m.handleAgentOutputKeyMsg(msg); handled {
+		return model, cmd, handled
+	}
+
+	if model, cmd, handled := m.handleDashboardKeyMsg(msg); handled {
+		return model, cmd, handled
+	}
+
+	if model, cmd, handled := m.handleTicketDetailModalKeyMsg(msg); handled {
+		return model, cmd, true
+	}
+
+	if model, cmd, handled := m.handleTicketDetailPaneKeyMsg(msg); handled {
 		return model, cmd, true
 	}
 
@@ -229,12 +270,18 @@ func (m UIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// at a rate proportional to overall UI activity, matching old behavior.
 	m.sidebar.TickAnimation()
 
-	if m.app.Opts.Debug && time.Since(lastPerfLog) >= 10*time.Second {
+	if time.Since(lastPerfLog) >= 10*time.Second && m.logger().Enabled(context.Background(), slog.LevelDebug) {
 		var memStats runtime.MemStats
 		runtime.ReadMemStats(&memStats)
-		fmt.Fprintf(os.Stderr, "[DEBUG][perf] heartbeat: goroutines=%d heapMB=%.1f agents=%d warnings=%d animFrame=%d refreshChecks=%d autoRefreshes=%d ticketLoads=%d\n",
-			runtime.NumGoroutine(), float64(memStats.HeapAlloc)/1024/1024, len(m.agents), len(m.warnings), m.sidebar.animFrame,
-			perfTicketCheckCount, perfAutoRefreshCount, perfTicketsLoadedCount)
+		m.logger().Debug("heartbeat",
+			"goroutines", runtime.NumGoroutine(),
+			"heap_mb", float64(memStats.HeapAlloc)/1024/1024,
+			"agents", len(m.agents),
+			"warnings", len(m.warnings),
+			"anim_frame", m.sidebar.animFrame,
+			"refresh_checks", perfTicketCheckCount,
+			"auto_refreshes", perfAutoRefreshCount,
+			"ticket_loads", perfTicketsLoadedCount)
 		lastPerfLog = time.Now()
 	}
 
@@ -272,6 +319,13 @@ func (m UIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return newModel, cmd
 	}
+	if newModel, cmd, handled := m.handleControlMsgs(msg); handled {
+		if uiModel, ok := newModel.(UIModel); ok {
+			uiModel.updateKeyBindings()
+			newModel = updateListCaches(&uiModel)
+		}
+		return newModel, cmd
+	}
 
 	uiModel, cmd := m.handleFocusUpdate(msg)
 	uiModel.updateKeyBindings()
@@ -36,6 +36,9 @@ func (m *UIModel) updateSizes() {
 		fpHeight = 5
 	}
 	m.filepicker.SetSize(m.layout.Width, fpHeight)
+
+	m.outputViewer.SetSize(agentOutputViewportSize(m.layout.Width, m.layout.Height))
+	m.resizeTicketDetail()
 }
 
 func (m UIModel) getThemeValue() ThemePalette {
@@ -56,7 +59,10 @@ func (m UIModel) renderMainContent() string {
 		SelectedWorktree:   m.selectedWorktree,
 		CurrentTheme:       m.getThemeValue(),
 		ShowModal:          m.showModal,
-		ModalContent:       m.modalContent,
+		ModalContent:       m.ticketDetail.View(),
+		PaletteView:        m.paletteView(),
+		FilterBarView:      m.filterBarView(),
+		ConfirmBlocked:     m.confirmBlocked,
 		PendingProjectPath: m.pendingProjectPath,
 		Warnings:           m.warnings,
 		Width:              m.layout.Width,
@@ -65,6 +71,9 @@ func (m UIModel) renderMainContent() string {
 		RetryStore:         m.retryStore,
 		MatrixConfig:       m.buildMatrixConfig(),
 		Agent:              m.agents[m.viewingAgentID],
+		OutputViewer:       m.outputViewer,
+		Dashboard:          m.buildDashboardConfig(),
+		Keys:               m.keys,
 		Filepicker:         m.filepicker,
 		FilePickerPurpose:  m.filePickerPurpose,
 		AnimState:          m.animState,
@@ -74,6 +83,20 @@ func (m UIModel) renderMainContent() string {
 	})
 }
 
+func (m UIModel) paletteView() string {
+	if !m.showPalette {
+		return ""
+	}
+	return m.palette.View(m.layout.Width, m.keys, m.getThemeValue())
+}
+
+func (m UIModel) filterBarView() string {
+	if !m.showFilterBar {
+		return ""
+	}
+	return m.filterBar.View(m.layout.Width, m.keys, m.getThemeValue())
+}
+
 func (m UIModel) buildMatrixConfig() MatrixConfig {
 	var theme ThemePalette
 	if m.currentTheme != nil {
@@ -111,6 +134,11 @@ func (m UIModel) buildMatrixConfig() MatrixConfig {
 		AgentTitle:          m.agentList.Title,
 	}
 
+	if m.ticketDetailPaneVisible() {
+		cfg.DetailView = m.ticketDetail.View()
+		cfg.DetailWidth, cfg.DetailHeight = ticketDetailPaneSize(m.layout)
+	}
+
 	if m.hoveredAgentID != "" {
 		if agent, ok := m.agents[m.hoveredAgentID]; ok && agent != nil && agent.Info != nil {
 			info := agent.Info
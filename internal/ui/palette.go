@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sahilm/fuzzy"
+)
+
+// paletteMaxRows is the number of actions shown at once in the palette.
+const paletteMaxRows = 10
+
+// commandPalette is the ctrl+p overlay that fuzzy-searches the actions
+// available in the current view.
+type commandPalette struct {
+	input   textinput.Model
+	actions []paletteAction
+	matches []int // indexes into actions, best match first
+	cursor  int
+}
+
+func newCommandPalette(actions []paletteAction) commandPalette {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Placeholder = "Type a command..."
+	ti.Focus()
+
+	p := commandPalette{input: ti, actions: actions}
+	p.filter()
+	return p
+}
+
+// filter ranks the actions against the query; an empty query keeps
+// registry order. The cursor returns to the best match.
+func (p *commandPalette) filter() {
+	p.matches = make([]int, 0, len(p.actions))
+	p.cursor = 0
+	query := strings.TrimSpace(p.input.Value())
+	if query == "" {
+		for i := range p.actions {
+			p.matches = append(p.matches, i)
+		}
+	} else {
+		titles := make([]string, len(p.actions))
+		for i, a := range p.actions {
+			titles[i] = a.title
+		}
+		for _, match := range fuzzy.Find(query, titles) {
+			p.matches = append(p.matches, match.Index)
+		}
+	}
+}
+
+func (p commandPalette) selected() (paletteAction, bool) {
+	if len(p.matches) == 0 {
+		return paletteAction{}, false
+	}
+	return p.actions[p.matches[p.cursor]], true
+}
+
+func (p *commandPalette) moveCursor(delta int) {
+	if len(p.matches) == 0 {
+		return
+	}
+	p.cursor = (p.cursor + delta + len(p.matches)) % len(p.matches)
+}
+
+// View renders the palette box; keys are shown next to each action so the
+// palette doubles as a cheat sheet.
+func (p commandPalette) View(width int, keys KeyMap, theme ThemePalette) string {
+	boxWidth := min(max(width-20, 40), 80)
+	innerWidth := boxWidth - 6
+
+	titleStyle := lipgloss.NewStyle().Foreground(theme.TitleColor).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(ThemeInactive)
+	selectedStyle := lipgloss.NewStyle().Foreground(theme.FocusIndicator).Bold(true)
+
+	lines := []string{titleStyle.Render("Commands"), p.input.View(), ""}
+
+	start := 0
+	if p.cursor >= paletteMaxRows {
+		start = p.cursor - paletteMaxRows + 1
+	}
+	end := min(start+paletteMaxRows, len(p.matches))
+	for i := start; i < end; i++ {
+		action := p.actions[p.matches[i]]
+		hint := action.keyHelp(keys)
+
+		prefix, style := "  ", lipgloss.NewStyle()
+		if i == p.cursor {
+			prefix, style = "▶ ", selectedStyle
+		}
+		title := ansi.Truncate(action.title, max(innerWidth-lipgloss.Width(hint)-3, 1), "…")
+		gap := max(innerWidth-lipgloss.Width(prefix+title)-lipgloss.Width(hint), 1)
+		lines = append(lines, style.Render(prefix+title)+strings.Repeat(" ", gap)+keyStyle.Render(hint))
+	}
+	if len(p.matches) == 0 {
+		lines = append(lines, keyStyle.Render("  No matching commands"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.TitleColor).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(strings.Join(lines, "\n"))
+}
+
+// canOpenPalette reports whether the palette may open over the current view.
+func (m UIModel) canOpenPalette() bool {
+	if m.showModal || isFocusedListFiltering(m) || m.outputViewer.inputMode != outputInputNone {
+		return false
+	}
+	switch m.state {
+	case ViewStateMatrix, ViewStateConfirm, ViewStateAgentOutput, ViewStateDashboard:
+		return true
+	}
+	return false
+}
+
+func (m UIModel) openPalette() (tea.Model, tea.Cmd, bool) {
+	m.palette = newCommandPalette(m.paletteActions())
+	m.showPalette = true
+	return m, nil, true
+}
+
+// handlePaletteKeyMsg drives the open palette. Choosing an action closes the
+// palette first so the action runs against the view it was opened from.
+func (m UIModel) handlePaletteKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	switch {
+	case msg.Type == tea.KeyEsc || key.Matches(msg, m.keys.Palette):
+		m.showPalette = false
+		return m, nil, true
+	case msg.Type == tea.KeyUp:
+		m.palette.moveCursor(-1)
+		return m, nil, true
+	case msg.Type == tea.KeyDown:
+		m.palette.moveCursor(1)
+		return m, nil, true
+	case msg.Type == tea.KeyEnter:
+		m.showPalette = false
+		action, ok := m.palette.selected()
+		if !ok {
+			return m, nil, true
+		}
+		return m, action.cmd(m), true
+	}
+
+	var cmd tea.Cmd
+	m.palette.input, cmd = m.palette.input.Update(msg)
+	m.palette.filter()
+	return m, cmd, true
+}
@@ -0,0 +1,341 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// paletteAction is a command palette entry. Actions bound to a key leave run
+// nil and dispatch that key's message, so they go through exactly the same
+// handlers as the key press; the rest dispatch the same messages their
+// counterpart in the UI does.
+type paletteAction struct {
+	title   string
+	binding func(k KeyMap) key.Binding
+	when    func(m UIModel) bool
+	run     func(m UIModel) tea.Cmd
+}
+
+// paletteRegistry is the central list of palette actions. New features add
+// their entries here (or a provider to paletteProviders) to show up in the
+// palette.
+var paletteRegistry = []paletteAction{
+	{
+		title:   "Toggle theme",
+		binding: func(k KeyMap) key.Binding { return k.ToggleTheme },
+		when:    func(m UIModel) bool { return m.state == ViewStateMatrix || m.state == ViewStateConfirm },
+	},
+	{
+		title:   "Toggle ticket zoom",
+		binding: func(k KeyMap) key.Binding { return k.Zoom },
+		when:    ticketColumnFocused,
+	},
+	{
+		title:   "Toggle sidebar",
+		binding: func(k KeyMap) key.Binding { return k.ToggleSidebar },
+		when:    func(m UIModel) bool { return m.state == ViewStateMatrix },
+	},
+	{
+		title:   "Refresh tickets",
+		binding: func(k KeyMap) key.Binding { return k.Refresh },
+		when:    ticketColumnFocused,
+	},
+	{
+		title:   "Show ticket details",
+		binding: func(k KeyMap) key.Binding { return k.Info },
+		when:    ticketColumnFocused,
+	},
+	{
+		title:   "Toggle ticket detail pane",
+		binding: func(k KeyMap) key.Binding { return k.TicketDetail },
+		when:    ticketColumnFocused,
+	},
+	{
+		title: "Reload templates",
+		when:  func(m UIModel) bool { return m.app != nil },
+		run:   func(m UIModel) tea.Cmd { return m.reloadTemplates() },
+	},
+	{
+		title:   "Add project (open file picker)",
+		binding: func(k KeyMap) key.Binding { return k.AddProject },
+		when:    func(m UIModel) bool { return m.state == ViewStateMatrix },
+		run:     func(UIModel) tea.Cmd { return OpenFilePickerCmd() },
+	},
+	{
+		title:   "Clear stopped agents",
+		binding: func(k KeyMap) key.Binding { return k.ClearStoppedAgents },
+		when:    func(m UIModel) bool { return m.clearStoppedAgentsCmd() != nil },
+		run:     func(m UIModel) tea.Cmd { return m.clearStoppedAgentsCmd() },
+	},
+	{
+		title:   "Pick template file",
+		binding: func(k KeyMap) key.Binding { return k.PickTemplate },
+		when:    func(m UIModel) bool { return m.state == ViewStateConfirm },
+	},
+	{
+		title:   "Edit template",
+		binding: func(k KeyMap) key.Binding { return k.EditTemplate },
+		when:    func(m UIModel) bool { return m.state == ViewStateConfirm },
+	},
+	{
+		title:   "Send input to agent",
+		binding: func(k KeyMap) key.Binding { return k.SendInput },
+		when:    agentOutputVisible,
+	},
+	{
+		title:   "Reply y to agent",
+		binding: func(k KeyMap) key.Binding { return k.ReplyYes },
+		when:    agentOutputVisible,
+	},
+	{
+		title:   "Reply continue to agent",
+		binding: func(k KeyMap) key.Binding { return k.ReplyContinue },
+		when:    agentOutputVisible,
+	},
+	{
+		title:   "Interrupt agent (Ctrl-C)",
+		binding: func(k KeyMap) key.Binding { return k.Interrupt },
+		when:    agentOutputVisible,
+	},
+	{
+		title:   "Search agent output",
+		binding: func(k KeyMap) key.Binding { return k.Search },
+		when:    agentOutputVisible,
+	},
+	{
+		title:   "Toggle follow mode",
+		binding: func(k KeyMap) key.Binding { return k.Follow },
+		when:    agentOutputVisible,
+	},
+	{
+		title:   "Save agent output to file",
+		binding: func(k KeyMap) key.Binding { return k.SaveOutput },
+		when:    agentOutputVisible,
+	},
+	{
+		title:   "Filter tickets",
+		binding: func(k KeyMap) key.Binding { return k.FilterBar },
+		when:    func(m UIModel) bool { return m.state == ViewStateMatrix },
+	},
+	{
+		title:   "Clear ticket filter",
+		binding: func(k KeyMap) key.Binding { return k.ClearFilter },
+		when:    func(m UIModel) bool { return m.state == ViewStateMatrix && m.filterActive() },
+	},
+	{
+		title:   "Cycle ticket scope (ready, in progress, blocked, all open)",
+		binding: func(k KeyMap) key.Binding { return k.TicketScope },
+		when:    func(m UIModel) bool { return m.state == ViewStateMatrix },
+	},
+	{
+		title:   "Open agent dashboard",
+		binding: func(k KeyMap) key.Binding { return k.Dashboard },
+		when:    func(m UIModel) bool { return m.state == ViewStateMatrix },
+	},
+	{
+		title:   "Sort dashboard by next column",
+		binding: func(k KeyMap) key.Binding { return k.SortColumn },
+		when:    dashboardVisible,
+	},
+	{
+		title:   "Filter dashboard by status",
+		binding: func(k KeyMap) key.Binding { return k.FilterStatus },
+		when:    dashboardVisible,
+	},
+	{
+		title:   "Filter dashboard by project",
+		binding: func(k KeyMap) key.Binding { return k.FilterProject },
+		when:    dashboardVisible,
+	},
+	{
+		title:   "Jump to agent's tmux window",
+		binding: func(k KeyMap) key.Binding { return k.JumpToWindow },
+		when:    dashboardVisible,
+	},
+	{
+		title:   "Quit",
+		binding: func(k KeyMap) key.Binding { return k.Quit },
+	},
+}
+
+// paletteProviders build actions from the current state.
+var paletteProviders = []func(m UIModel) []paletteAction{
+	launchDefaultsPaletteActions,
+	worktreePaletteActions,
+	agentPaletteActions,
+	filterPresetPaletteActions,
+}
+
+// paletteActions returns the actions available in the current view.
+func (m UIModel) paletteActions() []paletteAction {
+	var actions []paletteAction
+	for _, a := range paletteRegistry {
+		if a.when == nil || a.when(m) {
+			actions = append(actions, a)
+		}
+	}
+	for _, provide := range paletteProviders {
+		actions = append(actions, provide(m)...)
+	}
+	return actions
+}
+
+// keyHelp returns the key shown next to the action, if it has one.
+func (a paletteAction) keyHelp(keys KeyMap) string {
+	if a.binding == nil {
+		return ""
+	}
+	return a.binding(keys).Help().Key
+}
+
+// cmd returns the command that performs the action.
+func (a paletteAction) cmd(m UIModel) tea.Cmd {
+	if a.run != nil {
+		return a.run(m)
+	}
+	if a.binding == nil {
+		return nil
+	}
+	msg, ok := keyMsgFor(a.binding(m.keys))
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg { return msg }
+}
+
+func ticketColumnFocused(m UIModel) bool {
+	return m.state == ViewStateMatrix && m.focus == FocusTickets
+}
+
+func agentOutputVisible(m UIModel) bool {
+	return m.state == ViewStateAgentOutput
+}
+
+func dashboardVisible(m UIModel) bool {
+	return m.state == ViewStateDashboard
+}
+
+// worktreePaletteActions offers switching to any worktree in the sidebar.
+func worktreePaletteActions(m UIModel) []paletteAction {
+	var actions []paletteAction
+	for i := range m.sidebar.State().Nodes {
+		project := &m.sidebar.State().Nodes[i]
+		forEachWorktree(project, func(wt *domain.SidebarNode) {
+			path := wt.Path
+			actions = append(actions, paletteAction{
+				title: fmt.Sprintf("Switch to %s / %s", project.Name, wt.Name),
+				run:   func(UIModel) tea.Cmd { return SelectWorktreeCmd(path) },
+			})
+		})
+	}
+	return actions
+}
+
+// agentPaletteActions offers jumping to the output of any tracked agent.
+func agentPaletteActions(m UIModel) []paletteAction {
+	agents := make([]*domain.AgentInfo, 0, len(m.agents))
+	for _, agent := range m.agents {
+		if agent != nil && agent.Info != nil {
+			agents = append(agents, agent.Info)
+		}
+	}
+	slices.SortFunc(agents, func(a, b *domain.AgentInfo) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+
+	actions := make([]paletteAction, 0, len(agents))
+	for _, info := range agents {
+		id := info.ID
+		title := fmt.Sprintf("Jump to agent %s", info.Name)
+		if info.TicketTitle != "" {
+			title += " (" + info.TicketTitle + ")"
+		}
+		actions = append(actions, paletteAction{
+			title: title,
+			run:   func(UIModel) tea.Cmd { return SelectAgentCmd(id) },
+		})
+	}
+	return actions
+}
+
+// WithDefaults returns a copy of the model that offers launching the
+// highlighted ticket with the configured defaults from the palette.
+func (m UIModel) WithDefaults(defaults *domain.Defaults) UIModel {
+	m.defaults = defaults
+	return m
+}
+
+// launchDefaultsPaletteActions offers launching the highlighted ticket with
+// the default harness, model and agent through the regular launch pipeline.
+func launchDefaultsPaletteActions(m UIModel) []paletteAction {
+	if m.state != ViewStateMatrix || m.defaults == nil || m.defaults.Harness == "" || m.app == nil {
+		return nil
+	}
+	item, ok := m.ticketList.SelectedItem().(ticketItem)
+	if !ok || item.ticket.IsBlocked() {
+		// Blocked tickets go through the confirm screen and its override.
+		return nil
+	}
+	idx := slices.IndexFunc(m.harnesses, func(h domain.Harness) bool { return h.Name == m.defaults.Harness })
+	if idx < 0 {
+		return nil
+	}
+
+	selection := domain.Selection{
+		Ticket:  item.ticket,
+		Harness: m.harnesses[idx],
+		Model:   m.defaults.Model,
+		Agent:   m.defaults.Agent,
+	}
+	parts := []string{selection.Harness.Name}
+	for _, p := range []string{selection.Model, selection.Agent} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return []paletteAction{{
+		title: fmt.Sprintf("Launch %s with defaults (%s)", item.ticket.ID, strings.Join(parts, ", ")),
+		run: func(m UIModel) tea.Cmd {
+			m.selection = selection
+			return m.launchCmd()
+		},
+	}}
+}
+
+// keyNames maps key names as used in bindings to their key types.
+var keyNames = func() map[string]tea.KeyType {
+	names := make(map[string]tea.KeyType)
+	for kt := tea.KeyType(-128); kt < 128; kt++ {
+		if s := kt.String(); s != "" && kt != tea.KeyRunes {
+			names[s] = kt
+		}
+	}
+	return names
+}()
+
+// keyMsgFor returns the key message that triggers the binding's first key.
+func keyMsgFor(b key.Binding) (tea.KeyMsg, bool) {
+	keys := b.Keys()
+	if len(keys) == 0 {
+		return tea.KeyMsg{}, false
+	}
+	name := keys[0]
+
+	alt := false
+	if rest, ok := strings.CutPrefix(name, "alt+"); ok && rest != "" {
+		alt, name = true, rest
+	}
+	if kt, ok := keyNames[name]; ok {
+		return tea.KeyMsg{Type: kt, Alt: alt}, true
+	}
+	if runes := []rune(name); len(runes) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes, Alt: alt}, true
+	}
+	return tea.KeyMsg{}, false
+}
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func paletteTitles(m UIModel) []string {
+	var titles []string
+	for _, a := range m.paletteActions() {
+		titles = append(titles, a.title)
+	}
+	return titles
+}
+
+func typeInPalette(t *testing.T, m UIModel, text string) UIModel {
+	t.Helper()
+	for _, r := range text {
+		newModel, _, handled := m.handlePaletteKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		require.True(t, handled)
+		m = newModel.(UIModel)
+	}
+	return m
+}
+
+func TestKeyMsgFor(t *testing.T) {
+	tests := []struct {
+		keys []string
+		want string
+	}{
+		{[]string{"t"}, "t"},
+		{[]string{"ctrl+c", "q"}, "ctrl+c"},
+		{[]string{"esc"}, "esc"},
+		{[]string{"tab"}, "tab"},
+		{[]string{"alt+x"}, "alt+x"},
+	}
+
+	for _, tt := range tests {
+		b := key.NewBinding(key.WithKeys(tt.keys...))
+		msg, ok := keyMsgFor(b)
+		require.True(t, ok, tt.keys)
+		assert.Equal(t, tt.want, msg.String())
+		assert.True(t, key.Matches(msg, b))
+	}
+
+	_, ok := keyMsgFor(key.NewBinding())
+	assert.False(t, ok)
+}
+
+func TestPalette_OpensWithCtrlP(t *testing.T) {
+	m := *NewTestModel()
+	m.state = ViewStateMatrix
+
+	newModel, _, handled := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyCtrlP})
+	require.True(t, handled)
+	m = newModel.(UIModel)
+	assert.True(t, m.showPalette)
+	assert.NotEmpty(t, m.paletteView())
+
+	newModel, _, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.False(t, newModel.(UIModel).showPalette)
+}
+
+func TestPalette_DoesNotOpenOverFilePicker(t *testing.T) {
+	m := *NewTestModel()
+	m.state = ViewStateError
+	assert.False(t, m.canOpenPalette())
+}
+
+func TestPalette_ActionsFollowView(t *testing.T) {
+	m := *NewTestModel()
+	m.state = ViewStateMatrix
+	m.focus = FocusTickets
+	titles := paletteTitles(m)
+	assert.Contains(t, titles, "Toggle ticket zoom")
+	assert.Contains(t, titles, "Toggle sidebar")
+	assert.NotContains(t, titles, "Edit template")
+	assert.NotContains(t, titles, "Clear stopped agents")
+
+	m.state = ViewStateConfirm
+	titles = paletteTitles(m)
+	assert.Contains(t, titles, "Edit template")
+	assert.NotContains(t, titles, "Toggle sidebar")
+}
+
+func TestPalette_KeyActionDispatchesKeyMsg(t *testing.T) {
+	m := *NewTestModel()
+	m.state = ViewStateMatrix
+	m.focus = FocusTickets
+	m.keys.Zoom = key.NewBinding(key.WithKeys("Z"), key.WithHelp("Z", "zoom tickets"))
+
+	newModel, _, _ := m.openPalette()
+	m = typeInPalette(t, newModel.(UIModel), "zoom")
+	action, ok := m.palette.selected()
+	require.True(t, ok)
+	assert.Equal(t, "Toggle ticket zoom", action.title)
+	assert.Equal(t, "Z", action.keyHelp(m.keys))
+
+	newModel, cmd, _ := m.handlePaletteKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, newModel.(UIModel).showPalette)
+	require.NotNil(t, cmd)
+	assert.Equal(t, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Z")}, cmd())
+}
+
+func TestPalette_NoMatchRunsNothing(t *testing.T) {
+	m := *NewTestModel()
+	newModel, _, _ := m.openPalette()
+	m = typeInPalette(t, newModel.(UIModel), "xyzzy")
+
+	newModel, cmd, _ := m.handlePaletteKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.False(t, newModel.(UIModel).showPalette)
+	assert.Nil(t, cmd)
+}
+
+func TestPalette_ClearStoppedAgents(t *testing.T) {
+	m := *NewTestModel()
+	m.agents = map[string]*RunningAgent{
+		"a1": {Info: &domain.AgentInfo{ID: "a1", Name: "bd-1", Status: domain.AgentCompleted}},
+		"a2": {Info: &domain.AgentInfo{ID: "a2", Name: "bd-2", Status: domain.AgentRunning}},
+	}
+
+	newModel, _, _ := m.openPalette()
+	m = typeInPalette(t, newModel.(UIModel), "clear stopped")
+	_, cmd, _ := m.handlePaletteKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, AllStoppedAgentsClearedMsg{ClearedIDs: []string{"a1"}}, cmd())
+}
+
+func TestPalette_ProvidersAddWorktreesAndAgents(t *testing.T) {
+	m := *NewTestModel()
+	m.sidebar.State().SetNodes([]domain.SidebarNode{{
+		Type: domain.NodeTypeProject,
+		Name: "blunderbust",
+		Children: []domain.SidebarNode{
+			{Type: domain.NodeTypeWorktree, Name: "main", Path: "/src/bb"},
+			{Type: domain.NodeTypeWorktree, Name: "feature", Path: "/src/bb-feature"},
+		},
+	}})
+	m.agents = map[string]*RunningAgent{
+		"agent-1": {Info: &domain.AgentInfo{ID: "agent-1", Name: "bd-7", TicketTitle: "Fix login"}},
+	}
+
+	newModel, _, _ := m.openPalette()
+	m = typeInPalette(t, newModel.(UIModel), "switch feature")
+	_, cmd, _ := m.handlePaletteKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, WorktreeSelectedMsg{Path: "/src/bb-feature"}, cmd())
+
+	newModel, _, _ = m.openPalette()
+	m = typeInPalette(t, newModel.(UIModel), "jump login")
+	_, cmd, _ = m.handlePaletteKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, AgentSelectedMsg{AgentID: "agent-1"}, cmd())
+}
+
+func TestPalette_LaunchWithDefaults(t *testing.T) {
+	m := *NewTestModel()
+	m.app = newTestApp()
+	m.state = ViewStateMatrix
+	m.harnesses = []domain.Harness{{Name: "claude"}}
+	m.ticketList = list.New([]list.Item{ticketItem{ticket: domain.Ticket{ID: "bd-42", Title: "Answer"}}}, list.NewDefaultDelegate(), 40, 10)
+
+	assert.NotContains(t, paletteTitles(m), "Launch bd-42 with defaults (claude, sonnet)")
+
+	m = m.WithDefaults(&domain.Defaults{Harness: "claude", Model: "sonnet"})
+	assert.Contains(t, paletteTitles(m), "Launch bd-42 with defaults (claude, sonnet)")
+
+	m = m.WithDefaults(&domain.Defaults{Harness: "missing"})
+	for _, title := range paletteTitles(m) {
+		assert.NotContains(t, title, "with defaults")
+	}
+}
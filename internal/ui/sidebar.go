@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"maps"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -289,6 +291,10 @@ type SidebarModel struct {
 	hasStoreError bool
 	hasNerdFont   bool
 	animFrame     int
+	keys          sidebarKeyMap
+
+	// ticketCounts holds the number of ready tickets per project directory.
+	ticketCounts map[string]int
 }
 
 // NewSidebarModel creates a new sidebar model with default state.
@@ -296,9 +302,15 @@ func NewSidebarModel() SidebarModel {
 	return SidebarModel{
 		state:   NewSidebarState(),
 		focused: false,
+		keys:    newSidebarKeyMap(DefaultKeyMap()),
 	}
 }
 
+// SetKeys updates the sidebar bindings from the UI key map.
+func (m *SidebarModel) SetKeys(k KeyMap) {
+	m.keys = newSidebarKeyMap(k)
+}
+
 // Init implements tea.Model.
 func (m SidebarModel) Init() tea.Cmd {
 	return nil
@@ -332,23 +344,23 @@ func (m *SidebarModel) TickAnimation() {
 
 func (m SidebarModel) handleKey(msg tea.KeyMsg) (SidebarModel, tea.Cmd) {
 	switch {
-	case key.Matches(msg, sidebarKeys.Up):
+	case key.Matches(msg, m.keys.Up):
 		m.state.MoveUp()
-	case key.Matches(msg, sidebarKeys.Down):
+	case key.Matches(msg, m.keys.Down):
 		m.state.MoveDown()
-	case key.Matches(msg, sidebarKeys.Enter):
+	case key.Matches(msg, m.keys.Enter):
 		return m.handleSelect()
-	case key.Matches(msg, sidebarKeys.Expand):
+	case key.Matches(msg, m.keys.Expand):
 		node := m.state.CurrentNode()
 		if node != nil && len(node.Children) > 0 && !node.IsExpanded {
 			m.state.ToggleExpand()
 		}
-	case key.Matches(msg, sidebarKeys.Collapse):
+	case key.Matches(msg, m.keys.Collapse):
 		node := m.state.CurrentNode()
 		if node != nil && len(node.Children) > 0 && node.IsExpanded {
 			m.state.ToggleExpand()
 		}
-	case key.Matches(msg, sidebarKeys.AddProject):
+	case key.Matches(msg, m.keys.AddProject):
 		return m, OpenFilePickerCmd()
 	}
 	return m, nil
@@ -464,6 +476,9 @@ func (m SidebarModel) renderNode(node *domain.SidebarNode, depth int, isCursor b
 		branch := " " + branchStyle.Render("("+node.WorktreeInfo.Branch+")")
 		line += branch
 	}
+	if count, ok := m.ticketCounts[node.Path]; ok && node.Type == domain.NodeTypeProject {
+		line += " " + branchStyle.Render("("+strconv.Itoa(count)+")")
+	}
 
 	isSelected := node.Path == m.selectedPath && node.Type == domain.NodeTypeWorktree
 
@@ -612,6 +627,25 @@ func (m *SidebarModel) SetStoreError(hasError bool) {
 	m.hasStoreError = hasError
 }
 
+// SetTicketCount sets the number of ready tickets shown next to the
+// project at projectDir.
+func (m *SidebarModel) SetTicketCount(projectDir string, count int) {
+	// Copy, so earlier UIModel values keep their counts.
+	counts := maps.Clone(m.ticketCounts)
+	if counts == nil {
+		counts = make(map[string]int)
+	}
+	counts[projectDir] = count
+	m.ticketCounts = counts
+}
+
+// TicketCount returns the number of ready tickets of the project at
+// projectDir, if known.
+func (m *SidebarModel) TicketCount(projectDir string) (int, bool) {
+	count, ok := m.ticketCounts[projectDir]
+	return count, ok
+}
+
 // SetHasNerdFont sets the nerd font detection flag.
 func (m *SidebarModel) SetHasNerdFont(hasNerdFont bool) {
 	m.hasNerdFont = hasNerdFont
@@ -678,37 +712,24 @@ func OpenFilePickerCmd() tea.Cmd {
 // OpenFilePickerMsg is emitted when the user requests to add a project.
 type OpenFilePickerMsg struct{}
 
-// sidebarKeys defines the keybindings for sidebar navigation.
-var sidebarKeys = struct {
+// sidebarKeyMap defines the keybindings for sidebar navigation. It is
+// derived from the UI KeyMap so remapped keys apply to the sidebar too.
+type sidebarKeyMap struct {
 	Up         key.Binding
 	Down       key.Binding
 	Enter      key.Binding
 	Expand     key.Binding
 	Collapse   key.Binding
 	AddProject key.Binding
-}{
-	Up: key.NewBinding(
-		key.WithKeys("up", "k"),
-		key.WithHelp("↑/k", "up"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("down", "j"),
-		key.WithHelp("↓/j", "down"),
-	),
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "select"),
-	),
-	Expand: key.NewBinding(
-		key.WithKeys("right", "l"),
-		key.WithHelp("→/l", "expand"),
-	),
-	Collapse: key.NewBinding(
-		key.WithKeys("left", "h"),
-		key.WithHelp("←/h", "collapse"),
-	),
-	AddProject: key.NewBinding(
-		key.WithKeys("a"),
-		key.WithHelp("a", "add project"),
-	),
+}
+
+func newSidebarKeyMap(k KeyMap) sidebarKeyMap {
+	return sidebarKeyMap{
+		Up:         k.Up,
+		Down:       k.Down,
+		Enter:      k.Enter,
+		Expand:     k.Right,
+		Collapse:   k.Left,
+		AddProject: k.AddProject,
+	}
 }
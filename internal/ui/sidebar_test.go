@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -452,6 +453,10 @@ func TestSidebarModel_View(t *testing.T) {
 	view := m.View()
 	assert.Contains(t, view, "TestProject")
 	assert.Contains(t, view, "main")
+	assert.NotContains(t, view, "(7)")
+
+	m.SetTicketCount("/tmp/test", 7)
+	assert.Equal(t, 1, strings.Count(m.View(), "(7)"), "the count is shown next to the project only")
 }
 
 func TestSidebarModel_handleSelect_ProjectNode(t *testing.T) {
@@ -7,6 +7,7 @@
 package ui
 
 import (
+	"errors"
 	"io"
 	"strings"
 	"testing"
@@ -160,7 +161,8 @@ func TestTeatest_ModalDisplay(t *testing.T) {
 	harnesses := newTestHarnesses()
 	m := NewUIModel(app, harnesses)
 	m.showModal = true
-	m.modalContent = "Test modal content"
+	m.ticketDetail.Load("bb-001")
+	m.ticketDetail.SetDetail(nil, errors.New("Test modal content"))
 
 	tm := teatest.NewTestModel(t, m, teatest.WithInitialTermSize(100, 40))
 	defer func() { _ = tm.Quit() }()
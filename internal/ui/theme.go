@@ -25,6 +25,7 @@ type ThemePalette struct {
 	FocusIndicator lipgloss.Color // For ▶ indicator
 	AppBg          lipgloss.Color // Main app background
 	AppFg          lipgloss.Color // Main app foreground
+	ColorCycles    []ColorCycle   // Accent colors for cycling; empty pulses the gradient
 }
 
 // Glow intensity constants - tune these to adjust glow effect
@@ -61,6 +62,7 @@ var MatrixTheme = ThemePalette{
 	FocusIndicator: lipgloss.Color("#ffff00"), // Yellow indicator
 	AppBg:          lipgloss.Color("#051010"), // Very dark green/teal
 	AppFg:          lipgloss.Color("#e0ffe0"), // Very light green fade
+	ColorCycles:    MatrixThemeColorCycles,
 }
 
 // MatrixThemeColorCycles defines accent colors for cycling within Matrix theme
@@ -92,6 +94,7 @@ var CyberpunkTheme = ThemePalette{
 	FocusIndicator: lipgloss.Color("#00ffff"), // Cyan indicator
 	AppBg:          lipgloss.Color("#10051a"), // Very dark purple
 	AppFg:          lipgloss.Color("#ffe0ff"), // Very light pink
+	ColorCycles:    CyberpunkThemeColorCycles,
 }
 
 // CyberpunkThemeColorCycles defines accent colors for cycling within Cyberpunk theme
@@ -123,6 +126,7 @@ var TokyoNightTheme = ThemePalette{
 	FocusIndicator: lipgloss.Color("#7aa2f7"), // Light Blue
 	AppBg:          lipgloss.Color("#1a1b26"), // Main theme bg
 	AppFg:          lipgloss.Color("#a9b1d6"), // Main theme fg
+	ColorCycles:    TokyoNightThemeColorCycles,
 }
 
 // TokyoNightThemeColorCycles defines accent colors for cycling within TokyoNight theme
@@ -242,20 +246,7 @@ func getPulsingColorWithTheme(phase float64, theme *ThemePalette) lipgloss.Color
 // getCyclingColor returns a color from the theme's cycling palette
 // Uses cycleIndex to select base color, phase for pulse within that color
 func getCyclingColor(phase float64, cycleIndex int, theme *ThemePalette) lipgloss.Color {
-	var cycles []ColorCycle
-	if theme == nil {
-		cycles = MatrixThemeColorCycles
-	} else {
-		switch theme.Name {
-		case CyberpunkTheme.Name:
-			cycles = CyberpunkThemeColorCycles
-		case TokyoNightTheme.Name:
-			cycles = TokyoNightThemeColorCycles
-		default:
-			cycles = MatrixThemeColorCycles
-		}
-	}
-
+	cycles := themeColorCycles(theme)
 	if len(cycles) == 0 {
 		return getPulsingColorWithTheme(phase, theme)
 	}
@@ -276,6 +267,15 @@ func getCyclingColor(phase float64, cycleIndex int, theme *ThemePalette) lipglos
 	return lipgloss.Color(interpolateColor(cycle.Base, cycle.Bright, t))
 }
 
+// themeColorCycles returns the accent cycles of theme, defaulting to the
+// Matrix cycles when no theme is set.
+func themeColorCycles(theme *ThemePalette) []ColorCycle {
+	if theme == nil {
+		return MatrixThemeColorCycles
+	}
+	return theme.ColorCycles
+}
+
 // getGlowColor returns a background color for glow effect based on current pulse
 func getGlowColor(phase float64, theme *ThemePalette) lipgloss.Color {
 	if theme == nil {
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlTheme is the raw YAML structure of a user theme file.
+type yamlTheme struct {
+	Name           string           `yaml:"name"`
+	Gradient       []string         `yaml:"gradient"`
+	FlashColor     string           `yaml:"flash_color"`
+	GlowColor      string           `yaml:"glow_color"`
+	TitleColor     string           `yaml:"title_color"`
+	ReadyColor     string           `yaml:"ready_color"`
+	LaunchBg       string           `yaml:"launch_bg"`
+	LaunchFg       string           `yaml:"launch_fg"`
+	ArcadeGold     string           `yaml:"arcade_gold"`
+	FocusIndicator string           `yaml:"focus_indicator"`
+	AppBg          string           `yaml:"app_bg"`
+	AppFg          string           `yaml:"app_fg"`
+	ColorCycles    []yamlColorCycle `yaml:"color_cycles"`
+	Extra          map[string]any   `yaml:",inline"`
+}
+
+type yamlColorCycle struct {
+	Base   string `yaml:"base"`
+	Dark   string `yaml:"dark"`
+	Bright string `yaml:"bright"`
+}
+
+// LoadThemes returns the built-in themes followed by the user themes found
+// in dir (*.yaml and *.yml, in file name order). A missing dir yields only
+// the built-ins. Theme names must be unique, ignoring case.
+func LoadThemes(dir string) ([]*ThemePalette, error) {
+	themes := append([]*ThemePalette(nil), AvailableThemes...)
+	if dir == "" {
+		return themes, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return themes, nil
+		}
+		return nil, fmt.Errorf("failed to read themes directory %s: %w", dir, err)
+	}
+
+	var files []string
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if !e.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	for _, path := range files {
+		theme, err := LoadThemeFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if existing, ok := FindTheme(themes, theme.Name); ok {
+			return nil, fmt.Errorf("theme %s: name %q is already used by theme %q", path, theme.Name, existing.Name)
+		}
+		themes = append(themes, theme)
+	}
+	return themes, nil
+}
+
+// LoadThemeFile reads and validates a single theme file. Every color must
+// be a #RRGGBB hex value since themes are blended for pulse and glow effects.
+func LoadThemeFile(path string) (*ThemePalette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme %s: %w", path, err)
+	}
+
+	var raw yamlTheme
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse theme %s: %w", path, err)
+	}
+
+	theme, err := convertTheme(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid theme %s: %w", path, err)
+	}
+	return theme, nil
+}
+
+func convertTheme(raw yamlTheme) (*ThemePalette, error) {
+	if len(raw.Extra) > 0 {
+		keys := make([]string, 0, len(raw.Extra))
+		for k := range raw.Extra {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return nil, fmt.Errorf("unknown field %q", keys[0])
+	}
+	if strings.TrimSpace(raw.Name) == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if len(raw.Gradient) < 2 {
+		return nil, fmt.Errorf("gradient needs at least 2 colors, got %d", len(raw.Gradient))
+	}
+	for i, c := range raw.Gradient {
+		if err := validateThemeColor(fmt.Sprintf("gradient[%d]", i), c); err != nil {
+			return nil, err
+		}
+	}
+
+	colors := []struct {
+		field string
+		value string
+	}{
+		{"flash_color", raw.FlashColor},
+		{"glow_color", raw.GlowColor},
+		{"title_color", raw.TitleColor},
+		{"ready_color", raw.ReadyColor},
+		{"launch_bg", raw.LaunchBg},
+		{"launch_fg", raw.LaunchFg},
+		{"arcade_gold", raw.ArcadeGold},
+		{"focus_indicator", raw.FocusIndicator},
+		{"app_bg", raw.AppBg},
+		{"app_fg", raw.AppFg},
+	}
+	for _, c := range colors {
+		if err := validateThemeColor(c.field, c.value); err != nil {
+			return nil, err
+		}
+	}
+
+	cycles := make([]ColorCycle, 0, len(raw.ColorCycles))
+	for i, c := range raw.ColorCycles {
+		for _, v := range []struct{ field, value string }{
+			{"base", c.Base}, {"dark", c.Dark}, {"bright", c.Bright},
+		} {
+			if err := validateThemeColor(fmt.Sprintf("color_cycles[%d].%s", i, v.field), v.value); err != nil {
+				return nil, err
+			}
+		}
+		cycles = append(cycles, ColorCycle{Base: c.Base, Dark: c.Dark, Bright: c.Bright})
+	}
+
+	return &ThemePalette{
+		Name:           raw.Name,
+		Gradient:       raw.Gradient,
+		FlashColor:     lipgloss.Color(raw.FlashColor),
+		GlowColor:      lipgloss.Color(raw.GlowColor),
+		TitleColor:     lipgloss.Color(raw.TitleColor),
+		ReadyColor:     lipgloss.Color(raw.ReadyColor),
+		LaunchBg:       lipgloss.Color(raw.LaunchBg),
+		LaunchFg:       lipgloss.Color(raw.LaunchFg),
+		ArcadeGold:     lipgloss.Color(raw.ArcadeGold),
+		FocusIndicator: lipgloss.Color(raw.FocusIndicator),
+		AppBg:          lipgloss.Color(raw.AppBg),
+		AppFg:          lipgloss.Color(raw.AppFg),
+		ColorCycles:    cycles,
+	}, nil
+}
+
+func validateThemeColor(field, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s is required", field)
+	}
+	if !strings.HasPrefix(value, "#") {
+		return fmt.Errorf("%s: %q must be a hex color like #1a1b26", field, value)
+	}
+	if _, _, _, err := parseHexColor(value); err != nil {
+		return fmt.Errorf("%s: %q must be a hex color like #1a1b26", field, value)
+	}
+	return nil
+}
+
+// FindTheme looks up a theme by name, ignoring case.
+func FindTheme(themes []*ThemePalette, name string) (*ThemePalette, bool) {
+	for _, t := range themes {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// ThemeNames returns the names of themes in order.
+func ThemeNames(themes []*ThemePalette) []string {
+	names := make([]string, 0, len(themes))
+	for _, t := range themes {
+		names = append(names, t.Name)
+	}
+	return names
+}
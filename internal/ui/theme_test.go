@@ -1,10 +1,14 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetCyclingColor_UsesTokyoNightCycles(t *testing.T) {
@@ -48,3 +52,102 @@ func TestGetGlowColor_NonHexAppBackgroundFallsBackToGlowColor(t *testing.T) {
 	color := getGlowColor(0.7, &theme)
 	assert.Equal(t, "#445566", string(color))
 }
+
+const testThemeYAML = `name: Solarized
+gradient: ["#268bd2", "#2aa198", "#859900"]
+flash_color: "#cb4b16"
+glow_color: "#073642"
+title_color: "#268bd2"
+ready_color: "#859900"
+launch_bg: "#dc322f"
+launch_fg: "#fdf6e3"
+arcade_gold: "#b58900"
+focus_indicator: "#2aa198"
+app_bg: "#002b36"
+app_fg: "#839496"
+color_cycles:
+  - {base: "#268bd2", dark: "#134569", bright: "#6cb6ff"}
+`
+
+func writeThemeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+}
+
+func TestLoadThemes_AppendsUserThemes(t *testing.T) {
+	dir := t.TempDir()
+	writeThemeFile(t, dir, "solarized.yaml", testThemeYAML)
+	writeThemeFile(t, dir, "notes.txt", "ignored")
+
+	themes, err := LoadThemes(dir)
+	require.NoError(t, err)
+	require.Len(t, themes, len(AvailableThemes)+1)
+
+	theme, ok := FindTheme(themes, "solarized")
+	require.True(t, ok)
+	assert.Equal(t, lipgloss.Color("#073642"), theme.GlowColor)
+	assert.Equal(t, "#268bd2", string(getCyclingColor(0.5, 0, theme)))
+	assert.Equal(t, "#268bd2", string(getPulsingColorWithTheme(1, theme)))
+}
+
+func TestLoadThemes_MissingDirReturnsBuiltins(t *testing.T) {
+	themes, err := LoadThemes(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Equal(t, ThemeNames(AvailableThemes), ThemeNames(themes))
+}
+
+func TestLoadThemes_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"bad hex", strings.Replace(testThemeYAML, `"#073642"`, `"#07364g"`, 1), `glow_color: "#07364g" must be a hex color`},
+		{"short hex", strings.Replace(testThemeYAML, `"#268bd2", "#2aa198"`, `"#268bd2", "#2aa"`, 1), `gradient[1]: "#2aa" must be a hex color`},
+		{"ansi color", strings.Replace(testThemeYAML, `"#cb4b16"`, `"51"`, 1), `flash_color: "51" must be a hex color`},
+		{"missing color", strings.Replace(testThemeYAML, "app_fg: \"#839496\"\n", "", 1), "app_fg is required"},
+		{"bad cycle", strings.Replace(testThemeYAML, `dark: "#134569"`, `dark: "blue"`, 1), "color_cycles[0].dark"},
+		{"unknown field", testThemeYAML + "glowcolor: \"#000000\"\n", `unknown field "glowcolor"`},
+		{"duplicate built-in", strings.Replace(testThemeYAML, "Solarized", "matrix", 1), `name "matrix" is already used by theme "Matrix"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeThemeFile(t, dir, "theme.yaml", tt.content)
+
+			_, err := LoadThemes(dir)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), "theme.yaml")
+		})
+	}
+}
+
+func TestAnimationState_CyclesUserThemes(t *testing.T) {
+	custom := &ThemePalette{Name: "Custom"}
+	a := AnimationState{Themes: []*ThemePalette{&MatrixTheme, custom}}
+
+	a.nextTheme()
+	assert.Same(t, custom, a.getCurrentTheme())
+	a.nextTheme()
+	assert.Same(t, &MatrixTheme, a.getCurrentTheme())
+}
+
+func TestNewUIModel_SelectsConfiguredUserTheme(t *testing.T) {
+	dir := t.TempDir()
+	writeThemeFile(t, dir, "solarized.yaml", testThemeYAML)
+
+	app := newTestApp()
+	app.Opts.ThemesDir = dir
+	app.Opts.Theme = "Solarized"
+	m := NewUIModel(app, nil)
+
+	require.NotNil(t, m.currentTheme)
+	assert.Equal(t, "Solarized", m.currentTheme.Name)
+	assert.Same(t, m.currentTheme, m.animState.getCurrentTheme())
+
+	// Toggling wraps from the last (user) theme back to the first built-in.
+	newModel, _, _ := m.handleToggleThemeKeyMsg()
+	assert.Equal(t, AvailableThemes[0].Name, newModel.(UIModel).currentTheme.Name)
+}
@@ -0,0 +1,361 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// ticketDetailScrollLines is how far the detail pane keys scroll.
+const ticketDetailScrollLines = 3
+
+// ticketDetailRunLimit caps the past runs listed for a ticket.
+const ticketDetailRunLimit = 10
+
+// ticketDetailView shows the full issue of the highlighted ticket, either in
+// the side panel next to the ticket column or in the modal. Both share one
+// view, so switching between them does not reload the issue.
+type ticketDetailView struct {
+	viewport viewport.Model
+	ticketID string
+	detail   *domain.TicketDetail
+	err      error
+	runs     []domain.AgentRun // finished agent runs for the ticket
+	width    int               // wrap width of the rendered content
+}
+
+func newTicketDetailView() ticketDetailView {
+	return ticketDetailView{viewport: viewport.New(0, 0)}
+}
+
+// SetSize resizes the view, re-rendering the markdown when the wrap width
+// changes.
+func (v *ticketDetailView) SetSize(width, height int) {
+	v.viewport.Width = max(width, 1)
+	v.viewport.Height = max(height, 1)
+	if v.viewport.Width != v.width {
+		v.width = v.viewport.Width
+		v.render()
+	}
+}
+
+// Load shows a loading message for ticketID until SetDetail delivers it.
+func (v *ticketDetailView) Load(ticketID string) {
+	v.ticketID = ticketID
+	v.detail = nil
+	v.err = nil
+	v.runs = nil
+	v.render()
+	v.viewport.GotoTop()
+}
+
+func (v *ticketDetailView) SetDetail(detail *domain.TicketDetail, err error) {
+	v.detail = detail
+	v.err = err
+	v.render()
+}
+
+// SetRuns shows the past agent runs below the issue.
+func (v *ticketDetailView) SetRuns(runs []domain.AgentRun) {
+	v.runs = runs
+	v.render()
+}
+
+func (v *ticketDetailView) render() {
+	switch {
+	case v.ticketID == "":
+		v.viewport.SetContent("No ticket selected")
+	case v.err != nil:
+		v.viewport.SetContent(fmt.Sprintf("Failed to load %s:\n%v", v.ticketID, v.err))
+	case v.detail == nil:
+		v.viewport.SetContent(fmt.Sprintf("Loading %s...", v.ticketID))
+	default:
+		v.viewport.SetContent(renderMarkdown(ticketDetailMarkdown(v.detail, v.runs), v.width))
+	}
+}
+
+// Scroll moves the content by lines, negative values scroll up.
+func (v *ticketDetailView) Scroll(lines int) {
+	if lines < 0 {
+		v.viewport.ScrollUp(-lines)
+	} else {
+		v.viewport.ScrollDown(lines)
+	}
+}
+
+func (v ticketDetailView) View() string {
+	return v.viewport.View()
+}
+
+// ticketDetailMarkdown lays out an issue and the agent runs for it as a
+// markdown document.
+func ticketDetailMarkdown(d *domain.TicketDetail, runs []domain.AgentRun) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s %s\n\n", d.ID, d.Title)
+
+	meta := []string{
+		"**Status:** " + d.Status,
+		fmt.Sprintf("**Priority:** P%d", d.Priority),
+		"**Type:** " + d.IssueType,
+	}
+	if d.Assignee != "" {
+		meta = append(meta, "**Assignee:** "+d.Assignee)
+	}
+	b.WriteString(strings.Join(meta, " · ") + "\n\n")
+	if len(d.Labels) > 0 {
+		fmt.Fprintf(&b, "**Labels:** %s\n\n", strings.Join(d.Labels, ", "))
+	}
+
+	for _, section := range []struct{ title, body string }{
+		{"Description", d.Description},
+		{"Design", d.Design},
+		{"Acceptance Criteria", d.AcceptanceCriteria},
+		{"Notes", d.Notes},
+	} {
+		if strings.TrimSpace(section.body) == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "## %s\n\n%s\n\n", section.title, strings.TrimSpace(section.body))
+	}
+
+	if len(d.Dependencies) > 0 {
+		b.WriteString("## Dependencies\n\n")
+		for _, dep := range d.Dependencies {
+			fmt.Fprintf(&b, "- **%s** %s (%s", dep.ID, dep.Title, dep.Type)
+			if dep.Status != "" {
+				b.WriteString(", " + dep.Status)
+			}
+			b.WriteString(")\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if len(d.Comments) > 0 {
+		b.WriteString("## Comments\n\n")
+		for _, c := range d.Comments {
+			fmt.Fprintf(&b, "**%s** · %s\n\n%s\n\n", c.Author, c.CreatedAt.Format("2006-01-02 15:04"), strings.TrimSpace(c.Text))
+		}
+	}
+
+	if len(runs) > 0 {
+		b.WriteString("## Past Runs\n\n")
+		for _, r := range runs {
+			who := []string{r.HarnessName}
+			if r.Model != "" {
+				who = append(who, r.Model)
+			}
+			if r.Agent != "" {
+				who = append(who, r.Agent)
+			}
+			fmt.Fprintf(&b, "- **%s** %s: %s after %s, +%d/-%d lines in `%s`\n",
+				r.StartedAt.Local().Format("2006-01-02 15:04"), strings.Join(who, " · "), r.Outcome(),
+				r.Duration().Round(time.Second), r.LinesAdded, r.LinesRemoved, r.WorktreePath)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderMarkdown renders markdown for the terminal, wrapped to width. The
+// source is shown as is if rendering fails.
+func renderMarkdown(md string, width int) string {
+	r, err := glamour.NewTermRenderer(glamour.WithStandardStyle("dark"), glamour.WithWordWrap(width))
+	if err == nil {
+		var out string
+		if out, err = r.Render(md); err == nil {
+			return strings.Trim(out, "\n")
+		}
+	}
+	return lipgloss.NewStyle().Width(width).Render(md)
+}
+
+// loadTicketDetailCmd reads the full issue from the ticket's project store.
+func loadTicketDetailCmd(store data.TicketStore, ticketID string) tea.Cmd {
+	return func() tea.Msg {
+		detailer, ok := store.(data.TicketDetailStore)
+		if !ok {
+			return ticketDetailLoadedMsg{ticketID: ticketID, err: errors.New("ticket details are not supported by this store")}
+		}
+		detail, err := detailer.TicketDetail(context.Background(), ticketID)
+		return ticketDetailLoadedMsg{ticketID: ticketID, detail: detail, err: err}
+	}
+}
+
+// loadTicketRunsCmd reads the finished agent runs for a ticket of
+// projectDir from the project's agent store.
+func loadTicketRunsCmd(myApp *app.App, projectDir, ticketID string) tea.Cmd {
+	if myApp == nil || projectDir == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx := context.Background()
+		store, err := myApp.AgentStoreForProject(ctx, projectDir)
+		if err != nil {
+			return ticketRunsLoadedMsg{ticketID: ticketID, err: err}
+		}
+		runs, err := store.ListAgentRuns(ctx, data.AgentRunFilter{
+			ProjectDir: projectDir,
+			Ticket:     ticketID,
+			Limit:      ticketDetailRunLimit,
+		})
+		return ticketRunsLoadedMsg{ticketID: ticketID, runs: runs, err: err}
+	}
+}
+
+// selectedTicket returns the highlighted ticket, if any.
+func (m UIModel) selectedTicket() (ticketItem, bool) {
+	item, ok := m.ticketList.SelectedItem().(ticketItem)
+	return item, ok
+}
+
+// loadSelectedTicketDetail points the detail view at the highlighted ticket
+// and loads it unless it is already shown.
+func (m *UIModel) loadSelectedTicketDetail() tea.Cmd {
+	item, ok := m.selectedTicket()
+	if !ok {
+		m.ticketDetail.Load("")
+		return nil
+	}
+	if item.ticket.ID == m.ticketDetail.ticketID && m.ticketDetail.err == nil {
+		return nil
+	}
+	m.ticketDetail.Load(item.ticket.ID)
+	if item.project == nil || item.project.Store() == nil {
+		m.ticketDetail.SetDetail(nil, errors.New("ticket has no project store"))
+		return nil
+	}
+	return tea.Batch(
+		loadTicketDetailCmd(item.project.Store(), item.ticket.ID),
+		loadTicketRunsCmd(m.app, item.project.RootPath(), item.ticket.ID),
+	)
+}
+
+// ticketDetailPaneVisible reports whether the side panel is on screen. It
+// only shows next to the focused ticket column.
+func (m UIModel) ticketDetailPaneVisible() bool {
+	return m.showTicketDetail && m.state == ViewStateMatrix && m.focus == FocusTickets
+}
+
+// ticketDetailPaneSize returns the outer size of the side panel: it takes the
+// place of the harness, model and agent columns.
+func ticketDetailPaneSize(l LayoutDimensions) (width, height int) {
+	switch l.GridMode {
+	case Grid2x2:
+		return l.TWidth + l.HWidth + 2, l.MHeight
+	case Grid1x4:
+		return l.TWidth, l.HHeight + l.MHeight + l.AHeight + 2
+	default:
+		return l.HWidth + l.MWidth + l.AWidth + 4, l.THeight
+	}
+}
+
+// ticketDetailSize returns the viewport size for the modal while it is open
+// and for the side panel otherwise.
+func (m UIModel) ticketDetailSize() (width, height int) {
+	if m.showModal {
+		return modalWidth(m.layout.Width) - 4, m.layout.Height - 8
+	}
+	w, h := ticketDetailPaneSize(m.layout)
+	return w - 4, h - 3 // border, padding and title line
+}
+
+func (m *UIModel) resizeTicketDetail() {
+	m.ticketDetail.SetSize(m.ticketDetailSize())
+}
+
+// openTicketDetailModal shows the highlighted ticket in the modal.
+func (m UIModel) openTicketDetailModal() (tea.Model, tea.Cmd, bool) {
+	if _, ok := m.selectedTicket(); !ok {
+		return m, nil, false
+	}
+	m.showModal = true
+	m.resizeTicketDetail()
+	return m, m.loadSelectedTicketDetail(), true
+}
+
+func (m UIModel) toggleTicketDetailPane() (tea.Model, tea.Cmd, bool) {
+	m.showTicketDetail = !m.showTicketDetail
+	if !m.showTicketDetail {
+		return m, nil, true
+	}
+	m.resizeTicketDetail()
+	return m, m.loadSelectedTicketDetail(), true
+}
+
+// handleTicketDetailModalKeyMsg scrolls the modal; any other key closes it.
+func (m UIModel) handleTicketDetailModalKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if !m.showModal {
+		return m, nil, false
+	}
+	km := m.ticketDetail.viewport.KeyMap
+	if key.Matches(msg, km.PageDown, km.PageUp, km.HalfPageDown, km.HalfPageUp, km.Down, km.Up) {
+		var cmd tea.Cmd
+		m.ticketDetail.viewport, cmd = m.ticketDetail.viewport.Update(msg)
+		return m, cmd, true
+	}
+	m.showModal = false
+	m.resizeTicketDetail()
+	return m, nil, true
+}
+
+// handleTicketDetailPaneKeyMsg scrolls the side panel.
+func (m UIModel) handleTicketDetailPaneKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if !m.ticketDetailPaneVisible() {
+		return m, nil, false
+	}
+	switch {
+	case key.Matches(msg, m.keys.DetailDown):
+		m.ticketDetail.Scroll(ticketDetailScrollLines)
+	case key.Matches(msg, m.keys.DetailUp):
+		m.ticketDetail.Scroll(-ticketDetailScrollLines)
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+// HandleTicketDetailLoaded shows a loaded issue if it is still the one the
+// view is waiting for.
+func (m UIModel) HandleTicketDetailLoaded(msg ticketDetailLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.ticketID != m.ticketDetail.ticketID {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.logger().Warn("failed to load ticket detail", "ticket", msg.ticketID, "err", msg.err)
+	}
+	m.ticketDetail.SetDetail(msg.detail, msg.err)
+	return m, nil
+}
+
+// HandleTicketRunsLoaded shows the past runs of the ticket in the view.
+// Failures only leave the section out.
+func (m UIModel) HandleTicketRunsLoaded(msg ticketRunsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.ticketID != m.ticketDetail.ticketID {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.logger().Warn("failed to load agent runs", "ticket", msg.ticketID, "err", msg.err)
+		return m, nil
+	}
+	m.ticketDetail.SetRuns(msg.runs)
+	return m, nil
+}
+
+// RenderTicketDetailPane renders the side panel around the detail view.
+func RenderTicketDetailPane(view string, width, height int, theme ThemePalette) string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.TitleColor).Render("Details")
+	return createInactiveBorder(height, theme)(width).Render(
+		lipgloss.NewStyle().MaxHeight(height - 2).MaxWidth(width - 4).Render(title + "\n" + view))
+}
@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// ticketDetailModel returns a sized model with the ticket column focused on
+// two tickets from a fake store.
+func ticketDetailModel(t *testing.T) UIModel {
+	t.Helper()
+	store := &fake.TicketStore{
+		Tickets: []domain.Ticket{
+			{ID: "bb-1", Title: "Fix login", Status: "open", Priority: 1, IssueType: "bug"},
+			{ID: "bb-2", Title: "Add search", Status: "open", Priority: 2, IssueType: "feature"},
+		},
+		Details: map[string]domain.TicketDetail{
+			"bb-1": {
+				Ticket: domain.Ticket{ID: "bb-1", Title: "Fix login", Status: "open", Priority: 1, IssueType: "bug",
+					Description: "Login fails with **SSO**."},
+				Design: "Retry the token refresh.",
+				Labels: []string{"auth"},
+			},
+		},
+	}
+	project, err := data.NewProjectContext(store, "/src/app/.beads", "/src/app")
+	require.NoError(t, err)
+
+	m := NewUIModel(newTestApp(), nil)
+	items := make([]list.Item, len(store.Tickets))
+	for i, ticket := range store.Tickets {
+		items[i] = ticketItem{ticket: ticket, project: project}
+	}
+	m.ticketList = list.New(items, list.NewDefaultDelegate(), 40, 10)
+	m.state = ViewStateMatrix
+	m.focus = FocusTickets
+	sized, _ := m.handleWindowSizeMsg(tea.WindowSizeMsg{Width: 160, Height: 40})
+	return sized
+}
+
+// loadDetail runs the detail and run history load commands and delivers
+// their results.
+func loadDetail(t *testing.T, m UIModel, cmd tea.Cmd) UIModel {
+	t.Helper()
+	require.NotNil(t, cmd)
+	batch, ok := cmd().(tea.BatchMsg)
+	require.True(t, ok, "expected the detail and the runs to load together")
+	for _, c := range batch {
+		var newModel tea.Model
+		switch msg := c().(type) {
+		case ticketDetailLoadedMsg:
+			newModel, _ = m.HandleTicketDetailLoaded(msg)
+		case ticketRunsLoadedMsg:
+			newModel, _ = m.HandleTicketRunsLoaded(msg)
+		default:
+			t.Fatalf("unexpected message %T", msg)
+		}
+		m = newModel.(UIModel)
+	}
+	return m
+}
+
+func TestTicketDetail_ModalLoadsFromStore(t *testing.T) {
+	m := ticketDetailModel(t)
+
+	newModel, cmd, handled := m.handleInfoKeyMsg()
+	require.True(t, handled)
+	m = newModel.(UIModel)
+	assert.True(t, m.showModal)
+	assert.Contains(t, m.ticketDetail.View(), "Loading bb-1")
+
+	m = loadDetail(t, m, cmd)
+	view := ansi.Strip(m.ticketDetail.View())
+	assert.Contains(t, view, "Fix login")
+	assert.Contains(t, view, "Retry the token refresh.")
+
+	// Scroll keys keep the modal open, anything else closes it.
+	newModel, _, handled = m.handleTicketDetailModalKeyMsg(tea.KeyMsg{Type: tea.KeyDown})
+	require.True(t, handled)
+	assert.True(t, newModel.(UIModel).showModal)
+	newModel, _, handled = m.handleTicketDetailModalKeyMsg(runeKey("x"))
+	require.True(t, handled)
+	assert.False(t, newModel.(UIModel).showModal)
+}
+
+func TestTicketDetail_PaneFollowsSelection(t *testing.T) {
+	m := ticketDetailModel(t)
+	assert.Empty(t, m.buildMatrixConfig().DetailView, "pane starts hidden")
+
+	newModel, cmd, handled := m.handleGlobalKeyMsg(runeKey("v"))
+	require.True(t, handled)
+	m = loadDetail(t, newModel.(UIModel), cmd)
+	require.True(t, m.ticketDetailPaneVisible())
+
+	s := ansi.Strip(RenderMatrix(m.buildMatrixConfig()))
+	assert.Contains(t, s, "Details")
+	assert.Contains(t, s, "Login fails with SSO.")
+
+	// Opening the modal for the same ticket reuses the loaded detail.
+	_, cmd, _ = m.handleInfoKeyMsg()
+	assert.Nil(t, cmd)
+
+	m.focus = FocusHarness
+	assert.Empty(t, m.buildMatrixConfig().DetailView, "pane only shows next to the ticket column")
+}
+
+func TestTicketDetail_IgnoresStaleLoads(t *testing.T) {
+	m := ticketDetailModel(t)
+	m.ticketDetail.Load("bb-2")
+
+	newModel, _ := m.HandleTicketDetailLoaded(ticketDetailLoadedMsg{
+		ticketID: "bb-1",
+		detail:   &domain.TicketDetail{Ticket: domain.Ticket{ID: "bb-1", Title: "Fix login"}},
+	})
+	m = newModel.(UIModel)
+	assert.Nil(t, m.ticketDetail.detail)
+	assert.Contains(t, m.ticketDetail.View(), "Loading bb-2")
+}
+
+func TestTicketDetailMarkdown(t *testing.T) {
+	md := ticketDetailMarkdown(&domain.TicketDetail{
+		Ticket:             domain.Ticket{ID: "bb-1", Title: "Fix login", Status: "open", Priority: 1, IssueType: "bug"},
+		AcceptanceCriteria: "Users can log in.",
+		Labels:             []string{"auth", "ui"},
+		Dependencies:       []domain.TicketDependency{{ID: "bb-0", Title: "Schema", Status: "closed", Type: "blocks"}},
+		Comments:           []domain.TicketComment{{Author: "bob", Text: "On it", CreatedAt: time.Date(2026, 1, 2, 15, 4, 0, 0, time.UTC)}},
+	}, nil)
+
+	for _, want := range []string{
+		"# bb-1 Fix login", "**Priority:** P1", "**Labels:** auth, ui",
+		"## Acceptance Criteria", "- **bb-0** Schema (blocks, closed)",
+		"**bob** · 2026-01-02 15:04",
+	} {
+		assert.Contains(t, md, want)
+	}
+	assert.NotContains(t, md, "## Description", "empty sections are left out")
+}
+
+func TestTicketDetailMarkdown_PastRuns(t *testing.T) {
+	started := time.Date(2026, 1, 2, 15, 4, 0, 0, time.Local)
+	exitCode := 2
+	md := ticketDetailMarkdown(&domain.TicketDetail{Ticket: domain.Ticket{ID: "bb-1", Title: "Fix login"}}, []domain.AgentRun{
+		{HarnessName: "codex", Model: "gpt-5", WorktreePath: "/src/app-bb-1", StartedAt: started,
+			FinishedAt: started.Add(90 * time.Second), Status: domain.AgentFailed, ExitCode: &exitCode,
+			LinesAdded: 12, LinesRemoved: 3},
+	})
+
+	assert.Contains(t, md, "## Past Runs")
+	assert.Contains(t, md, "- **2026-01-02 15:04** codex · gpt-5: failed with exit code 2 after 1m30s, +12/-3 lines in `/src/app-bb-1`")
+}
+
+func TestTicketDetail_LoadsPastRuns(t *testing.T) {
+	m := ticketDetailModel(t)
+	store, err := m.app.AgentStoreForProject(context.Background(), "/src/app")
+	require.NoError(t, err)
+	started := time.Now().Add(-time.Hour)
+	for _, run := range []domain.AgentRun{
+		{ProjectDir: "/src/app", Ticket: "bb-1", HarnessName: "codex", StartedAt: started, FinishedAt: started.Add(time.Minute), Status: domain.AgentCompleted},
+		{ProjectDir: "/src/app", Ticket: "bb-2", HarnessName: "claude", StartedAt: started, FinishedAt: started.Add(time.Minute), Status: domain.AgentCompleted},
+		{ProjectDir: "/src/other", Ticket: "bb-1", HarnessName: "opencode", StartedAt: started, FinishedAt: started.Add(time.Minute), Status: domain.AgentCompleted},
+	} {
+		require.NoError(t, store.FinishRunningAgent(context.Background(), run))
+	}
+
+	newModel, cmd, handled := m.handleInfoKeyMsg()
+	require.True(t, handled)
+	m = loadDetail(t, newModel.(UIModel), cmd)
+
+	view := ansi.Strip(m.ticketDetail.View())
+	assert.Contains(t, view, "Past Runs")
+	assert.Contains(t, view, "codex")
+	assert.NotContains(t, view, "claude", "runs of other tickets are left out")
+	assert.NotContains(t, view, "opencode", "runs of other projects are left out")
+}
+
+func TestTicketDetail_ShowsRunsOfTheShownTicket(t *testing.T) {
+	m := ticketDetailModel(t)
+	m.ticketDetail.Load("bb-1")
+	m.ticketDetail.SetDetail(&domain.TicketDetail{Ticket: domain.Ticket{ID: "bb-1", Title: "Fix login"}}, nil)
+	runs := []domain.AgentRun{{HarnessName: "codex", Status: domain.AgentCompleted}}
+
+	newModel, _ := m.HandleTicketRunsLoaded(ticketRunsLoadedMsg{ticketID: "bb-2", runs: runs})
+	m = newModel.(UIModel)
+	assert.Empty(t, m.ticketDetail.runs, "runs of another ticket are ignored")
+
+	newModel, _ = m.HandleTicketRunsLoaded(ticketRunsLoadedMsg{ticketID: "bb-1", runs: runs})
+	m = newModel.(UIModel)
+	assert.Contains(t, ansi.Strip(m.ticketDetail.View()), "Past Runs")
+
+	m.ticketDetail.Load("bb-2")
+	assert.Empty(t, m.ticketDetail.runs, "loading another ticket clears the runs")
+}
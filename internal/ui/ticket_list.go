@@ -19,7 +19,11 @@ type ticketItem struct {
 
 func (i ticketItem) Title() string { return fmt.Sprintf("[%s] %s", i.ticket.ID, i.ticket.Title) }
 func (i ticketItem) Description() string {
-	return fmt.Sprintf("Status: %s | Priority: %d", i.ticket.Status, i.ticket.Priority)
+	desc := fmt.Sprintf("Status: %s | Priority: %d", i.ticket.Status, i.ticket.Priority)
+	if len(i.ticket.Blockers) > 0 {
+		desc += " | Blocked by: " + strings.Join(i.ticket.Blockers, ", ")
+	}
+	return desc
 }
 func (i ticketItem) FilterValue() string { return fmt.Sprintf("%s %s", i.ticket.ID, i.ticket.Title) }
 
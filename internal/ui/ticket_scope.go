@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// cycleTicketScope switches the ticket column to the next scope. The filter
+// and preset stay applied within the new scope.
+func (m UIModel) cycleTicketScope() (tea.Model, tea.Cmd, bool) {
+	m.ticketFilter.Scope = m.ticketFilter.Scope.Next()
+	return m, m.applyTicketFilter(m.ticketFilter, m.activePreset), true
+}
+
+// filterActive reports whether a filter other than the scope is applied.
+func (m UIModel) filterActive() bool {
+	f := m.ticketFilter
+	f.Scope = data.ScopeReady
+	return !f.IsZero()
+}
+
+// blockedReason describes why t is blocked.
+func blockedReason(t domain.Ticket) string {
+	if len(t.Blockers) == 0 {
+		return "status blocked"
+	}
+	return "blocked by " + strings.Join(t.Blockers, ", ")
+}
+
+// handleBlockedLaunchKeyMsg answers the override prompt shown when a blocked
+// ticket is launched from the confirm screen: the confirm key launches,
+// anything else dismisses the prompt.
+func (m UIModel) handleBlockedLaunchKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if !m.confirmBlocked {
+		return m, nil, false
+	}
+	m.confirmBlocked = false
+	if m.state != ViewStateConfirm {
+		return m, nil, false
+	}
+	if key.Matches(msg, m.keys.Confirm) {
+		m.state = ViewStateMatrix
+		return m, m.launchCmd(), true
+	}
+	return m, nil, true
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func TestTicketScope_CyclesAndKeepsFilter(t *testing.T) {
+	m := filterModel(t)
+	store := m.app.Stores["/src/app"].(*fake.TicketStore)
+	store.Tickets = append(store.Tickets,
+		domain.Ticket{ID: "bb-4", Title: "Refactor", Status: "in_progress", IssueType: "task"},
+		domain.Ticket{ID: "bb-5", Title: "Deploy", Status: "open", IssueType: "bug", Blockers: []string{"bb-4"}},
+	)
+
+	m, cmd := filterBarKey(t, m, runeKey("s"))
+	m = loadFiltered(t, m, cmd)
+	assert.Equal(t, data.ScopeInProgress, m.ticketFilter.Scope)
+	assert.Equal(t, []string{"bb-4"}, visibleTicketIDs(m))
+	assert.Equal(t, "Select a Ticket (in progress)", m.ticketList.Title)
+
+	m, cmd = filterBarKey(t, m, runeKey("s"))
+	m = loadFiltered(t, m, cmd)
+	assert.Equal(t, []string{"bb-5"}, visibleTicketIDs(m))
+	assert.Contains(t, m.ticketList.Items()[0].(ticketItem).Description(), "Blocked by: bb-4")
+
+	// Filters apply within the scope, and clearing them keeps it.
+	m, _ = filterBarKey(t, m, runeKey("s"))
+	m, _ = filterBarKey(t, m, runeKey("F"))
+	m.filterBar.inputs[filterFieldType].SetValue("bug")
+	m, cmd = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = loadFiltered(t, m, cmd)
+	assert.Equal(t, []string{"bb-1", "bb-3", "bb-5"}, visibleTicketIDs(m))
+	assert.Equal(t, "Select a Ticket (all open) [type:bug]", m.ticketList.Title)
+
+	m, cmd = filterBarKey(t, m, runeKey("0"))
+	m = loadFiltered(t, m, cmd)
+	assert.Equal(t, data.ScopeAll, m.ticketFilter.Scope)
+	assert.Len(t, visibleTicketIDs(m), 5)
+
+	m, cmd = filterBarKey(t, m, runeKey("s"))
+	m = loadFiltered(t, m, cmd)
+	assert.Equal(t, "Select a Ticket", m.ticketList.Title)
+	assert.Len(t, visibleTicketIDs(m), 3)
+}
+
+func TestConfirm_BlockedTicketNeedsOverride(t *testing.T) {
+	m := filterModel(t)
+	m.state = ViewStateConfirm
+	m.selection = domain.Selection{
+		Ticket:  domain.Ticket{ID: "bb-5", Title: "Deploy", Status: "open", Blockers: []string{"bb-4"}},
+		Harness: domain.Harness{Name: "claude"},
+	}
+
+	m, cmd := filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.True(t, m.confirmBlocked)
+	assert.Equal(t, ViewStateConfirm, m.state)
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "bb-5 is blocked by bb-4.")
+	assert.Contains(t, view, "Launch it anyway? [y/N]")
+
+	// Anything but y dismisses the prompt without launching.
+	m, cmd = filterBarKey(t, m, runeKey("n"))
+	assert.Nil(t, cmd)
+	assert.False(t, m.confirmBlocked)
+	assert.Equal(t, ViewStateConfirm, m.state)
+
+	m, _ = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd = filterBarKey(t, m, runeKey("y"))
+	assert.NotNil(t, cmd)
+	assert.False(t, m.confirmBlocked)
+	assert.Equal(t, ViewStateMatrix, m.state)
+}
+
+func TestConfirm_BlockedOverrideUsesConfirmKey(t *testing.T) {
+	m := filterModel(t)
+	m.keys.Confirm = key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "yes"))
+	m.state = ViewStateConfirm
+	m.selection = domain.Selection{
+		Ticket:  domain.Ticket{ID: "bb-5", Title: "Deploy", Status: "open", Blockers: []string{"bb-4"}},
+		Harness: domain.Harness{Name: "claude"},
+	}
+
+	m, _ = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Contains(t, ansi.Strip(m.View()), "Launch it anyway? [o/N]")
+	m, cmd := filterBarKey(t, m, runeKey("y"))
+	assert.Nil(t, cmd, "y is no longer the confirm key")
+
+	m, _ = filterBarKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd = filterBarKey(t, m, runeKey("o"))
+	assert.NotNil(t, cmd)
+	assert.Equal(t, ViewStateMatrix, m.state)
+}
@@ -11,6 +11,8 @@ import (
 // AgentConfig holds configuration for rendering the agent output view
 type AgentConfig struct {
 	Agent  *RunningAgent
+	Viewer agentOutputViewer
+	Keys   KeyMap
 	Width  int
 	Height int
 	Theme  ThemePalette
@@ -32,23 +34,37 @@ func RenderAgentOutput(cfg AgentConfig) string {
 	launcherLine := fmt.Sprintf("Launcher: %s", cfg.Agent.Info.LauncherID)
 
 	outputContent := getAgentOutputContent(cfg.Agent)
+	if cfg.Viewer.HasContent() {
+		outputContent = cfg.Viewer.viewport.View()
+	}
+
+	// The message box grows the footer, so the output box gives up the lines.
+	outputHeight := cfg.Height - 10
+	if cfg.Viewer.inputMode == outputInputSend {
+		outputHeight -= sendInputLines - 1
+	}
 
 	outputStyle := lipgloss.NewStyle().
 		Border(lipgloss.ThickBorder()).
 		BorderForeground(ThemeInactive).
 		Width(cfg.Width-4).
-		Height(cfg.Height-10).
+		Height(outputHeight).
 		Padding(0, 1)
 
+	quickReplies := ""
+	if cfg.Agent.Info.Status == domain.AgentRunning && cfg.Agent.Capture != nil {
+		quickReplies = renderQuickReplies(cfg.Keys)
+	}
+
 	content := lipgloss.JoinVertical(lipgloss.Top,
 		header,
 		statusLine,
 		launcherLine,
-		"",
-		"Output:",
+		renderInputHistory(cfg.Agent.History),
+		cfg.Viewer.Header(),
 		outputStyle.Render(outputContent),
-		"",
-		"[Press Enter to return to matrix]",
+		quickReplies,
+		cfg.Viewer.Footer(cfg.Keys),
 	)
 
 	return content
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// dashboardMinFlexWidth keeps flexible columns readable on narrow terminals.
+const dashboardMinFlexWidth = 12
+
+// DashboardConfig holds configuration for rendering the agent dashboard
+type DashboardConfig struct {
+	Rows       []dashboardRow
+	Cursor     int
+	SortColumn int
+	SortDesc   bool
+	Summary    string
+	Status     string
+	Keys       KeyMap
+	Width      int
+	Height     int
+	Theme      ThemePalette
+}
+
+// RenderDashboard renders the table of agents across all projects
+func RenderDashboard(cfg DashboardConfig) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Underline(true)
+	columnStyle := lipgloss.NewStyle().Foreground(cfg.Theme.TitleColor).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(ThemeInactive)
+	selectedStyle := lipgloss.NewStyle().Foreground(cfg.Theme.FocusIndicator).Bold(true)
+
+	widths := dashboardColumnWidths(cfg.Width - 2)
+
+	titles := make([]string, len(dashboardColumns))
+	for i, col := range dashboardColumns {
+		title := col.title
+		if i == cfg.SortColumn {
+			if cfg.SortDesc {
+				title += " ▼"
+			} else {
+				title += " ▲"
+			}
+		}
+		titles[i] = title
+	}
+
+	lines := []string{
+		headerStyle.Render("Agent Dashboard"),
+		mutedStyle.Render(cfg.Summary),
+		"",
+		"  " + columnStyle.Render(dashboardLine(titles, widths)),
+	}
+
+	if len(cfg.Rows) == 0 {
+		lines = append(lines, mutedStyle.Render("  No agents match the filters"))
+	}
+
+	visible := max(cfg.Height-8, 1)
+	cursor := min(cfg.Cursor, len(cfg.Rows)-1)
+	start := max(cursor-visible+1, 0)
+	end := min(start+visible, len(cfg.Rows))
+	for i := start; i < end; i++ {
+		row := cfg.Rows[i]
+		cells := make([]string, len(dashboardColumns))
+		for c, col := range dashboardColumns {
+			cells[c] = padCell(col.value(row), widths[c])
+		}
+		if i == cursor {
+			lines = append(lines, selectedStyle.Render("▶ "+strings.Join(cells, " ")))
+			continue
+		}
+		_, color := getAgentStatus(row.agent.Info.Status)
+		cells[dashboardStatusColumn] = lipgloss.NewStyle().Foreground(color).Render(cells[dashboardStatusColumn])
+		lines = append(lines, "  "+strings.Join(cells, " "))
+	}
+
+	footer := cfg.Status
+	if footer == "" {
+		footer = dashboardHelp(cfg.Keys)
+	}
+	lines = append(lines, "", mutedStyle.Render(footer))
+
+	return strings.Join(lines, "\n")
+}
+
+// dashboardColumnWidths gives the fixed columns their width and splits the
+// rest of the line between the flexible ones.
+func dashboardColumnWidths(total int) []int {
+	widths := make([]int, len(dashboardColumns))
+	fixed, flex := len(dashboardColumns)-1, 0 // one space between columns
+	for i, col := range dashboardColumns {
+		widths[i] = col.width
+		fixed += col.width
+		if col.width == 0 {
+			flex++
+		}
+	}
+	flexWidth := dashboardMinFlexWidth
+	if flex > 0 {
+		flexWidth = max((total-fixed)/flex, dashboardMinFlexWidth)
+	}
+	for i := range widths {
+		if widths[i] == 0 {
+			widths[i] = flexWidth
+		}
+	}
+	return widths
+}
+
+func dashboardLine(cells []string, widths []int) string {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		padded[i] = padCell(cell, widths[i])
+	}
+	return strings.Join(padded, " ")
+}
+
+func padCell(s string, width int) string {
+	s = ansi.Truncate(s, width, "…")
+	return s + strings.Repeat(" ", max(width-lipgloss.Width(s), 0))
+}
+
+func dashboardHelp(k KeyMap) string {
+	hints := []struct {
+		binding key.Binding
+		desc    string
+	}{
+		{k.Enter, "view output"},
+		{k.JumpToWindow, "jump to window"},
+		{k.SortColumn, "sort"},
+		{k.SortReverse, "reverse"},
+		{k.FilterStatus, "status"},
+		{k.FilterProject, "project"},
+		{k.Back, "back"},
+	}
+	parts := make([]string, 0, len(hints))
+	for _, h := range hints {
+		if keyHelp := h.binding.Help().Key; keyHelp != "" {
+			parts = append(parts, fmt.Sprintf("%s %s", keyHelp, h.desc))
+		}
+	}
+	return strings.Join(parts, " • ")
+}
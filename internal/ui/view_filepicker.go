@@ -14,6 +14,7 @@ type FilePickerConfig struct {
 	Filepicker filepicker.Model
 	Theme      ThemePalette
 	Purpose    filePickerPurpose
+	Keys       KeyMap
 }
 
 // RenderFilePicker renders the file picker for adding projects or picking templates
@@ -31,11 +32,15 @@ func RenderFilePicker(cfg FilePickerConfig) string {
 		Faint(true).
 		MarginTop(1)
 
+	fpKeys := cfg.Filepicker.KeyMap
 	title := "Add Project - Select Directory"
-	help := "Press 'a' to select highlighted directory, 'tab' to swap views, 'esc' to cancel"
+	help := fmt.Sprintf("Press '%s' to select highlighted directory, '%s' to swap views, '%s' to cancel",
+		cfg.Keys.AddDirectory.Help().Key, fpKeys.SwapView.Help().Key, cfg.Keys.ClosePicker.Help().Key)
 	if cfg.Purpose == fpPurposeTemplate {
 		title = "Pick Template File"
-		help = "Press Enter to select file, 'ctrl+a' for all extensions, 'ctrl+h' for hidden files, 'esc' to cancel"
+		help = fmt.Sprintf("Press '%s' to select file, '%s' for all extensions, '%s' for hidden files, '%s' to cancel",
+			fpKeys.Select.Help().Key, fpKeys.ToggleAllExts.Help().Key, fpKeys.ToggleHidden.Help().Key,
+			cfg.Keys.ClosePicker.Help().Key)
 	}
 
 	s.WriteString(titleStyle.Render(title))
@@ -60,6 +65,7 @@ func RenderFilePicker(cfg FilePickerConfig) string {
 type AddProjectConfig struct {
 	PendingProjectPath string
 	Theme              ThemePalette
+	Keys               KeyMap
 }
 
 // RenderAddProjectModal renders the confirmation modal for adding a project
@@ -85,7 +91,8 @@ func RenderAddProjectModal(cfg AddProjectConfig) string {
 	s.WriteString("\n\n")
 	fmt.Fprintf(&s, "Add project at:\n%s", pathStyle.Render(cfg.PendingProjectPath))
 	s.WriteString("\n\n")
-	s.WriteString(helpStyle.Render("Press 'y' or Enter to confirm, 'n' or Esc to cancel"))
+	s.WriteString(helpStyle.Render(fmt.Sprintf("Press '%s' or '%s' to confirm, '%s' to cancel",
+		cfg.Keys.Confirm.Help().Key, cfg.Keys.Enter.Help().Key, cfg.Keys.Cancel.Help().Key)))
 
 	return s.String()
 }
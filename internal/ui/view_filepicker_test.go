@@ -12,6 +12,7 @@ func TestRenderFilePicker_ContainsTitle(t *testing.T) {
 	cfg := FilePickerConfig{
 		Filepicker: fp,
 		Theme:      MatrixTheme,
+		Keys:       DefaultKeyMap(),
 	}
 
 	s := RenderFilePicker(cfg)
@@ -23,6 +24,7 @@ func TestRenderFilePicker_ContainsHelpText(t *testing.T) {
 	cfg := FilePickerConfig{
 		Filepicker: fp,
 		Theme:      MatrixTheme,
+		Keys:       DefaultKeyMap(),
 	}
 
 	s := RenderFilePicker(cfg)
@@ -35,6 +37,7 @@ func TestRenderFilePicker_RendersFilepicker(t *testing.T) {
 	cfg := FilePickerConfig{
 		Filepicker: fp,
 		Theme:      MatrixTheme,
+		Keys:       DefaultKeyMap(),
 	}
 
 	// Just verify it renders without error
@@ -46,6 +49,7 @@ func TestRenderAddProjectModal_ContainsTitle(t *testing.T) {
 	cfg := AddProjectConfig{
 		PendingProjectPath: "/path/to/project",
 		Theme:              MatrixTheme,
+		Keys:               DefaultKeyMap(),
 	}
 
 	s := RenderAddProjectModal(cfg)
@@ -56,6 +60,7 @@ func TestRenderAddProjectModal_ContainsProjectPath(t *testing.T) {
 	cfg := AddProjectConfig{
 		PendingProjectPath: "/path/to/project",
 		Theme:              MatrixTheme,
+		Keys:               DefaultKeyMap(),
 	}
 
 	s := RenderAddProjectModal(cfg)
@@ -66,11 +71,12 @@ func TestRenderAddProjectModal_ContainsConfirmationOptions(t *testing.T) {
 	cfg := AddProjectConfig{
 		PendingProjectPath: "/path/to/project",
 		Theme:              MatrixTheme,
+		Keys:               DefaultKeyMap(),
 	}
 
 	s := RenderAddProjectModal(cfg)
-	assert.Contains(t, s, "Press 'y' or Enter to confirm")
-	assert.Contains(t, s, "'n' or Esc to cancel")
+	assert.Contains(t, s, "Press 'y' or 'enter' to confirm")
+	assert.Contains(t, s, "'n/esc' to cancel")
 }
 
 func TestRenderAddProjectModal_UsesThemeColors(t *testing.T) {
@@ -52,6 +52,12 @@ type MatrixConfig struct {
 	HarnessTitle string
 	ModelTitle   string
 	AgentTitle   string
+
+	// DetailView is the ticket detail side panel; empty when hidden. It
+	// takes the place of the harness, model and agent columns.
+	DetailView   string
+	DetailWidth  int
+	DetailHeight int
 }
 
 // RenderMatrix renders the main matrix view with 4 columns
@@ -124,7 +130,34 @@ func RenderMatrix(cfg MatrixConfig) string {
 	spacingY := lipgloss.NewStyle().Height(1).Render("")
 
 	var matrixBox string
-	switch cfg.GridMode {
+	if cfg.DetailView != "" {
+		matrixBox = renderMatrixWithDetail(cfg, tView, spacingX, spacingY)
+	} else {
+		matrixBox = renderMatrixGrid(cfg.GridMode, tView, hView, mView, aView, spacingX, spacingY)
+	}
+
+	rightPanelBox := lipgloss.JoinVertical(lipgloss.Top, filterBox, matrixBox)
+
+	if cfg.ShowSidebar {
+		return applySidebarBorder(cfg, rightPanelBox, activeColor)
+	}
+
+	return rightPanelBox
+}
+
+// renderMatrixWithDetail places the ticket detail panel beside the ticket
+// column, or below it when the grid is too narrow.
+func renderMatrixWithDetail(cfg MatrixConfig, tView, spacingX, spacingY string) string {
+	detail := RenderTicketDetailPane(cfg.DetailView, cfg.DetailWidth, cfg.DetailHeight, cfg.Theme)
+	if cfg.GridMode == Grid4x1 {
+		return lipgloss.JoinHorizontal(lipgloss.Top, tView, spacingX, detail)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, tView, spacingY, detail)
+}
+
+func renderMatrixGrid(mode GridMode, tView, hView, mView, aView, spacingX, spacingY string) string {
+	var matrixBox string
+	switch mode {
 	case Grid4x1:
 		matrixBox = lipgloss.JoinHorizontal(lipgloss.Top,
 			tView, spacingX, hView, spacingX, mView, spacingX, aView,
@@ -140,14 +173,7 @@ func RenderMatrix(cfg MatrixConfig) string {
 			tView, spacingX, hView, spacingX, mView, spacingX, aView,
 		)
 	}
-
-	rightPanelBox := lipgloss.JoinVertical(lipgloss.Top, filterBox, matrixBox)
-
-	if cfg.ShowSidebar {
-		return applySidebarBorder(cfg, rightPanelBox, activeColor)
-	}
-
-	return rightPanelBox
+	return matrixBox
 }
 
 func getActiveColor(animState AnimationState, focus FocusColumn, theme ThemePalette) lipgloss.Color {
@@ -23,6 +23,9 @@ type MainContentConfig struct {
 	CurrentTheme       ThemePalette
 	ShowModal          bool
 	ModalContent       string
+	PaletteView        string
+	FilterBarView      string
+	ConfirmBlocked     bool
 	PendingProjectPath string
 	Warnings           []string
 	Width              int
@@ -33,6 +36,9 @@ type MainContentConfig struct {
 	// View dependencies
 	MatrixConfig      MatrixConfig
 	Agent             *RunningAgent
+	OutputViewer      agentOutputViewer
+	Dashboard         DashboardConfig
+	Keys              KeyMap
 	Filepicker        filepicker.Model
 	FilePickerPurpose filePickerPurpose
 	AnimState         AnimationState
@@ -58,23 +64,29 @@ func RenderMainContent(cfg MainContentConfig) string {
 			Filepicker: cfg.Filepicker,
 			Theme:      cfg.CurrentTheme,
 			Purpose:    cfg.FilePickerPurpose,
+			Keys:       cfg.Keys,
 		})
 	case ViewStateAddProjectModal:
 		s = RenderAddProjectModal(AddProjectConfig{
 			PendingProjectPath: cfg.PendingProjectPath,
 			Theme:              cfg.CurrentTheme,
+			Keys:               cfg.Keys,
 		})
 	case ViewStateAgentOutput:
 		s = RenderAgentOutput(AgentConfig{
 			Agent:  cfg.Agent,
+			Viewer: cfg.OutputViewer,
+			Keys:   cfg.Keys,
 			Width:  cfg.Width,
 			Height: cfg.Height,
 			Theme:  cfg.CurrentTheme,
 		})
+	case ViewStateDashboard:
+		s = RenderDashboard(cfg.Dashboard)
 	case ViewStateMatrix:
 		s = RenderMatrix(cfg.MatrixConfig)
 	case ViewStateConfirm:
-		s = confirmView(cfg.Selection, cfg.Renderer, cfg.DryRun, cfg.SelectedWorktree, cfg.CurrentTheme)
+		s = confirmView(cfg.Selection, cfg.Renderer, cfg.DryRun, cfg.SelectedWorktree, cfg.CurrentTheme, cfg.ConfirmBlocked, cfg.Keys)
 	case ViewStateInlineEdit:
 		s = RenderInlineEdit(InlineEditConfig{
 			Textarea: cfg.InlineEditTextarea,
@@ -91,6 +103,8 @@ func RenderMainContent(cfg MainContentConfig) string {
 	// Overlay modals on top
 	s = renderModalOverlay(s, cfg)
 	s = renderWarnings(s, cfg.Warnings)
+	s = renderFilterBarOverlay(s, cfg)
+	s = renderPaletteOverlay(s, cfg)
 
 	return s
 }
@@ -112,21 +126,35 @@ func renderModalOverlay(content string, cfg MainContentConfig) string {
 		return content
 	}
 
-	modalWidth := cfg.Width - 10
-	if modalWidth < 40 {
-		modalWidth = 40
-	}
-
 	modalBox := lipgloss.NewStyle().
 		Border(lipgloss.ThickBorder()).
 		BorderForeground(ThemeActive).
 		Padding(1, 2).
-		Width(modalWidth).
+		Width(modalWidth(cfg.Width)).
 		Render(cfg.ModalContent)
 
 	return lipgloss.Place(cfg.Width, cfg.Height, lipgloss.Center, lipgloss.Center, modalBox)
 }
 
+// modalWidth is the width of the modal box, padding included.
+func modalWidth(width int) int {
+	return max(width-10, 40)
+}
+
+func renderPaletteOverlay(content string, cfg MainContentConfig) string {
+	if cfg.PaletteView == "" {
+		return content
+	}
+	return lipgloss.Place(cfg.Width, cfg.Height, lipgloss.Center, lipgloss.Center, cfg.PaletteView)
+}
+
+func renderFilterBarOverlay(content string, cfg MainContentConfig) string {
+	if cfg.FilterBarView == "" {
+		return content
+	}
+	return lipgloss.Place(cfg.Width, cfg.Height, lipgloss.Center, lipgloss.Center, cfg.FilterBarView)
+}
+
 func renderWarnings(content string, warnings []string) string {
 	if len(warnings) == 0 {
 		return content
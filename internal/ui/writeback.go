@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// writebackTimeout bounds the writes for a single launch or outcome.
+const writebackTimeout = 10 * time.Second
+
+// WithWriteback returns a copy of the model that records launches and their
+// outcomes in beads as configured.
+func (m UIModel) WithWriteback(cfg *domain.WritebackConfig) UIModel {
+	m.writeback = cfg
+	return m
+}
+
+// writebackLaunchCmd claims the agent's ticket and comments the launch, as
+// far as configured. Failures are surfaced as warnings.
+func (m UIModel) writebackLaunchCmd(projectDir string, info domain.AgentInfo) tea.Cmd {
+	cfg := m.writeback
+	if !cfg.OnLaunch() || m.app == nil {
+		return nil
+	}
+	myApp := m.app
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), writebackTimeout)
+		defer cancel()
+
+		store, err := writableStore(ctx, myApp, projectDir)
+		if err != nil {
+			return warningMsg{err: fmt.Errorf("claim %s: %w", info.TicketID, err)}
+		}
+		var errs []error
+		if cfg.ClaimOnLaunch {
+			errs = append(errs, store.SetStatus(ctx, info.TicketID, "in_progress"))
+		}
+		if cfg.Assignee != "" {
+			errs = append(errs, store.SetAssignee(ctx, info.TicketID, cfg.Assignee))
+		}
+		if cfg.CommentOnLaunch {
+			errs = append(errs, store.AddComment(ctx, info.TicketID, cfg.Author, launchComment(info)))
+		}
+		if err := errors.Join(errs...); err != nil {
+			return warningMsg{err: fmt.Errorf("claim %s: %w", info.TicketID, err)}
+		}
+		return nil
+	}
+}
+
+// writebackFinishCmd comments the outcome of a finished agent, if configured.
+func (m UIModel) writebackFinishCmd(projectDir string, info domain.AgentInfo) tea.Cmd {
+	cfg := m.writeback
+	if !cfg.OnFinish() || m.app == nil || info.TicketID == "" {
+		return nil
+	}
+	myApp := m.app
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), writebackTimeout)
+		defer cancel()
+
+		store, err := writableStore(ctx, myApp, projectDir)
+		if err == nil {
+			err = store.AddComment(ctx, info.TicketID, cfg.Author, finishComment(info))
+		}
+		if err != nil {
+			return warningMsg{err: fmt.Errorf("comment outcome on %s: %w", info.TicketID, err)}
+		}
+		return nil
+	}
+}
+
+// writableStore returns the ticket store of projectDir if it can be written to.
+func writableStore(ctx context.Context, myApp *app.App, projectDir string) (data.WritableTicketStore, error) {
+	store, err := myApp.StoreForProject(ctx, projectDir)
+	if err != nil {
+		return nil, err
+	}
+	writable, ok := store.(data.WritableTicketStore)
+	if !ok {
+		return nil, fmt.Errorf("the ticket store of %s is read-only", projectDir)
+	}
+	return writable, nil
+}
+
+// launchComment describes the launch of an agent.
+func launchComment(info domain.AgentInfo) string {
+	parts := []string{"harness " + info.HarnessName}
+	if info.ModelName != "" {
+		parts = append(parts, "model "+info.ModelName)
+	}
+	if info.AgentName != "" {
+		parts = append(parts, "agent "+info.AgentName)
+	}
+	if info.WorktreePath != "" {
+		parts = append(parts, "worktree "+info.WorktreePath)
+	}
+	return "Agent launched with " + strings.Join(parts, ", ") + "."
+}
+
+// finishComment describes how an agent finished.
+func finishComment(info domain.AgentInfo) string {
+	outcome := "Agent " + info.Status.String()
+	if info.ExitCode != nil {
+		outcome += fmt.Sprintf(" with exit code %d", *info.ExitCode)
+	}
+	if !info.StartedAt.IsZero() && !info.FinishedAt.IsZero() {
+		outcome += " after " + info.FinishedAt.Sub(info.StartedAt).Round(time.Second).String()
+	}
+	return outcome + " (harness " + info.HarnessName + ")."
+}
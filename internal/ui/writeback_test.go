@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// writebackModel returns a model whose active project is a fake store with
+// one open ticket.
+func writebackModel(cfg *domain.WritebackConfig) (UIModel, *fake.TicketStore) {
+	store := &fake.TicketStore{Tickets: []domain.Ticket{{ID: "bb-1", Title: "Fix login", Status: "open"}}}
+	application := newTestApp()
+	application.ActiveProject = "/src/app"
+	application.Stores = map[string]data.TicketStore{"/src/app": store}
+	return NewUIModel(application, nil).WithWriteback(cfg), store
+}
+
+func ticketComments(t *testing.T, store *fake.TicketStore) []domain.TicketComment {
+	t.Helper()
+	d, err := store.TicketDetail(context.Background(), "bb-1")
+	require.NoError(t, err)
+	return d.Comments
+}
+
+func TestWritebackLaunchCmd_ClaimsAndComments(t *testing.T) {
+	m, store := writebackModel(&domain.WritebackConfig{
+		ClaimOnLaunch:   true,
+		Assignee:        "alice",
+		CommentOnLaunch: true,
+		Author:          "bdb",
+	})
+
+	cmd := m.writebackLaunchCmd("/src/app", domain.AgentInfo{
+		TicketID: "bb-1", HarnessName: "claude", ModelName: "opus", WorktreePath: "/src/app-wt",
+	})
+	require.NotNil(t, cmd)
+	assert.Nil(t, cmd())
+
+	assert.Equal(t, "in_progress", store.Tickets[0].Status)
+	assert.Equal(t, "alice", store.Tickets[0].Assignee)
+	comments := ticketComments(t, store)
+	require.Len(t, comments, 1)
+	assert.Equal(t, "bdb", comments[0].Author)
+	assert.Equal(t, "Agent launched with harness claude, model opus, worktree /src/app-wt.", comments[0].Text)
+}
+
+func TestWritebackLaunchCmd_OnlyConfiguredSteps(t *testing.T) {
+	m, store := writebackModel(&domain.WritebackConfig{CommentOnLaunch: true, Author: "bdb"})
+	assert.Nil(t, m.writebackLaunchCmd("/src/app", domain.AgentInfo{TicketID: "bb-1", HarnessName: "claude"})())
+
+	assert.Equal(t, "open", store.Tickets[0].Status)
+	assert.Empty(t, store.Tickets[0].Assignee)
+	assert.Len(t, ticketComments(t, store), 1)
+
+	m, _ = writebackModel(nil)
+	assert.Nil(t, m.writebackLaunchCmd("/src/app", domain.AgentInfo{TicketID: "bb-1"}), "no config, no command")
+	m, _ = writebackModel(&domain.WritebackConfig{CommentOnLaunch: true})
+	assert.Nil(t, m.writebackFinishCmd("/src/app", domain.AgentInfo{TicketID: "bb-1"}), "finish comments are off")
+}
+
+func TestWritebackLaunchCmd_ReadOnlyStore(t *testing.T) {
+	m, store := writebackModel(&domain.WritebackConfig{ClaimOnLaunch: true})
+	m.app.Stores["/src/app"] = struct{ data.TicketStore }{store}
+
+	msg := m.writebackLaunchCmd("/src/app", domain.AgentInfo{TicketID: "bb-1"})()
+	warning, ok := msg.(warningMsg)
+	require.True(t, ok)
+	assert.Contains(t, warning.err.Error(), "read-only")
+}
+
+func TestHandleAgentStatus_CommentsOutcome(t *testing.T) {
+	m, store := writebackModel(&domain.WritebackConfig{CommentOnFinish: true, Author: "bdb"})
+	exitCode := 1
+	started := time.Now().Add(-90 * time.Second)
+	m.agents = map[string]*RunningAgent{
+		"bb-1": {
+			Info:       &domain.AgentInfo{ID: "bb-1", TicketID: "bb-1", HarnessName: "claude", Status: domain.AgentRunning, StartedAt: started},
+			ProjectDir: "/src/app",
+		},
+	}
+
+	_, cmd := m.HandleAgentStatus(AgentStatusMsg{AgentID: "bb-1", Status: domain.AgentFailed, ExitCode: &exitCode})
+	require.NotNil(t, cmd)
+	batch, ok := cmd().(tea.BatchMsg)
+	require.True(t, ok)
+	for _, c := range batch {
+		assert.Nil(t, c())
+	}
+
+	comments := ticketComments(t, store)
+	require.Len(t, comments, 1)
+	assert.Regexp(t, `^Agent failed with exit code 1 after 1m3\ds \(harness claude\)\.$`, comments[0].Text)
+}
+
+func TestWriteback_TargetsTheAgentsProject(t *testing.T) {
+	m, active := writebackModel(&domain.WritebackConfig{ClaimOnLaunch: true, CommentOnFinish: true, Author: "bdb"})
+	other := &fake.TicketStore{Tickets: []domain.Ticket{
+		{ID: "bb-1", Title: "Fix login", Status: "open"},
+		{ID: "bb-2", Title: "Restored", Status: "in_progress"},
+	}}
+	m.app.AddProject(domain.Project{Dir: "/src/lib"})
+	m.app.Stores["/src/lib"] = other
+
+	// A launch in the other project claims the ticket there.
+	spec := &domain.LaunchSpec{Selection: domain.Selection{Ticket: domain.Ticket{ID: "bb-1"}, Harness: domain.Harness{Name: "claude"}}}
+	newModel, cmd := m.handleLaunchResult(launchResultMsg{
+		res:     &domain.LaunchResult{LauncherID: "bb-1"},
+		spec:    spec,
+		project: "/src/lib",
+	})
+	for _, msg := range runBatch(t, cmd) {
+		_, isWarning := msg.(warningMsg)
+		assert.False(t, isWarning, "%v", msg)
+	}
+	assert.Equal(t, "in_progress", other.Tickets[0].Status)
+	assert.Equal(t, "open", active.Tickets[0].Status, "the active project is not touched")
+
+	// An agent restored from the other project comments its outcome there.
+	m = newModel.(UIModel)
+	newModel, _ = m.handleRunningAgentsLoaded(runningAgentsLoadedMsg{agents: []domain.PersistedRunningAgent{
+		{ProjectDir: "/src/lib", LauncherID: "bb-2", Ticket: "bb-2", HarnessName: "claude"},
+	}})
+	m = newModel.(UIModel)
+	require.Equal(t, "/src/lib", m.agents["bb-2"].ProjectDir)
+
+	_, cmd = m.HandleAgentStatus(AgentStatusMsg{AgentID: "bb-2", Status: domain.AgentCompleted})
+	for _, msg := range runBatch(t, cmd) {
+		assert.Nil(t, msg)
+	}
+	d, err := other.TicketDetail(context.Background(), "bb-2")
+	require.NoError(t, err)
+	require.Len(t, d.Comments, 1)
+	assert.Equal(t, "bdb", d.Comments[0].Author)
+	assert.Empty(t, ticketComments(t, active))
+}
@@ -0,0 +1,352 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package wizard implements the interactive `bdb init` config generator.
+//
+// The wizard scans PATH for known harness binaries, proposes harness
+// entries from config presets, pulls model lists from the discovery
+// registry, collects workspace projects and writes the result through a
+// config.Loader. All prompts can be answered automatically for scripted
+// and test use.
+package wizard
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/discovery"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// ErrAborted is returned when the user declines to continue.
+var ErrAborted = errors.New("init aborted")
+
+// Options controls a wizard run.
+type Options struct {
+	// ConfigPath is where the generated config is written.
+	ConfigPath string
+	// Projects are project directories to add without prompting.
+	Projects []string
+	// AssumeYes accepts every proposal without prompting.
+	AssumeYes bool
+	// Force overwrites an existing config file.
+	Force bool
+}
+
+// Wizard generates a config file from the detected environment.
+type Wizard struct {
+	in              *bufio.Reader
+	out             io.Writer
+	loader          config.Loader
+	registry        *discovery.Registry
+	validateProject func(dir string) error
+	lookPath        func(file string) (string, error)
+	getwd           func() (string, error)
+}
+
+// New creates a Wizard reading answers from in and writing prompts to out.
+// registry may be nil, in which case no model lists are pulled.
+func New(in io.Reader, out io.Writer, loader config.Loader, registry *discovery.Registry, validateProject func(dir string) error) *Wizard {
+	return &Wizard{
+		in:              bufio.NewReader(in),
+		out:             out,
+		loader:          loader,
+		registry:        registry,
+		validateProject: validateProject,
+		lookPath:        osexec.LookPath,
+		getwd:           os.Getwd,
+	}
+}
+
+// Run walks through harness detection, model discovery and project
+// selection, then writes and re-validates the config file.
+func (w *Wizard) Run(ctx context.Context, opts Options) (*domain.Config, error) {
+	if err := w.checkExisting(opts); err != nil {
+		return nil, err
+	}
+
+	w.loadModels(ctx, opts.AssumeYes)
+
+	harnesses, err := w.selectHarnesses(opts.AssumeYes)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := w.selectProjects(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &domain.Config{
+		Harnesses: harnesses,
+		Launcher:  &domain.LauncherConfig{Target: "foreground"},
+		Defaults:  proposeDefaults(harnesses),
+		General:   &domain.GeneralConfig{AutostartDolt: true},
+		Workspace: domain.Workspace{Name: "default", Projects: projects},
+	}
+
+	if err := w.write(opts.ConfigPath, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (w *Wizard) checkExisting(opts Options) error {
+	if _, err := os.Stat(opts.ConfigPath); err != nil || opts.Force {
+		return nil
+	}
+	if opts.AssumeYes {
+		return fmt.Errorf("config already exists at %s (use --force to overwrite)", opts.ConfigPath)
+	}
+	overwrite, err := w.confirm(fmt.Sprintf("Config already exists at %s. Overwrite?", opts.ConfigPath), false)
+	if err != nil {
+		return err
+	}
+	if !overwrite {
+		return ErrAborted
+	}
+	return nil
+}
+
+// loadModels populates the registry from the local cache, offering a
+// download from models.dev when the cache is missing in interactive mode.
+func (w *Wizard) loadModels(ctx context.Context, assumeYes bool) {
+	if w.registry == nil {
+		return
+	}
+	if err := w.registry.LoadCache(); err == nil {
+		return
+	}
+	if assumeYes {
+		fmt.Fprintln(w.out, "No model cache found; run 'bdb update-models' to enable model discovery.")
+		return
+	}
+
+	download, err := w.confirm("No model cache found. Download the model list from models.dev?", true)
+	if err != nil || !download {
+		return
+	}
+	if err := w.registry.Refresh(ctx); err != nil {
+		fmt.Fprintf(w.out, "Warning: failed to download models: %v\n", err)
+	}
+}
+
+// selectHarnesses scans PATH for known harness binaries and proposes an
+// entry for each one found.
+func (w *Wizard) selectHarnesses(assumeYes bool) ([]domain.Harness, error) {
+	var harnesses []domain.Harness
+	for _, name := range config.KnownHarnesses() {
+		binary, path := w.findBinary(name)
+		if binary == "" {
+			continue
+		}
+
+		accept := assumeYes
+		if !accept {
+			var err error
+			accept, err = w.confirm(fmt.Sprintf("Found %s at %s. Add harness?", name, path), true)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !accept {
+			continue
+		}
+
+		harnesses = append(harnesses, config.NewHarnessFromPreset(name, binary, w.modelsFor(name)))
+	}
+
+	if len(harnesses) == 0 {
+		return nil, fmt.Errorf("no harness selected: none of the known tools (%s) were found on PATH or all were declined",
+			strings.Join(config.KnownHarnesses(), ", "))
+	}
+	return harnesses, nil
+}
+
+func (w *Wizard) findBinary(name string) (binary, path string) {
+	for _, candidate := range config.HarnessBinaryCandidates(name) {
+		if p, err := w.lookPath(candidate); err == nil {
+			return candidate, p
+		}
+	}
+	return "", ""
+}
+
+// modelsFor returns the model entries for a provider-bound harness using
+// the registry, or nil to keep the preset's models.
+func (w *Wizard) modelsFor(name string) []string {
+	preset := config.PresetForHarness(name)
+	if preset.Provider == "" {
+		return nil
+	}
+
+	var available []string
+	if w.registry != nil {
+		available = w.registry.GetModelsForProvider(preset.Provider)
+	}
+	if len(available) == 0 {
+		fmt.Fprintf(w.out, "  no %s models known; add models for %s to the config by hand\n", preset.Provider, name)
+		return []string{}
+	}
+
+	fmt.Fprintf(w.out, "  %s: %d %s model(s) via %s%s\n", name, len(available), preset.Provider, discovery.PrefixProvider, preset.Provider)
+	return []string{discovery.PrefixProvider + preset.Provider}
+}
+
+// selectProjects collects workspace projects from flags, the current
+// directory and (interactively) further prompts.
+func (w *Wizard) selectProjects(opts Options) ([]domain.Project, error) {
+	var projects []domain.Project
+	add := func(dir string) error {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("failed to resolve project path %s: %w", dir, err)
+		}
+		if err := w.validateProject(abs); err != nil {
+			return err
+		}
+		for _, p := range projects {
+			if p.Dir == abs {
+				return nil
+			}
+		}
+		projects = append(projects, domain.Project{Dir: abs, Name: uniqueProjectName(projects, filepath.Base(abs))})
+		return nil
+	}
+
+	for _, dir := range opts.Projects {
+		if err := add(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(opts.Projects) == 0 {
+		if err := w.offerWorkingDir(opts.AssumeYes, add); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.AssumeYes {
+		return projects, nil
+	}
+
+	for {
+		dir, err := w.ask("Add a project directory (blank to finish):")
+		if err != nil {
+			return nil, err
+		}
+		if dir == "" {
+			return projects, nil
+		}
+		if err := add(dir); err != nil {
+			fmt.Fprintf(w.out, "  skipped: %v\n", err)
+		}
+	}
+}
+
+func (w *Wizard) offerWorkingDir(assumeYes bool, add func(string) error) error {
+	cwd, err := w.getwd()
+	if err != nil || w.validateProject(cwd) != nil {
+		return nil //nolint:nilerr // Working directory is only a suggestion
+	}
+
+	accept := assumeYes
+	if !accept {
+		accept, err = w.confirm(fmt.Sprintf("Add current directory %s as a project?", cwd), true)
+		if err != nil {
+			return err
+		}
+	}
+	if !accept {
+		return nil
+	}
+	return add(cwd)
+}
+
+// uniqueProjectName mirrors App.AddProject's counter-suffix deduplication.
+func uniqueProjectName(projects []domain.Project, name string) string {
+	taken := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		taken[p.Name] = true
+	}
+	if !taken[name] {
+		return name
+	}
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", name, i)
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
+
+// proposeDefaults picks the first harness and its first concrete model and agent.
+func proposeDefaults(harnesses []domain.Harness) *domain.Defaults {
+	first := harnesses[0]
+	defaults := &domain.Defaults{Harness: first.Name}
+	for _, model := range first.SupportedModels {
+		if model == discovery.KeywordDiscoverActive || strings.HasPrefix(model, discovery.PrefixProvider) {
+			continue
+		}
+		defaults.Model = model
+		break
+	}
+	if len(first.SupportedAgents) > 0 {
+		defaults.Agent = first.SupportedAgents[0]
+	}
+	return defaults
+}
+
+func (w *Wizard) write(path string, cfg *domain.Config) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := w.loader.Save(path, cfg); err != nil {
+		return err
+	}
+	if _, err := w.loader.Load(path); err != nil {
+		return fmt.Errorf("generated config failed validation: %w", err)
+	}
+	fmt.Fprintf(w.out, "Wrote %d harness(es) and %d project(s) to %s\n",
+		len(cfg.Harnesses), len(cfg.Workspace.Projects), path)
+	return nil
+}
+
+func (w *Wizard) ask(prompt string) (string, error) {
+	fmt.Fprintf(w.out, "%s ", prompt)
+	line, err := w.in.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func (w *Wizard) confirm(prompt string, defaultYes bool) (bool, error) {
+	suffix := "[y/N]"
+	if defaultYes {
+		suffix = "[Y/n]"
+	}
+	answer, err := w.ask(prompt + " " + suffix)
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "":
+		return defaultYes, nil
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
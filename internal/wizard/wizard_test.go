@@ -0,0 +1,168 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package wizard
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/discovery"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func validateBeadsDir(dir string) error {
+	info, err := os.Stat(filepath.Join(dir, ".beads"))
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("directory %s does not contain a .beads subdirectory", dir)
+	}
+	return nil
+}
+
+func newProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".beads"), 0o750))
+	return dir
+}
+
+func newTestWizard(t *testing.T, input string, binaries ...string) (*Wizard, *bytes.Buffer) {
+	t.Helper()
+	registry, err := discovery.NewRegistry(t.TempDir())
+	require.NoError(t, err)
+	cache := `{"anthropic":{"id":"anthropic","env":["ANTHROPIC_API_KEY"],"models":{"claude-sonnet-4-6":{"id":"claude-sonnet-4-6"}}}}`
+	require.NoError(t, os.WriteFile(registry.GetCachePath(), []byte(cache), 0o600))
+
+	out := &bytes.Buffer{}
+	w := New(strings.NewReader(input), out, config.NewYAMLLoader(), registry, validateBeadsDir)
+	found := make(map[string]bool)
+	for _, b := range binaries {
+		found[b] = true
+	}
+	w.lookPath = func(file string) (string, error) {
+		if found[file] {
+			return "/usr/local/bin/" + file, nil
+		}
+		return "", errors.New("not found")
+	}
+	w.getwd = func() (string, error) { return t.TempDir(), nil }
+	return w, out
+}
+
+func TestRun_AssumeYesWritesLoadableConfig(t *testing.T) {
+	project := newProject(t)
+	cfgPath := filepath.Join(t.TempDir(), "blunderbust", "config.yaml")
+	w, _ := newTestWizard(t, "", "opencode", "claude", "kilo")
+
+	cfg, err := w.Run(context.Background(), Options{
+		ConfigPath: cfgPath,
+		Projects:   []string{project},
+		AssumeYes:  true,
+	})
+	require.NoError(t, err)
+
+	loaded, err := config.NewYAMLLoader().Load(cfgPath)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(loaded.Harnesses))
+	for _, h := range loaded.Harnesses {
+		names = append(names, h.Name)
+	}
+	assert.Equal(t, []string{"claude", "kilocode", "opencode"}, names)
+
+	assert.Equal(t, "claude --model {{.Model.Name}}", loaded.Harnesses[0].CommandTemplate)
+	assert.Equal(t, []string{"provider:anthropic"}, loaded.Harnesses[0].SupportedModels)
+	assert.Equal(t, "kilo", loaded.Harnesses[1].CommandTemplate, "detected alias is used as the binary")
+	assert.Equal(t, []string{"discover:active"}, loaded.Harnesses[2].SupportedModels)
+	assert.Equal(t, []string{"build", "plan"}, loaded.Harnesses[2].SupportedAgents)
+
+	require.Len(t, loaded.Workspace.Projects, 1)
+	assert.Equal(t, project, loaded.Workspace.Projects[0].Dir)
+	assert.Equal(t, "claude", cfg.Defaults.Harness)
+}
+
+func TestRun_AssumeYesRefusesToOverwrite(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte("harnesses: []\n"), 0o600))
+	w, _ := newTestWizard(t, "", "opencode")
+
+	_, err := w.Run(context.Background(), Options{ConfigPath: cfgPath, AssumeYes: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+
+	_, err = w.Run(context.Background(), Options{ConfigPath: cfgPath, AssumeYes: true, Force: true})
+	require.NoError(t, err)
+}
+
+func TestRun_NoHarnessesFound(t *testing.T) {
+	w, _ := newTestWizard(t, "")
+	_, err := w.Run(context.Background(), Options{
+		ConfigPath: filepath.Join(t.TempDir(), "config.yaml"),
+		AssumeYes:  true,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no harness selected")
+}
+
+func TestRun_InvalidProjectFlagFails(t *testing.T) {
+	w, _ := newTestWizard(t, "", "opencode")
+	_, err := w.Run(context.Background(), Options{
+		ConfigPath: filepath.Join(t.TempDir(), "config.yaml"),
+		Projects:   []string{t.TempDir()},
+		AssumeYes:  true,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ".beads")
+}
+
+func TestRun_Interactive(t *testing.T) {
+	project := newProject(t)
+	invalid := t.TempDir()
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+
+	// Answers: decline aider, accept opencode, then an invalid project,
+	// a valid project, and a blank line to finish.
+	input := strings.Join([]string{"n", "", invalid, project, ""}, "\n") + "\n"
+	w, out := newTestWizard(t, input, "aider", "opencode")
+
+	cfg, err := w.Run(context.Background(), Options{ConfigPath: cfgPath})
+	require.NoError(t, err)
+
+	require.Len(t, cfg.Harnesses, 1)
+	assert.Equal(t, "opencode", cfg.Harnesses[0].Name)
+	require.Len(t, cfg.Workspace.Projects, 1)
+	assert.Equal(t, project, cfg.Workspace.Projects[0].Dir)
+	assert.Contains(t, out.String(), "skipped:")
+}
+
+func TestRun_InteractiveDeclineOverwrite(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte("original"), 0o600))
+	w, _ := newTestWizard(t, "\n", "opencode")
+
+	_, err := w.Run(context.Background(), Options{ConfigPath: cfgPath})
+	require.ErrorIs(t, err, ErrAborted)
+
+	data, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(data))
+}
+
+func TestUniqueProjectName(t *testing.T) {
+	projects := []domain.Project{{Name: "app"}, {Name: "app-1"}}
+	assert.Equal(t, "web", uniqueProjectName(projects, "web"))
+	assert.Equal(t, "app-2", uniqueProjectName(projects, "app"))
+}